@@ -64,6 +64,8 @@ type BeaconBestState struct {
 
 	// Number of blocks produced by producers in epoch
 	NumOfBlocksByProducers map[string]uint64 `json:"NumOfBlocksByProducers"`
+	// Number of rounds timed out by producers in epoch, proven by the view change certificates of blocks
+	NumOfTimeoutsByProducers map[string]uint64 `json:"NumOfTimeoutsByProducers"`
 
 	lock               sync.RWMutex
 	BlockInterval      time.Duration
@@ -104,6 +106,7 @@ func NewBeaconBestStateWithConfig(netparam *Params) *BeaconBestState {
 	beaconBestState.LastCrossShardState = make(map[byte]map[byte]uint64)
 	beaconBestState.BlockInterval = netparam.MinBeaconBlockInterval
 	beaconBestState.BlockMaxCreateTime = netparam.MaxBeaconBlockCreation
+	beaconBestState.ConsensusAlgorithm = netparam.ConsensusAlgorithm
 	return beaconBestState
 }
 func SetBeaconBestState(beacon *BeaconBestState) {
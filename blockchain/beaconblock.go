@@ -14,6 +14,8 @@ type BeaconBlock struct {
 	// ProducerSig   string  `json:"ProducerSig"`
 
 	ValidationData string `json:"ValidationData"`
+	// certificates of the rounds timed out before this block, their hash is committed in the header
+	ViewChangeCertificates []ViewChangeCertificate `json:"ViewChangeCertificates,omitempty"`
 
 	Body   BeaconBody
 	Header BeaconHeader
@@ -42,7 +44,8 @@ func (beaconBlock BeaconBlock) GetHeight() uint64 {
 
 func (beaconBlock *BeaconBlock) UnmarshalJSON(data []byte) error {
 	tempBeaconBlock := &struct {
-		ValidationData string `json:"ValidationData"`
+		ValidationData         string `json:"ValidationData"`
+		ViewChangeCertificates []ViewChangeCertificate
 
 		Header BeaconHeader
 		Body   BeaconBody
@@ -56,6 +59,7 @@ func (beaconBlock *BeaconBlock) UnmarshalJSON(data []byte) error {
 	// beaconBlock.ValidatorsIdx = tempBlk.ValidatorsIdx
 	// beaconBlock.ProducerSig = tempBlk.ProducerSig
 	beaconBlock.ValidationData = tempBeaconBlock.ValidationData
+	beaconBlock.ViewChangeCertificates = tempBeaconBlock.ViewChangeCertificates
 	beaconBlock.Header = tempBeaconBlock.Header
 	beaconBlock.Body = tempBeaconBlock.Body
 	return nil
@@ -65,6 +69,21 @@ func (beaconBlock *BeaconBlock) AddValidationField(validationData string) error
 	beaconBlock.ValidationData = validationData
 	return nil
 }
+
+// AddViewChangeCertificates stores the certificates in block and commits them in the header, the block hash changes
+func (beaconBlock *BeaconBlock) AddViewChangeCertificates(certs []ViewChangeCertificate) error {
+	root, err := viewChangeCertificatesRoot(certs)
+	if err != nil {
+		return err
+	}
+	beaconBlock.ViewChangeCertificates = certs
+	beaconBlock.Header.ViewChangeRoot = root
+	return nil
+}
+
+func (beaconBlock BeaconBlock) GetViewChangeCertificates() []ViewChangeCertificate {
+	return beaconBlock.ViewChangeCertificates
+}
 func (beaconBlock BeaconBlock) GetValidationField() string {
 	return beaconBlock.ValidationData
 }
@@ -31,7 +31,7 @@ type BurningReqAction struct {
 	RequestedTxID *common.Hash            `json:"RequestedTxID"`
 }
 
-func (blockchain *BlockChain) processBridgeInstructions(block *BeaconBlock, bd *[]database.BatchData) error {
+func (blockchain *BlockChain) processBridgeInstructions(block *BeaconBlock, db database.DatabaseInterface) error {
 	updatingInfoByTokenID := map[common.Hash]UpdatingInfo{}
 	for _, inst := range block.Body.Instructions {
 		if len(inst) < 2 {
@@ -40,10 +40,10 @@ func (blockchain *BlockChain) processBridgeInstructions(block *BeaconBlock, bd *
 		var err error
 		switch inst[0] {
 		case strconv.Itoa(metadata.IssuingETHRequestMeta):
-			updatingInfoByTokenID, err = blockchain.processIssuingETHReq(inst, updatingInfoByTokenID)
+			updatingInfoByTokenID, err = blockchain.processIssuingETHReq(inst, updatingInfoByTokenID, db)
 
 		case strconv.Itoa(metadata.IssuingRequestMeta):
-			updatingInfoByTokenID, err = blockchain.processIssuingReq(inst, updatingInfoByTokenID)
+			updatingInfoByTokenID, err = blockchain.processIssuingReq(inst, updatingInfoByTokenID, db)
 
 		case strconv.Itoa(metadata.ContractingRequestMeta):
 			updatingInfoByTokenID, err = blockchain.processContractingReq(inst, updatingInfoByTokenID)
@@ -67,13 +67,13 @@ func (blockchain *BlockChain) processBridgeInstructions(block *BeaconBlock, bd *
 			updatingAmt = updatingInfo.deductAmt - updatingInfo.countUpAmt
 			updatingType = "-"
 		}
-		err := blockchain.GetDatabase().UpdateBridgeTokenInfo(
+		err := db.UpdateBridgeTokenInfo(
 			updatingInfo.tokenID,
 			updatingInfo.externalTokenID,
 			updatingInfo.isCentralized,
 			updatingAmt,
 			updatingType,
-			bd,
+			nil,
 		)
 		if err != nil {
 			return err
@@ -154,7 +154,7 @@ func (blockchain *BlockChain) processBurningReq(instruction []string, updatingIn
 	return updatingInfoByTokenID, nil
 }
 
-func (blockchain *BlockChain) processIssuingETHReq(instruction []string, updatingInfoByTokenID map[common.Hash]UpdatingInfo) (map[common.Hash]UpdatingInfo, error) {
+func (blockchain *BlockChain) processIssuingETHReq(instruction []string, updatingInfoByTokenID map[common.Hash]UpdatingInfo, db database.DatabaseInterface) (map[common.Hash]UpdatingInfo, error) {
 	if len(instruction) != 4 {
 		return nil, nil // skip the instruction
 	}
@@ -165,14 +165,12 @@ func (blockchain *BlockChain) processIssuingETHReq(instruction []string, updatin
 			fmt.Println("WARNING: an error occured while building tx request id in bytes from string: ", err)
 			return nil, nil
 		}
-		err = blockchain.GetDatabase().TrackBridgeReqWithStatus(*txReqID, common.BridgeRequestRejectedStatus, nil)
+		err = db.TrackBridgeReqWithStatus(*txReqID, common.BridgeRequestRejectedStatus, nil)
 		if err != nil {
 			fmt.Println("WARNING: an error occured while tracking bridge request with rejected status to leveldb: ", err)
 		}
 		return nil, nil
 	}
-
-	db := blockchain.GetDatabase()
 	contentBytes, err := base64.StdEncoding.DecodeString(instruction[3])
 	if err != nil {
 		fmt.Println("WARNING: an error occured while decoding content string of accepted issuance instruction: ", err)
@@ -206,7 +204,7 @@ func (blockchain *BlockChain) processIssuingETHReq(instruction []string, updatin
 	return updatingInfoByTokenID, nil
 }
 
-func (blockchain *BlockChain) processIssuingReq(instruction []string, updatingInfoByTokenID map[common.Hash]UpdatingInfo) (map[common.Hash]UpdatingInfo, error) {
+func (blockchain *BlockChain) processIssuingReq(instruction []string, updatingInfoByTokenID map[common.Hash]UpdatingInfo, db database.DatabaseInterface) (map[common.Hash]UpdatingInfo, error) {
 	if len(instruction) != 4 {
 		return nil, nil // skip the instruction
 	}
@@ -217,7 +215,7 @@ func (blockchain *BlockChain) processIssuingReq(instruction []string, updatingIn
 			fmt.Println("WARNING: an error occured while building tx request id in bytes from string: ", err)
 			return nil, nil
 		}
-		err = blockchain.GetDatabase().TrackBridgeReqWithStatus(*txReqID, common.BridgeRequestRejectedStatus, nil)
+		err = db.TrackBridgeReqWithStatus(*txReqID, common.BridgeRequestRejectedStatus, nil)
 		if err != nil {
 			fmt.Println("WARNING: an error occured while tracking bridge request with rejected status to leveldb: ", err)
 		}
@@ -259,7 +257,7 @@ func decodeContent(content string, action interface{}) error {
 	return json.Unmarshal(contentBytes, &action)
 }
 
-func (blockchain *BlockChain) storeBurningConfirm(block *ShardBlock, bd *[]database.BatchData) error {
+func (blockchain *BlockChain) storeBurningConfirm(block *ShardBlock, db database.DatabaseInterface) error {
 	for _, inst := range block.Body.Instructions {
 		if inst[0] != strconv.Itoa(metadata.BurningConfirmMeta) {
 			continue
@@ -270,15 +268,14 @@ func (blockchain *BlockChain) storeBurningConfirm(block *ShardBlock, bd *[]datab
 		if err != nil {
 			return errors.Wrap(err, "txid invalid")
 		}
-		if err := blockchain.config.DataBase.StoreBurningConfirm(*txID, block.Header.Height, bd); err != nil {
+		if err := db.StoreBurningConfirm(*txID, block.Header.Height, nil); err != nil {
 			return errors.Wrapf(err, "store failed, txID: %x", txID)
 		}
 	}
 	return nil
 }
 
-func (blockchain *BlockChain) updateBridgeIssuanceStatus(block *ShardBlock, bd *[]database.BatchData) error {
-	db := blockchain.config.DataBase
+func (blockchain *BlockChain) updateBridgeIssuanceStatus(block *ShardBlock, db database.DatabaseInterface) error {
 	for _, tx := range block.Body.Transactions {
 		metaType := tx.GetMetadataType()
 		var reqTxID common.Hash
@@ -290,7 +287,7 @@ func (blockchain *BlockChain) updateBridgeIssuanceStatus(block *ShardBlock, bd *
 			reqTxID = meta.RequestedTxID
 		}
 		var err error
-		err = db.TrackBridgeReqWithStatus(reqTxID, common.BridgeRequestAcceptedStatus, bd)
+		err = db.TrackBridgeReqWithStatus(reqTxID, common.BridgeRequestAcceptedStatus, nil)
 		if err != nil {
 			return err
 		}
@@ -27,7 +27,7 @@ func (blockchain *BlockChain) buildBridgeInstructions(
 		if len(inst) < 2 {
 			continue
 		}
-		if inst[0] == SetAction || inst[0] == StakeAction || inst[0] == SwapAction || inst[0] == RandomAction || inst[0] == AssignAction {
+		if inst[0] == SetAction || inst[0] == StakeAction || inst[0] == SwapAction || inst[0] == RandomAction || inst[0] == AssignAction || inst[0] == SlashAction {
 			continue
 		}
 
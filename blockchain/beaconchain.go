@@ -39,6 +39,10 @@ func (chain *BeaconChain) CurrentHeight() uint64 {
 	return chain.BestState.BestBlock.Header.Height
 }
 
+func (chain *BeaconChain) GetBestBlockHash() common.Hash {
+	return chain.BestState.BestBlockHash
+}
+
 func (chain *BeaconChain) GetCommittee() []incognitokey.CommitteePublicKey {
 	return chain.BestState.GetBeaconCommittee()
 }
@@ -26,6 +26,7 @@ type BeaconHeader struct {
 	ConsensusType                   string      `json:"ConsensusType"`
 	Producer                        string      `json:"Producer"`
 	ProducerPubKeyStr               string      `json:"ProducerPubKeyStr"`
+	ViewChangeRoot                  common.Hash `json:"ViewChangeRoot"` // hash of the view change certificates of the rounds timed out before this block
 }
 
 func (beaconHeader *BeaconHeader) toString() string {
@@ -44,6 +45,10 @@ func (beaconHeader *BeaconHeader) toString() string {
 	res += beaconHeader.AutoStakingRoot.String()
 	res += beaconHeader.ShardStateHash.String()
 	res += beaconHeader.InstructionHash.String()
+	// blocks without view change certificates keep their hash
+	if !beaconHeader.ViewChangeRoot.IsEqual(&common.Hash{}) {
+		res += beaconHeader.ViewChangeRoot.String()
+	}
 	return res
 }
 
@@ -11,9 +11,8 @@ import (
 	"github.com/incognitochain/incognito-chain/metadata"
 )
 
-func (blockchain *BlockChain) processPDEInstructions(block *BeaconBlock, bd *[]database.BatchData) error {
+func (blockchain *BlockChain) processPDEInstructions(block *BeaconBlock, db database.DatabaseInterface) error {
 	beaconHeight := block.Header.Height - 1
-	db := blockchain.GetDatabase()
 	currentPDEState, err := InitCurrentPDEStateFromDB(db, beaconHeight)
 	if err != nil {
 		Logger.log.Error(err)
@@ -26,11 +25,11 @@ func (blockchain *BlockChain) processPDEInstructions(block *BeaconBlock, bd *[]d
 		var err error
 		switch inst[0] {
 		case strconv.Itoa(metadata.PDEContributionMeta):
-			err = blockchain.processPDEContributionV2(beaconHeight, inst, currentPDEState)
+			err = blockchain.processPDEContributionV2(beaconHeight, inst, currentPDEState, db)
 		case strconv.Itoa(metadata.PDETradeRequestMeta):
-			err = blockchain.processPDETrade(beaconHeight, inst, currentPDEState)
+			err = blockchain.processPDETrade(beaconHeight, inst, currentPDEState, db)
 		case strconv.Itoa(metadata.PDEWithdrawalRequestMeta):
-			err = blockchain.processPDEWithdrawal(beaconHeight, inst, currentPDEState)
+			err = blockchain.processPDEWithdrawal(beaconHeight, inst, currentPDEState, db)
 		}
 		if err != nil {
 			Logger.log.Error(err)
@@ -70,6 +69,7 @@ func (blockchain *BlockChain) processPDEContributionV2(
 	beaconHeight uint64,
 	instruction []string,
 	currentPDEState *CurrentPDEState,
+	db database.DatabaseInterface,
 ) error {
 	if currentPDEState == nil {
 		Logger.log.Warn("WARN - [processPDEContribution]: Current PDE state is null.")
@@ -78,7 +78,6 @@ func (blockchain *BlockChain) processPDEContributionV2(
 	if len(instruction) != 4 {
 		return nil // skip the instruction
 	}
-	db := blockchain.GetDatabase()
 	contributionStatus := instruction[2]
 	if contributionStatus == common.PDEContributionWaitingChainStatus {
 		var waitingContribution metadata.PDEWaitingContribution
@@ -270,11 +269,11 @@ func (blockchain *BlockChain) processPDETrade(
 	beaconHeight uint64,
 	instruction []string,
 	currentPDEState *CurrentPDEState,
+	db database.DatabaseInterface,
 ) error {
 	if len(instruction) != 4 {
 		return nil // skip the instruction
 	}
-	db := blockchain.GetDatabase()
 	if instruction[2] == common.PDETradeRefundChainStatus {
 		contentBytes, err := base64.StdEncoding.DecodeString(instruction[3])
 		if err != nil {
@@ -350,11 +349,11 @@ func (blockchain *BlockChain) processPDEWithdrawal(
 	beaconHeight uint64,
 	instruction []string,
 	currentPDEState *CurrentPDEState,
+	db database.DatabaseInterface,
 ) error {
 	if len(instruction) != 4 {
 		return nil // skip the instruction
 	}
-	db := blockchain.GetDatabase()
 	if instruction[2] == common.PDEWithdrawalRejectedChainStatus {
 		contentBytes, err := base64.StdEncoding.DecodeString(instruction[3])
 		if err != nil {
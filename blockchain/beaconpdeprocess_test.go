@@ -9,7 +9,9 @@ import (
 	"testing"
 
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
 	"github.com/incognitochain/incognito-chain/database/lvdb"
+	_ "github.com/incognitochain/incognito-chain/database/memdb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/stretchr/testify/suite"
 )
@@ -22,6 +24,12 @@ type PDEProcessSuite struct {
 	currentPDEState *CurrentPDEState
 }
 
+// newPDETestBlockChain returns a blockchain with an in-memory database to track the PDE statuses
+func newPDETestBlockChain() *BlockChain {
+	db, _ := database.Open("memdb")
+	return &BlockChain{config: Config{DataBase: db}}
+}
+
 func (suite *PDEProcessSuite) SetupTest() {
 	suite.currentPDEState = &CurrentPDEState{
 		WaitingPDEContributions: make(map[string]*lvdb.PDEContribution),
@@ -70,26 +78,12 @@ func buildPDEContributionAction(
 	return [][]string{action}
 }
 
-func buildPDEContributionInst(
-	pdeContributionPairID string,
-	contributorAddressStr string,
-	contributedAmount uint64,
-	tokenIDStr string,
-) [][]string {
-	actionContentBase64Str := buildPDEContributionActionContent(
-		pdeContributionPairID,
-		contributorAddressStr,
-		contributedAmount,
-		tokenIDStr,
-	)
-	shardID := byte(1)
-	inst := []string{
-		strconv.Itoa(metadata.PDEContributionMeta),
-		strconv.Itoa(int(shardID)),
-		"accepted",
-		actionContentBase64Str,
-	}
-	return [][]string{inst}
+func (suite *PDEProcessSuite) getPDEContributionStatus(db database.DatabaseInterface, pairID string) metadata.PDEContributionStatus {
+	var contribStatus metadata.PDEContributionStatus
+	contribStatusBytes, err := db.GetPDEContributionStatus(lvdb.PDEContributionStatusPrefix, []byte(pairID))
+	suite.Equal(err, nil)
+	suite.Equal(json.Unmarshal(contribStatusBytes, &contribStatus), nil)
+	return contribStatus
 }
 
 // All methods that begin with "Test" are run as tests within a
@@ -100,15 +94,18 @@ func (suite *PDEProcessSuite) TestPDEContributionOnUnexistedWaitingUniqID() {
 	contributorAddr := "12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj"
 	contributedAmt := uint64(10000000000)
 	contribTokenIDStr := "0000000000000000000000000000000000000000000000000000000000000005"
-	contribInsts := buildPDEContributionInst(
+	waitingInst := buildWaitingContributionInst(
 		uniqPairID,
 		contributorAddr,
 		contributedAmt,
 		contribTokenIDStr,
+		metadata.PDEContributionMeta,
+		byte(1),
+		common.Hash{},
 	)
 	beaconHeight := uint64(1001)
-	bc := &BlockChain{}
-	err := bc.processPDEContribution(beaconHeight-1, contribInsts[0], suite.currentPDEState)
+	bc := newPDETestBlockChain()
+	err := bc.processPDEContributionV2(beaconHeight-1, waitingInst, suite.currentPDEState, bc.GetDatabase())
 	suite.Equal(err, nil)
 	waitingContribKey := string(lvdb.BuildWaitingPDEContributionKey(
 		beaconHeight-1,
@@ -121,6 +118,7 @@ func (suite *PDEProcessSuite) TestPDEContributionOnUnexistedWaitingUniqID() {
 	suite.Equal(suite.currentPDEState.WaitingPDEContributions[waitingContribKey].ContributorAddressStr, contributorAddr)
 	suite.Equal(suite.currentPDEState.WaitingPDEContributions[waitingContribKey].TokenIDStr, contribTokenIDStr)
 	suite.Equal(suite.currentPDEState.WaitingPDEContributions[waitingContribKey].Amount, contributedAmt)
+	suite.Equal(suite.getPDEContributionStatus(bc.GetDatabase(), uniqPairID).Status, byte(common.PDEContributionWaitingStatus))
 }
 
 func (suite *PDEProcessSuite) TestPDEContributionOnUnexistedPairForExistedWaitingUniqID() {
@@ -143,19 +141,22 @@ func (suite *PDEProcessSuite) TestPDEContributionOnUnexistedPairForExistedWaitin
 		Amount:                20000000000,
 	}
 
-	contribInsts := buildPDEContributionInst(
+	matchedInst := buildMatchedContributionInst(
 		uniqPairID,
 		contributorAddr,
 		contributedAmt,
 		contribToken2IDStr,
+		metadata.PDEContributionMeta,
+		byte(1),
+		common.Hash{},
 	)
-	bc := &BlockChain{}
-	err := bc.processPDEContribution(beaconHeight-1, contribInsts[0], suite.currentPDEState)
+	bc := newPDETestBlockChain()
+	err := bc.processPDEContributionV2(beaconHeight-1, matchedInst, suite.currentPDEState, bc.GetDatabase())
 	suite.Equal(err, nil)
 	_, found := currentPDEState.WaitingPDEContributions[existedWaitingContribKey]
 	suite.Equal(found, false)
 	suite.Equal(len(suite.currentPDEState.PDEPoolPairs), 1)
-	suite.Equal(len(suite.currentPDEState.PDEShares), 2)
+	suite.Equal(len(suite.currentPDEState.PDEShares), 1)
 	suite.Equal(len(suite.currentPDEState.WaitingPDEContributions), 0)
 
 	pairKey := string(lvdb.BuildPDEPoolForPairKey(beaconHeight-1, contribToken1IDStr, contribToken2IDStr))
@@ -165,11 +166,10 @@ func (suite *PDEProcessSuite) TestPDEContributionOnUnexistedPairForExistedWaitin
 	suite.Equal(newPair.Token1PoolValue, uint64(20000000000))
 	suite.Equal(newPair.Token2PoolValue, uint64(10000000000))
 
-	shareKey1 := string(lvdb.BuildPDESharesKey(beaconHeight-1, contribToken1IDStr, contribToken2IDStr, contribToken1IDStr, contributorAddr))
-	shareKey2 := string(lvdb.BuildPDESharesKey(beaconHeight-1, contribToken1IDStr, contribToken2IDStr, contribToken2IDStr, contributorAddr))
-
-	suite.Equal(suite.currentPDEState.PDEShares[shareKey1], uint64(20000000000))
-	suite.Equal(suite.currentPDEState.PDEShares[shareKey2], uint64(10000000000))
+	// the first contribution of a pair gets as many shares as its amount of the waiting token
+	shareKey := string(lvdb.BuildPDESharesKeyV2(beaconHeight-1, contribToken1IDStr, contribToken2IDStr, contributorAddr))
+	suite.Equal(suite.currentPDEState.PDEShares[shareKey], uint64(20000000000))
+	suite.Equal(suite.getPDEContributionStatus(bc.GetDatabase(), uniqPairID).Status, byte(common.PDEContributionAcceptedStatus))
 }
 
 func (suite *PDEProcessSuite) TestPDEContributionOnExistedPairForExistedWaitingUniqID() {
@@ -182,6 +182,7 @@ func (suite *PDEProcessSuite) TestPDEContributionOnExistedPairForExistedWaitingU
 	contribToken2IDStr := "0000000000000000000000000000000000000000000000000000000000000007"
 	contributedAmt := uint64(10000000000)
 	beaconHeight := uint64(1001)
+	bc := newPDETestBlockChain()
 
 	currentPDEState := suite.currentPDEState
 	// waiting contribution
@@ -194,6 +195,8 @@ func (suite *PDEProcessSuite) TestPDEContributionOnExistedPairForExistedWaitingU
 		TokenIDStr:            contribToken1IDStr,
 		Amount:                20000000000,
 	}
+	waitingStatusBytes, _ := json.Marshal(metadata.PDEContributionStatus{Status: byte(common.PDEContributionWaitingStatus)})
+	suite.Equal(bc.GetDatabase().TrackPDEContributionStatus(lvdb.PDEContributionStatusPrefix, []byte(uniqPairID1), waitingStatusBytes), nil)
 	existedWaitingContribKey2 := string(lvdb.BuildWaitingPDEContributionKey(
 		beaconHeight-1,
 		uniqPairID2,
@@ -229,40 +232,56 @@ func (suite *PDEProcessSuite) TestPDEContributionOnExistedPairForExistedWaitingU
 	}
 
 	// shares
-	shareKey1 := string(lvdb.BuildPDESharesKey(
+	shareKey1 := string(lvdb.BuildPDESharesKeyV2(
 		beaconHeight-1,
 		contribToken1IDStr,
 		contribToken2IDStr,
-		contribToken1IDStr,
 		contributorAddr,
 	))
-	shareKey2 := string(lvdb.BuildPDESharesKey(
+	shareKey2 := string(lvdb.BuildPDESharesKeyV2(
 		beaconHeight-1,
 		contribToken1IDStr,
 		contribToken2IDStr,
-		contribToken2IDStr,
-		contributorAddr,
+		contributorAddr+"-new",
 	))
-	shareKey3 := string(lvdb.BuildPDESharesKey(
+	shareKey3 := string(lvdb.BuildPDESharesKeyV2(
 		beaconHeight-1,
 		oldContribTokenIDStr,
-		contribToken2IDStr,
-		oldContribTokenIDStr,
+		contribToken1IDStr,
 		contributorAddr+"-new",
 	))
 	currentPDEState.PDEShares[shareKey1] = 10000000000
 	currentPDEState.PDEShares[shareKey2] = 10000000000
 	currentPDEState.PDEShares[shareKey3] = 10000000000
 
-	contribInsts := buildPDEContributionInst(
+	// with the 50:80 ratio of the pool, 6250000000 of the 20000000000 waiting token1 are matched
+	// with the 10000000000 incoming token2, the rest of token1 is returned
+	matchedNReturnedInst1 := buildMatchedNReturnedContributionInst(
 		uniqPairID1,
 		contributorAddr,
 		contributedAmt,
+		0,
 		contribToken2IDStr,
+		metadata.PDEContributionMeta,
+		byte(1),
+		common.Hash{},
+		6250000000,
 	)
-	bc := &BlockChain{}
-	err := bc.processPDEContribution(beaconHeight-1, contribInsts[0], suite.currentPDEState)
-	suite.Equal(err, nil)
+	matchedNReturnedInst2 := buildMatchedNReturnedContributionInst(
+		uniqPairID1,
+		contributorAddr,
+		6250000000,
+		13750000000,
+		contribToken1IDStr,
+		metadata.PDEContributionMeta,
+		byte(1),
+		common.Hash{},
+		0,
+	)
+	for _, inst := range [][]string{matchedNReturnedInst1, matchedNReturnedInst2} {
+		err := bc.processPDEContributionV2(beaconHeight-1, inst, suite.currentPDEState, bc.GetDatabase())
+		suite.Equal(err, nil)
+	}
 	newWaitingPDEContributions := suite.currentPDEState.WaitingPDEContributions
 	suite.Equal(len(newWaitingPDEContributions), 1)
 	waitingContrib, found := newWaitingPDEContributions[existedWaitingContribKey2]
@@ -271,14 +290,25 @@ func (suite *PDEProcessSuite) TestPDEContributionOnExistedPairForExistedWaitingU
 
 	newPoolPairs := suite.currentPDEState.PDEPoolPairs
 	suite.Equal(len(newPoolPairs), 2)
-	suite.Equal(newPoolPairs[existedPoolPairKey1].Token1PoolValue, uint64(50000000000+20000000000))
+	suite.Equal(newPoolPairs[existedPoolPairKey1].Token1PoolValue, uint64(50000000000+6250000000))
 	suite.Equal(newPoolPairs[existedPoolPairKey1].Token2PoolValue, uint64(80000000000+contributedAmt))
+	suite.Equal(newPoolPairs[existedPoolPairKey2].Token2PoolValue, uint64(90000000000))
 
+	// the contributor gets 20000000000 * 6250000000 / 50000000000 more shares of the pair
 	newShares := suite.currentPDEState.PDEShares
 	suite.Equal(len(newShares), 3)
-	suite.Equal(newShares[shareKey1], uint64(14000000000))
-	suite.Equal(newShares[shareKey2], uint64(11250000000))
+	suite.Equal(newShares[shareKey1], uint64(12500000000))
+	suite.Equal(newShares[shareKey2], uint64(10000000000))
 	suite.Equal(newShares[shareKey3], uint64(10000000000))
+
+	contribStatus := suite.getPDEContributionStatus(bc.GetDatabase(), uniqPairID1)
+	suite.Equal(contribStatus.Status, byte(common.PDEContributionMatchedNReturnedStatus))
+	suite.Equal(contribStatus.TokenID1Str, contribToken2IDStr)
+	suite.Equal(contribStatus.Contributed1Amount, contributedAmt)
+	suite.Equal(contribStatus.Returned1Amount, uint64(0))
+	suite.Equal(contribStatus.TokenID2Str, contribToken1IDStr)
+	suite.Equal(contribStatus.Contributed2Amount, uint64(6250000000))
+	suite.Equal(contribStatus.Returned2Amount, uint64(13750000000))
 }
 
 // In order for 'go test' to run this suite, we need to create
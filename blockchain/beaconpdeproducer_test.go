@@ -149,7 +149,7 @@ func (suite *PDEProducerSuite) TestBuyToken1OnExistedPair() {
 	pdeTradeAcceptedContent := metadata.PDETradeAcceptedContent{
 		TraderAddressStr: "12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
 		TokenIDToBuyStr:  "0000000000000000000000000000000000000000000000000000000000000005",
-		ReceiveAmount:    83111182,
+		ReceiveAmount:    83319446,
 		Token1IDStr:      "0000000000000000000000000000000000000000000000000000000000000005",
 		Token2IDStr:      "0000000000000000000000000000000000000000000000000000000000000007",
 		ShardID:          shardID,
@@ -157,7 +157,7 @@ func (suite *PDEProducerSuite) TestBuyToken1OnExistedPair() {
 	}
 	pdeTradeAcceptedContent.Token1PoolValueOperation = metadata.TokenPoolValueOperation{
 		Operator: "-",
-		Value:    83111182,
+		Value:    83319446,
 	}
 	pdeTradeAcceptedContent.Token2PoolValueOperation = metadata.TokenPoolValueOperation{
 		Operator: "+",
@@ -176,7 +176,7 @@ func (suite *PDEProducerSuite) TestBuyToken1OnExistedPair() {
 
 	remainingTk1PoolVal := suite.currentPDEState.PDEPoolPairs[pairKey].Token1PoolValue
 	remainingTk2PoolVal := suite.currentPDEState.PDEPoolPairs[pairKey].Token2PoolValue
-	suite.Equal(remainingTk1PoolVal, uint64(500000000000-83111182))
+	suite.Equal(remainingTk1PoolVal, uint64(500000000000-83319446))
 	suite.Equal(remainingTk2PoolVal, uint64(60000000000000+10000000000))
 }
 
@@ -208,7 +208,7 @@ func (suite *PDEProducerSuite) TestBuyToken2OnExistedPair() {
 	pdeTradeAcceptedContent := metadata.PDETradeAcceptedContent{
 		TraderAddressStr: "12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
 		TokenIDToBuyStr:  "0000000000000000000000000000000000000000000000000000000000000007",
-		ReceiveAmount:    1173586940536,
+		ReceiveAmount:    1176470588235,
 		Token1IDStr:      "0000000000000000000000000000000000000000000000000000000000000005",
 		Token2IDStr:      "0000000000000000000000000000000000000000000000000000000000000007",
 		ShardID:          shardID,
@@ -216,7 +216,7 @@ func (suite *PDEProducerSuite) TestBuyToken2OnExistedPair() {
 	}
 	pdeTradeAcceptedContent.Token2PoolValueOperation = metadata.TokenPoolValueOperation{
 		Operator: "-",
-		Value:    1173586940536,
+		Value:    1176470588235,
 	}
 	pdeTradeAcceptedContent.Token1PoolValueOperation = metadata.TokenPoolValueOperation{
 		Operator: "+",
@@ -236,7 +236,7 @@ func (suite *PDEProducerSuite) TestBuyToken2OnExistedPair() {
 	remainingTk1PoolVal := suite.currentPDEState.PDEPoolPairs[pairKey].Token1PoolValue
 	remainingTk2PoolVal := suite.currentPDEState.PDEPoolPairs[pairKey].Token2PoolValue
 	suite.Equal(remainingTk1PoolVal, uint64(500000000000+10000000000))
-	suite.Equal(remainingTk2PoolVal, uint64(60000000000000-1173586940536))
+	suite.Equal(remainingTk2PoolVal, uint64(60000000000000-1176470588235))
 }
 
 func (suite *PDEProducerSuite) TestSellVerySmallAmtOnExistedPair() {
@@ -283,9 +283,8 @@ func (suite *PDEProducerSuite) TestSellVerySmallAmtOnExistedPair() {
 func buildPDEWithdrawReqAction(
 	withdrawerAddressStr string,
 	withdrawalToken1IDStr string,
-	withdrawalShare1Amt uint64,
 	withdrawalToken2IDStr string,
-	withdrawalShare2Amt uint64,
+	withdrawalShareAmt uint64,
 ) []string {
 	metadataBase := metadata.MetadataBase{
 		Type: metadata.PDEWithdrawalRequestMeta,
@@ -293,9 +292,8 @@ func buildPDEWithdrawReqAction(
 	pdeWithdrawalRequest := metadata.PDEWithdrawalRequest{
 		WithdrawerAddressStr:  withdrawerAddressStr,
 		WithdrawalToken1IDStr: withdrawalToken1IDStr,
-		WithdrawalShare1Amt:   withdrawalShare1Amt,
 		WithdrawalToken2IDStr: withdrawalToken2IDStr,
-		WithdrawalShare2Amt:   withdrawalShare2Amt,
+		WithdrawalShareAmt:    withdrawalShareAmt,
 	}
 	pdeWithdrawalRequest.MetadataBase = metadataBase
 	actionContent := metadata.PDEWithdrawalRequestAction{
@@ -322,31 +320,22 @@ func (suite *PDEProducerSuite) TestWithdrawOnExistedPair() {
 		pairKey: &pair,
 	}
 
-	shareKey1 := string(lvdb.BuildPDESharesKey(
+	shareKey := string(lvdb.BuildPDESharesKeyV2(
 		beaconHeight-1,
 		"0000000000000000000000000000000000000000000000000000000000000005",
 		"0000000000000000000000000000000000000000000000000000000000000007",
-		"0000000000000000000000000000000000000000000000000000000000000005",
-		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
-	))
-	shareKey2 := string(lvdb.BuildPDESharesKey(
-		beaconHeight-1,
-		"0000000000000000000000000000000000000000000000000000000000000005",
-		"0000000000000000000000000000000000000000000000000000000000000007",
-		"0000000000000000000000000000000000000000000000000000000000000007",
 		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
 	))
 	suite.currentPDEState.PDEShares = map[string]uint64{
-		shareKey1: 500000000000,
-		shareKey2: 60000000000000,
+		shareKey: 1000000000000,
 	}
 
+	// withdraw half of the shares of the pair
 	reqAction := buildPDEWithdrawReqAction(
 		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
 		"0000000000000000000000000000000000000000000000000000000000000005",
-		250000000000,
 		"0000000000000000000000000000000000000000000000000000000000000007",
-		30000000000000,
+		500000000000,
 	)
 	metaType, _ := strconv.Atoi(reqAction[0])
 	contentStr := reqAction[1]
@@ -356,7 +345,7 @@ func (suite *PDEProducerSuite) TestWithdrawOnExistedPair() {
 		WithdrawalTokenIDStr: "0000000000000000000000000000000000000000000000000000000000000005",
 		WithdrawerAddressStr: "12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
 		DeductingPoolValue:   250000000000,
-		DeductingShares:      250000000000,
+		DeductingShares:      500000000000,
 		PairToken1IDStr:      "0000000000000000000000000000000000000000000000000000000000000005",
 		PairToken2IDStr:      "0000000000000000000000000000000000000000000000000000000000000007",
 		TxReqID:              common.Hash{},
@@ -366,7 +355,7 @@ func (suite *PDEProducerSuite) TestWithdrawOnExistedPair() {
 		WithdrawalTokenIDStr: "0000000000000000000000000000000000000000000000000000000000000007",
 		WithdrawerAddressStr: "12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
 		DeductingPoolValue:   30000000000000,
-		DeductingShares:      30000000000000,
+		DeductingShares:      0,
 		PairToken1IDStr:      "0000000000000000000000000000000000000000000000000000000000000005",
 		PairToken2IDStr:      "0000000000000000000000000000000000000000000000000000000000000007",
 		TxReqID:              common.Hash{},
@@ -392,16 +381,14 @@ func (suite *PDEProducerSuite) TestWithdrawOnExistedPair() {
 
 	remainingTk1PoolVal := suite.currentPDEState.PDEPoolPairs[pairKey].Token1PoolValue
 	remainingTk2PoolVal := suite.currentPDEState.PDEPoolPairs[pairKey].Token2PoolValue
-	remainingTk1Shares := suite.currentPDEState.PDEShares[shareKey1]
-	remainingTk2Shares := suite.currentPDEState.PDEShares[shareKey2]
+	remainingShares := suite.currentPDEState.PDEShares[shareKey]
 	suite.Equal(remainingTk1PoolVal, uint64(250000000000))
 	suite.Equal(remainingTk2PoolVal, uint64(30000000000000))
-	suite.Equal(remainingTk1Shares, uint64(250000000000))
-	suite.Equal(remainingTk2Shares, uint64(30000000000000))
+	suite.Equal(remainingShares, uint64(500000000000))
 }
 
-func (suite *PDEProducerSuite) TestWithdrawOnToken1OfExistedPair() {
-	fmt.Println("Running testcase: TestWithdrawOnToken1OfExistedPair")
+func (suite *PDEProducerSuite) TestWithdrawOnUnexistedPair() {
+	fmt.Println("Running testcase: TestWithdrawOnUnexistedPair")
 	beaconHeight := uint64(1001)
 	pair := lvdb.PDEPoolForPair{
 		Token1IDStr:     "0000000000000000000000000000000000000000000000000000000000000005",
@@ -414,47 +401,26 @@ func (suite *PDEProducerSuite) TestWithdrawOnToken1OfExistedPair() {
 		pairKey: &pair,
 	}
 
-	shareKey1 := string(lvdb.BuildPDESharesKey(
-		beaconHeight-1,
-		"0000000000000000000000000000000000000000000000000000000000000005",
-		"0000000000000000000000000000000000000000000000000000000000000007",
-		"0000000000000000000000000000000000000000000000000000000000000005",
-		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
-	))
-	shareKey2 := string(lvdb.BuildPDESharesKey(
+	shareKey := string(lvdb.BuildPDESharesKeyV2(
 		beaconHeight-1,
 		"0000000000000000000000000000000000000000000000000000000000000005",
 		"0000000000000000000000000000000000000000000000000000000000000007",
-		"0000000000000000000000000000000000000000000000000000000000000007",
 		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
 	))
 	suite.currentPDEState.PDEShares = map[string]uint64{
-		shareKey1: 500000000000,
-		shareKey2: 60000000000000,
+		shareKey: 1000000000000,
 	}
 
 	reqAction := buildPDEWithdrawReqAction(
 		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
 		"0000000000000000000000000000000000000000000000000000000000000005",
+		"0000000000000000000000000000000000000000000000000000000000000008",
 		250000000000,
-		"0000000000000000000000000000000000000000000000000000000000000007",
-		0,
 	)
 	metaType, _ := strconv.Atoi(reqAction[0])
 	contentStr := reqAction[1]
 	shardID := byte(1)
 	bc := &BlockChain{}
-	wdAcceptedContent1 := metadata.PDEWithdrawalAcceptedContent{
-		WithdrawalTokenIDStr: "0000000000000000000000000000000000000000000000000000000000000005",
-		WithdrawerAddressStr: "12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
-		DeductingPoolValue:   250000000000,
-		DeductingShares:      250000000000,
-		PairToken1IDStr:      "0000000000000000000000000000000000000000000000000000000000000005",
-		PairToken2IDStr:      "0000000000000000000000000000000000000000000000000000000000000007",
-		TxReqID:              common.Hash{},
-		ShardID:              shardID,
-	}
-	wdAcceptedContent1Bytes, err := json.Marshal(wdAcceptedContent1)
 	newInsts, err := bc.buildInstructionsForPDEWithdrawal(
 		contentStr,
 		shardID,
@@ -467,85 +433,19 @@ func (suite *PDEProducerSuite) TestWithdrawOnToken1OfExistedPair() {
 	suite.Equal(len(newInsts[0]), 4)
 	suite.Equal(newInsts[0][0], strconv.Itoa(metaType))
 	suite.Equal(newInsts[0][1], strconv.Itoa(int(shardID)))
-	suite.Equal(newInsts[0][2], "accepted")
-	suite.Equal(newInsts[0][3], string(wdAcceptedContent1Bytes))
-
-	remainingTk1PoolVal := suite.currentPDEState.PDEPoolPairs[pairKey].Token1PoolValue
-	remainingTk2PoolVal := suite.currentPDEState.PDEPoolPairs[pairKey].Token2PoolValue
-	remainingTk1Shares := suite.currentPDEState.PDEShares[shareKey1]
-	remainingTk2Shares := suite.currentPDEState.PDEShares[shareKey2]
-	suite.Equal(remainingTk1PoolVal, uint64(250000000000))
-	suite.Equal(remainingTk2PoolVal, uint64(60000000000000))
-	suite.Equal(remainingTk1Shares, uint64(250000000000))
-	suite.Equal(remainingTk2Shares, uint64(60000000000000))
-}
-
-func (suite *PDEProducerSuite) TestWithdrawOnUnexistedPair() {
-	fmt.Println("Running testcase: TestWithdrawOnUnexistedPair")
-	beaconHeight := uint64(1001)
-	pair := lvdb.PDEPoolForPair{
-		Token1IDStr:     "0000000000000000000000000000000000000000000000000000000000000005",
-		Token1PoolValue: 500000000000,
-		Token2IDStr:     "0000000000000000000000000000000000000000000000000000000000000007",
-		Token2PoolValue: 60000000000000,
-	}
-	pairKey := string(lvdb.BuildPDEPoolForPairKey(beaconHeight-1, pair.Token1IDStr, pair.Token2IDStr))
-	suite.currentPDEState.PDEPoolPairs = map[string]*lvdb.PDEPoolForPair{
-		pairKey: &pair,
-	}
-
-	shareKey1 := string(lvdb.BuildPDESharesKey(
-		beaconHeight-1,
-		"0000000000000000000000000000000000000000000000000000000000000005",
-		"0000000000000000000000000000000000000000000000000000000000000007",
-		"0000000000000000000000000000000000000000000000000000000000000005",
-		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
-	))
-	shareKey2 := string(lvdb.BuildPDESharesKey(
-		beaconHeight-1,
-		"0000000000000000000000000000000000000000000000000000000000000005",
-		"0000000000000000000000000000000000000000000000000000000000000007",
-		"0000000000000000000000000000000000000000000000000000000000000007",
-		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
-	))
-	suite.currentPDEState.PDEShares = map[string]uint64{
-		shareKey1: 500000000000,
-		shareKey2: 60000000000000,
-	}
-
-	reqAction := buildPDEWithdrawReqAction(
-		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
-		"0000000000000000000000000000000000000000000000000000000000000005",
-		250000000000,
-		"0000000000000000000000000000000000000000000000000000000000000008",
-		0,
-	)
-	metaType, _ := strconv.Atoi(reqAction[0])
-	contentStr := reqAction[1]
-	shardID := byte(1)
-	bc := &BlockChain{}
-	newInsts, err := bc.buildInstructionsForPDEWithdrawal(
-		contentStr,
-		shardID,
-		metaType,
-		suite.currentPDEState,
-		beaconHeight-1,
-	)
-	suite.Equal(err, nil)
-	suite.Equal(len(newInsts), 0)
+	suite.Equal(newInsts[0][2], "rejected")
+	suite.Equal(newInsts[0][3], contentStr)
 
 	remainingTk1PoolVal := suite.currentPDEState.PDEPoolPairs[pairKey].Token1PoolValue
 	remainingTk2PoolVal := suite.currentPDEState.PDEPoolPairs[pairKey].Token2PoolValue
-	remainingTk1Shares := suite.currentPDEState.PDEShares[shareKey1]
-	remainingTk2Shares := suite.currentPDEState.PDEShares[shareKey2]
+	remainingShares := suite.currentPDEState.PDEShares[shareKey]
 	suite.Equal(remainingTk1PoolVal, uint64(500000000000))
 	suite.Equal(remainingTk2PoolVal, uint64(60000000000000))
-	suite.Equal(remainingTk1Shares, uint64(500000000000))
-	suite.Equal(remainingTk2Shares, uint64(60000000000000))
+	suite.Equal(remainingShares, uint64(1000000000000))
 }
 
-func (suite *PDEProducerSuite) TestWithdrawExceededSharesOnToken2OfExistedPair() {
-	fmt.Println("Running testcase: TestWithdrawExceededSharesOnToken2OfExistedPair")
+func (suite *PDEProducerSuite) TestWithdrawExceededSharesOnExistedPair() {
+	fmt.Println("Running testcase: TestWithdrawExceededSharesOnExistedPair")
 	beaconHeight := uint64(1001)
 	pair := lvdb.PDEPoolForPair{
 		Token1IDStr:     "0000000000000000000000000000000000000000000000000000000000000005",
@@ -558,37 +458,27 @@ func (suite *PDEProducerSuite) TestWithdrawExceededSharesOnToken2OfExistedPair()
 		pairKey: &pair,
 	}
 
-	shareKey1 := string(lvdb.BuildPDESharesKey(
-		beaconHeight-1,
-		"0000000000000000000000000000000000000000000000000000000000000005",
-		"0000000000000000000000000000000000000000000000000000000000000007",
-		"0000000000000000000000000000000000000000000000000000000000000005",
-		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
-	))
-	shareKey2 := string(lvdb.BuildPDESharesKey(
+	shareKey1 := string(lvdb.BuildPDESharesKeyV2(
 		beaconHeight-1,
 		"0000000000000000000000000000000000000000000000000000000000000005",
 		"0000000000000000000000000000000000000000000000000000000000000007",
-		"0000000000000000000000000000000000000000000000000000000000000007",
 		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
 	))
-	shareKey3 := string(lvdb.BuildPDESharesKey(
+	shareKey2 := string(lvdb.BuildPDESharesKeyV2(
 		beaconHeight-1,
 		"0000000000000000000000000000000000000000000000000000000000000005",
 		"0000000000000000000000000000000000000000000000000000000000000007",
-		"0000000000000000000000000000000000000000000000000000000000000007",
 		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj-new",
 	))
 	suite.currentPDEState.PDEShares = map[string]uint64{
-		shareKey1: 500000000000,
-		shareKey2: 60000000000000,
-		shareKey3: 20000000000000,
+		shareKey1: 60000000000000,
+		shareKey2: 20000000000000,
 	}
 
+	// the withdrawal is capped to the 60000000000000 shares of the withdrawer, 3/4 of the pair
 	reqAction := buildPDEWithdrawReqAction(
 		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
 		"0000000000000000000000000000000000000000000000000000000000000005",
-		0,
 		"0000000000000000000000000000000000000000000000000000000000000007",
 		60000000000000+1000,
 	)
@@ -596,16 +486,27 @@ func (suite *PDEProducerSuite) TestWithdrawExceededSharesOnToken2OfExistedPair()
 	contentStr := reqAction[1]
 	shardID := byte(1)
 	bc := &BlockChain{}
+	wdAcceptedContent1 := metadata.PDEWithdrawalAcceptedContent{
+		WithdrawalTokenIDStr: "0000000000000000000000000000000000000000000000000000000000000005",
+		WithdrawerAddressStr: "12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
+		DeductingPoolValue:   375000000000,
+		DeductingShares:      60000000000000,
+		PairToken1IDStr:      "0000000000000000000000000000000000000000000000000000000000000005",
+		PairToken2IDStr:      "0000000000000000000000000000000000000000000000000000000000000007",
+		TxReqID:              common.Hash{},
+		ShardID:              shardID,
+	}
 	wdAcceptedContent2 := metadata.PDEWithdrawalAcceptedContent{
 		WithdrawalTokenIDStr: "0000000000000000000000000000000000000000000000000000000000000007",
 		WithdrawerAddressStr: "12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
 		DeductingPoolValue:   45000000000000,
-		DeductingShares:      60000000000000,
+		DeductingShares:      0,
 		PairToken1IDStr:      "0000000000000000000000000000000000000000000000000000000000000005",
 		PairToken2IDStr:      "0000000000000000000000000000000000000000000000000000000000000007",
 		TxReqID:              common.Hash{},
 		ShardID:              shardID,
 	}
+	wdAcceptedContent1Bytes, err := json.Marshal(wdAcceptedContent1)
 	wdAcceptedContent2Bytes, err := json.Marshal(wdAcceptedContent2)
 	newInsts, err := bc.buildInstructionsForPDEWithdrawal(
 		contentStr,
@@ -615,23 +516,22 @@ func (suite *PDEProducerSuite) TestWithdrawExceededSharesOnToken2OfExistedPair()
 		beaconHeight-1,
 	)
 	suite.Equal(err, nil)
-	suite.Equal(len(newInsts), 1)
+	suite.Equal(len(newInsts), 2)
 	suite.Equal(len(newInsts[0]), 4)
 	suite.Equal(newInsts[0][0], strconv.Itoa(metaType))
 	suite.Equal(newInsts[0][1], strconv.Itoa(int(shardID)))
 	suite.Equal(newInsts[0][2], "accepted")
-	suite.Equal(newInsts[0][3], string(wdAcceptedContent2Bytes))
+	suite.Equal(newInsts[0][3], string(wdAcceptedContent1Bytes))
+	suite.Equal(newInsts[1][3], string(wdAcceptedContent2Bytes))
 
 	remainingTk1PoolVal := suite.currentPDEState.PDEPoolPairs[pairKey].Token1PoolValue
 	remainingTk2PoolVal := suite.currentPDEState.PDEPoolPairs[pairKey].Token2PoolValue
-	remainingTk1Shares := suite.currentPDEState.PDEShares[shareKey1]
-	remainingTk2Shares := suite.currentPDEState.PDEShares[shareKey2]
-	remainingTk3Shares := suite.currentPDEState.PDEShares[shareKey3]
-	suite.Equal(remainingTk1PoolVal, uint64(500000000000))
+	remainingShares1 := suite.currentPDEState.PDEShares[shareKey1]
+	remainingShares2 := suite.currentPDEState.PDEShares[shareKey2]
+	suite.Equal(remainingTk1PoolVal, uint64(125000000000))
 	suite.Equal(remainingTk2PoolVal, uint64(15000000000000))
-	suite.Equal(remainingTk1Shares, uint64(500000000000))
-	suite.Equal(remainingTk2Shares, uint64(0))
-	suite.Equal(remainingTk3Shares, uint64(20000000000000))
+	suite.Equal(remainingShares1, uint64(0))
+	suite.Equal(remainingShares2, uint64(20000000000000))
 }
 
 // In order for 'go test' to run this suite, we need to create
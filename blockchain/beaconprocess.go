@@ -116,8 +116,13 @@ func (blockchain *BlockChain) InsertBeaconBlock(beaconBlock *BeaconBlock, isVali
 	} else {
 		Logger.log.Infof("BEACON | SKIP Verify Best State With Beacon Block, Beacon Block Height %+v with hash %+v", beaconBlock.Header.Height, blockHash)
 	}
+	// Mark the block as being committed until processStoreBeaconBlock commits its data
+	err := blockchain.config.DataBase.StorePendingBlockCommit(database.BlockCommit{IsBeacon: true, Height: beaconBlock.Header.Height, Hash: blockHash})
+	if err != nil {
+		return NewBlockChainError(StorePendingBlockCommitError, err)
+	}
 	// Backup beststate
-	err := blockchain.config.DataBase.CleanBackup(true, 0)
+	err = blockchain.config.DataBase.CleanBackup(true, 0)
 	if err != nil {
 		return NewBlockChainError(CleanBackUpError, err)
 	}
@@ -227,13 +232,21 @@ func (blockchain *BlockChain) InsertBeaconBlock(beaconBlock *BeaconBlock, isVali
 	}
 	Logger.log.Infof("BEACON | Process Store Beacon Block Height %+v with hash %+v", beaconBlock.Header.Height, blockHash)
 	if err := blockchain.processStoreBeaconBlock(beaconBlock, snapshotBeaconCommittee, snapshotAllShardCommittee, snapshotRewardReceiver); err != nil {
-		revertErr := blockchain.revertBeaconState()
+		// nothing of the block has been committed, only the best state in memory has to be reverted
+		revertErr := blockchain.revertBeaconBestState()
 		if revertErr != nil {
 			return errors.WithStack(revertErr)
 		}
+		if err := blockchain.config.DataBase.DeletePendingBlockCommit(true, 0); err != nil {
+			return NewBlockChainError(DeletePendingBlockCommitError, err)
+		}
 		return err
 	}
 	blockchain.removeOldDataAfterProcessingBeaconBlock()
+	// the block is committed, a failed pruning is retried after the next block
+	if err := blockchain.pruneBeaconChain(); err != nil {
+		Logger.log.Error(err)
+	}
 	// go metrics.AnalyzeTimeSeriesMetricDataWithTime(map[string]interface{}{
 	// 	metrics.Measurement:      metrics.NumOfBlockInsertToChain,
 	// 	metrics.MeasurementValue: float64(1),
@@ -256,6 +269,7 @@ func (blockchain *BlockChain) InsertBeaconBlock(beaconBlock *BeaconBlock, isVali
 	}
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewBeaconBlockTopic, beaconBlock))
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.BeaconBeststateTopic, blockchain.BestState.Beacon))
+	blockchain.publishFinalizedShardBlocks(beaconBlock)
 
 	// For masternode: broadcast new committee to highways
 	if notifyHighway {
@@ -276,7 +290,10 @@ func (beaconBestState *BeaconBestState) updateNumOfBlocksByProducers(beaconBlock
 		beaconBestState.NumOfBlocksByProducers = map[string]uint64{
 			producer: 1,
 		}
+		beaconBestState.NumOfTimeoutsByProducers = map[string]uint64{}
 	}
+	// Update number of proven timeouts of producers in epoch
+	beaconBestState.NumOfTimeoutsByProducers = addNumOfTimeoutsByProducers(beaconBestState.NumOfTimeoutsByProducers, beaconBlock.ViewChangeCertificates)
 	// Update number of blocks produced by producers in epoch
 	numOfBlks, found := beaconBestState.NumOfBlocksByProducers[producer]
 	if !found {
@@ -425,6 +442,8 @@ func (blockchain *BlockChain) verifyPreProcessingBeaconBlockForSigning(beaconBlo
 	bridgeInstructions := [][]string{}
 	acceptedBlockRewardInstructions := [][]string{}
 	stopAutoStakingInstructions := [][]string{}
+	slashInstructions := [][]string{}
+	slashedCommittees := []string{}
 	statefulActionsByShardID := map[byte][][]string{}
 	// Get Reward Instruction By Epoch
 	if beaconBlock.Header.Height%blockchain.config.ChainParams.Epoch == 1 {
@@ -474,7 +493,7 @@ func (blockchain *BlockChain) verifyPreProcessingBeaconBlockForSigning(beaconBlo
 				}
 			}
 			for _, shardBlock := range shardBlocks {
-				tempShardState, stakeInstruction, tempValidStakePublicKeys, swapInstruction, bridgeInstruction, acceptedBlockRewardInstruction, stopAutoStakingInstruction, statefulActions := blockchain.GetShardStateFromBlock(beaconBlock.Header.Height, shardBlock, shardID, false, validStakePublicKeys)
+				tempShardState, stakeInstruction, tempValidStakePublicKeys, swapInstruction, bridgeInstruction, acceptedBlockRewardInstruction, stopAutoStakingInstruction, statefulActions, slashInstruction := blockchain.GetShardStateFromBlock(beaconBlock.Header.Height, shardBlock, shardID, false, validStakePublicKeys, slashedCommittees)
 				tempShardStates[shardID] = append(tempShardStates[shardID], tempShardState[shardID])
 				stakeInstructions = append(stakeInstructions, stakeInstruction...)
 				swapInstructions[shardID] = append(swapInstructions[shardID], swapInstruction[shardID]...)
@@ -482,6 +501,10 @@ func (blockchain *BlockChain) verifyPreProcessingBeaconBlockForSigning(beaconBlo
 				acceptedBlockRewardInstructions = append(acceptedBlockRewardInstructions, acceptedBlockRewardInstruction)
 				stopAutoStakingInstructions = append(stopAutoStakingInstructions, stopAutoStakingInstruction...)
 				validStakePublicKeys = append(validStakePublicKeys, tempValidStakePublicKeys...)
+				slashInstructions = append(slashInstructions, slashInstruction...)
+				for _, instruction := range slashInstruction {
+					slashedCommittees = append(slashedCommittees, instruction[2])
+				}
 
 				// group stateful actions by shardID
 				_, found := statefulActionsByShardID[shardID]
@@ -504,7 +527,7 @@ func (blockchain *BlockChain) verifyPreProcessingBeaconBlockForSigning(beaconBlo
 	bridgeInstructions = append(bridgeInstructions, statefulInsts...)
 
 	tempInstruction, err := blockchain.BestState.Beacon.GenerateInstruction(beaconBlock.Header.Height,
-		stakeInstructions, swapInstructions, stopAutoStakingInstructions,
+		stakeInstructions, swapInstructions, stopAutoStakingInstructions, slashInstructions,
 		blockchain.BestState.Beacon.CandidateShardWaitingForCurrentRandom,
 		bridgeInstructions, acceptedBlockRewardInstructions,
 		blockchain.config.ChainParams.Epoch, blockchain.config.ChainParams.RandomTime, blockchain)
@@ -555,6 +578,10 @@ func (beaconBestState *BeaconBestState) verifyBestStateWithBeaconBlock(beaconBlo
 	if strings.Compare(string(tempProducer), producerPublicKey) != 0 {
 		return NewBlockChainError(BeaconBlockProducerError, fmt.Errorf("Expect Producer Public Key to be equal but get %+v From Index, %+v From Header", tempProducer, producerPublicKey))
 	}
+	// verify the proofs that the proposers of the previous rounds timed out
+	if err := verifyViewChangeCertificates(common.BeaconChainKey, beaconBestState.BestBlockHash, beaconBlock.Header.Height, beaconBlock.Header.Round, beaconBlock.ViewChangeCertificates, beaconBlock.Header.ViewChangeRoot, beaconBestState.BeaconCommittee, beaconBestState.BeaconProposerIndex, beaconBestState.ConsensusAlgorithm); err != nil {
+		return err
+	}
 
 	//=============End Verify Aggegrate signature
 	if !beaconBestState.BestBlockHash.IsEqual(&beaconBlock.Header.PreviousBlockHash) {
@@ -866,14 +893,17 @@ func (beaconBestState *BeaconBestState) initBeaconBestState(genesisBeaconBlock *
 		newShardCandidate = append(newShardCandidate, tempNewShardCandidate...)
 	}
 	beaconBestState.BeaconCommittee = append(beaconBestState.BeaconCommittee, newBeaconCandidate...)
-	beaconBestState.ConsensusAlgorithm = common.BlsConsensus
+	if beaconBestState.ConsensusAlgorithm == common.EmptyString {
+		beaconBestState.ConsensusAlgorithm = common.BlsConsensus
+	}
 	beaconBestState.ShardConsensusAlgorithm = make(map[byte]string)
 	for shardID := 0; shardID < beaconBestState.ActiveShards; shardID++ {
 		beaconBestState.ShardCommittee[byte(shardID)] = append(beaconBestState.ShardCommittee[byte(shardID)], newShardCandidate[shardID*beaconBestState.MinShardCommitteeSize:(shardID+1)*beaconBestState.MinShardCommitteeSize]...)
-		beaconBestState.ShardConsensusAlgorithm[byte(shardID)] = common.BlsConsensus
+		beaconBestState.ShardConsensusAlgorithm[byte(shardID)] = beaconBestState.ConsensusAlgorithm
 	}
 	beaconBestState.Epoch = 1
 	beaconBestState.NumOfBlocksByProducers = make(map[string]uint64)
+	beaconBestState.NumOfTimeoutsByProducers = make(map[string]uint64)
 	return nil
 }
 
@@ -934,6 +964,12 @@ func (beaconBestState *BeaconBestState) processInstruction(instruction []string)
 			}
 		}
 	}
+	if instruction[0] == SlashAction && len(instruction) == 5 {
+		// slashed committee member never stakes again automatically
+		if _, ok := beaconBestState.AutoStaking[instruction[2]]; ok {
+			beaconBestState.AutoStaking[instruction[2]] = false
+		}
+	}
 	if instruction[0] == SwapAction {
 		Logger.log.Info("Swap Instruction", instruction)
 		inPublickeys := strings.Split(instruction[1], ",")
@@ -1136,28 +1172,34 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 
 	Logger.log.Debugf("BEACON | Process Store Beacon Block Height %+v with hash %+v", beaconBlock.Header.Height, beaconBlock.Header.Hash())
 	blockHash := beaconBlock.Header.Hash()
+	dbTx, err := blockchain.config.DataBase.BeginTransaction()
+	if err != nil {
+		return NewBlockChainError(BeginTransactionError, err)
+	}
+	defer dbTx.Discard()
 	for shardID, shardStates := range beaconBlock.Body.ShardState {
 		for _, shardState := range shardStates {
-			err := blockchain.config.DataBase.StoreAcceptedShardToBeacon(shardID, beaconBlock.Header.Height, shardState.Hash)
+			err := dbTx.StoreAcceptedShardToBeacon(shardID, beaconBlock.Header.Height, shardState.Hash)
 			if err != nil {
 				return NewBlockChainError(StoreAcceptedShardToBeaconError, err)
 			}
 		}
 	}
 	Logger.log.Infof("BEACON | Store Committee in Beacon Block Height %+v ", beaconBlock.Header.Height)
-	if err := blockchain.config.DataBase.StoreShardCommitteeByHeight(beaconBlock.Header.Height, snapshotAllShardCommittees); err != nil {
+	if err := dbTx.StoreShardCommitteeByHeight(beaconBlock.Header.Height, snapshotAllShardCommittees); err != nil {
 		return NewBlockChainError(StoreShardCommitteeByHeightError, err)
 	}
-	if err := blockchain.config.DataBase.StoreBeaconCommitteeByHeight(beaconBlock.Header.Height, snapshotBeaconCommittees); err != nil {
+	if err := dbTx.StoreBeaconCommitteeByHeight(beaconBlock.Header.Height, snapshotBeaconCommittees); err != nil {
 		return NewBlockChainError(StoreBeaconCommitteeByHeightError, err)
 	}
-	if err := blockchain.config.DataBase.StoreRewardReceiverByHeight(beaconBlock.Header.Height, snapshotRewardReceivers); err != nil {
+	if err := dbTx.StoreRewardReceiverByHeight(beaconBlock.Header.Height, snapshotRewardReceivers); err != nil {
 		return NewBlockChainError(StoreRewardReceiverByHeightError, err)
 	}
-	if err := blockchain.config.DataBase.StoreAutoStakingByHeight(beaconBlock.Header.Height, blockchain.BestState.Beacon.AutoStaking); err != nil {
+	if err := dbTx.StoreAutoStakingByHeight(beaconBlock.Header.Height, blockchain.BestState.Beacon.AutoStaking); err != nil {
 		return NewBlockChainError(StoreAutoStakingByHeightError, err)
 	}
 	//================================Store cross shard state ==================================
+	var updatedCrossShardPools []byte
 	if beaconBlock.Body.ShardState != nil {
 		GetBeaconBestState().lock.Lock()
 		lastCrossShardState := GetBeaconBestState().LastCrossShardState
@@ -1172,14 +1214,14 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 					}
 					lastHeight := lastCrossShardState[fromShard][toShard] // get last cross shard height from shardID  to crossShardShardID
 					waitHeight := shardBlock.Height
-					err := blockchain.config.DataBase.StoreCrossShardNextHeight(fromShard, toShard, lastHeight, waitHeight)
+					err := dbTx.StoreCrossShardNextHeight(fromShard, toShard, lastHeight, waitHeight)
 					if err != nil {
 						GetBeaconBestState().lock.Unlock()
 						return NewBlockChainError(StoreCrossShardNextHeightError, err)
 					}
 					//beacon process shard_to_beacon in order so cross shard next height also will be saved in order
 					//dont care overwrite this value
-					err = blockchain.config.DataBase.StoreCrossShardNextHeight(fromShard, toShard, waitHeight, 0)
+					err = dbTx.StoreCrossShardNextHeight(fromShard, toShard, waitHeight, 0)
 					if err != nil {
 						GetBeaconBestState().lock.Unlock()
 						return NewBlockChainError(StoreCrossShardNextHeightError, err)
@@ -1190,41 +1232,49 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 					lastCrossShardState[fromShard][toShard] = waitHeight //update lastHeight to waitHeight
 				}
 			}
-			blockchain.config.CrossShardPool[fromShard].UpdatePool()
+			updatedCrossShardPools = append(updatedCrossShardPools, fromShard)
 		}
 		GetBeaconBestState().lock.Unlock()
 	}
 	//=============================END Store cross shard state ==================================
-	if err := blockchain.config.DataBase.StoreBeaconBlockIndex(blockHash, beaconBlock.Header.Height); err != nil {
+	if err := dbTx.StoreBeaconBlockIndex(blockHash, beaconBlock.Header.Height); err != nil {
 		return NewBlockChainError(StoreBeaconBlockIndexError, err)
 	}
 
-	batchPutData := []database.BatchData{}
-
 	Logger.log.Debugf("Store Beacon BestState Height %+v", beaconBlock.Header.Height)
-	if err := blockchain.StoreBeaconBestState(&batchPutData); err != nil {
+	if err := blockchain.StoreBeaconBestState(dbTx); err != nil {
 		return NewBlockChainError(StoreBeaconBestStateError, err)
 	}
 	Logger.log.Debugf("Store Beacon Block Height %+v with Hash %+v ", beaconBlock.Header.Height, blockHash)
-	if err := blockchain.config.DataBase.StoreBeaconBlock(beaconBlock, blockHash, &batchPutData); err != nil {
+	if err := dbTx.StoreBeaconBlock(beaconBlock, blockHash, nil); err != nil {
 		return NewBlockChainError(StoreBeaconBlockError, err)
 	}
 
-	err := blockchain.updateDatabaseWithBlockRewardInfo(beaconBlock, &batchPutData)
+	err = blockchain.updateDatabaseWithBlockRewardInfo(beaconBlock, dbTx)
 	if err != nil {
 		return NewBlockChainError(UpdateDatabaseWithBlockRewardInfoError, err)
 	}
 	// execute, store
-	err = blockchain.processBridgeInstructions(beaconBlock, &batchPutData)
+	err = blockchain.processBridgeInstructions(beaconBlock, dbTx)
 	if err != nil {
 		return NewBlockChainError(ProcessBridgeInstructionError, err)
 	}
 
 	// execute, store
-	err = blockchain.processPDEInstructions(beaconBlock, &batchPutData)
+	err = blockchain.processPDEInstructions(beaconBlock, dbTx)
 	if err != nil {
 		return NewBlockChainError(ProcessPDEInstructionError, err)
 	}
 
-	return blockchain.config.DataBase.PutBatch(batchPutData)
+	if err := dbTx.DeletePendingBlockCommit(true, 0); err != nil {
+		return NewBlockChainError(DeletePendingBlockCommitError, err)
+	}
+	if err := dbTx.Commit(); err != nil {
+		return NewBlockChainError(CommitTransactionError, err)
+	}
+	// cross shard pools read the next heights stored above, so they are updated once the block is committed
+	for _, fromShard := range updatedCrossShardPools {
+		blockchain.config.CrossShardPool[fromShard].UpdatePool()
+	}
+	return nil
 }
@@ -107,10 +107,10 @@ func (blockGenerator *BlockGenerator) NewBlockBeacon(round int, shardsToBeaconLi
 			return nil, NewBlockChainError(BuildRewardInstructionError, err)
 		}
 	}
-	tempShardState, stakeInstructions, swapInstructions, bridgeInstructions, acceptedRewardInstructions, stopAutoStakingInstructions := blockGenerator.GetShardState(beaconBestState, shardsToBeaconLimit)
+	tempShardState, stakeInstructions, swapInstructions, bridgeInstructions, acceptedRewardInstructions, stopAutoStakingInstructions, slashInstructions := blockGenerator.GetShardState(beaconBestState, shardsToBeaconLimit)
 	Logger.log.Infof("In NewBlockBeacon tempShardState: %+v", tempShardState)
 	tempInstruction, err := beaconBestState.GenerateInstruction(
-		beaconBlock.Header.Height, stakeInstructions, swapInstructions, stopAutoStakingInstructions,
+		beaconBlock.Header.Height, stakeInstructions, swapInstructions, stopAutoStakingInstructions, slashInstructions,
 		beaconBestState.CandidateShardWaitingForCurrentRandom, bridgeInstructions, acceptedRewardInstructions, blockGenerator.chain.config.ChainParams.Epoch,
 		blockGenerator.chain.config.ChainParams.RandomTime, blockGenerator.chain,
 	)
@@ -270,12 +270,15 @@ func (blockGenerator *BlockGenerator) NewBlockBeacon(round int, shardsToBeaconLi
 	4. bridge instructions
 	5. accepted reward instructions
 	6. stop auto staking instructions
+	7. slash instructions
 */
-func (blockGenerator *BlockGenerator) GetShardState(beaconBestState *BeaconBestState, shardsToBeacon map[byte]uint64) (map[byte][]ShardState, [][]string, map[byte][][]string, [][]string, [][]string, [][]string) {
+func (blockGenerator *BlockGenerator) GetShardState(beaconBestState *BeaconBestState, shardsToBeacon map[byte]uint64) (map[byte][]ShardState, [][]string, map[byte][][]string, [][]string, [][]string, [][]string, [][]string) {
 	shardStates := make(map[byte][]ShardState)
 	validStakeInstructions := [][]string{}
 	validStakePublicKeys := []string{}
 	validStopAutoStakingInstructions := [][]string{}
+	validSlashInstructions := [][]string{}
+	slashedCommittees := []string{}
 	validSwapInstructions := make(map[byte][][]string)
 	//Get shard to beacon block from pool
 	Logger.log.Infof("In GetShardState shardsToBeacon limit: %+v", shardsToBeacon)
@@ -320,7 +323,7 @@ func (blockGenerator *BlockGenerator) GetShardState(beaconBestState *BeaconBestS
 		}
 		Logger.log.Infof("Beacon Producer/ AFTER FILTER, Shard %+v ONLY GET %+v block", shardID, totalBlock+1)
 		for _, shardBlock := range shardBlocks[:totalBlock+1] {
-			shardState, validStakeInstruction, tempValidStakePublicKeys, validSwapInstruction, bridgeInstruction, acceptedRewardInstruction, stopAutoStakingInstruction, statefulActions := blockGenerator.chain.GetShardStateFromBlock(beaconBestState.BeaconHeight+1, shardBlock, shardID, true, validStakePublicKeys)
+			shardState, validStakeInstruction, tempValidStakePublicKeys, validSwapInstruction, bridgeInstruction, acceptedRewardInstruction, stopAutoStakingInstruction, statefulActions, slashInstruction := blockGenerator.chain.GetShardStateFromBlock(beaconBestState.BeaconHeight+1, shardBlock, shardID, true, validStakePublicKeys, slashedCommittees)
 			shardStates[shardID] = append(shardStates[shardID], shardState[shardID])
 			validStakeInstructions = append(validStakeInstructions, validStakeInstruction...)
 			validSwapInstructions[shardID] = append(validSwapInstructions[shardID], validSwapInstruction[shardID]...)
@@ -328,6 +331,10 @@ func (blockGenerator *BlockGenerator) GetShardState(beaconBestState *BeaconBestS
 			acceptedRewardInstructions = append(acceptedRewardInstructions, acceptedRewardInstruction)
 			validStopAutoStakingInstructions = append(validStopAutoStakingInstructions, stopAutoStakingInstruction...)
 			validStakePublicKeys = append(validStakePublicKeys, tempValidStakePublicKeys...)
+			validSlashInstructions = append(validSlashInstructions, slashInstruction...)
+			for _, instruction := range slashInstruction {
+				slashedCommittees = append(slashedCommittees, instruction[2])
+			}
 
 			// group stateful actions by shardID
 			_, found := statefulActionsByShardID[shardID]
@@ -345,7 +352,7 @@ func (blockGenerator *BlockGenerator) GetShardState(beaconBestState *BeaconBestS
 		blockGenerator.chain.GetDatabase(),
 	)
 	bridgeInstructions = append(bridgeInstructions, statefulInsts...)
-	return shardStates, validStakeInstructions, validSwapInstructions, bridgeInstructions, acceptedRewardInstructions, validStopAutoStakingInstructions
+	return shardStates, validStakeInstructions, validSwapInstructions, bridgeInstructions, acceptedRewardInstructions, validStopAutoStakingInstructions, validSlashInstructions
 }
 
 /*
@@ -359,12 +366,15 @@ func (blockGenerator *BlockGenerator) GetShardState(beaconBestState *BeaconBestS
 	+ ["stake", "pubkey1,pubkey2,..." "beacon" "txStake1,txStake2,..." "rewardReceiver1,rewardReceiver2,...", "flag1,flag2..."]
 	- assign instruction
 	+ ["assign" "shardCandidate1,shardCandidate2,..." "shard" "{shardID}"]
+	- slash instruction
+	+ ["slash" "chainID" "committeePublicKey" "height" "round"]
 */
 func (beaconBestState *BeaconBestState) GenerateInstruction(
 	newBeaconHeight uint64,
 	stakeInstructions [][]string,
 	swapInstructions map[byte][][]string,
 	stopAutoStakingInstructions [][]string,
+	slashInstructions [][]string,
 	shardCandidates []incognitokey.CommitteePublicKey,
 	bridgeInstructions [][]string,
 	acceptedRewardInstructions [][]string,
@@ -426,6 +436,8 @@ func (beaconBestState *BeaconBestState) GenerateInstruction(
 	instructions = append(instructions, stakeInstructions...)
 	// Stop Auto Staking
 	instructions = append(instructions, stopAutoStakingInstructions...)
+	// Slash
+	instructions = append(instructions, slashInstructions...)
 	// Random number for Assign Instruction
 	if newBeaconHeight%chainParamEpoch > randomTime && !beaconBestState.IsGetRandomNumber {
 		var err error
@@ -564,6 +576,8 @@ func (beaconBestState *BeaconBestState) GetValidStakers(stakers []string) []stri
 	- ["stake" "pubkey1,pubkey2,..." "beacon" "txStakeHash1, txStakeHash2,..." "txStakeRewardReceiver1, txStakeRewardReceiver2,..." "flag1,flag2,..."]
 	Stop Auto Staking:
 	- ["stopautostaking" "pubkey1,pubkey2,..."]
+	Slash:
+	- ["slash" "chainID" "committeePublicKey" "votes"]
 	Return Params:
 	1. ShardState
 	2. Stake Instruction
@@ -571,14 +585,18 @@ func (beaconBestState *BeaconBestState) GetValidStakers(stakers []string) []stri
 	4. Bridge Instruction
 	5. Accepted BlockReward Instruction
 	6. StopAutoStakingInstruction
+	7. Stateful Actions
+	8. Slash Instruction
 */
-func (blockchain *BlockChain) GetShardStateFromBlock(newBeaconHeight uint64, shardBlock *ShardToBeaconBlock, shardID byte, isProducer bool, validStakePublicKeys []string) (map[byte]ShardState, [][]string, []string, map[byte][][]string, [][]string, []string, [][]string, [][]string) {
+func (blockchain *BlockChain) GetShardStateFromBlock(newBeaconHeight uint64, shardBlock *ShardToBeaconBlock, shardID byte, isProducer bool, validStakePublicKeys []string, slashedCommittees []string) (map[byte]ShardState, [][]string, []string, map[byte][][]string, [][]string, []string, [][]string, [][]string, [][]string) {
 	//Variable Declaration
 	shardStates := make(map[byte]ShardState)
 	stakeInstructions := [][]string{}
 	swapInstructions := make(map[byte][][]string)
 	stopAutoStakingInstructions := [][]string{}
 	stopAutoStakingInstructionsFromBlock := [][]string{}
+	slashInstructionsFromBlock := [][]string{}
+	slashInstructions := [][]string{}
 	stakeInstructionFromShardBlock := [][]string{}
 	swapInstructionFromShardBlock := [][]string{}
 	bridgeInstructions := [][]string{}
@@ -632,6 +650,9 @@ func (blockchain *BlockChain) GetShardStateFromBlock(newBeaconHeight uint64, sha
 				}
 				stopAutoStakingInstructionsFromBlock = append(stopAutoStakingInstructionsFromBlock, instruction)
 			}
+			if instruction[0] == SlashAction {
+				slashInstructionsFromBlock = append(slashInstructionsFromBlock, instruction)
+			}
 		}
 	}
 	if len(stakeInstructionFromShardBlock) != 0 {
@@ -715,6 +736,15 @@ func (blockchain *BlockChain) GetShardStateFromBlock(newBeaconHeight uint64, sha
 	if len(stopAutoStakingPublicKeys) > 0 {
 		stopAutoStakingInstructions = append(stopAutoStakingInstructions, []string{StopAutoStake, strings.Join(stopAutoStakingPublicKeys, ",")})
 	}
+	// Verify equivocation proofs with committees of beacon beststate, do not lock beststate
+	tempSlashedCommittees := append([]string{}, slashedCommittees...)
+	for _, instruction := range slashInstructionsFromBlock {
+		slashInstruction := blockchain.buildSlashInstruction(instruction, newBeaconHeight, tempSlashedCommittees)
+		if slashInstruction != nil {
+			slashInstructions = append(slashInstructions, slashInstruction)
+			tempSlashedCommittees = append(tempSlashedCommittees, slashInstruction[2])
+		}
+	}
 	// Create bridge instruction
 	if len(shardBlock.Instructions) > 0 || shardBlock.Header.Height%10 == 0 {
 		BLogger.log.Debugf("Included shardID %d, block %d, insts: %s", shardID, shardBlock.Header.Height, shardBlock.Instructions)
@@ -741,7 +771,7 @@ func (blockchain *BlockChain) GetShardStateFromBlock(newBeaconHeight uint64, sha
 	statefulActions := blockchain.collectStatefulActions(shardBlock.Instructions)
 
 	Logger.log.Infof("Becon Produce: Got Shard Block %+v Shard %+v \n", shardBlock.Header.Height, shardID)
-	return shardStates, stakeInstructions, tempValidStakePublicKeys, swapInstructions, bridgeInstructions, acceptedRewardInstructions, stopAutoStakingInstructions, statefulActions
+	return shardStates, stakeInstructions, tempValidStakePublicKeys, swapInstructions, bridgeInstructions, acceptedRewardInstructions, stopAutoStakingInstructions, statefulActions, slashInstructions
 }
 
 // ["random" "{nonce}" "{blockheight}" "{timestamp}" "{bitcoinTimestamp}"]
@@ -66,6 +66,8 @@ type Config struct {
 	IsBlockGenStarted bool
 	PubSubManager     *pubsub.PubSubManager
 	RandomClient      btc.RandomClient
+	PruneHeights      uint64 // keep block bodies and PDE state of the last PruneHeights beacon heights, 0 disables pruning
+	MetadataTxIndex   bool   // index the txs of shard blocks by metadata type
 	Server            interface {
 		BoardcastNodeState() error
 		PublishNodeState(userLayer string, shardID int) error
@@ -210,6 +212,9 @@ func (blockchain *BlockChain) initChainState() error {
 		Beacon: nil,
 		Shard:  make(map[byte]*ShardBestState),
 	}
+	if err := blockchain.recoverPendingBlockCommits(); err != nil {
+		return err
+	}
 
 	bestStateBeaconBytes, err := blockchain.config.DataBase.FetchBeaconBestState()
 	if err == nil {
@@ -288,6 +293,40 @@ func (blockchain *BlockChain) initChainState() error {
 // genesis block.  This includes creating the necessary buckets and inserting
 // the genesis block, so it must only be called on an uninitialized database.
 */
+/*
+// recoverPendingBlockCommits checks the blocks whose commit was interrupted, e.g. by a crash.
+// The data of a block is committed in one transaction which also deletes its pending mark, so
+// such a block must not be in the database and the backup made before inserting it is dropped.
+*/
+func (blockchain *BlockChain) recoverPendingBlockCommits() error {
+	blockCommits, err := blockchain.config.DataBase.FetchPendingBlockCommits()
+	if err != nil {
+		return NewBlockChainError(PendingBlockCommitError, err)
+	}
+	for _, blockCommit := range blockCommits {
+		var isExist bool
+		if blockCommit.IsBeacon {
+			isExist, err = blockchain.config.DataBase.HasBeaconBlock(blockCommit.Hash)
+		} else {
+			isExist, err = blockchain.config.DataBase.HasBlock(blockCommit.Hash)
+		}
+		if err != nil {
+			return NewBlockChainError(PendingBlockCommitError, err)
+		}
+		if isExist {
+			return NewBlockChainError(PendingBlockCommitError, fmt.Errorf("block %+v at height %+v is stored but still marked as pending, database is inconsistent", blockCommit.Hash, blockCommit.Height))
+		}
+		Logger.log.Warnf("Block %+v at height %+v (beacon %+v, shard %+v) was not committed, drop its backup", blockCommit.Hash, blockCommit.Height, blockCommit.IsBeacon, blockCommit.ShardID)
+		if err := blockchain.config.DataBase.CleanBackup(blockCommit.IsBeacon, blockCommit.ShardID); err != nil {
+			return NewBlockChainError(CleanBackUpError, err)
+		}
+		if err := blockchain.config.DataBase.DeletePendingBlockCommit(blockCommit.IsBeacon, blockCommit.ShardID); err != nil {
+			return NewBlockChainError(DeletePendingBlockCommitError, err)
+		}
+	}
+	return nil
+}
+
 func (blockchain *BlockChain) initShardState(shardID byte) error {
 	blockchain.BestState.Shard[shardID] = NewBestStateShardWithConfig(shardID, blockchain.config.ChainParams)
 	// Create a new block from genesis block and set it as best block of chain
@@ -316,7 +355,7 @@ func (blockchain *BlockChain) initShardState(shardID byte) error {
 	if err != nil {
 		return err
 	}
-	err = blockchain.processStoreShardBlockAndUpdateDatabase(&initBlock)
+	err = blockchain.processStoreShardBlockAndUpdateDatabase(&initBlock, nil)
 	if err != nil {
 		return err
 	}
@@ -331,7 +370,7 @@ func (blockchain *BlockChain) initBeaconState() error {
 		return err
 	}
 	// Insert new block into beacon chain
-	if err := blockchain.StoreBeaconBestState(nil); err != nil {
+	if err := blockchain.StoreBeaconBestState(blockchain.config.DataBase); err != nil {
 		Logger.log.Error("Error Store best state for block", blockchain.BestState.Beacon.BestBlockHash, "in beacon chain")
 		return NewBlockChainError(UnExpectedError, err)
 	}
@@ -492,15 +531,15 @@ func (blockchain *BlockChain) GetShardBlockByHash(hash common.Hash) (*ShardBlock
 /*
 Store best state of block(best block, num of tx, ...) into Database
 */
-func (blockchain *BlockChain) StoreBeaconBestState(bd *[]database.BatchData) error {
-	return blockchain.config.DataBase.StoreBeaconBestState(blockchain.BestState.Beacon, bd)
+func (blockchain *BlockChain) StoreBeaconBestState(db database.DatabaseInterface) error {
+	return db.StoreBeaconBestState(blockchain.BestState.Beacon, nil)
 }
 
 /*
 Store best state of block(best block, num of tx, ...) into Database
 */
-func (blockchain *BlockChain) StoreShardBestState(shardID byte, bd *[]database.BatchData) error {
-	return blockchain.config.DataBase.StoreShardBestState(blockchain.BestState.Shard[shardID], shardID, bd)
+func (blockchain *BlockChain) StoreShardBestState(shardID byte, db database.DatabaseInterface) error {
+	return db.StoreShardBestState(blockchain.BestState.Shard[shardID], shardID, nil)
 }
 
 /*
@@ -519,8 +558,8 @@ func (blockchain *BlockChain) GetShardBestState(shardID byte) (*ShardBestState,
 /*
 Store block into Database
 */
-func (blockchain *BlockChain) StoreShardBlock(block *ShardBlock, bd *[]database.BatchData) error {
-	return blockchain.config.DataBase.StoreShardBlock(block, block.Header.Hash(), block.Header.ShardID, bd)
+func (blockchain *BlockChain) StoreShardBlock(block *ShardBlock, db database.DatabaseInterface) error {
+	return db.StoreShardBlock(block, block.Header.Hash(), block.Header.ShardID, nil)
 }
 
 /*
@@ -528,21 +567,21 @@ Save index(height) of block by block hash
 and
 Save block hash by index(height) of block
 */
-func (blockchain *BlockChain) StoreShardBlockIndex(block *ShardBlock, bd *[]database.BatchData) error {
-	return blockchain.config.DataBase.StoreShardBlockIndex(block.Header.Hash(), block.Header.Height, block.Header.ShardID, bd)
+func (blockchain *BlockChain) StoreShardBlockIndex(block *ShardBlock, db database.DatabaseInterface) error {
+	return db.StoreShardBlockIndex(block.Header.Hash(), block.Header.Height, block.Header.ShardID, nil)
 }
 
-func (blockchain *BlockChain) StoreTransactionIndex(txHash *common.Hash, blockHash common.Hash, index int, bd *[]database.BatchData) error {
-	return blockchain.config.DataBase.StoreTransactionIndex(*txHash, blockHash, index, bd)
+func (blockchain *BlockChain) StoreTransactionIndex(txHash *common.Hash, blockHash common.Hash, index int, db database.DatabaseInterface) error {
+	return db.StoreTransactionIndex(*txHash, blockHash, index, nil)
 }
 
 /*
 Uses an existing database to update the set of used tx by saving list serialNumber of privacy,
 this is a list tx-out which are used by a new tx
 */
-func (blockchain *BlockChain) StoreSerialNumbersFromTxViewPoint(view TxViewPoint) error {
+func (blockchain *BlockChain) StoreSerialNumbersFromTxViewPoint(view TxViewPoint, db database.DatabaseInterface) error {
 	if len(view.listSerialNumbers) > 0 {
-		err := blockchain.config.DataBase.StoreSerialNumbers(*view.tokenID, view.listSerialNumbers, view.shardID)
+		err := db.StoreSerialNumbers(*view.tokenID, view.listSerialNumbers, view.shardID)
 		if err != nil {
 			return err
 		}
@@ -554,7 +593,7 @@ func (blockchain *BlockChain) StoreSerialNumbersFromTxViewPoint(view TxViewPoint
 Uses an existing database to update the set of used tx by saving list SNDerivator of privacy,
 this is a list tx-out which are used by a new tx
 */
-func (blockchain *BlockChain) StoreSNDerivatorsFromTxViewPoint(view TxViewPoint) error {
+func (blockchain *BlockChain) StoreSNDerivatorsFromTxViewPoint(view TxViewPoint, db database.DatabaseInterface) error {
 	// commitment
 	keys := make([]string, 0, len(view.mapCommitments))
 	for k := range view.mapCommitments {
@@ -574,7 +613,7 @@ func (blockchain *BlockChain) StoreSNDerivatorsFromTxViewPoint(view TxViewPoint)
 		// if pubkeyShardID == shardID {
 		snDsArray := view.mapSnD[k]
 		//for _, snd := range snDsArray {
-		err := blockchain.config.DataBase.StoreSNDerivators(*view.tokenID, snDsArray)
+		err := db.StoreSNDerivators(*view.tokenID, snDsArray)
 		if err != nil {
 			return err
 		}
@@ -591,7 +630,7 @@ func (blockchain *BlockChain) StoreSNDerivatorsFromTxViewPoint(view TxViewPoint)
 	// 	pubkeyShardID := common.GetShardIDFromLastByte(lastByte)
 	// 	if pubkeyShardID == shardID {
 	// 		for _, item1 := range items {
-	// 			err := blockchain.config.DataBase.StoreSNDerivators(view.tokenID, item1, view.shardID)
+	// 			err := db.StoreSNDerivators(view.tokenID, item1, view.shardID)
 	// 			if err != nil {
 	// 				return err
 	// 			}
@@ -604,7 +643,7 @@ func (blockchain *BlockChain) StoreSNDerivatorsFromTxViewPoint(view TxViewPoint)
 // StoreTxByPublicKey - store txID by public key of receiver,
 // use this data to get tx which send to receiver, because we can get this tx from cross shard
 // -> only fullnode data can provide this data for all
-func (blockchain *BlockChain) StoreTxByPublicKey(view *TxViewPoint) error {
+func (blockchain *BlockChain) StoreTxByPublicKey(view *TxViewPoint, db database.DatabaseInterface) error {
 	for data := range view.txByPubKey {
 		dataArr := strings.Split(data, "_")
 		pubKey, _, err := base58.Base58Check{}.Decode(dataArr[0])
@@ -622,7 +661,7 @@ func (blockchain *BlockChain) StoreTxByPublicKey(view *TxViewPoint) error {
 		}
 		shardID, _ := strconv.Atoi(dataArr[2])
 
-		err = blockchain.config.DataBase.StoreTxByPublicKey(pubKey, txID, byte(shardID))
+		err = db.StoreTxByPublicKey(pubKey, txID, byte(shardID))
 		if err != nil {
 			return err
 		}
@@ -634,7 +673,7 @@ func (blockchain *BlockChain) StoreTxByPublicKey(view *TxViewPoint) error {
 Uses an existing database to update the set of not used tx by saving list commitments of privacy,
 this is a list tx-in which are used by a new tx
 */
-func (blockchain *BlockChain) StoreCommitmentsFromTxViewPoint(view TxViewPoint, shardID byte) error {
+func (blockchain *BlockChain) StoreCommitmentsFromTxViewPoint(view TxViewPoint, shardID byte, db database.DatabaseInterface) error {
 
 	// commitment and output are the same key in map
 	keys := make([]string, 0, len(view.mapCommitments))
@@ -654,7 +693,7 @@ func (blockchain *BlockChain) StoreCommitmentsFromTxViewPoint(view TxViewPoint,
 		if publicKeyShardID == shardID {
 			// commitment
 			commitmentsArray := view.mapCommitments[k]
-			err = blockchain.config.DataBase.StoreCommitments(*view.tokenID, publicKeyBytes, commitmentsArray, view.shardID)
+			err = db.StoreCommitments(*view.tokenID, publicKeyBytes, commitmentsArray, view.shardID)
 			if err != nil {
 				return err
 			}
@@ -664,7 +703,7 @@ func (blockchain *BlockChain) StoreCommitmentsFromTxViewPoint(view TxViewPoint,
 			for _, outputCoin := range outputCoinArray {
 				outputCoinBytesArray = append(outputCoinBytesArray, outputCoin.Bytes())
 			}
-			err = blockchain.config.DataBase.StoreOutputCoins(*view.tokenID, publicKeyBytes, outputCoinBytesArray, publicKeyShardID)
+			err = db.StoreOutputCoins(*view.tokenID, publicKeyBytes, outputCoinBytesArray, publicKeyShardID)
 			// clear cached data
 			if blockchain.config.MemCache != nil {
 				cachedKey := memcache.GetListOutputcoinCachedKey(publicKeyBytes, view.tokenID, publicKeyShardID)
@@ -687,11 +726,11 @@ func (blockchain *BlockChain) StoreCommitmentsFromTxViewPoint(view TxViewPoint,
 // CreateAndSaveTxViewPointFromBlock - fetch data from block, put into txviewpoint variable and save into db
 // @note: still storage full data of commitments, serialnumbersm snderivator to check double spend
 // @note: this function only work for transaction transfer token/prv within shard
-func (blockchain *BlockChain) CreateAndSaveTxViewPointFromBlock(block *ShardBlock, bd *[]database.BatchData) error {
+func (blockchain *BlockChain) CreateAndSaveTxViewPointFromBlock(block *ShardBlock, db database.DatabaseInterface) error {
 	//startTime := time.Now()
 	// Fetch data from block into tx View point
 	view := NewTxViewPoint(block.Header.ShardID)
-	err := view.fetchTxViewPointFromBlock(blockchain.config.DataBase, block)
+	err := view.fetchTxViewPointFromBlock(db, block)
 	if err != nil {
 		return err
 	}
@@ -702,7 +741,7 @@ func (blockchain *BlockChain) CreateAndSaveTxViewPointFromBlock(block *ShardBloc
 		case transaction.CustomTokenInit:
 			{
 				Logger.log.Info("Store custom token when it is issued", customTokenTx.TxTokenData.PropertyID, customTokenTx.TxTokenData.PropertySymbol, customTokenTx.TxTokenData.PropertyName)
-				err = blockchain.config.DataBase.StoreNormalToken(customTokenTx.TxTokenData.PropertyID, customTokenTx.Hash()[:])
+				err = db.StoreNormalToken(customTokenTx.TxTokenData.PropertyID, customTokenTx.Hash()[:])
 				if err != nil {
 					return err
 				}
@@ -714,7 +753,7 @@ func (blockchain *BlockChain) CreateAndSaveTxViewPointFromBlock(block *ShardBloc
 				//If don't exist then create
 				if !existedToken {
 					Logger.log.Info("Store Cross Shard Custom if It's not existed in DB", customTokenTx.TxTokenData.PropertyID, customTokenTx.TxTokenData.PropertySymbol, customTokenTx.TxTokenData.PropertyName)
-					err = blockchain.config.DataBase.StoreNormalToken(customTokenTx.TxTokenData.PropertyID, customTokenTx.Hash()[:])
+					err = db.StoreNormalToken(customTokenTx.TxTokenData.PropertyID, customTokenTx.Hash()[:])
 					if err != nil {
 						Logger.log.Error("CreateAndSaveTxViewPointFromBlock", err)
 					}
@@ -726,7 +765,7 @@ func (blockchain *BlockChain) CreateAndSaveTxViewPointFromBlock(block *ShardBloc
 				//If don't exist then create
 				if _, ok := listCustomToken[customTokenTx.TxNormalTokenData.PropertyID]; !ok {
 					Logger.log.Info("Store Cross Shard Custom if It's not existed in DB", customTokenTx.TxNormalTokenData.PropertyID, customTokenTx.TxNormalTokenData.PropertySymbol, customTokenTx.TxNormalTokenData.PropertyName)
-					err = blockchain.config.DataBase.StoreCustomToken(&customTokenTx.TxNormalTokenData.PropertyID, customTokenTx.Hash()[:])
+					err = db.StoreCustomToken(&customTokenTx.TxNormalTokenData.PropertyID, customTokenTx.Hash()[:])
 				}*/
 			}
 		case transaction.CustomTokenTransfer:
@@ -738,12 +777,12 @@ func (blockchain *BlockChain) CreateAndSaveTxViewPointFromBlock(block *ShardBloc
 		// Reject Double spend UTXO before enter this state
 		//fmt.Printf("StoreCustomTokenPaymentAddresstHistory/CustomTokenTx: \n VIN %+v VOUT %+v \n", customTokenTx.TxNormalTokenData.Vins, customTokenTx.TxNormalTokenData.Vouts)
 		Logger.log.Info("Store Custom Token History")
-		err = blockchain.StoreCustomTokenPaymentAddresstHistory(customTokenTx, block.Header.ShardID)
+		err = blockchain.StoreCustomTokenPaymentAddresstHistory(customTokenTx, block.Header.ShardID, db)
 		if err != nil {
 			// Skip double spend
 			return err
 		}
-		err = blockchain.config.DataBase.StoreNormalTokenTx(customTokenTx.TxTokenData.PropertyID, block.Header.ShardID, block.Header.Height, indexTx, customTokenTx.Hash()[:])
+		err = db.StoreNormalTokenTx(customTokenTx.TxTokenData.PropertyID, block.Header.ShardID, block.Header.Height, indexTx, customTokenTx.Hash()[:])
 		if err != nil {
 			return err
 		}
@@ -764,7 +803,7 @@ func (blockchain *BlockChain) CreateAndSaveTxViewPointFromBlock(block *ShardBloc
 			{
 				// check is bridge token
 				isBridgeToken := false
-				allBridgeTokensBytes, err := blockchain.config.DataBase.GetAllBridgeTokens()
+				allBridgeTokensBytes, err := db.GetAllBridgeTokens()
 				if err != nil {
 					return err
 				}
@@ -780,7 +819,7 @@ func (blockchain *BlockChain) CreateAndSaveTxViewPointFromBlock(block *ShardBloc
 				// not mintable tx
 				if !isBridgeToken && !privacyCustomTokenTx.TxPrivacyTokenData.Mintable {
 					Logger.log.Info("Store custom token when it is issued", privacyCustomTokenTx.TxPrivacyTokenData.PropertyID, privacyCustomTokenTx.TxPrivacyTokenData.PropertySymbol, privacyCustomTokenTx.TxPrivacyTokenData.PropertyName)
-					err = blockchain.config.DataBase.StorePrivacyToken(privacyCustomTokenTx.TxPrivacyTokenData.PropertyID, privacyCustomTokenTx.Hash()[:])
+					err = db.StorePrivacyToken(privacyCustomTokenTx.TxPrivacyTokenData.PropertyID, privacyCustomTokenTx.Hash()[:])
 					if err != nil {
 						return err
 					}
@@ -791,22 +830,22 @@ func (blockchain *BlockChain) CreateAndSaveTxViewPointFromBlock(block *ShardBloc
 				Logger.log.Info("Transfer custom token %+v", privacyCustomTokenTx)
 			}
 		}
-		err = blockchain.config.DataBase.StorePrivacyTokenTx(privacyCustomTokenTx.TxPrivacyTokenData.PropertyID, block.Header.ShardID, block.Header.Height, int32(indexTx), privacyCustomTokenTx.Hash()[:])
+		err = db.StorePrivacyTokenTx(privacyCustomTokenTx.TxPrivacyTokenData.PropertyID, block.Header.ShardID, block.Header.Height, int32(indexTx), privacyCustomTokenTx.Hash()[:])
 		if err != nil {
 			return err
 		}
 
-		err = blockchain.StoreSerialNumbersFromTxViewPoint(*privacyCustomTokenSubView)
+		err = blockchain.StoreSerialNumbersFromTxViewPoint(*privacyCustomTokenSubView, db)
 		if err != nil {
 			return err
 		}
 
-		err = blockchain.StoreCommitmentsFromTxViewPoint(*privacyCustomTokenSubView, block.Header.ShardID)
+		err = blockchain.StoreCommitmentsFromTxViewPoint(*privacyCustomTokenSubView, block.Header.ShardID, db)
 		if err != nil {
 			return err
 		}
 
-		err = blockchain.StoreSNDerivatorsFromTxViewPoint(*privacyCustomTokenSubView)
+		err = blockchain.StoreSNDerivatorsFromTxViewPoint(*privacyCustomTokenSubView, db)
 		if err != nil {
 			return err
 		}
@@ -815,22 +854,27 @@ func (blockchain *BlockChain) CreateAndSaveTxViewPointFromBlock(block *ShardBloc
 	// updateShardBestState the list serialNumber and commitment, snd set using the state of the used tx view point. This
 	// entails adding the new
 	// ones created by the block.
-	err = blockchain.StoreSerialNumbersFromTxViewPoint(*view)
+	err = blockchain.StoreSerialNumbersFromTxViewPoint(*view, db)
 	if err != nil {
 		return err
 	}
 
-	err = blockchain.StoreCommitmentsFromTxViewPoint(*view, block.Header.ShardID)
+	err = blockchain.StoreCommitmentsFromTxViewPoint(*view, block.Header.ShardID, db)
 	if err != nil {
 		return err
 	}
 
-	err = blockchain.StoreSNDerivatorsFromTxViewPoint(*view)
+	err = blockchain.StoreSNDerivatorsFromTxViewPoint(*view, db)
 	if err != nil {
 		return err
 	}
 
-	err = blockchain.StoreTxByPublicKey(view)
+	err = blockchain.StoreTxByPublicKey(view, db)
+	if err != nil {
+		return err
+	}
+
+	err = blockchain.storeMetadataTxIndex(block, db)
 	if err != nil {
 		return err
 	}
@@ -842,10 +886,10 @@ func (blockchain *BlockChain) CreateAndSaveTxViewPointFromBlock(block *ShardBloc
 	return nil
 }
 
-func (blockchain *BlockChain) CreateAndSaveCrossTransactionCoinViewPointFromBlock(block *ShardBlock, bd *[]database.BatchData) error {
+func (blockchain *BlockChain) CreateAndSaveCrossTransactionCoinViewPointFromBlock(block *ShardBlock, db database.DatabaseInterface) error {
 	// Fetch data from block into tx View point
 	view := NewTxViewPoint(block.Header.ShardID)
-	err := view.fetchCrossTransactionViewPointFromBlock(blockchain.config.DataBase, block)
+	err := view.fetchCrossTransactionViewPointFromBlock(db, block)
 	if err != nil {
 		Logger.log.Error("CreateAndSaveCrossTransactionCoinViewPointFromBlock", err)
 		return err
@@ -873,7 +917,7 @@ func (blockchain *BlockChain) CreateAndSaveCrossTransactionCoinViewPointFromBloc
 				// json.Unmarshal(tokenDataBytes, &crossShardTokenPrivacyMetaData)
 				// fmt.Println("New Token CrossShardTokenPrivacyMetaData", crossShardTokenPrivacyMetaDatla)
 
-				if err := blockchain.config.DataBase.StorePrivacyTokenCrossShard(*tokenID, tokenDataBytes); err != nil {
+				if err := db.StorePrivacyTokenCrossShard(*tokenID, tokenDataBytes); err != nil {
 					return err
 				}
 			}
@@ -892,18 +936,18 @@ func (blockchain *BlockChain) CreateAndSaveCrossTransactionCoinViewPointFromBloc
 				// json.Unmarshal(tokenDataBytes, &crossShardTokenPrivacyMetaData)
 				// fmt.Println("New Token CrossShardTokenPrivacyMetaData", crossShardTokenPrivacyMetaData)
 
-				if err := blockchain.config.DataBase.StorePrivacyCustomTokenCrossShard(tokenID, tokenDataBytes); err != nil {
+				if err := db.StorePrivacyCustomTokenCrossShard(tokenID, tokenDataBytes); err != nil {
 					return err
 				}
 			}
 		}*/
 		// Store both commitment and outcoin
-		err = blockchain.StoreCommitmentsFromTxViewPoint(*privacyCustomTokenSubView, block.Header.ShardID)
+		err = blockchain.StoreCommitmentsFromTxViewPoint(*privacyCustomTokenSubView, block.Header.ShardID, db)
 		if err != nil {
 			return err
 		}
 		// store snd
-		err = blockchain.StoreSNDerivatorsFromTxViewPoint(*privacyCustomTokenSubView)
+		err = blockchain.StoreSNDerivatorsFromTxViewPoint(*privacyCustomTokenSubView, db)
 		if err != nil {
 			return err
 		}
@@ -912,12 +956,12 @@ func (blockchain *BlockChain) CreateAndSaveCrossTransactionCoinViewPointFromBloc
 	// updateShardBestState the list serialNumber and commitment, snd set using the state of the used tx view point. This
 	// entails adding the new
 	// ones created by the block.
-	err = blockchain.StoreCommitmentsFromTxViewPoint(*view, block.Header.ShardID)
+	err = blockchain.StoreCommitmentsFromTxViewPoint(*view, block.Header.ShardID, db)
 	if err != nil {
 		return err
 	}
 
-	err = blockchain.StoreSNDerivatorsFromTxViewPoint(*view)
+	err = blockchain.StoreSNDerivatorsFromTxViewPoint(*view, db)
 	if err != nil {
 		return err
 	}
@@ -929,7 +973,7 @@ func (blockchain *BlockChain) CreateAndSaveCrossTransactionCoinViewPointFromBloc
 // 	KeyWallet: token-paymentAddress  -[-]-  {tokenId}  -[-]-  {paymentAddress}  -[-]-  {txHash}  -[-]-  {voutIndex}
 //   H: value-spent/unspent
 */
-func (blockchain *BlockChain) StoreCustomTokenPaymentAddresstHistory(customTokenTx *transaction.TxNormalToken, shardID byte) error {
+func (blockchain *BlockChain) StoreCustomTokenPaymentAddresstHistory(customTokenTx *transaction.TxNormalToken, shardID byte, db database.DatabaseInterface) error {
 	Splitter := lvdb.Splitter
 	TokenPaymentAddressPrefix := lvdb.TokenPaymentAddressPrefix
 	unspent := lvdb.Unspent
@@ -949,11 +993,11 @@ func (blockchain *BlockChain) StoreCustomTokenPaymentAddresstHistory(customToken
 		paymentAddressKey = append(paymentAddressKey, utxoHash[:]...)
 		paymentAddressKey = append(paymentAddressKey, Splitter...)
 		paymentAddressKey = append(paymentAddressKey, common.Int32ToBytes(int32(voutIndex))...)
-		_, err := blockchain.config.DataBase.HasValue(paymentAddressKey)
+		_, err := db.HasValue(paymentAddressKey)
 		if err != nil {
 			return err
 		}
-		value, err := blockchain.config.DataBase.Get(paymentAddressKey)
+		value, err := db.Get(paymentAddressKey)
 		if err != nil {
 			return err
 		}
@@ -964,7 +1008,7 @@ func (blockchain *BlockChain) StoreCustomTokenPaymentAddresstHistory(customToken
 		}
 		// new value: {value}-spent
 		newValues := values[0] + string(Splitter) + string(spent)
-		if err := blockchain.config.DataBase.Put(paymentAddressKey, []byte(newValues)); err != nil {
+		if err := db.Put(paymentAddressKey, []byte(newValues)); err != nil {
 			return err
 		}
 	}
@@ -994,7 +1038,7 @@ func (blockchain *BlockChain) StoreCustomTokenPaymentAddresstHistory(customToken
 		paymentAddressKey = append(paymentAddressKey, utxoHash[:]...)
 		paymentAddressKey = append(paymentAddressKey, Splitter...)
 		paymentAddressKey = append(paymentAddressKey, common.Int32ToBytes(int32(voutIndex))...)
-		ok, err := blockchain.config.DataBase.HasValue(paymentAddressKey)
+		ok, err := db.HasValue(paymentAddressKey)
 		// Vout already exist
 		if ok {
 			return errors.New("UTXO already exist")
@@ -1004,7 +1048,7 @@ func (blockchain *BlockChain) StoreCustomTokenPaymentAddresstHistory(customToken
 		}
 		// init value: {value}-unspent
 		paymentAddressValue := strconv.Itoa(int(value)) + string(Splitter) + string(unspent) + string(Splitter)
-		if err := blockchain.config.DataBase.Put(paymentAddressKey, []byte(paymentAddressValue)); err != nil {
+		if err := db.Put(paymentAddressKey, []byte(paymentAddressValue)); err != nil {
 			return err
 		}
 		Logger.log.Infof("STORE UTXO FOR CUSTOM TOKEN: tokenID %+v \n paymentAddress %+v \n txHash %+v, voutIndex %+v, value %+v \n", (customTokenTx.TxTokenData.PropertyID).String(), vout.PaymentAddress, customTokenTx.Hash(), voutIndex, value)
@@ -1296,6 +1340,12 @@ func (blockchain *BlockChain) GetListTokenHolders(tokenID *common.Hash) (map[str
 	return result, nil
 }
 
+// GetListTokenHoldersByCursor - return the balances of at most limit holders of custom token, starting at cursor
+// the returned cursor is used to get the next holders, it is empty once every holder has been returned
+func (blockchain *BlockChain) GetListTokenHoldersByCursor(tokenID *common.Hash, cursor []byte, limit int) ([]database.TokenHolderBalance, []byte, error) {
+	return blockchain.config.DataBase.GetNormalTokenPaymentAddressesBalanceByCursor(*tokenID, cursor, limit)
+}
+
 func (blockchain *BlockChain) GetCurrentBeaconBlockHeight(shardID byte) uint64 {
 	return blockchain.BestState.Beacon.BestBlock.Header.Height
 }
@@ -1675,11 +1725,11 @@ func (blockchain *BlockChain) BackupBeaconChain(writer io.Writer) error {
 	return nil
 }
 
-func (blockchain *BlockChain) StoreIncomingCrossShard(block *ShardBlock, bd *[]database.BatchData) error {
+func (blockchain *BlockChain) StoreIncomingCrossShard(block *ShardBlock, db database.DatabaseInterface) error {
 	crossShardMap, _ := block.Body.ExtractIncomingCrossShardMap()
 	for crossShard, crossBlks := range crossShardMap {
 		for _, crossBlk := range crossBlks {
-			err := blockchain.config.DataBase.StoreIncomingCrossShard(block.Header.ShardID, crossShard, block.Header.Height, crossBlk, bd)
+			err := db.StoreIncomingCrossShard(block.Header.ShardID, crossShard, block.Header.Height, crossBlk, nil)
 			if err != nil {
 				return NewBlockChainError(StoreIncomingCrossShardError, err)
 			}
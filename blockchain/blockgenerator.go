@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"sort"
 	"sync"
 	"time"
 
@@ -79,3 +80,25 @@ func (blockGenerator *BlockGenerator) GetPendingTxsV2() []metadata.Transaction {
 	}
 	return pendingTxs
 }
+
+// GetPendingTxsByFeeRate returns the pending txs from the highest fee per kilobyte to the lowest,
+// in the order of the mining descriptors of the tx pool. Pending txs which are not in the tx pool come last.
+func (blockGenerator *BlockGenerator) GetPendingTxsByFeeRate() []metadata.Transaction {
+	pendingTxs := blockGenerator.GetPendingTxsV2()
+	priority := make(map[common.Hash]int)
+	for index, desc := range blockGenerator.txPool.MiningDescs() {
+		priority[*desc.Tx.Hash()] = index
+	}
+	sort.SliceStable(pendingTxs, func(i, j int) bool {
+		priorityI, okI := priority[*pendingTxs[i].Hash()]
+		priorityJ, okJ := priority[*pendingTxs[j].Hash()]
+		if okI != okJ {
+			return okI
+		}
+		if !okI {
+			return pendingTxs[i].Hash().String() < pendingTxs[j].Hash().String()
+		}
+		return priorityI < priorityJ
+	})
+	return pendingTxs
+}
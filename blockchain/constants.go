@@ -182,4 +182,8 @@ const (
 	StakeAction   = "stake"
 	AssignAction  = "assign"
 	StopAutoStake = "stopautostake"
+	SlashAction   = "slash"
 )
+
+// committee members who voted for two blocks of the same height and round are black listed for EquivocationPunishedEpoches epoches
+const EquivocationPunishedEpoches = uint8(255)
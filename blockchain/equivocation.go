@@ -0,0 +1,211 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+/*
+	Equivocation of a committee member is slashed in 3 steps:
+	- the shard block which includes an equivocation proof tx sends the proof to beacon in a shard instruction
+		["slash" "chainID" "committeePublicKey" "votes (json)"]
+	- beacon verifies the proof with the committee of the chain and confirms it in a beacon instruction
+		["slash" "chainID" "committeePublicKey" "height" "round"]
+	  the votes at height and round of the chain are recorded as slashed so that the proof can not be replayed
+	- the slashed committee member is black listed for EquivocationPunishedEpoches epoches, which swaps it out of its committee,
+	  its auto staking is stopped and its staking transaction is forgotten by shards, so its staking amount is never returned
+*/
+
+// equivocationVoteBlock returns height, round and hash of the block voted by a vote of an equivocation proof
+func equivocationVoteBlock(chainID int, vote metadata.EquivocationVote) (uint64, int, common.Hash, error) {
+	if chainID == metadata.BeaconOnly {
+		header := BeaconHeader{}
+		if err := json.Unmarshal(vote.BlockHeader, &header); err != nil {
+			return 0, 0, common.Hash{}, err
+		}
+		return header.Height, header.Round, header.Hash(), nil
+	}
+	header := ShardHeader{}
+	if err := json.Unmarshal(vote.BlockHeader, &header); err != nil {
+		return 0, 0, common.Hash{}, err
+	}
+	if int(header.ShardID) != chainID {
+		return 0, 0, common.Hash{}, fmt.Errorf("Expect block of shard %+v but get %+v", chainID, header.ShardID)
+	}
+	return header.Height, header.Round, header.Hash(), nil
+}
+
+/*
+	verifyEquivocationProof verifies that a committee member of a chain confirmed its votes
+	for two different blocks of the same height and round, returns the committee public key in base58, the height and the round
+*/
+func verifyEquivocationProof(chainID int, committeePublicKey string, votes [2]metadata.EquivocationVote, committee []incognitokey.CommitteePublicKey) (string, uint64, int, error) {
+	offender := incognitokey.CommitteePublicKey{}
+	if err := offender.FromString(committeePublicKey); err != nil {
+		return "", 0, 0, NewBlockChainError(EquivocationProofError, err)
+	}
+	offenderStr, err := offender.ToBase58()
+	if err != nil {
+		return "", 0, 0, NewBlockChainError(EquivocationProofError, err)
+	}
+	committeeStr, err := incognitokey.CommitteeKeyListToString(committee)
+	if err != nil {
+		return "", 0, 0, NewBlockChainError(EquivocationProofError, err)
+	}
+	if common.IndexOfStr(offenderStr, committeeStr) == -1 {
+		return "", 0, 0, NewBlockChainError(EquivocationProofError, fmt.Errorf("%+v is not a committee member of chain %+v", offenderStr, chainID))
+	}
+	heights, rounds, blockHashes := [2]uint64{}, [2]int{}, [2]common.Hash{}
+	for i, vote := range votes {
+		heights[i], rounds[i], blockHashes[i], err = equivocationVoteBlock(chainID, vote)
+		if err != nil {
+			return "", 0, 0, NewBlockChainError(EquivocationProofError, err)
+		}
+		data := []byte{}
+		data = append(data, blockHashes[i].GetBytes()...)
+		data = append(data, vote.BLS...)
+		data = append(data, vote.BRI...)
+		dataHash := common.HashH(data)
+		valid, err := bridgesig.Verify(offender.MiningPubKey[common.BridgeConsensus], dataHash.GetBytes(), vote.Confirmation)
+		if err != nil {
+			return "", 0, 0, NewBlockChainError(EquivocationProofError, err)
+		}
+		if !valid {
+			return "", 0, 0, NewBlockChainError(EquivocationProofError, fmt.Errorf("Invalid confirmation of vote for block %+v", blockHashes[i]))
+		}
+	}
+	if heights[0] != heights[1] || rounds[0] != rounds[1] {
+		return "", 0, 0, NewBlockChainError(EquivocationProofError, fmt.Errorf("Votes for block %+v round %+v and block %+v round %+v", heights[0], rounds[0], heights[1], rounds[1]))
+	}
+	if blockHashes[0].IsEqual(&blockHashes[1]) {
+		return "", 0, 0, NewBlockChainError(EquivocationProofError, errors.New("Votes for the same block"))
+	}
+	return offenderStr, heights[0], rounds[0], nil
+}
+
+// VerifyEquivocationProof verifies an equivocation proof with the committees of current beacon beststate,
+// a proof of votes which have already been slashed is invalid
+func (blockchain *BlockChain) VerifyEquivocationProof(proof *metadata.EquivocationProofMetadata) error {
+	committee := []incognitokey.CommitteePublicKey{}
+	if proof.ChainID == metadata.BeaconOnly {
+		committee = blockchain.BestState.Beacon.GetBeaconCommittee()
+	} else {
+		committee = blockchain.BestState.Beacon.GetAShardCommittee(byte(proof.ChainID))
+	}
+	offender, height, round, err := verifyEquivocationProof(proof.ChainID, proof.CommitteePublicKey, proof.Votes, committee)
+	if err != nil {
+		return err
+	}
+	slashed, err := blockchain.isEquivocationSlashed(proof.ChainID, offender, height, round, blockchain.BestState.Beacon.BeaconHeight+1)
+	if err != nil {
+		return NewBlockChainError(EquivocationProofError, err)
+	}
+	if slashed {
+		return NewBlockChainError(EquivocationProofError, fmt.Errorf("Votes of %+v at height %+v round %+v are already slashed", offender, height, round))
+	}
+	return nil
+}
+
+/*
+	isEquivocationSlashed tells if the votes of a committee member at height and round of a chain
+	have been slashed by a beacon block before newBeaconHeight.
+	A record at newBeaconHeight or above was stored by a beacon block which has been reverted or failed to be inserted
+*/
+func (blockchain *BlockChain) isEquivocationSlashed(chainID int, offender string, height uint64, round int, newBeaconHeight uint64) (bool, error) {
+	beaconHeight, found, err := blockchain.GetDatabase().GetSlashedEquivocation(chainID, offender, height, round)
+	if err != nil {
+		return false, err
+	}
+	return found && beaconHeight < newBeaconHeight, nil
+}
+
+// buildEquivocationProofInstruction builds the shard instruction which sends the proof of an equivocation proof tx to beacon
+func buildEquivocationProofInstruction(proof *metadata.EquivocationProofMetadata) ([]string, error) {
+	votesBytes, err := json.Marshal(proof.Votes)
+	if err != nil {
+		return nil, err
+	}
+	return []string{SlashAction, strconv.Itoa(proof.ChainID), proof.CommitteePublicKey, string(votesBytes)}, nil
+}
+
+/*
+	buildSlashInstruction verifies a slash instruction of a shard block with the committees of beacon beststate
+	and returns the slash instruction of beacon block, nil if the proof is invalid, the committee member is already slashed by the beacon block
+	or the votes of the proof have been slashed before
+	This function MUST be called without changing beacon beststate committees
+*/
+func (blockchain *BlockChain) buildSlashInstruction(instruction []string, newBeaconHeight uint64, slashedCommittees []string) []string {
+	if len(instruction) != 4 {
+		return nil
+	}
+	chainID, err := strconv.Atoi(instruction[1])
+	if err != nil {
+		return nil
+	}
+	votes := [2]metadata.EquivocationVote{}
+	if err := json.Unmarshal([]byte(instruction[3]), &votes); err != nil {
+		return nil
+	}
+	committee := []incognitokey.CommitteePublicKey{}
+	if chainID == metadata.BeaconOnly {
+		committee = blockchain.BestState.Beacon.BeaconCommittee
+	} else if chainID >= 0 && chainID < blockchain.BestState.Beacon.ActiveShards {
+		committee = blockchain.BestState.Beacon.ShardCommittee[byte(chainID)]
+	}
+	offender, height, round, err := verifyEquivocationProof(chainID, instruction[2], votes, committee)
+	if err != nil {
+		Logger.log.Errorf("Invalid equivocation proof of %+v, error %+v", instruction[2], err)
+		return nil
+	}
+	if common.IndexOfStr(offender, slashedCommittees) > -1 {
+		return nil
+	}
+	slashed, err := blockchain.isEquivocationSlashed(chainID, offender, height, round, newBeaconHeight)
+	if err != nil {
+		Logger.log.Error(err)
+		return nil
+	}
+	if slashed {
+		Logger.log.Infof("Votes of %+v at height %+v round %+v are already slashed", offender, height, round)
+		return nil
+	}
+	return []string{SlashAction, instruction[1], offender, strconv.FormatUint(height, 10), strconv.Itoa(round)}
+}
+
+// storeSlashedEquivocation records the votes slashed by a slash instruction of the beacon block at beaconHeight
+func storeSlashedEquivocation(db database.DatabaseInterface, instruction []string, beaconHeight uint64) error {
+	chainID, err := strconv.Atoi(instruction[1])
+	if err != nil {
+		return err
+	}
+	height, err := strconv.ParseUint(instruction[3], 10, 64)
+	if err != nil {
+		return err
+	}
+	round, err := strconv.Atoi(instruction[4])
+	if err != nil {
+		return err
+	}
+	return db.StoreSlashedEquivocation(chainID, instruction[2], height, round, beaconHeight)
+}
+
+// getSlashedCommitteesFromBeaconBlocks returns the committee members slashed by beacon blocks
+func getSlashedCommitteesFromBeaconBlocks(beaconBlocks []*BeaconBlock) []string {
+	slashedCommittees := []string{}
+	for _, beaconBlock := range beaconBlocks {
+		for _, instruction := range beaconBlock.Body.Instructions {
+			if len(instruction) == 5 && instruction[0] == SlashAction {
+				slashedCommittees = append(slashedCommittees, instruction[2])
+			}
+		}
+	}
+	return slashedCommittees
+}
@@ -0,0 +1,82 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+// newEquivocationTestProof returns a committee member of shard 0 and its proof of votes for two blocks at height 5 round 1
+func newEquivocationTestProof(t *testing.T) (incognitokey.CommitteePublicKey, *metadata.EquivocationProofMetadata) {
+	seed := []byte("equivocation test committee member")
+	committeeKey, err := incognitokey.NewCommitteeKeyFromSeed(seed, make([]byte, common.PublicKeySize))
+	assert.Nil(t, err)
+	committeeKeyStr, err := committeeKey.ToBase58()
+	assert.Nil(t, err)
+	briPrivateKey, _ := bridgesig.KeyGen(seed)
+	proof := &metadata.EquivocationProofMetadata{ChainID: 0, CommitteePublicKey: committeeKeyStr}
+	for i := range proof.Votes {
+		header := ShardHeader{ShardID: 0, Height: 5, Round: 1, Timestamp: int64(i)}
+		proof.Votes[i].BlockHeader, err = json.Marshal(header)
+		assert.Nil(t, err)
+		blockHash := header.Hash()
+		dataHash := common.HashH(blockHash.GetBytes())
+		proof.Votes[i].Confirmation, err = bridgesig.Sign(bridgesig.SKBytes(&briPrivateKey), dataHash.GetBytes())
+		assert.Nil(t, err)
+	}
+	return committeeKey, proof
+}
+
+func TestBuildSlashInstructionReplay(t *testing.T) {
+	committeeKey, proof := newEquivocationTestProof(t)
+	bc := newPDETestBlockChain()
+	bc.BestState = &BestState{Beacon: &BeaconBestState{
+		BeaconHeight:   9,
+		ActiveShards:   1,
+		ShardCommittee: map[byte][]incognitokey.CommitteePublicKey{0: {committeeKey}},
+	}}
+	assert.Nil(t, bc.VerifyEquivocationProof(proof))
+	shardInstruction, err := buildEquivocationProofInstruction(proof)
+	assert.Nil(t, err)
+
+	slashInstruction := bc.buildSlashInstruction(shardInstruction, 10, []string{})
+	assert.Equal(t, []string{SlashAction, "0", proof.CommitteePublicKey, "5", "1"}, slashInstruction)
+	// the committee member is slashed once by a beacon block
+	assert.Nil(t, bc.buildSlashInstruction(shardInstruction, 10, []string{proof.CommitteePublicKey}))
+
+	// the beacon block at height 10 slashes the votes
+	assert.Nil(t, storeSlashedEquivocation(bc.GetDatabase(), slashInstruction, 10))
+	assert.Nil(t, bc.buildSlashInstruction(shardInstruction, 11, []string{}))
+	assert.Nil(t, bc.buildSlashInstruction(shardInstruction, 1000, []string{}))
+	bc.BestState.Beacon.BeaconHeight = 10
+	assert.NotNil(t, bc.VerifyEquivocationProof(proof))
+
+	// the beacon block at height 10 is reverted, another beacon block at height 10 may slash the votes
+	assert.Equal(t, slashInstruction, bc.buildSlashInstruction(shardInstruction, 10, []string{}))
+}
+
+func TestSlashedCommitteeStakingIsForfeited(t *testing.T) {
+	shardBestState := &ShardBestState{StakingTx: map[string]string{"A": "txA", "B": "txB"}}
+	swapInstruction := []string{SwapAction, "", "A,B,C", "shard", "0"}
+
+	// the staking amount of a committee member slashed by the beacon blocks being processed is not returned
+	slashBlock := &BeaconBlock{Body: BeaconBody{Instructions: [][]string{{SlashAction, "0", "A", "5", "1"}}}}
+	slashedCommittees := getSlashedCommitteesFromBeaconBlocks([]*BeaconBlock{slashBlock})
+	assert.Equal(t, []string{"B"}, getReturnStakingPublicKeys(swapInstruction, map[string]bool{}, shardBestState.StakingTx, slashedCommittees))
+
+	shardBestState.updateStakingTx(map[string]string{"C": "txC"}, []*BeaconBlock{slashBlock})
+	assert.Equal(t, map[string]string{"B": "txB", "C": "txC"}, shardBestState.StakingTx)
+
+	// nor when the slashed committee member is swapped out by a later beacon block
+	laterBlock := &BeaconBlock{Body: BeaconBody{Instructions: [][]string{swapInstruction}}}
+	slashedCommittees = getSlashedCommitteesFromBeaconBlocks([]*BeaconBlock{laterBlock})
+	assert.Equal(t, 0, len(slashedCommittees))
+	assert.Equal(t, []string{"B", "C"}, getReturnStakingPublicKeys(swapInstruction, map[string]bool{}, shardBestState.StakingTx, slashedCommittees))
+	// a committee member with auto staking stakes again
+	assert.Equal(t, []string{"B"}, getReturnStakingPublicKeys(swapInstruction, map[string]bool{"C": true}, shardBestState.StakingTx, slashedCommittees))
+}
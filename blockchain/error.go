@@ -164,6 +164,23 @@ const (
 	NotEnoughRewardError
 	InitPDETradeResponseTransactionError
 	ProcessPDEInstructionError
+	BeginTransactionError
+	CommitTransactionError
+	StorePendingBlockCommitError
+	DeletePendingBlockCommitError
+	PendingBlockCommitError
+	GetPrunedHeightError
+	StorePrunedHeightError
+	PruneBlockError
+	PrunePDEStateError
+	ExportSnapshotError
+	ImportSnapshotError
+	CheckDatabaseIntegrityError
+	StoreMetadataTxIndexError
+	DeleteMetadataTxIndexError
+	ListMetadataTxIndicesError
+	ViewChangeCertificateError
+	EquivocationProofError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -313,6 +330,23 @@ var ErrCodeMessage = map[int]struct {
 	NotEnoughRewardError:                              {-1140, "Not enough reward Error"},
 	InitPDETradeResponseTransactionError:              {-1141, "Init PDE trade response tx Error"},
 	ProcessPDEInstructionError:                        {-1142, "Process PDE instruction Error"},
+	BeginTransactionError:                             {-1143, "Begin Transaction Error"},
+	CommitTransactionError:                            {-1144, "Commit Transaction Error"},
+	StorePendingBlockCommitError:                      {-1145, "Store Pending Block Commit Error"},
+	DeletePendingBlockCommitError:                     {-1146, "Delete Pending Block Commit Error"},
+	PendingBlockCommitError:                           {-1147, "Pending Block Commit Error"},
+	GetPrunedHeightError:                              {-1148, "Get Pruned Height Error"},
+	StorePrunedHeightError:                            {-1149, "Store Pruned Height Error"},
+	PruneBlockError:                                   {-1150, "Prune Block Error"},
+	PrunePDEStateError:                                {-1151, "Prune PDE State Error"},
+	ExportSnapshotError:                               {-1152, "Export Snapshot Error"},
+	ImportSnapshotError:                               {-1153, "Import Snapshot Error"},
+	CheckDatabaseIntegrityError:                       {-1154, "Check Database Integrity Error"},
+	StoreMetadataTxIndexError:                         {-1155, "Store Metadata Tx Index Error"},
+	DeleteMetadataTxIndexError:                        {-1156, "Delete Metadata Tx Index Error"},
+	ListMetadataTxIndicesError:                        {-1157, "List Metadata Tx Indices Error"},
+	EquivocationProofError:                            {-1159, "Equivocation Proof Error"},
+	ViewChangeCertificateError:                        {-1158, "View Change Certificate Error"},
 }
 
 type BlockChainError struct {
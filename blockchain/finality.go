@@ -0,0 +1,94 @@
+package blockchain
+
+import (
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/pubsub"
+)
+
+/*
+	A shard block is final once the beacon chain confirms it with the shard to beacon state of a beacon block,
+	the best block of a shard may still be replaced until then.
+	The finalized height of a shard is the last shard height in the ShardState of the beacon blocks,
+	which is the BestShardHeight of the beacon best state, so it follows the beacon chain when a beacon block is reverted.
+	The beacon block itself is not final, a beacon committee member may revert the best beacon block.
+	FinalizedShardBlock events are published in the order of the beacon blocks, when a beacon block is reverted
+	the shards it confirmed are published again with their finalized block of the reverted beacon best state
+*/
+
+// FinalizedShardBlock is published on pubsub.FinalizedShardBlockTopic when a beacon block confirms new blocks of a shard,
+// Reverted is true when the finalized height of the shard goes back to Height because the beacon block was reverted
+type FinalizedShardBlock struct {
+	ShardID      byte        `json:"ShardID"`
+	Height       uint64      `json:"Height"`
+	Hash         common.Hash `json:"Hash"`
+	BeaconHeight uint64      `json:"BeaconHeight"`
+	BeaconHash   common.Hash `json:"BeaconHash"`
+	Reverted     bool        `json:"Reverted"`
+}
+
+// GetFinalizedShardHeight returns the height of the last block of a shard confirmed by the beacon chain
+func (blockchain *BlockChain) GetFinalizedShardHeight(shardID byte) uint64 {
+	if blockchain.BestState == nil || blockchain.BestState.Beacon == nil {
+		return 0
+	}
+	return blockchain.BestState.Beacon.GetBestHeightOfShard(shardID)
+}
+
+// GetFinalizedShardHeights returns the finalized height of every shard confirmed by the beacon chain
+func (blockchain *BlockChain) GetFinalizedShardHeights() map[byte]uint64 {
+	if blockchain.BestState == nil || blockchain.BestState.Beacon == nil {
+		return make(map[byte]uint64)
+	}
+	return blockchain.BestState.Beacon.GetBestShardHeight()
+}
+
+// getConfirmedShardIDs returns the sorted IDs of the shards whose blocks are confirmed by a beacon block
+func getConfirmedShardIDs(beaconBlock *BeaconBlock) []byte {
+	shardIDs := []int{}
+	for shardID, shardStates := range beaconBlock.Body.ShardState {
+		if len(shardStates) > 0 {
+			shardIDs = append(shardIDs, int(shardID))
+		}
+	}
+	sort.Ints(shardIDs)
+	result := make([]byte, len(shardIDs))
+	for i, shardID := range shardIDs {
+		result[i] = byte(shardID)
+	}
+	return result
+}
+
+// publishFinalizedShardBlocks publishes the last shard block of each shard confirmed by a beacon block,
+// it must be called in the order the beacon blocks are inserted
+func (blockchain *BlockChain) publishFinalizedShardBlocks(beaconBlock *BeaconBlock) {
+	for _, shardID := range getConfirmedShardIDs(beaconBlock) {
+		shardStates := beaconBlock.Body.ShardState[shardID]
+		finalizedShardBlock := &FinalizedShardBlock{
+			ShardID:      shardID,
+			Height:       shardStates[len(shardStates)-1].Height,
+			Hash:         shardStates[len(shardStates)-1].Hash,
+			BeaconHeight: beaconBlock.Header.Height,
+			BeaconHash:   *beaconBlock.Hash(),
+		}
+		blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.FinalizedShardBlockTopic, finalizedShardBlock))
+	}
+}
+
+// publishRevertedShardBlocks publishes the finalized block of the reverted beacon best state
+// for each shard confirmed by the reverted beacon block
+func (blockchain *BlockChain) publishRevertedShardBlocks(revertedBeaconBlock *BeaconBlock) {
+	beaconBestState := blockchain.BestState.Beacon
+	for _, shardID := range getConfirmedShardIDs(revertedBeaconBlock) {
+		finalizedShardBlock := &FinalizedShardBlock{
+			ShardID:      shardID,
+			Height:       beaconBestState.BestShardHeight[shardID],
+			Hash:         beaconBestState.BestShardHash[shardID],
+			BeaconHeight: beaconBestState.BeaconHeight,
+			BeaconHash:   beaconBestState.BestBlockHash,
+			Reverted:     true,
+		}
+		blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.FinalizedShardBlockTopic, finalizedShardBlock))
+	}
+}
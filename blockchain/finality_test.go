@@ -0,0 +1,87 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFinalizedShardHeights(t *testing.T) {
+	bc := &BlockChain{}
+	assert.Equal(t, map[byte]uint64{}, bc.GetFinalizedShardHeights())
+	assert.Equal(t, uint64(0), bc.GetFinalizedShardHeight(0))
+
+	bc.BestState = &BestState{Beacon: &BeaconBestState{BestShardHeight: map[byte]uint64{0: 10, 1: 7}}}
+	finalizedShardHeights := bc.GetFinalizedShardHeights()
+	assert.Equal(t, map[byte]uint64{0: 10, 1: 7}, finalizedShardHeights)
+	assert.Equal(t, uint64(7), bc.GetFinalizedShardHeight(1))
+	assert.Equal(t, uint64(0), bc.GetFinalizedShardHeight(2))
+	// the result is a copy of the beacon best state
+	finalizedShardHeights[0] = 0
+	assert.Equal(t, uint64(10), bc.GetFinalizedShardHeight(0))
+}
+
+// receiveFinalizedShardBlocks returns the next numOfBlocks finalized shard blocks published on event
+func receiveFinalizedShardBlocks(t *testing.T, event pubsub.EventChannel, numOfBlocks int) []FinalizedShardBlock {
+	finalizedShardBlocks := []FinalizedShardBlock{}
+	for i := 0; i < numOfBlocks; i++ {
+		select {
+		case msg := <-event:
+			finalizedShardBlock, ok := msg.Value.(*FinalizedShardBlock)
+			assert.True(t, ok)
+			finalizedShardBlocks = append(finalizedShardBlocks, *finalizedShardBlock)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Receive %+v of %+v finalized shard blocks", i, numOfBlocks)
+		}
+	}
+	return finalizedShardBlocks
+}
+
+func TestPublishFinalizedShardBlocks(t *testing.T) {
+	pubSubManager := pubsub.NewPubSubManager()
+	go pubSubManager.Start()
+	id, event, err := pubSubManager.RegisterNewSubscriber(pubsub.FinalizedShardBlockTopic)
+	assert.Nil(t, err)
+	defer pubSubManager.Unsubscribe(pubsub.FinalizedShardBlockTopic, id)
+	bc := &BlockChain{config: Config{PubSubManager: pubSubManager}}
+
+	beaconBlocks := []*BeaconBlock{}
+	expected := []FinalizedShardBlock{}
+	for height := uint64(1); height <= 50; height++ {
+		beaconBlock := &BeaconBlock{
+			Header: BeaconHeader{Height: height},
+			Body: BeaconBody{ShardState: map[byte][]ShardState{
+				2: {{Height: 2 * height}},
+				0: {{Height: 3*height - 1}, {Height: 3 * height, Hash: common.HashH([]byte{byte(height)})}},
+				// a shard without new blocks is not published
+				1: {},
+			}},
+		}
+		beaconBlocks = append(beaconBlocks, beaconBlock)
+		expected = append(expected,
+			FinalizedShardBlock{ShardID: 0, Height: 3 * height, Hash: common.HashH([]byte{byte(height)}), BeaconHeight: height, BeaconHash: *beaconBlock.Hash()},
+			FinalizedShardBlock{ShardID: 2, Height: 2 * height, BeaconHeight: height, BeaconHash: *beaconBlock.Hash()},
+		)
+	}
+	for _, beaconBlock := range beaconBlocks {
+		bc.publishFinalizedShardBlocks(beaconBlock)
+	}
+	assert.Equal(t, expected, receiveFinalizedShardBlocks(t, event, len(expected)))
+
+	// the last beacon block is reverted, the finalized heights go back to the ones of the previous beacon block
+	previousBeaconBlock := beaconBlocks[len(beaconBlocks)-2]
+	bc.BestState = &BestState{Beacon: &BeaconBestState{
+		BeaconHeight:    previousBeaconBlock.Header.Height,
+		BestBlockHash:   *previousBeaconBlock.Hash(),
+		BestShardHeight: map[byte]uint64{0: 147, 1: 1, 2: 98},
+		BestShardHash:   map[byte]common.Hash{0: common.HashH([]byte{49})},
+	}}
+	bc.publishRevertedShardBlocks(beaconBlocks[len(beaconBlocks)-1])
+	assert.Equal(t, []FinalizedShardBlock{
+		{ShardID: 0, Height: 147, Hash: common.HashH([]byte{49}), BeaconHeight: 49, BeaconHash: *previousBeaconBlock.Hash(), Reverted: true},
+		{ShardID: 2, Height: 98, BeaconHeight: 49, BeaconHash: *previousBeaconBlock.Hash(), Reverted: true},
+	}, receiveFinalizedShardBlocks(t, event, 2))
+}
@@ -0,0 +1,308 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+)
+
+// Kinds of issue found by CheckDatabaseIntegrity
+const (
+	MissingBestStateIssue       = "missingbeststate"
+	BestStateMismatchIssue      = "beststatemismatch"
+	BlockIndexIssue             = "blockindex"
+	MissingBlockIssue           = "missingblock"
+	InvalidBlockIssue           = "invalidblock"
+	TxIndexIssue                = "txindex"
+	StaleTxIndexIssue           = "staletxindex"
+	CommitmentLengthIssue       = "commitmentlength"
+	MissingCommitmentIndexIssue = "missingcommitmentindex"
+)
+
+const (
+	beaconIntegrityChainName   = "beacon"
+	shardIntegrityChainNameFmt = "shard-%d"
+)
+
+// IntegrityIssue is a problem found in the chain database.
+// Only index records can be repaired, they are rebuilt from the block data.
+type IntegrityIssue struct {
+	Kind       string `json:"Kind"`
+	Chain      string `json:"Chain"`
+	Height     uint64 `json:"Height,omitempty"`
+	Hash       string `json:"Hash,omitempty"`
+	Detail     string `json:"Detail"`
+	Repairable bool   `json:"Repairable"`
+	Repaired   bool   `json:"Repaired"`
+}
+
+// IntegrityReport is the result of CheckDatabaseIntegrity.
+// OK is true when no issue is left in the database.
+type IntegrityReport struct {
+	OK               bool             `json:"OK"`
+	BeaconHeight     uint64           `json:"BeaconHeight"`
+	ShardHeights     map[byte]uint64  `json:"ShardHeights"`
+	CheckedBlocks    uint64           `json:"CheckedBlocks"`
+	CheckedTxIndices uint64           `json:"CheckedTxIndices"`
+	Issues           []IntegrityIssue `json:"Issues"`
+}
+
+type integrityChecker struct {
+	db     database.DatabaseInterface
+	repair bool
+	report *IntegrityReport
+}
+
+// CheckDatabaseIntegrity checks the chain database of a stopped node:
+// - the stored best states match the tip of their chain
+// - every beacon and shard height has a block index, and the blocks are linked from the tip down to genesis
+// - every tx of the walked shard blocks has a tx index pointing at its block, and every tx index points at a known block
+// - the commitment length of every token matches the stored commitment indices
+// Block bodies below the pruned height of a chain are not expected to be in the database.
+// If repair is set, index-only issues are repaired in place.
+func CheckDatabaseIntegrity(db database.DatabaseInterface, repair bool) (*IntegrityReport, error) {
+	checker := &integrityChecker{
+		db:     db,
+		repair: repair,
+		report: &IntegrityReport{
+			ShardHeights: make(map[byte]uint64),
+			Issues:       []IntegrityIssue{},
+		},
+	}
+	beaconBestStateBytes, err := db.FetchBeaconBestState()
+	if err != nil {
+		checker.addIssue(IntegrityIssue{Kind: MissingBestStateIssue, Chain: beaconIntegrityChainName, Detail: err.Error()}, nil)
+		return checker.finish(), nil
+	}
+	beaconBestState := &BeaconBestState{}
+	if err := json.Unmarshal(beaconBestStateBytes, beaconBestState); err != nil {
+		return nil, NewBlockChainError(UnmashallJsonBeaconBestStateError, err)
+	}
+	checker.report.BeaconHeight = beaconBestState.BeaconHeight
+	if err := checker.checkChain(true, 0, beaconBestState.BeaconHeight, beaconBestState.BestBlockHash); err != nil {
+		return nil, err
+	}
+	for shardID := byte(0); int(shardID) < beaconBestState.ActiveShards; shardID++ {
+		chain := fmt.Sprintf(shardIntegrityChainNameFmt, shardID)
+		shardBestStateBytes, err := db.FetchShardBestState(shardID)
+		if err != nil {
+			checker.addIssue(IntegrityIssue{Kind: MissingBestStateIssue, Chain: chain, Detail: err.Error()}, nil)
+			continue
+		}
+		shardBestState := &ShardBestState{}
+		if err := json.Unmarshal(shardBestStateBytes, shardBestState); err != nil {
+			return nil, NewBlockChainError(UnmashallJsonShardBestStateError, err)
+		}
+		checker.report.ShardHeights[shardID] = shardBestState.ShardHeight
+		if err := checker.checkChain(false, shardID, shardBestState.ShardHeight, shardBestState.BestBlockHash); err != nil {
+			return nil, err
+		}
+		if err := checker.checkCommitments(shardID); err != nil {
+			return nil, err
+		}
+	}
+	if err := checker.checkStaleTxIndices(); err != nil {
+		return nil, err
+	}
+	return checker.finish(), nil
+}
+
+func (checker *integrityChecker) finish() *IntegrityReport {
+	checker.report.OK = true
+	for _, issue := range checker.report.Issues {
+		if !issue.Repaired {
+			checker.report.OK = false
+		}
+	}
+	return checker.report
+}
+
+// addIssue records an issue, repairFn is called to repair it when it is repairable and repair is enabled.
+func (checker *integrityChecker) addIssue(issue IntegrityIssue, repairFn func() error) {
+	issue.Repairable = repairFn != nil
+	if checker.repair && repairFn != nil {
+		if err := repairFn(); err != nil {
+			issue.Detail += ", repair failed: " + err.Error()
+		} else {
+			issue.Repaired = true
+		}
+	}
+	checker.report.Issues = append(checker.report.Issues, issue)
+}
+
+func (checker *integrityChecker) getBlockHashByIndex(isBeacon bool, shardID byte, height uint64) (common.Hash, error) {
+	if isBeacon {
+		return checker.db.GetBeaconBlockHashByIndex(height)
+	}
+	return checker.db.GetBlockByIndex(height, shardID)
+}
+
+func (checker *integrityChecker) getBlockHeight(isBeacon bool, shardID byte, hash common.Hash) (uint64, error) {
+	if isBeacon {
+		return checker.db.GetIndexOfBeaconBlock(hash)
+	}
+	height, blockShardID, err := checker.db.GetIndexOfBlock(hash)
+	if err == nil && blockShardID != shardID {
+		return 0, fmt.Errorf("block belongs to shard %d", blockShardID)
+	}
+	return height, err
+}
+
+func (checker *integrityChecker) storeBlockIndex(isBeacon bool, shardID byte, height uint64, hash common.Hash) func() error {
+	return func() error {
+		if isBeacon {
+			return checker.db.StoreBeaconBlockIndex(hash, height)
+		}
+		return checker.db.StoreShardBlockIndex(hash, height, shardID, nil)
+	}
+}
+
+// checkChain walks a chain from its best block down to genesis following the previous block hashes.
+// Once a block body is missing, the lower heights can only be checked by their block index.
+func (checker *integrityChecker) checkChain(isBeacon bool, shardID byte, bestHeight uint64, bestBlockHash common.Hash) error {
+	chain := beaconIntegrityChainName
+	if !isBeacon {
+		chain = fmt.Sprintf(shardIntegrityChainNameFmt, shardID)
+	}
+	if _, err := checker.getBlockHashByIndex(isBeacon, shardID, bestHeight+1); err == nil {
+		checker.addIssue(IntegrityIssue{Kind: BestStateMismatchIssue, Chain: chain, Height: bestHeight + 1, Detail: "a block is stored above the best state"}, nil)
+	}
+	prunedHeight, err := checker.db.GetPrunedHeight(isBeacon, shardID)
+	if err != nil {
+		return NewBlockChainError(GetPrunedHeightError, err)
+	}
+	hash := bestBlockHash
+	linked := true
+	for height := bestHeight; height >= 1; height-- {
+		if !linked {
+			indexHash, err := checker.getBlockHashByIndex(isBeacon, shardID, height)
+			if err != nil {
+				checker.addIssue(IntegrityIssue{Kind: BlockIndexIssue, Chain: chain, Height: height, Detail: "missing block hash by height, the block is unknown"}, nil)
+				continue
+			}
+			hash = indexHash
+		}
+		checker.checkBlockIndex(isBeacon, shardID, chain, height, hash)
+		checker.report.CheckedBlocks++
+		if !linked {
+			continue
+		}
+		previousBlockHash, err := checker.checkBlock(isBeacon, shardID, chain, height, hash, prunedHeight)
+		if err != nil {
+			linked = false
+			continue
+		}
+		hash = previousBlockHash
+	}
+	return nil
+}
+
+// checkBlockIndex checks both block index records of a block whose hash is known.
+func (checker *integrityChecker) checkBlockIndex(isBeacon bool, shardID byte, chain string, height uint64, hash common.Hash) {
+	indexHash, err := checker.getBlockHashByIndex(isBeacon, shardID, height)
+	if err != nil || !indexHash.IsEqual(&hash) {
+		checker.addIssue(IntegrityIssue{Kind: BlockIndexIssue, Chain: chain, Height: height, Hash: hash.String(), Detail: fmt.Sprintf("block hash by height is %+v, %+v", indexHash, err)}, checker.storeBlockIndex(isBeacon, shardID, height, hash))
+		return
+	}
+	indexHeight, err := checker.getBlockHeight(isBeacon, shardID, hash)
+	if err != nil || indexHeight != height {
+		checker.addIssue(IntegrityIssue{Kind: BlockIndexIssue, Chain: chain, Height: height, Hash: hash.String(), Detail: fmt.Sprintf("block height by hash is %+v, %+v", indexHeight, err)}, checker.storeBlockIndex(isBeacon, shardID, height, hash))
+	}
+}
+
+// checkBlock checks the body of a block and the tx indices of its txs, it returns the hash of the previous block.
+func (checker *integrityChecker) checkBlock(isBeacon bool, shardID byte, chain string, height uint64, hash common.Hash, prunedHeight uint64) (common.Hash, error) {
+	var blockBytes []byte
+	var err error
+	if isBeacon {
+		blockBytes, err = checker.db.FetchBeaconBlock(hash)
+	} else {
+		blockBytes, err = checker.db.FetchBlock(hash)
+	}
+	if err != nil || len(blockBytes) == 0 {
+		if height > prunedHeight {
+			checker.addIssue(IntegrityIssue{Kind: MissingBlockIssue, Chain: chain, Height: height, Hash: hash.String(), Detail: "block body is missing"}, nil)
+		}
+		return common.Hash{}, fmt.Errorf("missing block %+v", hash)
+	}
+	if isBeacon {
+		beaconBlock := &BeaconBlock{}
+		if err := json.Unmarshal(blockBytes, beaconBlock); err != nil || beaconBlock.Header.Height != height {
+			checker.addIssue(IntegrityIssue{Kind: InvalidBlockIssue, Chain: chain, Height: height, Hash: hash.String(), Detail: fmt.Sprintf("block can not be read at this height, %+v", err)}, nil)
+			return common.Hash{}, fmt.Errorf("invalid block %+v", hash)
+		}
+		return beaconBlock.Header.PreviousBlockHash, nil
+	}
+	shardBlock := &ShardBlock{}
+	if err := json.Unmarshal(blockBytes, shardBlock); err != nil || shardBlock.Header.Height != height || shardBlock.Header.ShardID != shardID {
+		checker.addIssue(IntegrityIssue{Kind: InvalidBlockIssue, Chain: chain, Height: height, Hash: hash.String(), Detail: fmt.Sprintf("block can not be read at this height, %+v", err)}, nil)
+		return common.Hash{}, fmt.Errorf("invalid block %+v", hash)
+	}
+	for index, tx := range shardBlock.Body.Transactions {
+		txHash := *tx.Hash()
+		blockHash, indexInBlock, err := checker.db.GetTransactionIndexById(txHash)
+		checker.report.CheckedTxIndices++
+		if err != nil || !blockHash.IsEqual(&hash) || indexInBlock != index {
+			index := index
+			checker.addIssue(IntegrityIssue{Kind: TxIndexIssue, Chain: chain, Height: height, Hash: txHash.String(), Detail: fmt.Sprintf("tx index is block %+v index %+v, %+v", blockHash, indexInBlock, err)}, func() error {
+				return checker.db.StoreTransactionIndex(txHash, hash, index, nil)
+			})
+		}
+	}
+	return shardBlock.Header.PreviousBlockHash, nil
+}
+
+// checkStaleTxIndices reports the tx indices pointing at an unknown shard block.
+func (checker *integrityChecker) checkStaleTxIndices() error {
+	staleTxIDs := []common.Hash{}
+	err := checker.db.ForEachTransactionIndex(func(txID common.Hash, blockHash common.Hash, indexInBlock int) error {
+		if _, _, err := checker.db.GetIndexOfBlock(blockHash); err != nil {
+			staleTxIDs = append(staleTxIDs, txID)
+		}
+		return nil
+	})
+	if err != nil {
+		return NewBlockChainError(CheckDatabaseIntegrityError, err)
+	}
+	// tx indices are deleted once the iteration is over
+	for _, txID := range staleTxIDs {
+		txID := txID
+		checker.addIssue(IntegrityIssue{Kind: StaleTxIndexIssue, Hash: txID.String(), Detail: "tx index points at an unknown block"}, func() error {
+			return checker.db.DeleteTransactionIndex(txID)
+		})
+	}
+	return nil
+}
+
+// checkCommitments re-derives the commitment length of every token of a shard from the stored commitment indices.
+func (checker *integrityChecker) checkCommitments(shardID byte) error {
+	chain := fmt.Sprintf(shardIntegrityChainNameFmt, shardID)
+	tokenIDs, err := checker.db.ListCommitmentTokenIDs(shardID)
+	if err != nil {
+		return NewBlockChainError(CheckDatabaseIntegrityError, err)
+	}
+	for _, tokenID := range tokenIDs {
+		commitments, err := checker.db.ListCommitmentIndices(tokenID, shardID)
+		if err != nil {
+			return NewBlockChainError(CheckDatabaseIntegrityError, err)
+		}
+		length := uint64(0)
+		if commitmentLength, err := checker.db.GetCommitmentLength(tokenID, shardID); err != nil {
+			return NewBlockChainError(CheckDatabaseIntegrityError, err)
+		} else if commitmentLength != nil {
+			length = commitmentLength.Uint64()
+		}
+		if uint64(len(commitments)) != length {
+			checker.addIssue(IntegrityIssue{Kind: CommitmentLengthIssue, Chain: chain, Hash: tokenID.String(), Detail: fmt.Sprintf("commitment length is %+v, %+v commitment indices are stored", length, len(commitments))}, nil)
+		}
+		for index := uint64(0); index < length; index++ {
+			if _, ok := commitments[index]; !ok {
+				checker.addIssue(IntegrityIssue{Kind: MissingCommitmentIndexIssue, Chain: chain, Hash: tokenID.String(), Detail: fmt.Sprintf("commitment index %+v is missing", index)}, nil)
+				break
+			}
+		}
+	}
+	return nil
+}
@@ -85,6 +85,8 @@ type TxPool interface {
 
 	MaybeAcceptTransactionForBlockProducing(metadata.Transaction, int64) (*metadata.TxDesc, error)
 	ValidateTxList(txs []metadata.Transaction) error
+	// ValidateTxProofsInBatch verifies the privacy proofs of txs in batches before they are validated one by one
+	ValidateTxProofsInBatch(txs []metadata.Transaction) error
 	//CheckTransactionFee
 	// CheckTransactionFee(tx metadata.Transaction) (uint64, error)
 
@@ -106,6 +108,7 @@ type ChainInterface interface {
 	GetActiveShardNumber() int
 	GetPubkeyRole(pubkey string, round int) (string, byte)
 	CurrentHeight() uint64
+	GetBestBlockHash() common.Hash
 	GetCommitteeSize() int
 	GetCommittee() []incognitokey.CommitteePublicKey
 	GetPubKeyCommitteeIndex(string) int
@@ -0,0 +1,56 @@
+package blockchain
+
+import (
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/pkg/errors"
+)
+
+// storeMetadataTxIndex indexes the transactions with metadata of a shard block by (metadata type, shard, height, index in block).
+// Nothing is stored unless config.MetadataTxIndex is set.
+func (blockchain *BlockChain) storeMetadataTxIndex(block *ShardBlock, db database.DatabaseInterface) error {
+	if !blockchain.config.MetadataTxIndex {
+		return nil
+	}
+	for index, tx := range block.Body.Transactions {
+		metaType := tx.GetMetadataType()
+		if metaType == metadata.InvalidMeta {
+			continue
+		}
+		if err := db.StoreMetadataTxIndex(metaType, block.Header.ShardID, block.Header.Height, index, *tx.Hash()); err != nil {
+			return NewBlockChainError(StoreMetadataTxIndexError, err)
+		}
+	}
+	return nil
+}
+
+// deleteMetadataTxIndex removes the indices stored by storeMetadataTxIndex for a shard block.
+func (blockchain *BlockChain) deleteMetadataTxIndex(block *ShardBlock) error {
+	if !blockchain.config.MetadataTxIndex {
+		return nil
+	}
+	for index, tx := range block.Body.Transactions {
+		metaType := tx.GetMetadataType()
+		if metaType == metadata.InvalidMeta {
+			continue
+		}
+		if err := blockchain.config.DataBase.DeleteMetadataTxIndex(metaType, block.Header.ShardID, block.Header.Height, index); err != nil {
+			return NewBlockChainError(DeleteMetadataTxIndexError, err)
+		}
+	}
+	return nil
+}
+
+// ListTxsByMetadataType returns at most limit transactions with metadata type metaType in shardID, starting from
+// the index fromTxIndex of the block at fromHeight and ending with the block at toHeight.
+// Only the blocks inserted while config.MetadataTxIndex is set are indexed.
+func (blockchain *BlockChain) ListTxsByMetadataType(metaType int, shardID byte, fromHeight uint64, fromTxIndex int, toHeight uint64, limit int) ([]database.MetadataTxIndex, error) {
+	if !blockchain.config.MetadataTxIndex {
+		return nil, NewBlockChainError(ListMetadataTxIndicesError, errors.New("metadata tx index is disabled, restart the node with --metatxindex"))
+	}
+	txIndices, err := blockchain.config.DataBase.ListMetadataTxIndices(metaType, shardID, fromHeight, fromTxIndex, toHeight, limit)
+	if err != nil {
+		return nil, NewBlockChainError(ListMetadataTxIndicesError, err)
+	}
+	return txIndices, nil
+}
@@ -44,6 +44,7 @@ type Params struct {
 	CheckForce                       bool   // true on testnet and false on mainnet
 	ChainVersion                     string
 	AssignOffset                     int
+	ConsensusAlgorithm               string // consensus of the beacon and shard chains from genesis
 }
 
 type GenesisParams struct {
@@ -86,6 +87,7 @@ func init() {
 		// blockChain parameters
 		GenesisBeaconBlock:               CreateBeaconGenesisBlock(1, Testnet, TestnetGenesisBlockTime, genesisParamsTestnetNew),
 		GenesisShardBlock:                CreateShardGenesisBlock(1, Testnet, TestnetGenesisBlockTime, genesisParamsTestnetNew),
+		ConsensusAlgorithm:               genesisParamsTestnetNew.ConsensusAlgorithm,
 		MinShardBlockInterval:            TestNetMinShardBlkInterval,
 		MaxShardBlockCreation:            TestNetMaxShardBlkCreation,
 		MinBeaconBlockInterval:           TestNetMinBeaconBlkInterval,
@@ -130,6 +132,7 @@ func init() {
 		// blockChain parameters
 		GenesisBeaconBlock:               CreateBeaconGenesisBlock(1, Mainnet, MainnetGenesisBlockTime, genesisParamsMainnetNew),
 		GenesisShardBlock:                CreateShardGenesisBlock(1, Mainnet, MainnetGenesisBlockTime, genesisParamsMainnetNew),
+		ConsensusAlgorithm:               genesisParamsMainnetNew.ConsensusAlgorithm,
 		MinShardBlockInterval:            MainnetMinShardBlkInterval,
 		MaxShardBlockCreation:            MainnetMaxShardBlkCreation,
 		MinBeaconBlockInterval:           MainnetMinBeaconBlkInterval,
@@ -37,7 +37,7 @@ func (suite *PDEFlowsSuite) SetupSuite() {
 // suite.
 func (suite *PDEFlowsSuite) TestSimulatedBeaconBlock1001() {
 	fmt.Println("Running testcase: TestSimulatedBeaconBlock1001")
-	bc := &BlockChain{}
+	bc := newPDETestBlockChain()
 	shardID := byte(1)
 	beaconHeight := uint64(1001)
 	contribInst1 := buildPDEContributionAction(
@@ -67,9 +67,8 @@ func (suite *PDEFlowsSuite) TestSimulatedBeaconBlock1001() {
 	withdrawalInst1 := buildPDEWithdrawReqAction(
 		"withdrawer-address-1",
 		"token-id-1",
-		1000000000000,
 		"token-id-2",
-		2000000000000,
+		3000000000000,
 	)
 	tradeInst2 := buildPDETradeReqAction(
 		"token-id-2",
@@ -87,7 +86,7 @@ func (suite *PDEFlowsSuite) TestSimulatedBeaconBlock1001() {
 		var err error
 		switch metaType {
 		case metadata.PDEContributionMeta:
-			newInst, err = bc.buildInstructionsForPDEContribution(contentStr, shardID, metaType)
+			newInst, err = bc.buildInstructionsForPDEContribution(contentStr, shardID, metaType, &suite.currentPDEStateForProducer, beaconHeight-1)
 		case metadata.PDETradeRequestMeta:
 			newInst, err = bc.buildInstructionsForPDETrade(contentStr, shardID, metaType, &suite.currentPDEStateForProducer, beaconHeight-1)
 		case metadata.PDEWithdrawalRequestMeta:
@@ -99,15 +98,23 @@ func (suite *PDEFlowsSuite) TestSimulatedBeaconBlock1001() {
 		newInsts = append(newInsts, newInst...)
 	}
 
-	suite.Equal(len(newInsts), 5)
+	suite.Equal(len(newInsts), 6)
 
-	// skip withdrawal inst, and refund for 2 trade insts
+	// the pair of token-id-1 and token-id-2 is matched before tradeInst2, tradeInst1 has no pair to trade on
+	// and withdrawer-address-1 has no shares to withdraw
+	suite.Equal(newInsts[0][2], "waiting")
+	suite.Equal(newInsts[1][2], "matched")
+	suite.Equal(newInsts[2][2], "waiting")
 	suite.Equal(newInsts[3][2], "refund")
-	suite.Equal(newInsts[4][2], "refund")
+	suite.Equal(newInsts[4][2], "accepted")
+	suite.Equal(newInsts[5][2], "rejected")
+	var tradeAcceptedContent metadata.PDETradeAcceptedContent
+	suite.Equal(json.Unmarshal([]byte(newInsts[4][3]), &tradeAcceptedContent), nil)
+	suite.Equal(tradeAcceptedContent.ReceiveAmount, uint64(399999))
 
-	suite.Equal(len(suite.currentPDEStateForProducer.WaitingPDEContributions), 0)
-	suite.Equal(len(suite.currentPDEStateForProducer.PDEPoolPairs), 0)
-	suite.Equal(len(suite.currentPDEStateForProducer.PDEShares), 0)
+	suite.Equal(len(suite.currentPDEStateForProducer.WaitingPDEContributions), 1)
+	suite.Equal(len(suite.currentPDEStateForProducer.PDEPoolPairs), 1)
+	suite.Equal(len(suite.currentPDEStateForProducer.PDEShares), 1)
 
 	for _, inst := range newInsts {
 		if len(inst) < 2 {
@@ -116,11 +123,11 @@ func (suite *PDEFlowsSuite) TestSimulatedBeaconBlock1001() {
 		var err error
 		switch inst[0] {
 		case strconv.Itoa(metadata.PDEContributionMeta):
-			err = bc.processPDEContribution(beaconHeight-1, inst, &suite.currentPDEStateForProcess)
+			err = bc.processPDEContributionV2(beaconHeight-1, inst, &suite.currentPDEStateForProcess, bc.GetDatabase())
 		case strconv.Itoa(metadata.PDETradeRequestMeta):
-			err = bc.processPDETrade(beaconHeight-1, inst, &suite.currentPDEStateForProcess)
+			err = bc.processPDETrade(beaconHeight-1, inst, &suite.currentPDEStateForProcess, bc.GetDatabase())
 		case strconv.Itoa(metadata.PDEWithdrawalRequestMeta):
-			err = bc.processPDEWithdrawal(beaconHeight-1, inst, &suite.currentPDEStateForProcess)
+			err = bc.processPDEWithdrawal(beaconHeight-1, inst, &suite.currentPDEStateForProcess, bc.GetDatabase())
 		}
 		suite.Equal(err, nil)
 	}
@@ -137,18 +144,17 @@ func (suite *PDEFlowsSuite) TestSimulatedBeaconBlock1001() {
 	suite.Equal(newWaitingPDEContribs[waitingContrib].TokenIDStr, "token-id-3")
 	suite.Equal(newWaitingPDEContribs[waitingContrib].Amount, uint64(5000000000000))
 
-	// pool pairs
+	// pool pairs, tradeInst2 sells 200000 token-id-1 for 399999 token-id-2
 	suite.Equal(len(newPoolPairs), 1)
 	poolPairKey := string(lvdb.BuildPDEPoolForPairKey(beaconHeight-1, "token-id-1", "token-id-2"))
-	suite.Equal(newPoolPairs[poolPairKey].Token1PoolValue, uint64(1000000000000))
-	suite.Equal(newPoolPairs[poolPairKey].Token2PoolValue, uint64(2000000000000))
+	suite.Equal(newPoolPairs[poolPairKey].Token1PoolValue, uint64(1000000000000+200000))
+	suite.Equal(newPoolPairs[poolPairKey].Token2PoolValue, uint64(2000000000000-399999))
+	suite.Equal(*newPoolPairs[poolPairKey], *suite.currentPDEStateForProducer.PDEPoolPairs[poolPairKey])
 
 	// shares
-	suite.Equal(len(newPDEShares), 2)
-	shareKey1 := string(lvdb.BuildPDESharesKey(beaconHeight-1, "token-id-1", "token-id-2", "token-id-1", "contributor-address-1"))
-	shareKey2 := string(lvdb.BuildPDESharesKey(beaconHeight-1, "token-id-1", "token-id-2", "token-id-2", "contributor-address-1"))
-	suite.Equal(newPDEShares[shareKey1], uint64(1000000000000))
-	suite.Equal(newPDEShares[shareKey2], uint64(2000000000000))
+	suite.Equal(len(newPDEShares), 1)
+	shareKey := string(lvdb.BuildPDESharesKeyV2(beaconHeight-1, "token-id-1", "token-id-2", "contributor-address-1"))
+	suite.Equal(newPDEShares[shareKey], uint64(1000000000000))
 
 	// simulate storing pde state to db
 	waitingContributionsWithNewKey := make(map[string]*lvdb.PDEContribution)
@@ -181,7 +187,7 @@ func update(currentState *CurrentPDEState) {
 
 func (suite *PDEFlowsSuite) TestSimulatedBeaconBlock1002() {
 	fmt.Println("Running testcase: TestSimulatedBeaconBlock1002")
-	bc := &BlockChain{}
+	bc := newPDETestBlockChain()
 	shardID := byte(1)
 	beaconHeight := uint64(1002)
 	tradeInst1 := buildPDETradeReqAction(
@@ -248,23 +254,20 @@ func (suite *PDEFlowsSuite) TestSimulatedBeaconBlock1002() {
 	withdrawalInst1 := buildPDEWithdrawReqAction(
 		"withdrawer-address-1",
 		"token-id-1",
-		1000000000000,
 		"token-id-2",
-		2000000000000,
+		3000000000000,
 	)
 	withdrawalInst2 := buildPDEWithdrawReqAction(
 		"contributor-address-1",
 		"token-id-1",
-		500000000000,
 		"token-id-2",
-		1000000000000,
+		1500000000000,
 	)
 	withdrawalInst3 := buildPDEWithdrawReqAction(
 		"contributor-address-1",
 		"token-id-1",
-		500000000000,
 		"token-id-3",
-		1000000000000,
+		1500000000000,
 	)
 
 	// simulate beacon block producer
@@ -277,7 +280,7 @@ func (suite *PDEFlowsSuite) TestSimulatedBeaconBlock1002() {
 		var err error
 		switch metaType {
 		case metadata.PDEContributionMeta:
-			newInst, err = bc.buildInstructionsForPDEContribution(contentStr, shardID, metaType)
+			newInst, err = bc.buildInstructionsForPDEContribution(contentStr, shardID, metaType, &suite.currentPDEStateForProducer, beaconHeight-1)
 		case metadata.PDETradeRequestMeta:
 			newInst, err = bc.buildInstructionsForPDETrade(contentStr, shardID, metaType, &suite.currentPDEStateForProducer, beaconHeight-1)
 		case metadata.PDEWithdrawalRequestMeta:
@@ -289,22 +292,39 @@ func (suite *PDEFlowsSuite) TestSimulatedBeaconBlock1002() {
 		newInsts = append(newInsts, newInst...)
 	}
 
-	suite.Equal(len(newInsts), 12)
+	suite.Equal(len(newInsts), 16)
+
+	// contribInst1 and contribInst4 refund themselves and the waiting contribution of their pair
+	expectedStatuses := []string{
+		"accepted", "refund", "refund", "waiting", "waiting", "refund", "accepted", "refund",
+		"refund", "waiting", "refund", "refund", "rejected", "accepted", "accepted", "rejected",
+	}
+	for i, status := range expectedStatuses {
+		suite.Equal(newInsts[i][2], status)
+	}
+	var tradeAcceptedContent1, tradeAcceptedContent3 metadata.PDETradeAcceptedContent
+	suite.Equal(json.Unmarshal([]byte(newInsts[0][3]), &tradeAcceptedContent1), nil)
+	suite.Equal(json.Unmarshal([]byte(newInsts[6][3]), &tradeAcceptedContent3), nil)
+	suite.Equal(tradeAcceptedContent1.ReceiveAmount, uint64(50000))
+	suite.Equal(tradeAcceptedContent3.ReceiveAmount, uint64(599999))
 
-	suite.Equal(newInsts[4][2], "refund")
-	suite.Equal(newInsts[8][2], "refund")
-	suite.Equal(newInsts[9][2], "refund")
-	suite.Equal(newInsts[10][0], strconv.Itoa(metadata.PDEWithdrawalRequestMeta))
-	suite.Equal(newInsts[11][0], strconv.Itoa(metadata.PDEWithdrawalRequestMeta))
+	// contributor-address-1 owns all shares of the pair, withdrawalInst2 is capped to them and empties the pool
+	var wdAcceptedContent1, wdAcceptedContent2 metadata.PDEWithdrawalAcceptedContent
+	suite.Equal(json.Unmarshal([]byte(newInsts[13][3]), &wdAcceptedContent1), nil)
+	suite.Equal(json.Unmarshal([]byte(newInsts[14][3]), &wdAcceptedContent2), nil)
+	suite.Equal(wdAcceptedContent1.WithdrawalTokenIDStr, "token-id-1")
+	suite.Equal(wdAcceptedContent1.DeductingPoolValue, uint64(1000000200000-50000+300000))
+	suite.Equal(wdAcceptedContent1.DeductingShares, uint64(1000000000000))
+	suite.Equal(wdAcceptedContent2.WithdrawalTokenIDStr, "token-id-2")
+	suite.Equal(wdAcceptedContent2.DeductingPoolValue, uint64(1999999600001+100000-599999))
+	suite.Equal(wdAcceptedContent2.DeductingShares, uint64(0))
 
 	poolPairKey := string(lvdb.BuildPDEPoolForPairKey(beaconHeight-1, "token-id-1", "token-id-2"))
-	suite.Equal(suite.currentPDEStateForProducer.PDEPoolPairs[poolPairKey].Token1PoolValue, uint64(500000125063))
-	suite.Equal(suite.currentPDEStateForProducer.PDEPoolPairs[poolPairKey].Token2PoolValue, uint64(999999750751))
+	suite.Equal(suite.currentPDEStateForProducer.PDEPoolPairs[poolPairKey].Token1PoolValue, uint64(0))
+	suite.Equal(suite.currentPDEStateForProducer.PDEPoolPairs[poolPairKey].Token2PoolValue, uint64(0))
 
-	sharesKey1 := string(lvdb.BuildPDESharesKey(beaconHeight-1, "token-id-1", "token-id-2", "token-id-1", "contributor-address-1"))
-	sharesKey2 := string(lvdb.BuildPDESharesKey(beaconHeight-1, "token-id-1", "token-id-2", "token-id-2", "contributor-address-1"))
-	suite.Equal(suite.currentPDEStateForProducer.PDEShares[sharesKey1], uint64(500000000000))
-	suite.Equal(suite.currentPDEStateForProducer.PDEShares[sharesKey2], uint64(1000000000000))
+	sharesKey := string(lvdb.BuildPDESharesKeyV2(beaconHeight-1, "token-id-1", "token-id-2", "contributor-address-1"))
+	suite.Equal(suite.currentPDEStateForProducer.PDEShares[sharesKey], uint64(0))
 
 	// simulate beacon block process
 	for _, inst := range newInsts {
@@ -314,30 +334,31 @@ func (suite *PDEFlowsSuite) TestSimulatedBeaconBlock1002() {
 		var err error
 		switch inst[0] {
 		case strconv.Itoa(metadata.PDEContributionMeta):
-			err = bc.processPDEContribution(beaconHeight-1, inst, &suite.currentPDEStateForProcess)
+			err = bc.processPDEContributionV2(beaconHeight-1, inst, &suite.currentPDEStateForProcess, bc.GetDatabase())
 		case strconv.Itoa(metadata.PDETradeRequestMeta):
-			err = bc.processPDETrade(beaconHeight-1, inst, &suite.currentPDEStateForProcess)
+			err = bc.processPDETrade(beaconHeight-1, inst, &suite.currentPDEStateForProcess, bc.GetDatabase())
 		case strconv.Itoa(metadata.PDEWithdrawalRequestMeta):
-			err = bc.processPDEWithdrawal(beaconHeight-1, inst, &suite.currentPDEStateForProcess)
+			err = bc.processPDEWithdrawal(beaconHeight-1, inst, &suite.currentPDEStateForProcess, bc.GetDatabase())
 		}
 		suite.Equal(err, nil)
 	}
 
-	suite.Equal(len(suite.currentPDEStateForProcess.WaitingPDEContributions), 1)
-	waitingContributionKey := string(lvdb.BuildWaitingPDEContributionKey(beaconHeight-1, "unique-pair-4"))
-	suite.Equal(suite.currentPDEStateForProcess.WaitingPDEContributions[waitingContributionKey].ContributorAddressStr, "contributor-address-3")
-	suite.Equal(suite.currentPDEStateForProcess.WaitingPDEContributions[waitingContributionKey].TokenIDStr, "token-id-3")
-	suite.Equal(suite.currentPDEStateForProcess.WaitingPDEContributions[waitingContributionKey].Amount, uint64(3000000000000))
+	suite.Equal(len(suite.currentPDEStateForProcess.WaitingPDEContributions), 2)
+	waitingContributionKey1 := string(lvdb.BuildWaitingPDEContributionKey(beaconHeight-1, "unique-pair-2"))
+	suite.Equal(suite.currentPDEStateForProcess.WaitingPDEContributions[waitingContributionKey1].ContributorAddressStr, "contributor-address-4")
+	suite.Equal(suite.currentPDEStateForProcess.WaitingPDEContributions[waitingContributionKey1].TokenIDStr, "token-id-4")
+	suite.Equal(suite.currentPDEStateForProcess.WaitingPDEContributions[waitingContributionKey1].Amount, uint64(10000000000000))
+	waitingContributionKey2 := string(lvdb.BuildWaitingPDEContributionKey(beaconHeight-1, "unique-pair-4"))
+	suite.Equal(suite.currentPDEStateForProcess.WaitingPDEContributions[waitingContributionKey2].ContributorAddressStr, "contributor-address-3")
+	suite.Equal(suite.currentPDEStateForProcess.WaitingPDEContributions[waitingContributionKey2].TokenIDStr, "token-id-3")
+	suite.Equal(suite.currentPDEStateForProcess.WaitingPDEContributions[waitingContributionKey2].Amount, uint64(3000000000000))
 
-	suite.Equal(len(suite.currentPDEStateForProcess.PDEPoolPairs), 2)
-	poolPairKey1 := string(lvdb.BuildPDEPoolForPairKey(beaconHeight-1, "token-id-1", "token-id-2"))
-	poolPairKey2 := string(lvdb.BuildPDEPoolForPairKey(beaconHeight-1, "token-id-3", "token-id-4"))
-	suite.Equal(suite.currentPDEStateForProcess.PDEPoolPairs[poolPairKey1].Token1PoolValue, uint64(10500000125063))
-	suite.Equal(suite.currentPDEStateForProcess.PDEPoolPairs[poolPairKey1].Token2PoolValue, uint64(4999999750751))
-	suite.Equal(suite.currentPDEStateForProcess.PDEPoolPairs[poolPairKey2].Token1PoolValue, uint64(9000000000000))
-	suite.Equal(suite.currentPDEStateForProcess.PDEPoolPairs[poolPairKey2].Token2PoolValue, uint64(10000000000000))
+	// processing the instructions gives the same pool as producing them
+	suite.Equal(len(suite.currentPDEStateForProcess.PDEPoolPairs), 1)
+	suite.Equal(*suite.currentPDEStateForProcess.PDEPoolPairs[poolPairKey], *suite.currentPDEStateForProducer.PDEPoolPairs[poolPairKey])
 
-	suite.Equal(len(suite.currentPDEStateForProcess.PDEShares), 6)
+	suite.Equal(len(suite.currentPDEStateForProcess.PDEShares), 1)
+	suite.Equal(suite.currentPDEStateForProcess.PDEShares[sharesKey], uint64(0))
 }
 
 // In order for 'go test' to run this suite, we need to create
@@ -0,0 +1,145 @@
+package blockchain
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+)
+
+// maxPrunedHeightsPerBlock bounds the work done after inserting a block, a node which enables
+// pruning on an existing database catches up over the next blocks.
+const maxPrunedHeightsPerBlock = 1000
+
+/*
+	pruneBeaconChain deletes the beacon block bodies and the PDE state snapshotted at beacon heights
+	older than the last config.PruneHeights beacon heights.
+	Beacon blocks which have not been processed by a synced shard yet are kept, shard blocks are
+	validated against the beacon blocks following the shard's beacon height.
+	Block indexes, commitments, serial numbers and SND derivators are never pruned.
+*/
+func (blockchain *BlockChain) pruneBeaconChain() error {
+	if blockchain.config.PruneHeights == 0 {
+		return nil
+	}
+	keepFrom := uint64(0)
+	if blockchain.BestState.Beacon.BeaconHeight > blockchain.config.PruneHeights {
+		keepFrom = blockchain.BestState.Beacon.BeaconHeight - blockchain.config.PruneHeights + 1
+	}
+	for _, shardBestState := range blockchain.BestState.Shard {
+		// shards which are not synced by this node stay at their genesis block
+		if shardBestState.ShardHeight <= 1 {
+			continue
+		}
+		if shardBestState.BeaconHeight+1 < keepFrom {
+			keepFrom = shardBestState.BeaconHeight + 1
+		}
+	}
+	prunedHeight, err := blockchain.config.DataBase.GetPrunedHeight(true, 0)
+	if err != nil {
+		return NewBlockChainError(GetPrunedHeightError, err)
+	}
+	// genesis block is kept
+	fromHeight := prunedHeight + 1
+	if fromHeight < 2 {
+		fromHeight = 2
+	}
+	if fromHeight >= keepFrom {
+		return nil
+	}
+	toHeight := keepFrom - 1
+	if toHeight-fromHeight >= maxPrunedHeightsPerBlock {
+		toHeight = fromHeight + maxPrunedHeightsPerBlock - 1
+	}
+	dbTx, err := blockchain.config.DataBase.BeginTransaction()
+	if err != nil {
+		return NewBlockChainError(BeginTransactionError, err)
+	}
+	defer dbTx.Discard()
+	for height := fromHeight; height <= toHeight; height++ {
+		blockHash, err := dbTx.GetBeaconBlockHashByIndex(height)
+		if err != nil {
+			return NewBlockChainError(PruneBlockError, err)
+		}
+		if err := dbTx.DeleteBlockBody(blockHash); err != nil {
+			return NewBlockChainError(PruneBlockError, err)
+		}
+		if err := dbTx.DeletePDEStateByHeight(height); err != nil {
+			return NewBlockChainError(PrunePDEStateError, err)
+		}
+	}
+	if err := dbTx.StorePrunedHeight(true, 0, toHeight); err != nil {
+		return NewBlockChainError(StorePrunedHeightError, err)
+	}
+	if err := dbTx.Commit(); err != nil {
+		return NewBlockChainError(CommitTransactionError, err)
+	}
+	Logger.log.Debugf("BEACON | Pruned beacon height %+v to %+v", fromHeight, toHeight)
+	return nil
+}
+
+/*
+	pruneShardChain deletes the bodies of shard blocks which were produced more than
+	config.PruneHeights beacon heights before the shard's current beacon height.
+	The best block is always kept.
+*/
+func (blockchain *BlockChain) pruneShardChain(shardID byte) error {
+	if blockchain.config.PruneHeights == 0 {
+		return nil
+	}
+	shardBestState := blockchain.BestState.Shard[shardID]
+	prunedHeight, err := blockchain.config.DataBase.GetPrunedHeight(false, shardID)
+	if err != nil {
+		return NewBlockChainError(GetPrunedHeightError, err)
+	}
+	fromHeight := prunedHeight + 1
+	if fromHeight < 2 {
+		fromHeight = 2
+	}
+	dbTx, err := blockchain.config.DataBase.BeginTransaction()
+	if err != nil {
+		return NewBlockChainError(BeginTransactionError, err)
+	}
+	defer dbTx.Discard()
+	toHeight := fromHeight - 1
+	for height := fromHeight; height < shardBestState.ShardHeight && height < fromHeight+maxPrunedHeightsPerBlock; height++ {
+		blockHash, err := dbTx.GetBlockByIndex(height, shardID)
+		if err != nil {
+			return NewBlockChainError(PruneBlockError, err)
+		}
+		shardBlock, err := fetchShardBlock(dbTx, blockHash)
+		if err != nil {
+			return NewBlockChainError(PruneBlockError, err)
+		}
+		if shardBlock.Header.BeaconHeight+blockchain.config.PruneHeights > shardBestState.BeaconHeight {
+			break
+		}
+		if err := dbTx.DeleteBlockBody(blockHash); err != nil {
+			return NewBlockChainError(PruneBlockError, err)
+		}
+		toHeight = height
+	}
+	if toHeight < fromHeight {
+		return nil
+	}
+	if err := dbTx.StorePrunedHeight(false, shardID, toHeight); err != nil {
+		return NewBlockChainError(StorePrunedHeightError, err)
+	}
+	if err := dbTx.Commit(); err != nil {
+		return NewBlockChainError(CommitTransactionError, err)
+	}
+	Logger.log.Debugf("SHARD %+v | Pruned shard height %+v to %+v", shardID, fromHeight, toHeight)
+	return nil
+}
+
+func fetchShardBlock(db database.DatabaseInterface, blockHash common.Hash) (*ShardBlock, error) {
+	blockBytes, err := db.FetchBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	shardBlock := &ShardBlock{}
+	if err := json.Unmarshal(blockBytes, shardBlock); err != nil {
+		return nil, err
+	}
+	return shardBlock, nil
+}
@@ -124,6 +124,10 @@ func (blockchain *BlockChain) revertShardState(shardID byte) error {
 		}
 	}
 
+	if err := blockchain.deleteMetadataTxIndex(currentBestStateBlk); err != nil {
+		return NewBlockChainError(RevertStateError, err)
+	}
+
 	if err := blockchain.restoreFromTxViewPoint(currentBestStateBlk); err != nil {
 		return NewBlockChainError(RevertStateError, err)
 	}
@@ -145,7 +149,7 @@ func (blockchain *BlockChain) revertShardState(shardID byte) error {
 	// DeleteIncomingCrossShard
 	blockchain.config.DataBase.DeleteBlock(currentBestStateBlk.Header.Hash(), currentBestStateBlk.Header.Height, shardID)
 
-	if err := blockchain.StoreShardBestState(shardID, nil); err != nil {
+	if err := blockchain.StoreShardBestState(shardID, blockchain.config.DataBase); err != nil {
 		return NewBlockChainError(RevertStateError, err)
 	}
 	Logger.log.Critical("REVERT SHARD SUCCESS")
@@ -193,7 +197,7 @@ func (blockchain *BlockChain) backupDatabaseFromBeaconInstruction(
 	db := blockchain.config.DataBase
 	for _, beaconBlock := range beaconBlocks {
 		for _, l := range beaconBlock.Body.Instructions {
-			if l[0] == StakeAction || l[0] == RandomAction || l[0] == SwapAction || l[0] == AssignAction || l[0] == StopAutoStake {
+			if l[0] == StakeAction || l[0] == RandomAction || l[0] == SwapAction || l[0] == AssignAction || l[0] == StopAutoStake || l[0] == SlashAction {
 				continue
 			}
 			if len(l) <= 2 {
@@ -271,7 +275,7 @@ func (blockchain *BlockChain) backupDatabaseFromBeaconInstruction(
 					}
 				}
 				//TODO: check later
-				err = blockchain.getRewardAmountForUserOfShard(shardID, shardRewardInfo, committee[byte(shardToProcess)], &rewardReceivers, true)
+				err = blockchain.getRewardAmountForUserOfShard(shardID, shardRewardInfo, committee[byte(shardToProcess)], &rewardReceivers, true, db)
 				if err != nil {
 					return err
 				}
@@ -408,7 +412,7 @@ func (blockchain *BlockChain) restoreDatabaseFromBeaconInstruction(beaconBlocks
 	// listShardCommittee := blockchain.config.DataBase.FetchCommitteeByEpoch
 	for _, beaconBlock := range beaconBlocks {
 		for _, l := range beaconBlock.Body.Instructions {
-			if l[0] == StakeAction || l[0] == RandomAction {
+			if l[0] == StakeAction || l[0] == RandomAction || l[0] == SlashAction {
 				continue
 			}
 			if len(l) <= 2 {
@@ -814,9 +818,10 @@ func (blockchain *BlockChain) revertBeaconState() error {
 		return err
 	}
 
-	if err := blockchain.StoreBeaconBestState(nil); err != nil {
+	if err := blockchain.StoreBeaconBestState(blockchain.config.DataBase); err != nil {
 		return err
 	}
+	blockchain.publishRevertedShardBlocks(&currentBestStateBlk)
 	Logger.log.Critical("REVERT BEACON SUCCESS")
 	return nil
 }
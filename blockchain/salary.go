@@ -146,16 +146,15 @@ func (blockchain *BlockChain) BuildRewardInstructionByEpoch(blkHeight, epoch uin
 	return resInst, nil
 }
 
-func (blockchain *BlockChain) updateDatabaseFromBeaconInstructions(beaconBlocks []*BeaconBlock, shardID byte) error {
+func (blockchain *BlockChain) updateDatabaseFromBeaconInstructions(beaconBlocks []*BeaconBlock, shardID byte, db database.DatabaseInterface) error {
 	rewardReceivers := make(map[string]string)
 	committee := make(map[byte][]incognitokey.CommitteePublicKey)
 	isInit := false
 	epoch := uint64(0)
-	db := blockchain.config.DataBase
 	for _, beaconBlock := range beaconBlocks {
 		//fmt.Printf("RewardLog Process BeaconBlock %v\n", beaconBlock.GetHeight())
 		for _, l := range beaconBlock.Body.Instructions {
-			if l[0] == StakeAction || l[0] == RandomAction {
+			if l[0] == StakeAction || l[0] == RandomAction || l[0] == SlashAction {
 				continue
 			}
 			if len(l) <= 2 {
@@ -218,18 +217,18 @@ func (blockchain *BlockChain) updateDatabaseFromBeaconInstructions(beaconBlocks
 				if (!isInit) || (epoch != shardRewardInfo.Epoch) {
 					isInit = true
 					epoch = shardRewardInfo.Epoch
-					rewardReceiverBytes, err := blockchain.config.DataBase.FetchRewardReceiverByHeight(epoch * blockchain.config.ChainParams.Epoch)
+					rewardReceiverBytes, err := db.FetchRewardReceiverByHeight(epoch * blockchain.config.ChainParams.Epoch)
 					if err != nil {
 						return err
 					}
 					json.Unmarshal(rewardReceiverBytes, &rewardReceivers)
-					committeeBytes, err := blockchain.config.DataBase.FetchShardCommitteeByHeight(epoch * blockchain.config.ChainParams.Epoch)
+					committeeBytes, err := db.FetchShardCommitteeByHeight(epoch * blockchain.config.ChainParams.Epoch)
 					if err != nil {
 						return err
 					}
 					json.Unmarshal(committeeBytes, &committee)
 				}
-				err = blockchain.getRewardAmountForUserOfShard(shardID, shardRewardInfo, committee[byte(shardToProcess)], &rewardReceivers, false)
+				err = blockchain.getRewardAmountForUserOfShard(shardID, shardRewardInfo, committee[byte(shardToProcess)], &rewardReceivers, false, db)
 				if err != nil {
 					return err
 				}
@@ -241,8 +240,7 @@ func (blockchain *BlockChain) updateDatabaseFromBeaconInstructions(beaconBlocks
 	return nil
 }
 
-func (blockchain *BlockChain) updateDatabaseWithBlockRewardInfo(beaconBlock *BeaconBlock, bd *[]database.BatchData) error {
-	db := blockchain.config.DataBase
+func (blockchain *BlockChain) updateDatabaseWithBlockRewardInfo(beaconBlock *BeaconBlock, db database.DatabaseInterface) error {
 	for _, inst := range beaconBlock.Body.Instructions {
 		if len(inst) <= 2 {
 			continue
@@ -270,7 +268,7 @@ func (blockchain *BlockChain) updateDatabaseWithBlockRewardInfo(beaconBlock *Bea
 			}
 			for key, value := range acceptedBlkRewardInfo.TxsFee {
 				if value != 0 {
-					err = db.AddShardRewardRequest(beaconBlock.Header.Epoch, acceptedBlkRewardInfo.ShardID, value, key, bd)
+					err = db.AddShardRewardRequest(beaconBlock.Header.Epoch, acceptedBlkRewardInfo.ShardID, value, key, nil)
 					if err != nil {
 						return err
 					}
@@ -368,6 +366,7 @@ func (blockchain *BlockChain) getRewardAmountForUserOfShard(
 	committeeOfShardToProcess []incognitokey.CommitteePublicKey,
 	rewardReceiver *map[string]string,
 	forBackup bool,
+	db database.DatabaseInterface,
 ) (
 	err error,
 ) {
@@ -387,9 +386,9 @@ func (blockchain *BlockChain) getRewardAmountForUserOfShard(
 		if common.GetShardIDFromLastByte(wl.KeySet.PaymentAddress.Pk[common.PublicKeySize-1]) == selfShardID {
 			for key, value := range rewardInfoShardToProcess.ShardReward {
 				if forBackup {
-					err = blockchain.GetDatabase().BackupCommitteeReward(wl.KeySet.PaymentAddress.Pk, key)
+					err = db.BackupCommitteeReward(wl.KeySet.PaymentAddress.Pk, key)
 				} else {
-					err = blockchain.GetDatabase().AddCommitteeReward(wl.KeySet.PaymentAddress.Pk, value/uint64(committeeSize), key)
+					err = db.AddCommitteeReward(wl.KeySet.PaymentAddress.Pk, value/uint64(committeeSize), key)
 				}
 				if err != nil {
 					// errChan <- err
@@ -46,6 +46,8 @@ type ShardBestState struct {
 
 	// Number of blocks produced by producers in epoch
 	NumOfBlocksByProducers map[string]uint64 `json:"NumOfBlocksByProducers"`
+	// Number of rounds timed out by producers in epoch, proven by the view change certificates of blocks
+	NumOfTimeoutsByProducers map[string]uint64 `json:"NumOfTimeoutsByProducers"`
 
 	BlockInterval      time.Duration
 	BlockMaxCreateTime time.Duration
@@ -84,6 +86,7 @@ func NewBestStateShardWithConfig(shardID byte, netparam *Params) *ShardBestState
 	bestStateShard.BeaconHeight = 1
 	bestStateShard.BlockInterval = netparam.MinShardBlockInterval
 	bestStateShard.BlockMaxCreateTime = netparam.MaxShardBlockCreation
+	bestStateShard.ConsensusAlgorithm = netparam.ConsensusAlgorithm
 	return bestStateShard
 }
 
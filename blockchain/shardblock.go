@@ -18,6 +18,8 @@ type ShardBlock struct {
 	// ProducerSig   string  `json:"ProducerSig"`
 
 	ValidationData string `json:"ValidationData"`
+	// certificates of the rounds timed out before this block, their hash is committed in the header
+	ViewChangeCertificates []ViewChangeCertificate `json:"ViewChangeCertificates,omitempty"`
 
 	Body   ShardBody
 	Header ShardHeader
@@ -217,15 +219,17 @@ func (shardBlock *ShardBlock) validateSanityData() (bool, error) {
 
 func (shardBlock *ShardBlock) UnmarshalJSON(data []byte) error {
 	tempShardBlock := &struct {
-		ValidationData string `json:"ValidationData"`
-		Header         ShardHeader
-		Body           *json.RawMessage
+		ValidationData         string `json:"ValidationData"`
+		ViewChangeCertificates []ViewChangeCertificate
+		Header                 ShardHeader
+		Body                   *json.RawMessage
 	}{}
 	err := json.Unmarshal(data, &tempShardBlock)
 	if err != nil {
 		return NewBlockChainError(UnmashallJsonShardBlockError, err)
 	}
 	shardBlock.ValidationData = tempShardBlock.ValidationData
+	shardBlock.ViewChangeCertificates = tempShardBlock.ViewChangeCertificates
 	blkBody := ShardBody{}
 	err = blkBody.UnmarshalJSON(*tempShardBlock.Body)
 	if err != nil {
@@ -406,6 +410,21 @@ func (block *ShardBlock) AddValidationField(validationData string) error {
 	return nil
 }
 
+// AddViewChangeCertificates stores the certificates in block and commits them in the header, the block hash changes
+func (block *ShardBlock) AddViewChangeCertificates(certs []ViewChangeCertificate) error {
+	root, err := viewChangeCertificatesRoot(certs)
+	if err != nil {
+		return err
+	}
+	block.ViewChangeCertificates = certs
+	block.Header.ViewChangeRoot = root
+	return nil
+}
+
+func (block ShardBlock) GetViewChangeCertificates() []ViewChangeCertificate {
+	return block.ViewChangeCertificates
+}
+
 func (block ShardBlock) GetCurrentEpoch() uint64 {
 	return block.Header.Epoch
 }
@@ -39,6 +39,10 @@ func (chain *ShardChain) CurrentHeight() uint64 {
 	return chain.BestState.BestBlock.Header.Height
 }
 
+func (chain *ShardChain) GetBestBlockHash() common.Hash {
+	return chain.BestState.BestBlockHash
+}
+
 func (chain *ShardChain) GetCommittee() []incognitokey.CommitteePublicKey {
 	result := []incognitokey.CommitteePublicKey{}
 	return append(result, chain.BestState.ShardCommittee...)
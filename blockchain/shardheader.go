@@ -36,6 +36,7 @@ type ShardHeader struct {
 	StakingTxRoot         common.Hash            `json:"StakingTxRoot"`         // hash from staking transaction map in shard best state
 	InstructionMerkleRoot common.Hash            `json:"InstructionMerkleRoot"` // Merkle root of all instructions (using Keccak256 hash func) to relay to Ethreum
 	// This obsoletes InstructionMerkleRoot but for simplicity, we keep it for now
+	ViewChangeRoot common.Hash `json:"ViewChangeRoot"` // hash of the view change certificates of the rounds timed out before this block
 }
 
 func (shardHeader *ShardHeader) String() string {
@@ -72,6 +73,10 @@ func (shardHeader *ShardHeader) String() string {
 	for _, value := range shardHeader.CrossShardBitMap {
 		res += string(value)
 	}
+	// blocks without view change certificates keep their hash
+	if !shardHeader.ViewChangeRoot.IsEqual(&common.Hash{}) {
+		res += shardHeader.ViewChangeRoot.String()
+	}
 	return res
 }
 
@@ -142,6 +142,11 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, isValidat
 	if err := blockchain.BestState.Shard[shardID].verifyBestStateWithShardBlock(shardBlock, true, shardID); err != nil {
 		return err
 	}
+	// Mark the block as being committed until processStoreShardBlockAndUpdateDatabase commits its data
+	err = blockchain.config.DataBase.StorePendingBlockCommit(database.BlockCommit{ShardID: shardID, Height: shardBlock.Header.Height, Hash: blockHash})
+	if err != nil {
+		return NewBlockChainError(StorePendingBlockCommitError, err)
+	}
 	Logger.log.Infof("SHARD %+v | BackupCurrentShardState, block height %+v with hash %+v \n", shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash)
 	// Backup beststate
 	err = blockchain.config.DataBase.CleanBackup(false, shardBlock.Header.ShardID)
@@ -190,21 +195,24 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, isValidat
 	}
 	Logger.log.Infof("SHARD %+v | Remove Data After Processed, block height %+v with hash %+v \n", shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash)
 	blockchain.removeOldDataAfterProcessingShardBlock(shardBlock, shardID)
-	Logger.log.Infof("SHARD %+v | Update Beacon Instruction, block height %+v with hash %+v \n", shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash)
-	err = blockchain.updateDatabaseFromBeaconInstructions(beaconBlocks, shardID)
-	if err != nil {
-		return err
-	}
 	Logger.log.Infof("SHARD %+v | Store New Shard Block And Update Data, block height %+v with hash %+v \n", shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash)
 	//========Store new  Shard block and new shard bestState
-	err = blockchain.processStoreShardBlockAndUpdateDatabase(shardBlock)
+	err = blockchain.processStoreShardBlockAndUpdateDatabase(shardBlock, beaconBlocks)
 	if err != nil {
-		revertErr := blockchain.revertShardState(shardID)
+		// nothing of the block has been committed, only the best state in memory has to be reverted
+		revertErr := blockchain.revertShardBestState(shardID)
 		if revertErr != nil {
 			return errors.WithStack(revertErr)
 		}
+		if err := blockchain.config.DataBase.DeletePendingBlockCommit(false, shardID); err != nil {
+			return NewBlockChainError(DeletePendingBlockCommitError, err)
+		}
 		return err
 	}
+	// the block is committed, a failed pruning is retried after the next block
+	if err := blockchain.pruneShardChain(shardID); err != nil {
+		Logger.log.Error(err)
+	}
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewShardblockTopic, shardBlock))
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.ShardBeststateTopic, blockchain.BestState.Shard[shardID]))
 	//shardIDForMetric := strconv.Itoa(int(shardBlock.Header.ShardID))
@@ -251,6 +259,7 @@ func (shardBestState *ShardBestState) updateNumOfBlocksByProducers(shardBlock *S
 		shardBestState.NumOfBlocksByProducers = map[string]uint64{
 			producer: 1,
 		}
+		shardBestState.NumOfTimeoutsByProducers = map[string]uint64{}
 	} else {
 		// Update number of blocks produced by producers in epoch
 		numOfBlks, found := shardBestState.NumOfBlocksByProducers[producer]
@@ -260,6 +269,8 @@ func (shardBestState *ShardBestState) updateNumOfBlocksByProducers(shardBlock *S
 			shardBestState.NumOfBlocksByProducers[producer] = numOfBlks + 1
 		}
 	}
+	// Update number of proven timeouts of producers in epoch
+	shardBestState.NumOfTimeoutsByProducers = addNumOfTimeoutsByProducers(shardBestState.NumOfTimeoutsByProducers, shardBlock.ViewChangeCertificates)
 }
 
 /* Verify Pre-prosessing data
@@ -636,6 +647,10 @@ func (shardBestState *ShardBestState) verifyBestStateWithShardBlock(shardBlock *
 	if strings.Compare(tempProducer, producerPublicKey) != 0 {
 		return NewBlockChainError(ProducerError, fmt.Errorf("Producer should be should be %+v", tempProducer))
 	}
+	// verify the proofs that the proposers of the previous rounds timed out
+	if err := verifyViewChangeCertificates(common.GetShardChainKey(shardID), shardBestState.BestBlockHash, shardBlock.Header.Height, shardBlock.Header.Round, shardBlock.ViewChangeCertificates, shardBlock.Header.ViewChangeRoot, shardBestState.ShardCommittee, shardBestState.ShardProposerIdx, shardBestState.ConsensusAlgorithm); err != nil {
+		return err
+	}
 	//=============End Verify producer signature
 	//=============Verify aggegrate signature
 	// if isVerifySig {
@@ -702,9 +717,7 @@ func (shardBestState *ShardBestState) updateShardBestState(blockchain *BlockChai
 	if err != nil {
 		return err
 	}
-	for stakePublicKey, txHash := range stakingTx {
-		shardBestState.StakingTx[stakePublicKey] = txHash
-	}
+	shardBestState.updateStakingTx(stakingTx, beaconBlocks)
 	err = shardBestState.processShardBlockInstruction(blockchain, shardBlock)
 	if err != nil {
 		return err
@@ -726,6 +739,17 @@ func (shardBestState *ShardBestState) updateShardBestState(blockchain *BlockChai
 	Logger.log.Debugf("SHARD %+v | Finish update Beststate with new Block with height %+v at hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, shardBlock.Hash())
 	return nil
 }
+// updateStakingTx records the staking txs of new candidates and forgets the staking txs of the committee members
+// slashed by beaconBlocks, so their staking amount is never returned when they are swapped out later
+func (shardBestState *ShardBestState) updateStakingTx(stakingTx map[string]string, beaconBlocks []*BeaconBlock) {
+	for stakePublicKey, txHash := range stakingTx {
+		shardBestState.StakingTx[stakePublicKey] = txHash
+	}
+	for _, slashedCommittee := range getSlashedCommitteesFromBeaconBlocks(beaconBlocks) {
+		delete(shardBestState.StakingTx, slashedCommittee)
+	}
+}
+
 func (shardBestState *ShardBestState) initShardBestState(blockchain *BlockChain, genesisShardBlock *ShardBlock, genesisBeaconBlock *BeaconBlock) error {
 	shardBestState.BestBeaconHash = *ChainTestParam.GenesisBeaconBlock.Hash()
 	shardBestState.BestBlock = genesisShardBlock
@@ -751,8 +775,11 @@ func (shardBestState *ShardBestState) initShardBestState(blockchain *BlockChain,
 	if err != nil {
 		return err
 	}
-	shardBestState.ConsensusAlgorithm = common.BlsConsensus
+	if shardBestState.ConsensusAlgorithm == common.EmptyString {
+		shardBestState.ConsensusAlgorithm = common.BlsConsensus
+	}
 	shardBestState.NumOfBlocksByProducers = make(map[string]uint64)
+	shardBestState.NumOfTimeoutsByProducers = make(map[string]uint64)
 	return nil
 }
 
@@ -889,6 +916,12 @@ func (blockchain *BlockChain) verifyTransactionFromNewBlock(txs []metadata.Trans
 		Logger.log.Errorf("Error validating transaction in block creation: %+v \n", err)
 		return NewBlockChainError(TransactionFromNewBlockError, errors.New("Some Transactions in New Block IS invalid"))
 	}*/
+	// privacy proofs of all txs are verified in batches, MaybeAcceptTransactionForBlockProducing does not verify them again
+	err := blockchain.config.TempTxPool.ValidateTxProofsInBatch(txs)
+	if err != nil {
+		Logger.log.Errorf("Error validating transaction proofs of new block: %+v \n", err)
+		return NewBlockChainError(TransactionFromNewBlockError, err)
+	}
 	// TODO: uncomment to synchronize validate method with shard process and mempool
 	for index, tx := range txs {
 		if !tx.IsSalaryTx() {
@@ -919,30 +952,39 @@ func (blockchain *BlockChain) verifyTransactionFromNewBlock(txs []metadata.Trans
 	- Store Burning Confirmation
 	- Update Mempool fee estimator
 */
-func (blockchain *BlockChain) processStoreShardBlockAndUpdateDatabase(shardBlock *ShardBlock) error {
+func (blockchain *BlockChain) processStoreShardBlockAndUpdateDatabase(shardBlock *ShardBlock, beaconBlocks []*BeaconBlock) error {
 	blockHash := shardBlock.Hash().String()
-	batchPutData := []database.BatchData{}
+	dbTx, err := blockchain.config.DataBase.BeginTransaction()
+	if err != nil {
+		return NewBlockChainError(BeginTransactionError, err)
+	}
+	defer dbTx.Discard()
+
+	Logger.log.Infof("SHARD %+v | Update Beacon Instruction, block height %+v", shardBlock.Header.ShardID, shardBlock.Header.Height)
+	if err := blockchain.updateDatabaseFromBeaconInstructions(beaconBlocks, shardBlock.Header.ShardID, dbTx); err != nil {
+		return err
+	}
 
 	Logger.log.Infof("SHARD %+v | Process store block height %+v at hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, *shardBlock.Hash())
-	if err := blockchain.StoreShardBlock(shardBlock, &batchPutData); err != nil {
+	if err := blockchain.StoreShardBlock(shardBlock, dbTx); err != nil {
 		return NewBlockChainError(StoreShardBlockError, err)
 	}
-	if err := blockchain.StoreShardBlockIndex(shardBlock, &batchPutData); err != nil {
+	if err := blockchain.StoreShardBlockIndex(shardBlock, dbTx); err != nil {
 		return NewBlockChainError(StoreShardBlockError, err)
 	}
-	if err := blockchain.StoreShardBestState(shardBlock.Header.ShardID, &batchPutData); err != nil {
+	if err := blockchain.StoreShardBestState(shardBlock.Header.ShardID, dbTx); err != nil {
 		return NewBlockChainError(StoreBestStateError, err)
 	}
 
 	if len(shardBlock.Body.CrossTransactions) != 0 {
 		Logger.log.Critical("processStoreShardBlockAndUpdateDatabase/CrossTransactions	", shardBlock.Body.CrossTransactions)
 	}
-	if err := blockchain.CreateAndSaveTxViewPointFromBlock(shardBlock, &batchPutData); err != nil {
+	if err := blockchain.CreateAndSaveTxViewPointFromBlock(shardBlock, dbTx); err != nil {
 		return NewBlockChainError(FetchAndStoreTransactionError, err)
 	}
 
 	for index, tx := range shardBlock.Body.Transactions {
-		if err := blockchain.StoreTransactionIndex(tx.Hash(), shardBlock.Header.Hash(), index, &batchPutData); err != nil {
+		if err := blockchain.StoreTransactionIndex(tx.Hash(), shardBlock.Header.Hash(), index, dbTx); err != nil {
 			Logger.log.Errorf("Transaction in block with hash %+v and index %+v: %+v, err %+v", blockHash, index, tx, err)
 			return NewBlockChainError(FetchAndStoreTransactionError, err)
 		}
@@ -950,7 +992,7 @@ func (blockchain *BlockChain) processStoreShardBlockAndUpdateDatabase(shardBlock
 		metaType := tx.GetMetadataType()
 		if metaType == metadata.WithDrawRewardResponseMeta {
 			_, requesterRes, amountRes, coinID := tx.GetTransferData()
-			err := blockchain.config.DataBase.RemoveCommitteeReward(requesterRes, amountRes, *coinID, &batchPutData)
+			err := dbTx.RemoveCommitteeReward(requesterRes, amountRes, *coinID, nil)
 			if err != nil {
 				return NewBlockChainError(RemoveCommitteeRewardError, err)
 			}
@@ -958,35 +1000,40 @@ func (blockchain *BlockChain) processStoreShardBlockAndUpdateDatabase(shardBlock
 		Logger.log.Debugf("Transaction in block with hash", blockHash, "and index", index)
 	}
 	// Store Incomming Cross Shard
-	if err := blockchain.CreateAndSaveCrossTransactionCoinViewPointFromBlock(shardBlock, &batchPutData); err != nil {
+	if err := blockchain.CreateAndSaveCrossTransactionCoinViewPointFromBlock(shardBlock, dbTx); err != nil {
 		return NewBlockChainError(FetchAndStoreCrossTransactionError, err)
 	}
-	err := blockchain.StoreIncomingCrossShard(shardBlock, &batchPutData)
+	err = blockchain.StoreIncomingCrossShard(shardBlock, dbTx)
 	if err != nil {
 		return NewBlockChainError(StoreIncomingCrossShardError, err)
 	}
 	// Save result of BurningConfirm instruction to get proof later
-	err = blockchain.storeBurningConfirm(shardBlock, &batchPutData)
+	err = blockchain.storeBurningConfirm(shardBlock, dbTx)
 	if err != nil {
 		return NewBlockChainError(StoreBurningConfirmError, err)
 	}
 
 	// Update bridge issuance request status
-	err = blockchain.updateBridgeIssuanceStatus(shardBlock, &batchPutData)
+	err = blockchain.updateBridgeIssuanceStatus(shardBlock, dbTx)
 	if err != nil {
 		return NewBlockChainError(UpdateBridgeIssuanceStatusError, err)
 	}
 
-	// call FeeEstimator for processing
+	Logger.log.Infof("SHARD %+v | 🔎 %d transactions in block height %+v \n", shardBlock.Header.ShardID, len(shardBlock.Body.Transactions), shardBlock.Header.Height)
+	if err := dbTx.DeletePendingBlockCommit(false, shardBlock.Header.ShardID); err != nil {
+		return NewBlockChainError(DeletePendingBlockCommitError, err)
+	}
+	if err := dbTx.Commit(); err != nil {
+		return NewBlockChainError(CommitTransactionError, err)
+	}
+	// call FeeEstimator for processing, only once the block is stored
 	if feeEstimator, ok := blockchain.config.FeeEstimator[shardBlock.Header.ShardID]; ok {
 		err := feeEstimator.RegisterBlock(shardBlock)
 		if err != nil {
 			Logger.log.Error(NewBlockChainError(RegisterEstimatorFeeError, err))
 		}
 	}
-	Logger.log.Infof("SHARD %+v | 🔎 %d transactions in block height %+v \n", shardBlock.Header.ShardID, len(shardBlock.Body.Transactions), shardBlock.Header.Height)
-	return blockchain.config.DataBase.PutBatch(batchPutData)
-	//return nil
+	return nil
 }
 
 //func (blockchain *BlockChain) updateDatabaseWithTransactionMetadata(shardBlock *ShardBlock) error {
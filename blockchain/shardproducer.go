@@ -414,11 +414,37 @@ func (blockGenerator *BlockGenerator) getTransactionForNewBlock(privatekey *priv
 	return txsToAdd, nil
 }
 
+/*
+	getReturnStakingPublicKeys returns the public keys swapped out by a swap instruction whose staking amount is returned:
+	- a public key with auto staking stakes again
+	- the staking amount of a slashed public key is forfeited, stakingTx of the shard best state forgets the slashed public keys
+	  of the previous beacon blocks and slashedCommittees holds the ones of the beacon blocks being processed
+*/
+func getReturnStakingPublicKeys(swapInstruction []string, autoStaking map[string]bool, stakingTx map[string]string, slashedCommittees []string) []string {
+	publicKeys := []string{}
+	for _, outPublicKey := range strings.Split(swapInstruction[2], ",") {
+		if _, ok := autoStaking[outPublicKey]; ok {
+			continue
+		}
+		if common.IndexOfStr(outPublicKey, slashedCommittees) > -1 {
+			Logger.log.Infof("Ignore return staking of slashed committee %+v", outPublicKey)
+			continue
+		}
+		if _, ok := stakingTx[outPublicKey]; !ok {
+			Logger.log.Infof("Ignore return staking of %+v without staking tx", outPublicKey)
+			continue
+		}
+		publicKeys = append(publicKeys, outPublicKey)
+	}
+	return publicKeys
+}
+
 // buildResponseTxsFromBeaconInstructions builds response txs from beacon instructions
 func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(beaconBlocks []*BeaconBlock, producerPrivateKey *privacy.PrivateKey, shardID byte) ([]metadata.Transaction, [][]string, error) {
 	responsedTxs := []metadata.Transaction{}
 	responsedHashTxs := []common.Hash{} // capture hash of responsed tx
 	errorInstructions := [][]string{}   // capture error instruction -> which instruction can not create tx
+	slashedCommittees := getSlashedCommitteesFromBeaconBlocks(beaconBlocks)
 	for _, beaconBlock := range beaconBlocks {
 		autoStaking := make(map[string]bool)
 		autoStakingBytes, err := blockGenerator.chain.config.DataBase.FetchAutoStakingByHeight(beaconBlock.Header.Height)
@@ -431,11 +457,7 @@ func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(bea
 		}
 		for _, l := range beaconBlock.Body.Instructions {
 			if l[0] == SwapAction {
-				for _, outPublicKeys := range strings.Split(l[2], ",") {
-					// If out public key has auto staking then ignore this public key
-					if _, ok := autoStaking[outPublicKeys]; ok {
-						continue
-					}
+				for _, outPublicKeys := range getReturnStakingPublicKeys(l, autoStaking, GetBestStateShard(shardID).StakingTx, slashedCommittees) {
 					tx, err := blockGenerator.buildReturnStakingAmountTx(outPublicKeys, producerPrivateKey)
 					if err != nil {
 						Logger.log.Error(err)
@@ -453,7 +475,7 @@ func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(bea
 				}
 
 			}
-			if l[0] == StakeAction || l[0] == RandomAction || l[0] == AssignAction || l[0] == SwapAction {
+			if l[0] == StakeAction || l[0] == RandomAction || l[0] == AssignAction || l[0] == SwapAction || l[0] == SlashAction {
 				continue
 			}
 			if len(l) <= 2 {
@@ -755,6 +777,8 @@ func (blockGenerator *BlockGenerator) getCrossShardData(toShard byte, lastBeacon
 
 /*
 	Verify Transaction with these condition: defined in mempool.go
+	Transactions are picked from the highest fee per kilobyte to the lowest,
+	a transaction spending a serial number of a picked transaction is skipped
 */
 func (blockGenerator *BlockGenerator) getPendingTransaction(
 	shardID byte,
@@ -763,7 +787,7 @@ func (blockGenerator *BlockGenerator) getPendingTransaction(
 	beaconHeight uint64,
 ) (txsToAdd []metadata.Transaction, txToRemove []metadata.Transaction, totalFee uint64) {
 	startTime := time.Now()
-	sourceTxns := blockGenerator.GetPendingTxsV2()
+	sourceTxns := blockGenerator.GetPendingTxsByFeeRate()
 	txsProcessTimeInBlockCreation := int64(blockGenerator.chain.BestState.Shard[shardID].BlockMaxCreateTime.Nanoseconds())
 	var elasped int64
 	Logger.log.Info("Number of transaction get from Block Generator: ", len(sourceTxns))
@@ -772,6 +796,7 @@ func (blockGenerator *BlockGenerator) getPendingTransaction(
 		return []metadata.Transaction{}, []metadata.Transaction{}, 0
 	}
 	currentSize := uint64(0)
+	spentSerialNumbers := make(map[common.Hash]struct{})
 	for _, tx := range sourceTxns {
 		if tx.IsPrivacy() {
 			txsProcessTimeInBlockCreation = blockCreationTime - time.Duration(2500*time.Millisecond).Nanoseconds()
@@ -787,6 +812,10 @@ func (blockGenerator *BlockGenerator) getPendingTransaction(
 		if txShardID != shardID {
 			continue
 		}
+		serialNumbers := tx.ListSerialNumbersHashH()
+		if isSerialNumberSpent(serialNumbers, spentSerialNumbers) {
+			continue
+		}
 		tempTxDesc, err := blockGenerator.chain.config.TempTxPool.MaybeAcceptTransactionForBlockProducing(tx, int64(beaconHeight))
 		if err != nil {
 			txToRemove = append(txToRemove, tx)
@@ -800,12 +829,24 @@ func (blockGenerator *BlockGenerator) getPendingTransaction(
 		}
 		currentSize += tempSize
 		txsToAdd = append(txsToAdd, tempTx)
+		for _, serialNumber := range serialNumbers {
+			spentSerialNumbers[serialNumber] = struct{}{}
+		}
 	}
 	Logger.log.Criticalf(" 🔎 %+v transactions for New Block from pool \n", len(txsToAdd))
 	blockGenerator.chain.config.TempTxPool.EmptyPool()
 	return txsToAdd, txToRemove, totalFee
 }
 
+func isSerialNumberSpent(serialNumbers []common.Hash, spentSerialNumbers map[common.Hash]struct{}) bool {
+	for _, serialNumber := range serialNumbers {
+		if _, ok := spentSerialNumbers[serialNumber]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 /*
 	1. Get valid tx for specific shard and their fee, also return unvalid tx
 		a. Validate Tx By it self
@@ -195,6 +195,17 @@ func CreateShardInstructionsFromTransactionAndInstruction(transactions []metadat
 				}
 				stopAutoStaking = append(stopAutoStaking, stopAutoStakingMetadata.CommitteePublicKey)
 			}
+		case metadata.EquivocationProofMeta:
+			equivocationProof, ok := tx.GetMetadata().(*metadata.EquivocationProofMetadata)
+			if !ok {
+				return nil, fmt.Errorf("Expect metadata type to be *metadata.EquivocationProofMetadata but get %+v", reflect.TypeOf(tx.GetMetadata()))
+			}
+			// ["slash" "chainID" "committeePublicKey" "votes"]
+			instruction, err := buildEquivocationProofInstruction(equivocationProof)
+			if err != nil {
+				return nil, NewBlockChainError(EquivocationProofError, err)
+			}
+			instructions = append(instructions, instruction)
 		}
 	}
 	if !reflect.DeepEqual(stakeShardPublicKey, []string{}) {
@@ -25,11 +25,42 @@ func (blockchain *BlockChain) buildBadProducersWithPunishment(
 ) map[string]uint8 {
 	slashLevels := blockchain.config.ChainParams.SlashLevels
 	numOfBlocksByProducers := map[string]uint64{}
+	numOfTimeoutsByProducers := map[string]uint64{}
 	if isBeacon {
 		numOfBlocksByProducers = blockchain.BestState.Beacon.NumOfBlocksByProducers
+		numOfTimeoutsByProducers = blockchain.BestState.Beacon.NumOfTimeoutsByProducers
 	} else {
 		numOfBlocksByProducers = blockchain.BestState.Shard[byte(shardID)].NumOfBlocksByProducers
+		numOfTimeoutsByProducers = blockchain.BestState.Shard[byte(shardID)].NumOfTimeoutsByProducers
 	}
+	return buildBadProducers(slashLevels, committee, numOfBlocksByProducers, numOfTimeoutsByProducers)
+}
+
+// buildBadProducers punishes a producer for the blocks it missed in the epoch and for the rounds it had to propose
+// which timed out with a view change certificate, by the heavier of the two punishments.
+// The certificates in an epoch only add punishments, they never spare a producer which missed its blocks
+func buildBadProducers(
+	slashLevels []SlashLevel,
+	committee []string,
+	numOfBlocksByProducers map[string]uint64,
+	numOfTimeoutsByProducers map[string]uint64,
+) map[string]uint8 {
+	badProducersWithPunishment := buildBadProducersWithMissingBlocks(slashLevels, committee, numOfBlocksByProducers)
+	for producer, punishedEpoches := range buildBadProducersWithProvenTimeouts(slashLevels, committee, numOfBlocksByProducers, numOfTimeoutsByProducers) {
+		if punishedEpoches > badProducersWithPunishment[producer] {
+			badProducersWithPunishment[producer] = punishedEpoches
+		}
+	}
+	return sortMapStringUint8Keys(badProducersWithPunishment)
+}
+
+// buildBadProducersWithMissingBlocks punishes a producer by the percent of blocks it missed
+// from the number of blocks expected from each producer in the epoch
+func buildBadProducersWithMissingBlocks(
+	slashLevels []SlashLevel,
+	committee []string,
+	numOfBlocksByProducers map[string]uint64,
+) map[string]uint8 {
 	// numBlkPerEpoch := blockchain.config.ChainParams.Epoch
 	numBlkPerEpoch := uint64(0)
 	for _, numBlk := range numOfBlocksByProducers {
@@ -65,6 +96,35 @@ func (blockchain *BlockChain) buildBadProducersWithPunishment(
 			badProducersWithPunishment[producer] = selectedSlLev.PunishedEpoches
 		}
 	}
+	return badProducersWithPunishment
+}
+
+// buildBadProducersWithProvenTimeouts punishes a producer by the percent of the rounds it had to propose
+// which timed out with a view change certificate
+func buildBadProducersWithProvenTimeouts(
+	slashLevels []SlashLevel,
+	committee []string,
+	numOfBlocksByProducers map[string]uint64,
+	numOfTimeoutsByProducers map[string]uint64,
+) map[string]uint8 {
+	badProducersWithPunishment := make(map[string]uint8)
+	for _, producer := range committee {
+		numOfTimeouts := numOfTimeoutsByProducers[producer]
+		if numOfTimeouts == 0 {
+			continue
+		}
+		numOfRounds := numOfBlocksByProducers[producer] + numOfTimeouts
+		timeoutPercent := uint8(numOfTimeouts * 100 / numOfRounds)
+		var selectedSlLev *SlashLevel
+		for i := range slashLevels {
+			if timeoutPercent >= slashLevels[i].MinRange {
+				selectedSlLev = &slashLevels[i]
+			}
+		}
+		if selectedSlLev != nil {
+			badProducersWithPunishment[producer] = selectedSlLev.PunishedEpoches
+		}
+	}
 	return sortMapStringUint8Keys(badProducersWithPunishment)
 }
 
@@ -128,6 +188,13 @@ func (blockchain *BlockChain) processForSlashing(block *BeaconBlock) error {
 		if len(inst) == 0 {
 			continue
 		}
+		if inst[0] == SlashAction && len(inst) == 5 {
+			producersBlackList[inst[2]] = EquivocationPunishedEpoches
+			if err := storeSlashedEquivocation(db, inst, beaconHeight); err != nil {
+				return err
+			}
+			continue
+		}
 		if inst[0] != SwapAction {
 			continue
 		}
@@ -0,0 +1,31 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildBadProducers(t *testing.T) {
+	slashLevels := []SlashLevel{
+		SlashLevel{MinRange: 50, PunishedEpoches: 2},
+		SlashLevel{MinRange: 75, PunishedEpoches: 3},
+	}
+	committee := []string{"A", "B", "C", "D"}
+	numOfBlocksByProducers := map[string]uint64{"A": 10, "B": 10, "C": 10}
+
+	// D produced no block in the epoch
+	badProducers := buildBadProducers(slashLevels, committee, numOfBlocksByProducers, map[string]uint64{})
+	assert.Equal(t, map[string]uint8{"D": 3}, badProducers)
+
+	// a view change certificate of another producer does not spare D, and B is punished for its proven timeouts
+	badProducers = buildBadProducers(slashLevels, committee, numOfBlocksByProducers, map[string]uint64{"B": 10})
+	assert.Equal(t, map[string]uint8{"B": 2, "D": 3}, badProducers)
+
+	// a producer which missed blocks and timed out gets the heavier punishment only
+	badProducers = buildBadProducers(slashLevels, committee, numOfBlocksByProducers, map[string]uint64{"D": 1})
+	assert.Equal(t, map[string]uint8{"D": 3}, badProducers)
+
+	// an empty committee punishes nobody
+	assert.Equal(t, 0, len(buildBadProducers(slashLevels, []string{}, numOfBlocksByProducers, map[string]uint64{})))
+}
@@ -0,0 +1,162 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+)
+
+// SnapshotManifest describes the chain state stored in a snapshot.
+// A node importing the snapshot resumes syncing from BeaconHeight and the shard heights.
+type SnapshotManifest struct {
+	SchemaVersion        uint32                     `json:"SchemaVersion"`
+	BeaconHeight         uint64                     `json:"BeaconHeight"`
+	BeaconBestBlockHash  common.Hash                `json:"BeaconBestBlockHash"`
+	ShardHeights         map[byte]uint64            `json:"ShardHeights"`
+	ShardBestBlockHashes map[byte]common.Hash       `json:"ShardBestBlockHashes"`
+	Sections             []database.SnapshotSection `json:"Sections"`
+}
+
+// ExportSnapshot writes the chain state at the current beacon best state to writer.
+// Block bodies are not exported except the ones needed to validate the next blocks:
+// - the best block of the beacon chain and of every shard
+// - the beacon blocks which have not been processed by a shard yet
+func (blockchain *BlockChain) ExportSnapshot(writer io.Writer) (*SnapshotManifest, error) {
+	beaconBestState := blockchain.BestState.Beacon
+	manifest := &SnapshotManifest{
+		BeaconHeight:         beaconBestState.BeaconHeight,
+		BeaconBestBlockHash:  beaconBestState.BestBlockHash,
+		ShardHeights:         make(map[byte]uint64),
+		ShardBestBlockHashes: make(map[byte]common.Hash),
+	}
+	schemaVersion, err := blockchain.config.DataBase.GetSchemaVersion()
+	if err != nil {
+		return nil, NewBlockChainError(ExportSnapshotError, err)
+	}
+	manifest.SchemaVersion = schemaVersion
+	blockHashes := []common.Hash{beaconBestState.BestBlockHash}
+	fromBeaconHeight := beaconBestState.BeaconHeight
+	for shardID, shardBestState := range blockchain.BestState.Shard {
+		manifest.ShardHeights[shardID] = shardBestState.ShardHeight
+		manifest.ShardBestBlockHashes[shardID] = shardBestState.BestBlockHash
+		blockHashes = append(blockHashes, shardBestState.BestBlockHash)
+		if shardBestState.BeaconHeight+1 < fromBeaconHeight {
+			fromBeaconHeight = shardBestState.BeaconHeight + 1
+		}
+	}
+	for height := fromBeaconHeight; height < beaconBestState.BeaconHeight; height++ {
+		blockHash, err := blockchain.config.DataBase.GetBeaconBlockHashByIndex(height)
+		if err != nil {
+			return nil, NewBlockChainError(ExportSnapshotError, err)
+		}
+		blockHashes = append(blockHashes, blockHash)
+	}
+	manifest.Sections, err = blockchain.config.DataBase.ExportSnapshot(writer, blockHashes)
+	if err != nil {
+		return nil, NewBlockChainError(ExportSnapshotError, err)
+	}
+	return manifest, nil
+}
+
+// ImportSnapshot imports a snapshot of size bytes into the empty database db.
+// The manifest comes with the snapshot so it is only trusted if its beacon best block is expectedBeaconBlockHash,
+// a hash obtained from a trusted source.
+// Besides the records of every section, the imported beacon best state must match the manifest,
+// and every shard best state must match the manifest and contain the shard block confirmed by the beacon best state.
+func ImportSnapshot(db database.DatabaseInterface, reader io.Reader, size int64, manifest *SnapshotManifest, expectedBeaconBlockHash common.Hash) error {
+	if !expectedBeaconBlockHash.IsEqual(&manifest.BeaconBestBlockHash) {
+		return NewBlockChainError(ImportSnapshotError, errors.Errorf("snapshot beacon best block %+v, expected %+v", manifest.BeaconBestBlockHash, expectedBeaconBlockHash))
+	}
+	verify := func() error {
+		return verifySnapshot(db, manifest)
+	}
+	if err := db.ImportSnapshot(reader, size, manifest.SchemaVersion, manifest.Sections, verify); err != nil {
+		return NewBlockChainError(ImportSnapshotError, err)
+	}
+	// bodies of the blocks before the snapshot are not in the database
+	fromBeaconHeight := manifest.BeaconHeight
+	for shardID, shardHeight := range manifest.ShardHeights {
+		if err := db.StorePrunedHeight(false, shardID, shardHeight-1); err != nil {
+			return NewBlockChainError(StorePrunedHeightError, err)
+		}
+		shardBestStateBytes, err := db.FetchShardBestState(shardID)
+		if err != nil {
+			return NewBlockChainError(ImportSnapshotError, err)
+		}
+		shardBestState := &ShardBestState{}
+		if err := json.Unmarshal(shardBestStateBytes, shardBestState); err != nil {
+			return NewBlockChainError(UnmashallJsonShardBestStateError, err)
+		}
+		if shardBestState.BeaconHeight+1 < fromBeaconHeight {
+			fromBeaconHeight = shardBestState.BeaconHeight + 1
+		}
+	}
+	if err := db.StorePrunedHeight(true, 0, fromBeaconHeight-1); err != nil {
+		return NewBlockChainError(StorePrunedHeightError, err)
+	}
+	return nil
+}
+
+// verifySnapshot checks the imported chain state against the beacon best state.
+func verifySnapshot(db database.DatabaseInterface, manifest *SnapshotManifest) error {
+	beaconBestStateBytes, err := db.FetchBeaconBestState()
+	if err != nil {
+		return err
+	}
+	beaconBestState := &BeaconBestState{}
+	if err := json.Unmarshal(beaconBestStateBytes, beaconBestState); err != nil {
+		return err
+	}
+	if beaconBestState.BeaconHeight != manifest.BeaconHeight || !beaconBestState.BestBlockHash.IsEqual(&manifest.BeaconBestBlockHash) {
+		return errors.Errorf("beacon best state at height %+v with block %+v, manifest expects height %+v with block %+v", beaconBestState.BeaconHeight, beaconBestState.BestBlockHash, manifest.BeaconHeight, manifest.BeaconBestBlockHash)
+	}
+	if bestBlockHash := beaconBestState.BestBlock.Header.Hash(); !bestBlockHash.IsEqual(&manifest.BeaconBestBlockHash) {
+		return errors.Errorf("beacon best block has hash %+v, expected %+v", bestBlockHash, manifest.BeaconBestBlockHash)
+	}
+	beaconBlockHash, err := db.GetBeaconBlockHashByIndex(manifest.BeaconHeight)
+	if err != nil {
+		return err
+	}
+	if !beaconBlockHash.IsEqual(&manifest.BeaconBestBlockHash) {
+		return errors.Errorf("beacon block at height %+v is %+v, expected %+v", manifest.BeaconHeight, beaconBlockHash, manifest.BeaconBestBlockHash)
+	}
+	if len(manifest.ShardHeights) != beaconBestState.ActiveShards {
+		return errors.Errorf("snapshot has %+v shards, beacon best state has %+v active shards", len(manifest.ShardHeights), beaconBestState.ActiveShards)
+	}
+	for shardID, shardHeight := range manifest.ShardHeights {
+		shardBestStateBytes, err := db.FetchShardBestState(shardID)
+		if err != nil {
+			return err
+		}
+		shardBestState := &ShardBestState{}
+		if err := json.Unmarshal(shardBestStateBytes, shardBestState); err != nil {
+			return err
+		}
+		shardBestBlockHash := manifest.ShardBestBlockHashes[shardID]
+		if shardBestState.ShardHeight != shardHeight || !shardBestState.BestBlockHash.IsEqual(&shardBestBlockHash) {
+			return errors.Errorf("shard %+v best state at height %+v with block %+v, manifest expects height %+v with block %+v", shardID, shardBestState.ShardHeight, shardBestState.BestBlockHash, shardHeight, shardBestBlockHash)
+		}
+		if shardBestState.BeaconHeight > beaconBestState.BeaconHeight {
+			return errors.Errorf("shard %+v best state at beacon height %+v, beyond beacon best state height %+v", shardID, shardBestState.BeaconHeight, beaconBestState.BeaconHeight)
+		}
+		// the shard chain must contain the shard block confirmed by the beacon chain
+		confirmedHeight := beaconBestState.BestShardHeight[shardID]
+		if confirmedHeight > shardBestState.ShardHeight {
+			return errors.Errorf("shard %+v best state at height %+v, beacon best state confirms height %+v", shardID, shardBestState.ShardHeight, confirmedHeight)
+		}
+		if confirmedHeight > 1 {
+			confirmedHash, err := db.GetBlockByIndex(confirmedHeight, shardID)
+			if err != nil {
+				return err
+			}
+			expectedHash := beaconBestState.BestShardHash[shardID]
+			if !confirmedHash.IsEqual(&expectedHash) {
+				return errors.Errorf("shard %+v block at height %+v is %+v, beacon best state confirms %+v", shardID, confirmedHeight, confirmedHash, expectedHash)
+			}
+		}
+	}
+	return nil
+}
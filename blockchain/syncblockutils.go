@@ -99,3 +99,13 @@ func arrayCommonElements(a, b []uint64) (c []uint64) {
 	}
 	return
 }
+
+func removeBlkHeight(blksHeight []uint64, height uint64) []uint64 {
+	result := []uint64{}
+	for _, blkHeight := range blksHeight {
+		if blkHeight != height {
+			result = append(result, blkHeight)
+		}
+	}
+	return result
+}
@@ -794,6 +794,28 @@ func (synker *Synker) SyncBlkCrossShard(getFromPool bool, byHash bool, blksHash
 	}
 }
 
+// RefetchBlkCrossShard requests a cross shard block again even if it has been requested recently or the last pools state has it,
+// ex: the block is dropped from cross shard pool
+func (synker *Synker) RefetchBlkCrossShard(fromShard byte, toShard byte, height uint64) {
+	prefix := getBlkPrefixSyncKey(false, CrossShardBlk, toShard, fromShard)
+	synker.Status.CurrentlySyncBlks.Delete(fmt.Sprintf("%v%v", prefix, height))
+	synker.States.PoolsState.Lock()
+	synker.States.PoolsState.CrossShardPool[fromShard] = removeBlkHeight(synker.States.PoolsState.CrossShardPool[fromShard], height)
+	synker.States.PoolsState.Unlock()
+	synker.SyncBlkCrossShard(false, false, nil, []uint64{height}, fromShard, toShard, libp2p.ID(""))
+}
+
+// RefetchBlkShardToBeacon requests a shard to beacon block again even if it has been requested recently or the last pools state has it,
+// ex: the block is dropped from shard to beacon pool
+func (synker *Synker) RefetchBlkShardToBeacon(shardID byte, height uint64) {
+	prefix := getBlkPrefixSyncKey(false, ShardToBeaconBlk, shardID, 0)
+	synker.Status.CurrentlySyncBlks.Delete(fmt.Sprintf("%v%v", prefix, height))
+	synker.States.PoolsState.Lock()
+	synker.States.PoolsState.ShardToBeaconPool[shardID] = removeBlkHeight(synker.States.PoolsState.ShardToBeaconPool[shardID], height)
+	synker.States.PoolsState.Unlock()
+	synker.SyncBlkShardToBeacon(shardID, false, true, false, nil, []uint64{height}, 0, 0, libp2p.ID(""))
+}
+
 func (synker *Synker) SetChainState(shard bool, shardID byte, ready bool) {
 	synker.Status.IsLatest.Lock()
 	defer synker.Status.IsLatest.Unlock()
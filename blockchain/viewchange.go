@@ -0,0 +1,119 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+// ViewChangeCertificate proves that the proposer of a round did not propose a block the committee could vote:
+// more than 2/3 of the committee signed the timeout of the round with their BLS mining key.
+// The proposer of the next round stores the certificates of the timed out rounds of a height in its block.
+// A certificate is bound to the previous block of the height, so it can not be reused on another branch.
+type ViewChangeCertificate struct {
+	PreviousBlockHash common.Hash
+	Height            uint64
+	Round             int
+	Proposer          string // committee public key of the proposer of the round, in base58
+	ValidatorsIdx     []int
+	AggSig            []byte
+}
+
+// ViewChangeDataHash returns the hash the committee members of a chain sign to time out a round on top of a previous block
+func ViewChangeDataHash(chainKey string, previousBlockHash common.Hash, height uint64, round int, proposer string) common.Hash {
+	return common.HashH([]byte(fmt.Sprint(chainKey, "_", previousBlockHash.String(), "_", height, "_", round, "_", proposer)))
+}
+
+// viewChangeCertificatesRoot returns the hash of certificates committed in a block header, zero hash for no certificate
+func viewChangeCertificatesRoot(certs []ViewChangeCertificate) (common.Hash, error) {
+	if len(certs) == 0 {
+		return common.Hash{}, nil
+	}
+	certsBytes, err := json.Marshal(certs)
+	if err != nil {
+		return common.Hash{}, NewBlockChainError(ViewChangeCertificateError, err)
+	}
+	return common.HashH(certsBytes), nil
+}
+
+/*
+verifyViewChangeCertificates verifies the certificates of a block with the best state before the block:
+	- certificates match the root committed in the block header
+	- each certificate times out a round before the round of the block, at the block height on top of its previous block, once
+	- the proposer of the round is the one expected by the proposer index of the best state
+	- more than 2/3 of the committee signed the timeout
+*/
+func verifyViewChangeCertificates(
+	chainKey string,
+	previousBlockHash common.Hash,
+	height uint64,
+	round int,
+	certs []ViewChangeCertificate,
+	root common.Hash,
+	committee []incognitokey.CommitteePublicKey,
+	lastProposerIdx int,
+	consensusAlgorithm string,
+) error {
+	certsRoot, err := viewChangeCertificatesRoot(certs)
+	if err != nil {
+		return err
+	}
+	if !certsRoot.IsEqual(&root) {
+		return NewBlockChainError(ViewChangeCertificateError, fmt.Errorf("Expect view change root %+v but get %+v", root, certsRoot))
+	}
+	if len(certs) == 0 {
+		return nil
+	}
+	committeeBLSKeys := []blsmultisig.PublicKey{}
+	for _, member := range committee {
+		committeeBLSKeys = append(committeeBLSKeys, member.MiningPubKey[common.GetMiningKeySchemeName(consensusAlgorithm)])
+	}
+	rounds := make(map[int]bool)
+	for _, cert := range certs {
+		if !cert.PreviousBlockHash.IsEqual(&previousBlockHash) {
+			return NewBlockChainError(ViewChangeCertificateError, fmt.Errorf("Certificate of round %+v times out a round on top of block %+v but the previous block is %+v", cert.Round, cert.PreviousBlockHash, previousBlockHash))
+		}
+		if cert.Height != height || cert.Round < 1 || cert.Round >= round || rounds[cert.Round] {
+			return NewBlockChainError(ViewChangeCertificateError, fmt.Errorf("Unexpected certificate of height %+v round %+v in block %+v round %+v", cert.Height, cert.Round, height, round))
+		}
+		rounds[cert.Round] = true
+		proposer, err := committee[(lastProposerIdx+cert.Round)%len(committee)].ToBase58()
+		if err != nil {
+			return NewBlockChainError(ViewChangeCertificateError, err)
+		}
+		if proposer != cert.Proposer {
+			return NewBlockChainError(ViewChangeCertificateError, fmt.Errorf("Proposer of round %+v should be %+v", cert.Round, proposer))
+		}
+		if len(cert.ValidatorsIdx) <= 2*len(committee)/3 {
+			return NewBlockChainError(ViewChangeCertificateError, fmt.Errorf("Certificate of round %+v is signed by %+v of %+v committee members", cert.Round, len(cert.ValidatorsIdx), len(committee)))
+		}
+		for i, idx := range cert.ValidatorsIdx {
+			if idx < 0 || idx >= len(committee) || (i > 0 && idx <= cert.ValidatorsIdx[i-1]) {
+				return NewBlockChainError(ViewChangeCertificateError, fmt.Errorf("Invalid validator index %+v in certificate of round %+v", idx, cert.Round))
+			}
+		}
+		dataHash := ViewChangeDataHash(chainKey, cert.PreviousBlockHash, cert.Height, cert.Round, cert.Proposer)
+		valid, err := blsmultisig.Verify(cert.AggSig, dataHash.GetBytes(), cert.ValidatorsIdx, committeeBLSKeys)
+		if err != nil {
+			return NewBlockChainError(ViewChangeCertificateError, err)
+		}
+		if !valid {
+			return NewBlockChainError(ViewChangeCertificateError, fmt.Errorf("Invalid signature of certificate of round %+v", cert.Round))
+		}
+	}
+	return nil
+}
+
+// addNumOfTimeoutsByProducers counts the proven timeouts of the proposers in the certificates of a block
+func addNumOfTimeoutsByProducers(numOfTimeoutsByProducers map[string]uint64, certs []ViewChangeCertificate) map[string]uint64 {
+	if numOfTimeoutsByProducers == nil {
+		numOfTimeoutsByProducers = make(map[string]uint64)
+	}
+	for _, cert := range certs {
+		numOfTimeoutsByProducers[cert.Proposer]++
+	}
+	return numOfTimeoutsByProducers
+}
@@ -77,7 +77,7 @@ func (rpcServer *RpcServer) AddOrUpdatePeer(rawAddress string, publicKeyType str
 		}
 		publicKeyByte := []byte(publicKeyMining)
 		miningKey := map[string][]byte{}
-		if publicKeyType == common.BlsConsensus {
+		if common.GetMiningKeySchemeName(publicKeyType) == common.BlsConsensus {
 			err := json.Unmarshal(publicKeyByte, &miningKey)
 			if err != nil {
 				return err
@@ -23,6 +23,9 @@ func makeBlockChain(databaseDir string, testNet bool) (*blockchain.BlockChain, e
 	if err != nil {
 		return nil, err
 	}
+	if err := db.CheckSchemaVersion(); err != nil {
+		return nil, err
+	}
 	log.Printf("Open leveldb at %+v successfully", filepath.Join(databaseDir))
 	bc := blockchain.NewBlockChain(&blockchain.Config{}, false)
 	var bcParams *blockchain.Params
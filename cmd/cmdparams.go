@@ -15,6 +15,7 @@ const (
 	defaultConfigFilename = "component.conf"
 	defaultDataDirname    = "data"
 	defaultLogDirname     = "logs"
+	defaultDatabaseType   = "leveldb"
 )
 
 var (
@@ -39,6 +40,9 @@ type params struct {
 	ChainDataDir string `long:"chaindatadir" description:"Directory of Stored Blockchain Database"`
 	OutDataDir   string `long:"outdatadir" description:"Directory of Export Blockchain Data"`
 	FileName     string `long:"filename" description:"Filename of Backup Blockchin Data"`
+	DatabaseType string `long:"dbtype" description:"Database driver of Stored Blockchain Database {leveldb, badgerdb}, default is leveldb"`
+	BeaconHash   string `long:"beaconhash" description:"Hash of the beacon best block of an imported snapshot, taken from a trusted source"`
+	Repair       bool   `long:"repair" description:"Repair the index-only problems found by checkdb"`
 	// wallet
 	WalletName        string `long:"wallet" description:"Wallet Database Name file, default is 'wallet'"`
 	WalletPassphrase  string `long:"walletpassphrase" description:"Wallet passphrase"`
@@ -58,8 +62,9 @@ func newConfigParser(cfg *params, options flags.Options) *flags.Parser {
 
 func loadParams() (*params, error) {
 	cfg := params{
-		DataDir: defaultDataDir,
-		TestNet: false,
+		DataDir:      defaultDataDir,
+		TestNet:      false,
+		DatabaseType: defaultDatabaseType,
 	}
 
 	preParser := newConfigParser(&cfg, flags.HelpFlag)
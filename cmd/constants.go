@@ -8,6 +8,10 @@ const (
 	getPrivacyTokenID      = "getprivacytokenid"
 	backupChain            = "backupchain"
 	restoreChain           = "restorechain"
+	upgradeDB              = "upgradedb"
+	exportSnapshotCmd      = "exportsnapshot"
+	importSnapshotCmd      = "importsnapshot"
+	checkDB                = "checkdb"
 )
 
 var CmdList = []string{
@@ -18,4 +22,8 @@ var CmdList = []string{
 	getPrivacyTokenID,
 	backupChain,
 	restoreChain,
+	upgradeDB,
+	exportSnapshotCmd,
+	importSnapshotCmd,
+	checkDB,
 }
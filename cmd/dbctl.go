@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	_ "github.com/incognitochain/incognito-chain/database/badgerdb"
+	_ "github.com/incognitochain/incognito-chain/database/lvdb"
+)
+
+// upgradeDatabase migrates the data dir in place to the schema version of this build
+func upgradeDatabase(databaseDir string, databaseType string) error {
+	database.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
+	db, err := database.Open(databaseType, filepath.Join(databaseDir))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	version, err := db.GetSchemaVersion()
+	if err != nil {
+		return err
+	}
+	log.Printf("Open %+v at %+v with schema version %+v", databaseType, filepath.Join(databaseDir), version)
+	if err := db.UpgradeSchema(); err != nil {
+		return err
+	}
+	version, err = db.GetSchemaVersion()
+	if err != nil {
+		return err
+	}
+	log.Printf("Upgrade database to schema version %+v successfully", version)
+	return nil
+}
+
+// checkDatabase prints the integrity report of the data dir as json on stdout
+func checkDatabase(databaseDir string, databaseType string, repair bool) error {
+	database.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
+	db, err := database.Open(databaseType, filepath.Join(databaseDir))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if err := db.CheckSchemaVersion(); err != nil {
+		return err
+	}
+	report, err := blockchain.CheckDatabaseIntegrity(db, repair)
+	if err != nil {
+		return err
+	}
+	result, err := parseToJsonString(report)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(result))
+	return nil
+}
@@ -187,6 +187,59 @@ func processCmd() {
 				}
 			}
 		}
+	case upgradeDB:
+		{
+			if cfg.ChainDataDir == "" {
+				log.Println("No Database to Upgrade")
+				return
+			}
+			err := upgradeDatabase(cfg.ChainDataDir, cfg.DatabaseType)
+			if err != nil {
+				log.Printf("Upgrade database failed, err %+v", err)
+			}
+		}
+	case exportSnapshotCmd:
+		{
+			bc, err := makeBlockChain(cfg.ChainDataDir, cfg.TestNet)
+			if err != nil {
+				log.Println("Error create blockchain variable ", err)
+				return
+			}
+			err = exportSnapshot(bc, cfg.OutDataDir, cfg.FileName)
+			if err != nil {
+				log.Printf("Export snapshot failed, err %+v", err)
+			}
+		}
+	case importSnapshotCmd:
+		{
+			if cfg.FileName == "" {
+				log.Println("No Snapshot File to Process")
+				return
+			}
+			if cfg.ChainDataDir == "" {
+				log.Println("No Database to Import Snapshot into")
+				return
+			}
+			if cfg.BeaconHash == "" {
+				log.Println("No Trusted Beacon Block Hash of the Snapshot")
+				return
+			}
+			err := importSnapshot(cfg.ChainDataDir, cfg.DatabaseType, cfg.FileName, cfg.BeaconHash)
+			if err != nil {
+				log.Printf("Import snapshot failed, err %+v", err)
+			}
+		}
+	case checkDB:
+		{
+			if cfg.ChainDataDir == "" {
+				log.Println("No Database to Check")
+				return
+			}
+			err := checkDatabase(cfg.ChainDataDir, cfg.DatabaseType, cfg.Repair)
+			if err != nil {
+				log.Printf("Check database failed, err %+v", err)
+			}
+		}
 	case restoreChain:
 		{
 			if cfg.FileName == "" {
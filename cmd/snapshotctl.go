@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+)
+
+// snapshot manifest is stored next to the snapshot data file
+const snapshotManifestSuffix = ".manifest"
+
+func exportSnapshot(bc *blockchain.BlockChain, outDatadir string, fileName string) error {
+	if fileName == "" {
+		fileName = "snapshot-incognito"
+	}
+	if outDatadir == "" {
+		outDatadir = "./"
+	}
+	file := filepath.Join(outDatadir, fileName)
+	fileHandler, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer fileHandler.Close()
+	manifest, err := bc.ExportSnapshot(fileHandler)
+	if err != nil {
+		return err
+	}
+	manifestBytes, err := json.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(file+snapshotManifestSuffix, manifestBytes, os.ModePerm); err != nil {
+		return err
+	}
+	log.Printf("Export Snapshot at Beacon Height %+v, file %+v", manifest.BeaconHeight, file)
+	return nil
+}
+
+func importSnapshot(databaseDir string, databaseType string, fileName string, beaconHash string) error {
+	blockchain.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
+	database.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
+	// the manifest is read from the snapshot, only a beacon block hash from a trusted source can vouch for it
+	expectedBeaconBlockHash, err := common.Hash{}.NewHashFromStr(beaconHash)
+	if err != nil {
+		return err
+	}
+	manifestBytes, err := ioutil.ReadFile(fileName + snapshotManifestSuffix)
+	if err != nil {
+		return err
+	}
+	manifest := &blockchain.SnapshotManifest{}
+	if err := json.Unmarshal(manifestBytes, manifest); err != nil {
+		return err
+	}
+	fileHandler, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer fileHandler.Close()
+	fileInfo, err := fileHandler.Stat()
+	if err != nil {
+		return err
+	}
+	db, err := database.Open(databaseType, filepath.Join(databaseDir))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if err := blockchain.ImportSnapshot(db, fileHandler, fileInfo.Size(), manifest, *expectedBeaconBlockHash); err != nil {
+		return err
+	}
+	log.Printf("Import Snapshot at Beacon Height %+v into %+v successfully", manifest.BeaconHeight, filepath.Join(databaseDir))
+	return nil
+}
@@ -459,3 +459,13 @@ func GetValidStaker(committees []string, stakers []string) []string {
 func GetShardChainKey(shardID byte) string {
 	return ShardChainKey + "-" + strconv.Itoa(int(shardID))
 }
+
+// GetMiningKeySchemeName returns the name of the mining key scheme used by a consensus,
+// consensuses which sign with the same scheme share the same mining key of a committee member
+func GetMiningKeySchemeName(consensusName string) string {
+	switch consensusName {
+	case BlsHotStuffConsensus:
+		return BlsConsensus
+	}
+	return consensusName
+}
@@ -82,9 +82,10 @@ const (
 	WaitingRole    = "waiting"
 	MaxShardNumber = 8
 
-	BlsConsensus    = "bls"
-	BridgeConsensus = "dsa"
-	IncKeyType      = "inc"
+	BlsConsensus         = "bls"
+	BlsHotStuffConsensus = "blshotstuff"
+	BridgeConsensus      = "dsa"
+	IncKeyType           = "inc"
 )
 
 const (
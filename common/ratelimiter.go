@@ -0,0 +1,85 @@
+package common
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// rateLimiterCleanupInterval is how often a RateLimiter forgets the keys whose bucket is full again
+const rateLimiterCleanupInterval = 5 * time.Minute
+
+// RateLimiter keeps one token bucket for each key (a peer ID, a public key...).
+// A bucket holds at most burst tokens and is refilled with rate tokens per second,
+// each allowed event takes one token.
+// A nil RateLimiter or a RateLimiter with a zero rate allows everything.
+// It is safe for concurrent access.
+type RateLimiter struct {
+	rate        float64
+	burst       float64
+	buckets     map[string]*tokenBucket
+	lastCleanup time.Time
+	mtx         sync.Mutex
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func NewRateLimiter(rate float64, burst uint) *RateLimiter {
+	if burst == 0 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:        rate,
+		burst:       float64(burst),
+		buckets:     make(map[string]*tokenBucket),
+		lastCleanup: time.Now(),
+	}
+}
+
+// Allow takes a token from the bucket of key, it returns false if the bucket is empty
+func (rateLimiter *RateLimiter) Allow(key string) bool {
+	return rateLimiter.allowAt(key, time.Now())
+}
+
+func (rateLimiter *RateLimiter) allowAt(key string, now time.Time) bool {
+	if rateLimiter == nil || rateLimiter.rate <= 0 {
+		return true
+	}
+	rateLimiter.mtx.Lock()
+	defer rateLimiter.mtx.Unlock()
+	if now.Sub(rateLimiter.lastCleanup) > rateLimiterCleanupInterval {
+		rateLimiter.cleanup(now)
+	}
+	bucket, ok := rateLimiter.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: rateLimiter.burst, updated: now}
+		rateLimiter.buckets[key] = bucket
+	}
+	rateLimiter.refill(bucket, now)
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+func (rateLimiter *RateLimiter) refill(bucket *tokenBucket, now time.Time) {
+	if elapsed := now.Sub(bucket.updated).Seconds(); elapsed > 0 {
+		bucket.tokens = math.Min(rateLimiter.burst, bucket.tokens+elapsed*rateLimiter.rate)
+		bucket.updated = now
+	}
+}
+
+// cleanup removes the buckets which are full, their keys are back to the initial state
+func (rateLimiter *RateLimiter) cleanup(now time.Time) {
+	for key, bucket := range rateLimiter.buckets {
+		rateLimiter.refill(bucket, now)
+		if bucket.tokens >= rateLimiter.burst {
+			delete(rateLimiter.buckets, key)
+		}
+	}
+	rateLimiter.lastCleanup = now
+}
@@ -0,0 +1,51 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	Unit test for RateLimiter
+*/
+
+func TestRateLimiterAllow(t *testing.T) {
+	rateLimiter := NewRateLimiter(1, 2)
+	now := time.Now()
+	assert.True(t, rateLimiter.allowAt("a", now))
+	assert.True(t, rateLimiter.allowAt("a", now))
+	assert.False(t, rateLimiter.allowAt("a", now))
+	// keys have their own bucket
+	assert.True(t, rateLimiter.allowAt("b", now))
+
+	// one token is added per second
+	assert.True(t, rateLimiter.allowAt("a", now.Add(time.Second)))
+	assert.False(t, rateLimiter.allowAt("a", now.Add(time.Second)))
+}
+
+func TestRateLimiterCleanup(t *testing.T) {
+	rateLimiter := NewRateLimiter(1, 2)
+	now := time.Now()
+	rateLimiter.lastCleanup = now
+	rateLimiter.allowAt("a", now)
+	rateLimiter.allowAt("b", now.Add(rateLimiterCleanupInterval))
+	rateLimiter.allowAt("b", now.Add(rateLimiterCleanupInterval))
+	assert.Equal(t, 2, len(rateLimiter.buckets))
+	// bucket of a is full again, bucket of b is not
+	rateLimiter.allowAt("c", now.Add(rateLimiterCleanupInterval+time.Second))
+	_, ok := rateLimiter.buckets["a"]
+	assert.False(t, ok)
+	_, ok = rateLimiter.buckets["b"]
+	assert.True(t, ok)
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	var rateLimiter *RateLimiter
+	assert.True(t, rateLimiter.Allow("a"))
+	rateLimiter = NewRateLimiter(0, 1)
+	for i := 0; i < 10; i++ {
+		assert.True(t, rateLimiter.Allow("a"))
+	}
+}
@@ -25,6 +25,8 @@ const (
 	DefaultDataDirname            = "data"
 	DefaultDatabaseDirname        = "block"
 	DefaultDatabaseMempoolDirname = "mempool"
+	DefaultMempoolJournalFilename = "mempool.journal"
+	DefaultDatabaseType           = "leveldb"
 	DefaultLogLevel               = "info"
 	DefaultLogDirname             = "logs"
 	DefaultLogFilename            = "log.log"
@@ -44,7 +46,15 @@ const (
 	DefaultEnableMining           = true
 	DefaultTxPoolTTL              = uint(15 * 60) // 15 minutes
 	DefaultTxPoolMaxTx            = uint64(100000)
+	DefaultTxPoolReplaceFeeBump   = uint64(10) // 10 percent
+	DefaultTxPoolMaxReplacement   = uint(10)
+	DefaultTxPeerRate             = float64(10) // 10 txs per second
+	DefaultTxPeerBurst            = uint(200)
+	DefaultTxPeerBanThreshold     = uint(1000)
+	DefaultTxSenderRate           = float64(1) // 1 tx per second
+	DefaultTxSenderBurst          = uint(20)
 	DefaultLimitFee               = uint64(1) // 1 nano PRV = 10^-9 PRV
+	MinPruneHeights               = uint64(100)
 	// For wallet
 	DefaultWalletName     = "wallet"
 	DefaultPersistMempool = false
@@ -73,6 +83,8 @@ type config struct {
 	DataDir            string `short:"D" long:"datadir" description:"Directory to store data"`
 	DatabaseDir        string `short:"d" long:"datapre" description:"Database dir"`
 	DatabaseMempoolDir string `short:"m" long:"datamempool" description:"Mempool Database Dir"`
+	MempoolJournal     string `long:"mempooljournal" description:"Journal file of the transactions in mempool, in the data dir"`
+	DatabaseType       string `long:"dbtype" description:"Database driver to store chain data {leveldb, badgerdb}, default is leveldb"`
 	LogDir             string `short:"l" long:"logdir" description:"Directory to log output."`
 	LogLevel           string `long:"loglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
 
@@ -125,11 +137,21 @@ type config struct {
 	WalletAutoInit   bool   `long:"walletautoinit" description:"Init wallet automatically if not exist"`
 	WalletShardID    int    `long:"walletshardid" description:"ShardID which wallet use to create account"`
 
-	FastStartup bool `long:"faststartup" description:"Load existed shard/chain dependencies instead of rebuild from block data"`
+	FastStartup bool   `long:"faststartup" description:"Load existed shard/chain dependencies instead of rebuild from block data"`
+	Prune       uint64 `long:"prune" description:"Keep only the block bodies and PDE state of the last N beacon heights, default is 0 (keep everything)"`
+	MetaTxIndex bool   `long:"metatxindex" description:"Index the transactions of shard blocks by metadata type, used by the listtxsbymetadatatype RPC"`
 
-	TxPoolTTL   uint   `long:"txpoolttl" description:"Set Time To Live (TTL) Value for transaction that enter pool"`
-	TxPoolMaxTx uint64 `long:"txpoolmaxtx" description:"Set Maximum number of transaction in pool"`
-	LimitFee    uint64 `long:"limitfee" description:"Limited fee for tx(per Kb data), default is 0.00 PRV"`
+	TxPoolTTL            uint   `long:"txpoolttl" description:"Set Time To Live (TTL) Value for transaction that enter pool"`
+	TxPoolMaxTx          uint64 `long:"txpoolmaxtx" description:"Set Maximum number of transaction in pool"`
+	TxPoolReplaceFeeBump uint64 `long:"txpoolreplacefeebump" description:"Minimum fee increase in percent for a transaction to replace a transaction in pool"`
+	TxPoolMaxReplacement uint   `long:"txpoolmaxreplacement" description:"Maximum number of times a transaction in pool can be replaced"`
+	LimitFee             uint64 `long:"limitfee" description:"Limited fee for tx(per Kb data), default is 0.00 PRV"`
+
+	TxPeerRate         float64 `long:"txpeerrate" description:"Number of transactions per second accepted from one peer, 0 means no limit"`
+	TxPeerBurst        uint    `long:"txpeerburst" description:"Number of transactions accepted at once from one peer"`
+	TxPeerBanThreshold uint    `long:"txpeerbanthreshold" description:"Number of transactions over its rate limit a peer can send in 10 minutes before it is banned, 0 means never ban"`
+	TxSenderRate       float64 `long:"txsenderrate" description:"Number of non privacy transactions per second accepted from one sender public key, 0 means no limit"`
+	TxSenderBurst      uint    `long:"txsenderburst" description:"Number of transactions accepted at once from one sender public key"`
 
 	LoadMempool       bool   `long:"loadmempool" description:"Load transactions from Mempool database"`
 	PersistMempool    bool   `long:"persistmempool" description:"Persistence transaction in memepool database"`
@@ -309,6 +331,8 @@ func loadConfig() (*config, []string, error) {
 		DataDir:              defaultDataDir,
 		DatabaseDir:          DefaultDatabaseDirname,
 		DatabaseMempoolDir:   DefaultDatabaseMempoolDirname,
+		MempoolJournal:       DefaultMempoolJournalFilename,
+		DatabaseType:         DefaultDatabaseType,
 		LogDir:               defaultLogDir,
 		RPCKey:               defaultRPCKeyFile,
 		RPCCert:              defaultRPCCertFile,
@@ -326,6 +350,13 @@ func loadConfig() (*config, []string, error) {
 		FastStartup:          DefaultFastStartup,
 		TxPoolTTL:            DefaultTxPoolTTL,
 		TxPoolMaxTx:          DefaultTxPoolMaxTx,
+		TxPoolReplaceFeeBump: DefaultTxPoolReplaceFeeBump,
+		TxPoolMaxReplacement: DefaultTxPoolMaxReplacement,
+		TxPeerRate:           DefaultTxPeerRate,
+		TxPeerBurst:          DefaultTxPeerBurst,
+		TxPeerBanThreshold:   DefaultTxPeerBanThreshold,
+		TxSenderRate:         DefaultTxSenderRate,
+		TxSenderBurst:        DefaultTxSenderBurst,
 		PersistMempool:       DefaultPersistMempool,
 		LimitFee:             DefaultLimitFee,
 		MetricUrl:            DefaultMetricUrl,
@@ -475,6 +506,15 @@ func loadConfig() (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	// --prune must keep enough heights to validate new blocks.
+	if cfg.Prune > 0 && cfg.Prune < MinPruneHeights {
+		str := "%s: the --prune option must be 0 or at least %d"
+		err := fmt.Errorf(str, funcName, MinPruneHeights)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
 	// --proxy or --connect without --listen disables listening.
 	if (cfg.Proxy != common.EmptyString || len(cfg.ConnectPeers) > 0) &&
 		len(cfg.Listener) == 0 {
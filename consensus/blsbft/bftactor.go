@@ -15,6 +15,7 @@ import (
 	"github.com/incognitochain/incognito-chain/consensus"
 	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
 	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/wire"
 )
 
@@ -28,6 +29,7 @@ type BLSBFT struct {
 	BFTMessageCh     chan wire.MessageBFT
 	ProposeMessageCh chan BFTPropose
 	VoteMessageCh    chan BFTVote
+	TimeoutMessageCh chan BFTTimeout
 
 	RoundData struct {
 		Block             common.BlockInterface
@@ -38,6 +40,7 @@ type BLSBFT struct {
 		Votes             map[string]vote
 		Round             int
 		NextHeight        uint64
+		PreviousBlockHash common.Hash
 		State             string
 		NotYetSendVote    bool
 		Committee         []incognitokey.CommitteePublicKey
@@ -50,10 +53,22 @@ type BLSBFT struct {
 	Blocks         map[string]common.BlockInterface
 	EarlyVotes     map[string]map[string]vote
 	lockEarlyVotes sync.Mutex
-	isOngoing      bool
-	isStarted      bool
-	StopCh         chan struct{}
-	logger         common.Logger
+	// signed timeouts and view change certificates of the rounds which got no block, by round key
+	TimeoutVotes    map[string]map[string][]byte
+	ViewChangeCerts map[string]blockchain.ViewChangeCertificate
+	// first votes by round key and validator, blocks by hash and equivocation proofs of the validators which voted twice
+	VoteHistory            map[string]map[string]BFTVote
+	VotedBlocks            map[string]common.BlockInterface
+	EquivocationProofs     map[string]metadata.EquivocationProofMetadata
+	lockEquivocationProofs sync.Mutex
+	// clock is the system clock unless the consensus runs in a simulation,
+	// asyncTasks are the messages being pushed and the votes being validated
+	clock      Clock
+	asyncTasks sync.WaitGroup
+	isOngoing  bool
+	isStarted  bool
+	StopCh     chan struct{}
+	logger     common.Logger
 }
 
 func (e *BLSBFT) IsOngoing() bool {
@@ -85,11 +100,7 @@ func (e *BLSBFT) Start() error {
 	e.isStarted = true
 	e.isOngoing = false
 	e.StopCh = make(chan struct{})
-	e.EarlyVotes = make(map[string]map[string]vote)
-	e.Blocks = map[string]common.BlockInterface{}
-	e.ProposeMessageCh = make(chan BFTPropose)
-	e.VoteMessageCh = make(chan BFTVote)
-	e.InitRoundData()
+	e.initState()
 
 	ticker := time.Tick(500 * time.Millisecond)
 	e.logger.Info("start bls-bft consensus for chain", e.ChainKey)
@@ -100,199 +111,252 @@ func (e *BLSBFT) Start() error {
 			case <-e.StopCh:
 				return
 			case proposeMsg := <-e.ProposeMessageCh:
-				block, err := e.Chain.UnmarshalBlock(proposeMsg.Block)
-				if err != nil {
-					e.logger.Info(err)
-					continue
-				}
-				blockRoundKey := getRoundKey(block.GetHeight(), block.GetRound())
-				e.logger.Info("receive block", blockRoundKey, getRoundKey(e.RoundData.NextHeight, e.RoundData.Round))
-				if block.GetHeight() == e.RoundData.NextHeight {
-					if e.RoundData.Round == block.GetRound() {
-						if e.RoundData.Block == nil {
-							e.Blocks[blockRoundKey] = block
-							continue
-						}
-					} else {
-						if e.RoundData.Round < block.GetRound() {
-							e.Blocks[blockRoundKey] = block
-							continue
-						}
-					}
-					continue
-				}
-				if block.GetHeight() > e.RoundData.NextHeight {
-					e.Blocks[blockRoundKey] = block
-					continue
-				}
+				e.processProposeMsg(proposeMsg)
 			case msg := <-e.VoteMessageCh:
-				e.logger.Info("Receive vote", msg.RoundKey, getRoundKey(e.RoundData.NextHeight, e.RoundData.Round))
-				validatorIdx := common.IndexOfStr(msg.Validator, e.RoundData.CommitteeBLS.StringList)
-				if validatorIdx == -1 {
-					continue
-				}
-				height, round := parseRoundKey(msg.RoundKey)
-				if height < e.RoundData.NextHeight {
-					continue
-				}
-				if (height == e.RoundData.NextHeight) && (round < e.RoundData.Round) {
-					continue
-				}
-				// roundKey := getRoundKey(e.RoundData.NextHeight, e.RoundData.Round)
-				if (height == e.RoundData.NextHeight) && (round == e.RoundData.Round) {
-					//validate single sig
-					if !(new(common.Hash).IsEqual(&e.RoundData.BlockHash)) {
-						e.RoundData.lockVotes.Lock()
-						if _, ok := e.RoundData.Votes[msg.Validator]; !ok {
-							// committeeArr := []incognitokey.CommitteePublicKey{}
-							// committeeArr = append(committeeArr, e.RoundData.Committee...)
-							e.RoundData.lockVotes.Unlock()
-							go func(voteMsg BFTVote, blockHash common.Hash, committee []incognitokey.CommitteePublicKey) {
-								if err := e.preValidateVote(blockHash.GetBytes(), &(voteMsg.Vote), committee[validatorIdx].MiningPubKey[common.BridgeConsensus]); err != nil {
-									e.logger.Error(err)
-									return
-								}
-								if len(voteMsg.Vote.BRI) != 0 {
-									if err := validateSingleBriSig(&blockHash, voteMsg.Vote.BRI, committee[validatorIdx].MiningPubKey[common.BridgeConsensus]); err != nil {
-										e.logger.Error(err)
-										return
-									}
-								}
-								go func() {
-									voteCtnBytes, err := json.Marshal(voteMsg)
-									if err != nil {
-										e.logger.Error(consensus.NewConsensusError(consensus.UnExpectedError, err))
-										return
-									}
-									msg, _ := wire.MakeEmptyMessage(wire.CmdBFT)
-									msg.(*wire.MessageBFT).ChainKey = e.ChainKey
-									msg.(*wire.MessageBFT).Content = voteCtnBytes
-									msg.(*wire.MessageBFT).Type = MSG_VOTE
-									// TODO uncomment here when switch to non-highway mode
-									// e.Node.PushMessageToChain(msg, e.Chain)
-								}()
-								e.addVote(voteMsg)
-							}(msg, e.RoundData.BlockHash, append([]incognitokey.CommitteePublicKey{}, e.RoundData.Committee...))
-							continue
-						} else {
-							e.RoundData.lockVotes.Unlock()
-							continue
-						}
-					}
-				}
-				e.addEarlyVote(msg)
-
+				e.processVoteMsg(msg)
+			case msg := <-e.TimeoutMessageCh:
+				e.processTimeoutMsg(msg)
 			case <-ticker:
+				e.processTick()
+			}
+		}
+	}()
+	return nil
+}
 
-				metrics.SetGlobalParam("RoundKey", getRoundKey(e.RoundData.NextHeight, e.RoundData.Round), "Phase", e.RoundData.State)
-
-				pubKey := e.UserKeySet.GetPublicKey()
-				if common.IndexOfStr(pubKey.GetMiningKeyBase58(consensusName), e.RoundData.CommitteeBLS.StringList) == -1 {
-					e.enterNewRound()
-					continue
-				}
-
-				if !e.Chain.IsReady() {
-					e.isOngoing = false
-					//fmt.Println("CONSENSUS: ticker 1")
-					continue
-				}
-
-				if !e.isInTimeFrame() || e.RoundData.State == "" {
-					e.enterNewRound()
-				}
+func (e *BLSBFT) initState() {
+	e.EarlyVotes = make(map[string]map[string]vote)
+	e.Blocks = map[string]common.BlockInterface{}
+	e.ProposeMessageCh = make(chan BFTPropose)
+	e.VoteMessageCh = make(chan BFTVote)
+	e.TimeoutMessageCh = make(chan BFTTimeout)
+	e.TimeoutVotes = make(map[string]map[string][]byte)
+	e.ViewChangeCerts = make(map[string]blockchain.ViewChangeCertificate)
+	e.VoteHistory = make(map[string]map[string]BFTVote)
+	e.VotedBlocks = make(map[string]common.BlockInterface)
+	e.EquivocationProofs = make(map[string]metadata.EquivocationProofMetadata)
+	e.InitRoundData()
+}
 
-				switch e.RoundData.State {
-				case listenPhase:
-					// timeout or vote nil?
-					//fmt.Println("CONSENSUS: listen phase 1")
-					if e.Chain.CurrentHeight() == e.RoundData.NextHeight {
-						e.enterNewRound()
-						continue
-					}
-					roundKey := getRoundKey(e.RoundData.NextHeight, e.RoundData.Round)
-					if e.Blocks[roundKey] != nil {
-						metrics.SetGlobalParam("ReceiveBlockTime", time.Since(e.RoundData.TimeStart).Seconds())
-						//fmt.Println("CONSENSUS: listen phase 2")
-						if err := e.validatePreSignBlock(e.Blocks[roundKey]); err != nil {
-							delete(e.Blocks, roundKey)
-							e.logger.Error(err)
-							continue
-						}
+func (e *BLSBFT) processProposeMsg(proposeMsg BFTPropose) {
+	block, err := e.Chain.UnmarshalBlock(proposeMsg.Block)
+	if err != nil {
+		e.logger.Info(err)
+		return
+	}
+	blockRoundKey := getRoundKey(block.GetHeight(), block.GetRound())
+	e.logger.Info("receive block", blockRoundKey, getRoundKey(e.RoundData.NextHeight, e.RoundData.Round))
+	e.recordVotedBlock(block)
+	if block.GetHeight() == e.RoundData.NextHeight {
+		if e.RoundData.Round == block.GetRound() {
+			if e.RoundData.Block == nil {
+				e.Blocks[blockRoundKey] = block
+				return
+			}
+		} else {
+			if e.RoundData.Round < block.GetRound() {
+				e.Blocks[blockRoundKey] = block
+				return
+			}
+		}
+		return
+	}
+	if block.GetHeight() > e.RoundData.NextHeight {
+		e.Blocks[blockRoundKey] = block
+		return
+	}
+}
 
-						if e.RoundData.Block == nil {
-							// blockData, _ := json.Marshal(e.Blocks[roundKey])
-							// msg, _ := MakeBFTProposeMsg(blockData, e.ChainKey, e.UserKeySet)
-							// go e.Node.PushMessageToChain(msg, e.Chain)
-
-							e.RoundData.Block = e.Blocks[roundKey]
-							e.RoundData.BlockHash = *e.RoundData.Block.Hash()
-							valData, err := DecodeValidationData(e.RoundData.Block.GetValidationField())
-							if err != nil {
-								e.logger.Error(err)
-								continue
-							}
-							e.RoundData.BlockValidateData = *valData
-							e.enterVotePhase()
-						}
+func (e *BLSBFT) processVoteMsg(msg BFTVote) {
+	e.logger.Info("Receive vote", msg.RoundKey, getRoundKey(e.RoundData.NextHeight, e.RoundData.Round))
+	validatorIdx := common.IndexOfStr(msg.Validator, e.RoundData.CommitteeBLS.StringList)
+	if validatorIdx == -1 {
+		return
+	}
+	e.detectEquivocation(msg, validatorIdx)
+	height, round := parseRoundKey(msg.RoundKey)
+	if height < e.RoundData.NextHeight {
+		return
+	}
+	if (height == e.RoundData.NextHeight) && (round < e.RoundData.Round) {
+		return
+	}
+	// roundKey := getRoundKey(e.RoundData.NextHeight, e.RoundData.Round)
+	if (height == e.RoundData.NextHeight) && (round == e.RoundData.Round) {
+		//validate single sig
+		if !(new(common.Hash).IsEqual(&e.RoundData.BlockHash)) {
+			e.RoundData.lockVotes.Lock()
+			if _, ok := e.RoundData.Votes[msg.Validator]; !ok {
+				// committeeArr := []incognitokey.CommitteePublicKey{}
+				// committeeArr = append(committeeArr, e.RoundData.Committee...)
+				e.RoundData.lockVotes.Unlock()
+				e.asyncTasks.Add(1)
+				go func(voteMsg BFTVote, blockHash common.Hash, committee []incognitokey.CommitteePublicKey) {
+					defer e.asyncTasks.Done()
+					if err := e.preValidateVote(blockHash.GetBytes(), &(voteMsg.Vote), committee[validatorIdx].MiningPubKey[common.BridgeConsensus]); err != nil {
+						e.logger.Error(err)
+						return
 					}
-				case votePhase:
-					e.logger.Info("Case: In vote phase")
-					if e.RoundData.NotYetSendVote {
-						err := e.sendVote()
-						if err != nil {
+					if len(voteMsg.Vote.BRI) != 0 {
+						if err := validateSingleBriSig(&blockHash, voteMsg.Vote.BRI, committee[validatorIdx].MiningPubKey[common.BridgeConsensus]); err != nil {
 							e.logger.Error(err)
-							continue
+							return
 						}
 					}
-					if !(new(common.Hash).IsEqual(&e.RoundData.BlockHash)) && e.isHasMajorityVotes() {
-						e.RoundData.lockVotes.Lock()
-						aggSig, brigSigs, validatorIdx, err := combineVotes(e.RoundData.Votes, e.RoundData.CommitteeBLS.StringList)
-						e.RoundData.lockVotes.Unlock()
+					go func() {
+						voteCtnBytes, err := json.Marshal(voteMsg)
 						if err != nil {
-							e.logger.Error(err)
-							continue
+							e.logger.Error(consensus.NewConsensusError(consensus.UnExpectedError, err))
+							return
 						}
+						msg, _ := wire.MakeEmptyMessage(wire.CmdBFT)
+						msg.(*wire.MessageBFT).ChainKey = e.ChainKey
+						msg.(*wire.MessageBFT).Content = voteCtnBytes
+						msg.(*wire.MessageBFT).Type = MSG_VOTE
+						// TODO uncomment here when switch to non-highway mode
+						// e.Node.PushMessageToChain(msg, e.Chain)
+					}()
+					e.addVote(voteMsg)
+				}(msg, e.RoundData.BlockHash, append([]incognitokey.CommitteePublicKey{}, e.RoundData.Committee...))
+				return
+			} else {
+				e.RoundData.lockVotes.Unlock()
+				return
+			}
+		}
+	}
+	e.addEarlyVote(msg)
+}
+
+func (e *BLSBFT) processTimeoutMsg(msg BFTTimeout) {
+	e.logger.Info("Receive timeout", msg.RoundKey, getRoundKey(e.RoundData.NextHeight, e.RoundData.Round))
+	validatorIdx := common.IndexOfStr(msg.Validator, e.RoundData.CommitteeBLS.StringList)
+	if validatorIdx == -1 {
+		return
+	}
+	height, round := parseRoundKey(msg.RoundKey)
+	if height != e.RoundData.NextHeight || round < 1 || !msg.PreviousBlockHash.IsEqual(&e.RoundData.PreviousBlockHash) {
+		return
+	}
+	proposer, err := e.getRoundProposer(round)
+	if err != nil || proposer != msg.Proposer {
+		return
+	}
+	dataHash := blockchain.ViewChangeDataHash(e.ChainKey, msg.PreviousBlockHash, height, round, proposer)
+	if err := validateSingleBLSSig(&dataHash, msg.BLS, validatorIdx, e.RoundData.CommitteeBLS.ByteList); err != nil {
+		e.logger.Error(err)
+		return
+	}
+	e.addTimeout(msg)
+}
 
-						e.RoundData.BlockValidateData.AggSig = aggSig
-						e.RoundData.BlockValidateData.BridgeSig = brigSigs
-						e.RoundData.BlockValidateData.ValidatiorsIdx = validatorIdx
+func (e *BLSBFT) processTick() {
 
-						validationDataString, _ := EncodeValidationData(e.RoundData.BlockValidateData)
-						e.RoundData.Block.(blockValidation).AddValidationField(validationDataString)
+	metrics.SetGlobalParam("RoundKey", getRoundKey(e.RoundData.NextHeight, e.RoundData.Round), "Phase", e.RoundData.State)
 
-						//TODO: check issue invalid sig when swap
-						//TODO 0xakk0r0kamui trace who is malicious node if ValidateCommitteeSig return false
-						err = e.ValidateCommitteeSig(e.RoundData.Block, e.RoundData.Committee)
-						if err != nil {
-							e.logger.Error(err)
-							e.logger.Errorf("e.RoundData.Block.GetValidationField()=%+v\n", e.RoundData.Block.GetValidationField())
-							e.logger.Errorf("e.RoundData.Committee=%+v\n", e.RoundData.Committee)
-							for _, member := range e.RoundData.Committee {
-								e.logger.Errorf("member.MiningPubKey[%+v] %+v\n", consensusName, base58.Base58Check{}.Encode(member.MiningPubKey[consensusName], common.Base58Version))
-							}
-							continue
-						}
+	pubKey := e.UserKeySet.GetPublicKey()
+	if common.IndexOfStr(pubKey.GetMiningKeyBase58(consensusName), e.RoundData.CommitteeBLS.StringList) == -1 {
+		e.enterNewRound()
+		return
+	}
 
-						if err := e.Chain.InsertAndBroadcastBlock(e.RoundData.Block); err != nil {
-							e.logger.Error(err)
-							if blockchainError, ok := err.(*blockchain.BlockChainError); ok {
-								if blockchainError.Code != blockchain.ErrCodeMessage[blockchain.DuplicateShardBlockError].Code {
-									e.logger.Error(err)
-								}
-							}
-							continue
-						}
-						metrics.SetGlobalParam("CommitTime", time.Since(time.Unix(e.Chain.GetLastBlockTimeStamp(), 0)).Seconds())
-						// e.Node.PushMessageToAll()
-						e.logger.Infof("Commit block (%d votes) %+v hash=%+v \n Wait for next round", len(e.RoundData.Votes), e.RoundData.Block.GetHeight(), e.RoundData.Block.Hash().String())
-						e.enterNewRound()
+	if !e.Chain.IsReady() {
+		e.isOngoing = false
+		//fmt.Println("CONSENSUS: ticker 1")
+		return
+	}
+
+	if !e.isInTimeFrame() || e.RoundData.State == "" {
+		e.enterNewRound()
+	}
+
+	switch e.RoundData.State {
+	case listenPhase:
+		// timeout or vote nil?
+		//fmt.Println("CONSENSUS: listen phase 1")
+		if e.Chain.CurrentHeight() == e.RoundData.NextHeight {
+			e.enterNewRound()
+			return
+		}
+		roundKey := getRoundKey(e.RoundData.NextHeight, e.RoundData.Round)
+		if e.Blocks[roundKey] != nil {
+			metrics.SetGlobalParam("ReceiveBlockTime", e.now().Sub(e.RoundData.TimeStart).Seconds())
+			//fmt.Println("CONSENSUS: listen phase 2")
+			if err := e.validatePreSignBlock(e.Blocks[roundKey]); err != nil {
+				delete(e.Blocks, roundKey)
+				e.logger.Error(err)
+				return
+			}
+
+			if e.RoundData.Block == nil {
+				// blockData, _ := json.Marshal(e.Blocks[roundKey])
+				// msg, _ := MakeBFTProposeMsg(blockData, e.ChainKey, e.UserKeySet)
+				// go e.Node.PushMessageToChain(msg, e.Chain)
+
+				e.RoundData.Block = e.Blocks[roundKey]
+				e.RoundData.BlockHash = *e.RoundData.Block.Hash()
+				valData, err := DecodeValidationData(e.RoundData.Block.GetValidationField())
+				if err != nil {
+					e.logger.Error(err)
+					return
+				}
+				e.RoundData.BlockValidateData = *valData
+				e.enterVotePhase()
+			}
+		}
+	case votePhase:
+		e.logger.Info("Case: In vote phase")
+		if e.RoundData.NotYetSendVote {
+			err := e.sendVote()
+			if err != nil {
+				e.logger.Error(err)
+				return
+			}
+		}
+		if !(new(common.Hash).IsEqual(&e.RoundData.BlockHash)) && e.isHasMajorityVotes() {
+			e.RoundData.lockVotes.Lock()
+			aggSig, brigSigs, validatorIdx, err := combineVotes(e.RoundData.Votes, e.RoundData.CommitteeBLS.StringList)
+			e.RoundData.lockVotes.Unlock()
+			if err != nil {
+				e.logger.Error(err)
+				return
+			}
+
+			e.RoundData.BlockValidateData.AggSig = aggSig
+			e.RoundData.BlockValidateData.BridgeSig = brigSigs
+			e.RoundData.BlockValidateData.ValidatiorsIdx = validatorIdx
+
+			validationDataString, _ := EncodeValidationData(e.RoundData.BlockValidateData)
+			e.RoundData.Block.(blockValidation).AddValidationField(validationDataString)
+
+			//TODO: check issue invalid sig when swap
+			//TODO 0xakk0r0kamui trace who is malicious node if ValidateCommitteeSig return false
+			err = e.ValidateCommitteeSig(e.RoundData.Block, e.RoundData.Committee)
+			if err != nil {
+				e.logger.Error(err)
+				e.logger.Errorf("e.RoundData.Block.GetValidationField()=%+v\n", e.RoundData.Block.GetValidationField())
+				e.logger.Errorf("e.RoundData.Committee=%+v\n", e.RoundData.Committee)
+				for _, member := range e.RoundData.Committee {
+					e.logger.Errorf("member.MiningPubKey[%+v] %+v\n", consensusName, base58.Base58Check{}.Encode(member.MiningPubKey[consensusName], common.Base58Version))
+				}
+				return
+			}
+
+			if err := e.Chain.InsertAndBroadcastBlock(e.RoundData.Block); err != nil {
+				e.logger.Error(err)
+				if blockchainError, ok := err.(*blockchain.BlockChainError); ok {
+					if blockchainError.Code != blockchain.ErrCodeMessage[blockchain.DuplicateShardBlockError].Code {
+						e.logger.Error(err)
 					}
 				}
+				return
 			}
+			metrics.SetGlobalParam("CommitTime", e.getTimeSinceLastBlock().Seconds())
+			// e.Node.PushMessageToAll()
+			e.logger.Infof("Commit block (%d votes) %+v hash=%+v \n Wait for next round", len(e.RoundData.Votes), e.RoundData.Block.GetHeight(), e.RoundData.Block.Hash().String())
+			e.enterNewRound()
 		}
-	}()
-	return nil
+	}
 }
 
 func (e *BLSBFT) enterProposePhase() {
@@ -302,7 +366,7 @@ func (e *BLSBFT) enterProposePhase() {
 	e.setState(proposePhase)
 	e.isOngoing = true
 	block, err := e.createNewBlock()
-	metrics.SetGlobalParam("CreateTime", time.Since(e.RoundData.TimeStart).Seconds())
+	metrics.SetGlobalParam("CreateTime", e.now().Sub(e.RoundData.TimeStart).Seconds())
 	if err != nil {
 		e.isOngoing = false
 		e.logger.Error("can't create block", err)
@@ -312,6 +376,13 @@ func (e *BLSBFT) enterProposePhase() {
 	if e.Chain.CurrentHeight()+1 != block.GetHeight() {
 		return
 	}
+	if certs := e.getViewChangeCerts(block.GetHeight(), block.GetRound()); len(certs) > 0 {
+		if viewChangeBlock, ok := block.(blockViewChange); ok {
+			if err := viewChangeBlock.AddViewChangeCertificates(certs); err != nil {
+				e.logger.Error(err)
+			}
+		}
+	}
 	validationData := e.CreateValidationData(block)
 	validationDataString, _ := EncodeValidationData(validationData)
 	block.(blockValidation).AddValidationField(validationDataString)
@@ -319,11 +390,12 @@ func (e *BLSBFT) enterProposePhase() {
 	e.RoundData.Block = block
 	e.RoundData.BlockHash = *block.Hash()
 	e.RoundData.BlockValidateData = validationData
+	e.recordVotedBlock(block)
 
 	blockData, _ := json.Marshal(e.RoundData.Block)
 	msg, _ := MakeBFTProposeMsg(blockData, e.ChainKey, e.UserKeySet)
 	// e.logger.Info("push block", time.Since(time1).Seconds())
-	go e.Node.PushMessageToChain(msg, e.Chain)
+	e.pushMessage(msg)
 	e.enterVotePhase()
 }
 
@@ -362,7 +434,11 @@ func (e *BLSBFT) enterNewRound() {
 	if e.waitForNextRound() {
 		return
 	}
+	lastHeight, lastRound, lastBlock := e.RoundData.NextHeight, e.RoundData.Round, e.RoundData.Block
 	e.InitRoundData()
+	if lastHeight == e.RoundData.NextHeight && lastRound >= 1 && lastRound < e.RoundData.Round && lastBlock == nil {
+		e.timeoutRound(lastRound)
+	}
 	e.logger.Info("")
 	e.logger.Info("============================================")
 	e.logger.Info("")
@@ -444,3 +520,58 @@ func (e BLSBFT) NewInstance(chain blockchain.ChainInterface, chainKey string, no
 func init() {
 	consensus.RegisterConsensus(common.BlsConsensus, &BLSBFT{})
 }
+
+// timeoutRound logs the leader rotation after a round which got no block and signs the timeout of the round
+func (e *BLSBFT) timeoutRound(round int) {
+	proposer, err := e.getRoundProposer(round)
+	if err != nil {
+		e.logger.Error(err)
+		return
+	}
+	nextProposer, err := e.getRoundProposer(e.RoundData.Round)
+	if err != nil {
+		e.logger.Error(err)
+		return
+	}
+	e.logger.Warnf("BFT: round %+v of height %+v timed out, proposer %+v is replaced by %+v in round %+v", round, e.RoundData.NextHeight, proposer, nextProposer, e.RoundData.Round)
+	if err := e.sendTimeout(e.RoundData.NextHeight, round, proposer); err != nil {
+		e.logger.Error(err)
+	}
+}
+
+// addTimeout adds a verified timeout and certifies the view change of its round once more than 2/3 of the committee timed out
+func (e *BLSBFT) addTimeout(timeoutMsg BFTTimeout) {
+	if _, ok := e.ViewChangeCerts[timeoutMsg.RoundKey]; ok {
+		return
+	}
+	if _, ok := e.TimeoutVotes[timeoutMsg.RoundKey]; !ok {
+		e.TimeoutVotes[timeoutMsg.RoundKey] = make(map[string][]byte)
+	}
+	timeoutVotes := e.TimeoutVotes[timeoutMsg.RoundKey]
+	timeoutVotes[timeoutMsg.Validator] = timeoutMsg.BLS
+	if len(timeoutVotes) <= 2*len(e.RoundData.CommitteeBLS.StringList)/3 {
+		return
+	}
+	cert, err := combineTimeouts(timeoutVotes, e.RoundData.CommitteeBLS.StringList)
+	if err != nil {
+		e.logger.Error(err)
+		return
+	}
+	cert.PreviousBlockHash = timeoutMsg.PreviousBlockHash
+	cert.Height, cert.Round = parseRoundKey(timeoutMsg.RoundKey)
+	cert.Proposer = timeoutMsg.Proposer
+	e.ViewChangeCerts[timeoutMsg.RoundKey] = *cert
+	delete(e.TimeoutVotes, timeoutMsg.RoundKey)
+	e.logger.Warnf("BFT: view change certificate of round %+v: proposer %+v was offline, %+v of %+v committee members timed out", timeoutMsg.RoundKey, cert.Proposer, len(cert.ValidatorsIdx), len(e.RoundData.CommitteeBLS.StringList))
+}
+
+// getViewChangeCerts returns the certificates of the rounds of a height on top of the current previous block before a round, ordered by round
+func (e *BLSBFT) getViewChangeCerts(height uint64, round int) []blockchain.ViewChangeCertificate {
+	certs := []blockchain.ViewChangeCertificate{}
+	for r := 1; r < round; r++ {
+		if cert, ok := e.ViewChangeCerts[getRoundKey(height, r)]; ok && cert.PreviousBlockHash.IsEqual(&e.RoundData.PreviousBlockHash) {
+			certs = append(certs, cert)
+		}
+	}
+	return certs
+}
@@ -0,0 +1,114 @@
+package blsbft
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// recordVotedBlock keeps a block of the current or a next height, so votes for it can be used as equivocation proof
+func (e *BLSBFT) recordVotedBlock(block common.BlockInterface) {
+	if block.GetHeight() < e.RoundData.NextHeight {
+		return
+	}
+	e.VotedBlocks[block.Hash().String()] = block
+}
+
+/*
+	detectEquivocation keeps the first vote of each validator in each round of the current height,
+	a later valid vote of the same validator for another block of the same round makes an equivocation proof
+*/
+func (e *BLSBFT) detectEquivocation(voteMsg BFTVote, validatorIdx int) {
+	if voteMsg.BlockHash == "" {
+		return
+	}
+	height, _ := parseRoundKey(voteMsg.RoundKey)
+	if height != e.RoundData.NextHeight {
+		return
+	}
+	blockHash, err := common.Hash{}.NewHashFromStr(voteMsg.BlockHash)
+	if err != nil {
+		return
+	}
+	if err := e.preValidateVote(blockHash.GetBytes(), &voteMsg.Vote, e.RoundData.Committee[validatorIdx].MiningPubKey[common.BridgeConsensus]); err != nil {
+		return
+	}
+	if _, ok := e.VoteHistory[voteMsg.RoundKey]; !ok {
+		e.VoteHistory[voteMsg.RoundKey] = make(map[string]BFTVote)
+	}
+	firstVote, ok := e.VoteHistory[voteMsg.RoundKey][voteMsg.Validator]
+	if !ok {
+		e.VoteHistory[voteMsg.RoundKey][voteMsg.Validator] = voteMsg
+		return
+	}
+	if firstVote.BlockHash == voteMsg.BlockHash {
+		return
+	}
+	proof, err := e.buildEquivocationProof(firstVote, voteMsg, validatorIdx)
+	if err != nil {
+		e.logger.Error(err)
+		return
+	}
+	e.lockEquivocationProofs.Lock()
+	e.EquivocationProofs[voteMsg.Validator+"-"+voteMsg.RoundKey] = *proof
+	e.lockEquivocationProofs.Unlock()
+	e.logger.Criticalf("Validator %+v voted for block %+v and block %+v in round %+v", voteMsg.Validator, firstVote.BlockHash, voteMsg.BlockHash, voteMsg.RoundKey)
+}
+
+func (e *BLSBFT) buildEquivocationProof(firstVote BFTVote, secondVote BFTVote, validatorIdx int) (*metadata.EquivocationProofMetadata, error) {
+	votes := [2]metadata.EquivocationVote{}
+	for i, voteMsg := range []BFTVote{firstVote, secondVote} {
+		block, ok := e.VotedBlocks[voteMsg.BlockHash]
+		if !ok {
+			return nil, consensus.NewConsensusError(consensus.UnExpectedError, fmt.Errorf("block %+v of vote of %+v not found", voteMsg.BlockHash, voteMsg.Validator))
+		}
+		blockHeader, err := getBlockHeader(block)
+		if err != nil {
+			return nil, consensus.NewConsensusError(consensus.UnExpectedError, err)
+		}
+		votes[i] = metadata.EquivocationVote{
+			BlockHeader:  blockHeader,
+			BLS:          voteMsg.Vote.BLS,
+			BRI:          voteMsg.Vote.BRI,
+			Confirmation: voteMsg.Vote.Confirmation,
+		}
+	}
+	committeePublicKey, err := e.RoundData.Committee[validatorIdx].ToBase58()
+	if err != nil {
+		return nil, consensus.NewConsensusError(consensus.UnExpectedError, err)
+	}
+	chainID := e.Chain.GetShardID()
+	if chainID == -1 {
+		chainID = metadata.BeaconOnly
+	}
+	return metadata.NewEquivocationProofMetadata(metadata.EquivocationProofMeta, chainID, committeePublicKey, votes)
+}
+
+// getBlockHeader returns the json of the header of a block
+func getBlockHeader(block common.BlockInterface) (json.RawMessage, error) {
+	blockBytes, err := json.Marshal(block)
+	if err != nil {
+		return nil, err
+	}
+	blockWithHeader := struct {
+		Header json.RawMessage
+	}{}
+	if err := json.Unmarshal(blockBytes, &blockWithHeader); err != nil {
+		return nil, err
+	}
+	return blockWithHeader.Header, nil
+}
+
+// GetEquivocationProofs returns the equivocation proofs detected by this node
+func (e *BLSBFT) GetEquivocationProofs() []metadata.EquivocationProofMetadata {
+	e.lockEquivocationProofs.Lock()
+	defer e.lockEquivocationProofs.Unlock()
+	proofs := []metadata.EquivocationProofMetadata{}
+	for _, proof := range e.EquivocationProofs {
+		proofs = append(proofs, proof)
+	}
+	return proofs
+}
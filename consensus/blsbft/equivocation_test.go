@@ -0,0 +1,90 @@
+package blsbft
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+type shardChainStub struct {
+	blockchain.ChainInterface
+	shardID int
+}
+
+func (chain *shardChainStub) GetShardID() int {
+	return chain.shardID
+}
+
+func TestDetectEquivocation(t *testing.T) {
+	e := &BLSBFT{
+		Chain:              &shardChainStub{shardID: 1},
+		ChainKey:           common.GetShardChainKey(1),
+		VoteHistory:        make(map[string]map[string]BFTVote),
+		VotedBlocks:        make(map[string]common.BlockInterface),
+		EquivocationProofs: make(map[string]metadata.EquivocationProofMetadata),
+		logger:             common.NewBackend(nil).Logger("test", true),
+	}
+	seed := common.HashB([]byte{1})
+	voter := &BLSBFT{}
+	assert.Nil(t, voter.LoadUserKey(base58.Base58Check{}.Encode(seed, common.Base58Version)))
+	key, err := incognitokey.NewCommitteeKeyFromSeed(seed, common.HashB([]byte{1}))
+	assert.Nil(t, err)
+	e.RoundData.Committee = []incognitokey.CommitteePublicKey{key}
+	e.RoundData.CommitteeBLS.StringList = []string{key.GetMiningKeyBase58(common.BlsConsensus)}
+	e.RoundData.NextHeight = 7
+
+	makeVote := func(block *blockchain.ShardBlock) BFTVote {
+		Vote := vote{BLS: []byte{1, 2, 3}}
+		data := block.Hash().GetBytes()
+		data = append(data, Vote.BLS...)
+		data = append(data, Vote.BRI...)
+		Vote.Confirmation, err = voter.UserKeySet.BriSignData(common.HashB(data))
+		assert.Nil(t, err)
+		return BFTVote{RoundKey: getRoundKey(7, 2), Validator: e.RoundData.CommitteeBLS.StringList[0], BlockHash: block.Hash().String(), Vote: Vote}
+	}
+	blocks := []*blockchain.ShardBlock{{}, {}}
+	for i, block := range blocks {
+		block.Header.ShardID = 1
+		block.Header.Height = 7
+		block.Header.Round = 2
+		block.Header.Timestamp = int64(i)
+		e.recordVotedBlock(block)
+	}
+
+	// a vote with a forged confirmation is ignored
+	forgedVote := makeVote(blocks[0])
+	forgedVote.BlockHash = blocks[1].Hash().String()
+	e.detectEquivocation(forgedVote, 0)
+	assert.Equal(t, 0, len(e.VoteHistory))
+
+	// voting twice for the same block is not an equivocation
+	e.detectEquivocation(makeVote(blocks[0]), 0)
+	e.detectEquivocation(makeVote(blocks[0]), 0)
+	assert.Equal(t, 0, len(e.GetEquivocationProofs()))
+
+	e.detectEquivocation(makeVote(blocks[1]), 0)
+	proofs := e.GetEquivocationProofs()
+	assert.Equal(t, 1, len(proofs))
+	proof := proofs[0]
+	assert.Equal(t, 1, proof.ChainID)
+	committeePublicKey, _ := key.ToBase58()
+	assert.Equal(t, committeePublicKey, proof.CommitteePublicKey)
+	assert.True(t, proof.ValidateMetadataByItself())
+	for i, equivocationVote := range proof.Votes {
+		header := blockchain.ShardHeader{}
+		assert.Nil(t, json.Unmarshal(equivocationVote.BlockHeader, &header))
+		assert.Equal(t, blocks[i].Header.Hash(), header.Hash())
+		blockHash := header.Hash()
+		assert.Nil(t, e.preValidateVote(blockHash.GetBytes(), &vote{BLS: equivocationVote.BLS, BRI: equivocationVote.BRI, Confirmation: equivocationVote.Confirmation}, key.MiningPubKey[common.BridgeConsensus]))
+	}
+
+	// the same equivocation is proven once
+	e.detectEquivocation(makeVote(blocks[1]), 0)
+	assert.Equal(t, 1, len(e.GetEquivocationProofs()))
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"sort"
 
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/consensus"
@@ -137,3 +138,21 @@ func combineVotes(votes map[string]vote, committee []string) (aggSig []byte, bri
 	}
 	return
 }
+
+func combineTimeouts(timeouts map[string][]byte, committee []string) (*blockchain.ViewChangeCertificate, error) {
+	cert := &blockchain.ViewChangeCertificate{}
+	var blsSigList [][]byte
+	for validator := range timeouts {
+		cert.ValidatorsIdx = append(cert.ValidatorsIdx, common.IndexOfStr(validator, committee))
+	}
+	sort.Ints(cert.ValidatorsIdx)
+	for _, idx := range cert.ValidatorsIdx {
+		blsSigList = append(blsSigList, timeouts[committee[idx]])
+	}
+	aggSig, err := blsmultisig.Combine(blsSigList)
+	if err != nil {
+		return nil, consensus.NewConsensusError(consensus.CombineSignatureError, err)
+	}
+	cert.AggSig = aggSig
+	return cert, nil
+}
@@ -2,8 +2,8 @@ package blsbft
 
 import (
 	"encoding/json"
-	"fmt"
 
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/consensus"
 	"github.com/incognitochain/incognito-chain/metadata"
@@ -13,6 +13,7 @@ import (
 const (
 	MSG_PROPOSE = "propose"
 	MSG_VOTE    = "vote"
+	MSG_TIMEOUT = "timeout"
 )
 
 type BFTPropose struct {
@@ -22,9 +23,20 @@ type BFTPropose struct {
 type BFTVote struct {
 	RoundKey  string
 	Validator string
+	BlockHash string
 	Vote      vote
 }
 
+// BFTTimeout is the signature of a validator which got no valid block from the proposer of a round,
+// the signatures of more than 2/3 of the committee make the view change certificate of the round
+type BFTTimeout struct {
+	RoundKey          string
+	PreviousBlockHash common.Hash
+	Validator         string
+	Proposer          string
+	BLS               []byte
+}
+
 func MakeBFTProposeMsg(block []byte, chainKey string, userKeySet *MiningKey) (wire.Message, error) {
 	var proposeCtn BFTPropose
 	proposeCtn.Block = block
@@ -39,10 +51,11 @@ func MakeBFTProposeMsg(block []byte, chainKey string, userKeySet *MiningKey) (wi
 	return msg, nil
 }
 
-func MakeBFTVoteMsg(userPublicKey string, chainKey, roundKey string, vote vote) (wire.Message, error) {
+func MakeBFTVoteMsg(userPublicKey string, chainKey, roundKey string, blockHash string, vote vote) (wire.Message, error) {
 	var voteCtn BFTVote
 	voteCtn.RoundKey = roundKey
 	voteCtn.Validator = userPublicKey
+	voteCtn.BlockHash = blockHash
 	voteCtn.Vote = vote
 	voteCtnBytes, err := json.Marshal(voteCtn)
 	if err != nil {
@@ -55,29 +68,61 @@ func MakeBFTVoteMsg(userPublicKey string, chainKey, roundKey string, vote vote)
 	return msg, nil
 }
 
+func MakeBFTTimeoutMsg(userPublicKey string, chainKey, roundKey string, previousBlockHash common.Hash, proposer string, blsSig []byte) (wire.Message, error) {
+	var timeoutCtn BFTTimeout
+	timeoutCtn.RoundKey = roundKey
+	timeoutCtn.PreviousBlockHash = previousBlockHash
+	timeoutCtn.Validator = userPublicKey
+	timeoutCtn.Proposer = proposer
+	timeoutCtn.BLS = blsSig
+	timeoutCtnBytes, err := json.Marshal(timeoutCtn)
+	if err != nil {
+		return nil, consensus.NewConsensusError(consensus.UnExpectedError, err)
+	}
+	msg, _ := wire.MakeEmptyMessage(wire.CmdBFT)
+	msg.(*wire.MessageBFT).ChainKey = chainKey
+	msg.(*wire.MessageBFT).Content = timeoutCtnBytes
+	msg.(*wire.MessageBFT).Type = MSG_TIMEOUT
+	return msg, nil
+}
+
 //TODO merman
 func (e *BLSBFT) ProcessBFTMsg(msg *wire.MessageBFT) {
+	bftMsg, err := decodeBFTMsg(msg)
+	if err != nil {
+		e.logger.Error(err)
+		return
+	}
+	switch bftMsg := bftMsg.(type) {
+	case BFTPropose:
+		e.ProposeMessageCh <- bftMsg
+	case BFTVote:
+		e.VoteMessageCh <- bftMsg
+	case BFTTimeout:
+		e.TimeoutMessageCh <- bftMsg
+	default:
+		e.logger.Critical("???")
+		return
+	}
+}
+
+// decodeBFTMsg returns the BFTPropose, BFTVote or BFTTimeout in the content of a BFT message
+func decodeBFTMsg(msg *wire.MessageBFT) (interface{}, error) {
 	switch msg.Type {
 	case MSG_PROPOSE:
 		var msgPropose BFTPropose
 		err := json.Unmarshal(msg.Content, &msgPropose)
-		if err != nil {
-			fmt.Println(err)
-			return
-		}
-		e.ProposeMessageCh <- msgPropose
+		return msgPropose, err
 	case MSG_VOTE:
 		var msgVote BFTVote
 		err := json.Unmarshal(msg.Content, &msgVote)
-		if err != nil {
-			fmt.Println(err)
-			return
-		}
-		e.VoteMessageCh <- msgVote
-	default:
-		e.logger.Critical("???")
-		return
+		return msgVote, err
+	case MSG_TIMEOUT:
+		var msgTimeout BFTTimeout
+		err := json.Unmarshal(msg.Content, &msgTimeout)
+		return msgTimeout, err
 	}
+	return nil, nil
 }
 
 func (e *BLSBFT) confirmVote(Vote *vote) error {
@@ -128,13 +173,37 @@ func (e *BLSBFT) sendVote() error {
 	}
 	key := e.UserKeySet.GetPublicKey()
 
-	msg, err := MakeBFTVoteMsg(key.GetMiningKeyBase58(consensusName), e.ChainKey, getRoundKey(e.RoundData.NextHeight, e.RoundData.Round), Vote)
+	msg, err := MakeBFTVoteMsg(key.GetMiningKeyBase58(consensusName), e.ChainKey, getRoundKey(e.RoundData.NextHeight, e.RoundData.Round), e.RoundData.Block.Hash().String(), Vote)
 	if err != nil {
 		return consensus.NewConsensusError(consensus.UnExpectedError, err)
 	}
 	e.RoundData.Votes[pubKey.GetMiningKeyBase58(consensusName)] = Vote
 	e.logger.Info("sending vote...", getRoundKey(e.RoundData.NextHeight, e.RoundData.Round))
-	go e.Node.PushMessageToChain(msg, e.Chain)
+	e.pushMessage(msg)
 	e.RoundData.NotYetSendVote = false
 	return nil
 }
+
+// sendTimeout signs the timeout of a round in which this node got no valid block from the proposer
+func (e *BLSBFT) sendTimeout(height uint64, round int, proposer string) error {
+	pubKey := e.UserKeySet.GetPublicKey()
+	selfKey := pubKey.GetMiningKeyBase58(consensusName)
+	selfIdx := common.IndexOfStr(selfKey, e.RoundData.CommitteeBLS.StringList)
+	if selfIdx == -1 {
+		return nil
+	}
+	dataHash := blockchain.ViewChangeDataHash(e.ChainKey, e.RoundData.PreviousBlockHash, height, round, proposer)
+	blsSig, err := e.UserKeySet.BLSSignData(dataHash.GetBytes(), selfIdx, e.RoundData.CommitteeBLS.ByteList)
+	if err != nil {
+		return consensus.NewConsensusError(consensus.UnExpectedError, err)
+	}
+	roundKey := getRoundKey(height, round)
+	msg, err := MakeBFTTimeoutMsg(selfKey, e.ChainKey, roundKey, e.RoundData.PreviousBlockHash, proposer, blsSig)
+	if err != nil {
+		return err
+	}
+	e.addTimeout(BFTTimeout{RoundKey: roundKey, PreviousBlockHash: e.RoundData.PreviousBlockHash, Validator: selfKey, Proposer: proposer, BLS: blsSig})
+	e.logger.Info("sending timeout...", roundKey)
+	e.pushMessage(msg)
+	return nil
+}
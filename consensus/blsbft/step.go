@@ -0,0 +1,76 @@
+package blsbft
+
+import (
+	"errors"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/consensus"
+	"github.com/incognitochain/incognito-chain/wire"
+)
+
+// Clock gives the current time to the consensus
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (e *BLSBFT) now() time.Time {
+	if e.clock == nil {
+		return systemClock{}.Now()
+	}
+	return e.clock.Now()
+}
+
+func (e *BLSBFT) pushMessage(msg wire.Message) {
+	e.asyncTasks.Add(1)
+	go func() {
+		defer e.asyncTasks.Done()
+		e.Node.PushMessageToChain(msg, e.Chain)
+	}()
+}
+
+/*
+	StartStepByStep starts the consensus without its actor loop and ticker,
+	time is given by clock and the consensus only runs on HandleBFTMsg and HandleTick.
+	It lets a simulation run committee members deterministically with a virtual clock
+*/
+func (e *BLSBFT) StartStepByStep(clock Clock) error {
+	if e.isStarted {
+		return consensus.NewConsensusError(consensus.ConsensusAlreadyStartedError, errors.New(e.ChainKey))
+	}
+	e.isStarted = true
+	e.isOngoing = false
+	e.StopCh = make(chan struct{})
+	e.clock = clock
+	e.initState()
+	return nil
+}
+
+// HandleBFTMsg processes a BFT message and returns when all the messages it makes are pushed
+func (e *BLSBFT) HandleBFTMsg(msg *wire.MessageBFT) {
+	bftMsg, err := decodeBFTMsg(msg)
+	if err != nil {
+		e.logger.Error(err)
+		return
+	}
+	switch bftMsg := bftMsg.(type) {
+	case BFTPropose:
+		e.processProposeMsg(bftMsg)
+	case BFTVote:
+		e.processVoteMsg(bftMsg)
+	case BFTTimeout:
+		e.processTimeoutMsg(bftMsg)
+	}
+	e.asyncTasks.Wait()
+}
+
+// HandleTick runs a tick of the consensus and returns when all the messages it makes are pushed
+func (e *BLSBFT) HandleTick() {
+	e.processTick()
+	e.asyncTasks.Wait()
+}
@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/consensus"
 	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
 	"github.com/incognitochain/incognito-chain/incognitokey"
@@ -17,7 +18,7 @@ import (
 )
 
 func (e *BLSBFT) getTimeSinceLastBlock() time.Duration {
-	return time.Since(time.Unix(int64(e.Chain.GetLastBlockTimeStamp()), 0))
+	return e.now().Sub(time.Unix(int64(e.Chain.GetLastBlockTimeStamp()), 0))
 }
 
 func (e *BLSBFT) waitForNextRound() bool {
@@ -148,12 +149,36 @@ func (e *BLSBFT) InitRoundData() {
 		delete(e.Blocks, roundKey)
 	}
 	e.RoundData.NextHeight = e.Chain.CurrentHeight() + 1
+	// timeouts are only accepted on top of the current previous block, they are useless once it changes
+	if previousBlockHash := e.Chain.GetBestBlockHash(); !previousBlockHash.IsEqual(&e.RoundData.PreviousBlockHash) {
+		e.RoundData.PreviousBlockHash = previousBlockHash
+		e.TimeoutVotes = make(map[string]map[string][]byte)
+		e.ViewChangeCerts = make(map[string]blockchain.ViewChangeCertificate)
+	}
+	for voteRoundKey := range e.VoteHistory {
+		if height, _ := parseRoundKey(voteRoundKey); height < e.RoundData.NextHeight {
+			delete(e.VoteHistory, voteRoundKey)
+		}
+	}
+	for blockHash, block := range e.VotedBlocks {
+		if block.GetHeight() < e.RoundData.NextHeight {
+			delete(e.VotedBlocks, blockHash)
+		}
+	}
 	e.RoundData.Round = e.getCurrentRound()
 	e.RoundData.Votes = make(map[string]vote)
 	e.RoundData.Block = nil
 	e.RoundData.BlockHash = common.Hash{}
 	e.RoundData.NotYetSendVote = true
 	e.RoundData.LastProposerIndex = e.Chain.GetLastProposerIndex()
-	e.RoundData.TimeStart = time.Now()
+	e.RoundData.TimeStart = e.now()
 	e.UpdateCommitteeBLSList()
 }
+
+// getRoundProposer returns the committee public key of the proposer of a round of the current height, in base58
+func (e *BLSBFT) getRoundProposer(round int) (string, error) {
+	if len(e.RoundData.Committee) == 0 {
+		return "", consensus.NewConsensusError(consensus.UnExpectedError, fmt.Errorf("empty committee"))
+	}
+	return e.RoundData.Committee[(e.RoundData.LastProposerIndex+round)%len(e.RoundData.Committee)].ToBase58()
+}
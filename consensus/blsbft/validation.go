@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/consensus"
@@ -24,6 +25,10 @@ type blockValidation interface {
 	AddValidationField(validationData string) error
 }
 
+type blockViewChange interface {
+	AddViewChangeCertificates(certs []blockchain.ViewChangeCertificate) error
+}
+
 type ValidationData struct {
 	ProducerBLSSig []byte
 	ProducerBriSig []byte
@@ -0,0 +1,83 @@
+package blsbft
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewChangeCertificate(t *testing.T) {
+	e := &BLSBFT{
+		ChainKey:        common.GetShardChainKey(0),
+		TimeoutVotes:    make(map[string]map[string][]byte),
+		ViewChangeCerts: make(map[string]blockchain.ViewChangeCertificate),
+		logger:          common.NewBackend(nil).Logger("test", true),
+	}
+	miningKeys := []*MiningKey{}
+	for i := 0; i < 4; i++ {
+		seed := common.HashB([]byte{byte(i)})
+		engine := &BLSBFT{}
+		assert.Nil(t, engine.LoadUserKey(base58.Base58Check{}.Encode(seed, common.Base58Version)))
+		key, err := incognitokey.NewCommitteeKeyFromSeed(seed, []byte{byte(i)})
+		assert.Nil(t, err)
+		e.RoundData.Committee = append(e.RoundData.Committee, key)
+		e.RoundData.CommitteeBLS.ByteList = append(e.RoundData.CommitteeBLS.ByteList, key.MiningPubKey[common.BlsConsensus])
+		e.RoundData.CommitteeBLS.StringList = append(e.RoundData.CommitteeBLS.StringList, key.GetMiningKeyBase58(common.BlsConsensus))
+		miningKeys = append(miningKeys, engine.UserKeySet)
+	}
+	e.RoundData.NextHeight = 5
+	e.RoundData.Round = 3
+	e.RoundData.LastProposerIndex = 1
+	e.RoundData.PreviousBlockHash = common.HashH([]byte("block 4"))
+
+	// the proposer of round 2 is member (1+2)%4
+	proposer, err := e.getRoundProposer(2)
+	assert.Nil(t, err)
+	expectedProposer, _ := e.RoundData.Committee[3].ToBase58()
+	assert.Equal(t, expectedProposer, proposer)
+
+	roundKey := getRoundKey(5, 2)
+	dataHash := blockchain.ViewChangeDataHash(e.ChainKey, e.RoundData.PreviousBlockHash, 5, 2, proposer)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, 0, len(e.getViewChangeCerts(5, 3)))
+		blsSig, err := miningKeys[i].BLSSignData(dataHash.GetBytes(), i, e.RoundData.CommitteeBLS.ByteList)
+		assert.Nil(t, err)
+		assert.Nil(t, validateSingleBLSSig(&dataHash, blsSig, i, e.RoundData.CommitteeBLS.ByteList))
+		e.addTimeout(BFTTimeout{RoundKey: roundKey, PreviousBlockHash: e.RoundData.PreviousBlockHash, Validator: e.RoundData.CommitteeBLS.StringList[i], Proposer: proposer, BLS: blsSig})
+	}
+
+	// 3 of 4 members timed out round 2, the certificate is attached to the blocks of later rounds
+	assert.Equal(t, 0, len(e.getViewChangeCerts(5, 2)))
+	certs := e.getViewChangeCerts(5, 3)
+	assert.Equal(t, 1, len(certs))
+	assert.Equal(t, e.RoundData.PreviousBlockHash, certs[0].PreviousBlockHash)
+	assert.Equal(t, uint64(5), certs[0].Height)
+	assert.Equal(t, 2, certs[0].Round)
+	assert.Equal(t, proposer, certs[0].Proposer)
+	assert.Equal(t, []int{0, 1, 2}, certs[0].ValidatorsIdx)
+	valid, err := blsmultisig.Verify(certs[0].AggSig, dataHash.GetBytes(), certs[0].ValidatorsIdx, e.RoundData.CommitteeBLS.ByteList)
+	assert.Nil(t, err)
+	assert.True(t, valid)
+
+	block := &blockchain.ShardBlock{}
+	block.Header.Height = 5
+	block.Header.Round = 3
+	hashWithoutCerts := *block.Hash()
+	var _ blockViewChange = block
+	assert.Nil(t, block.AddViewChangeCertificates(certs))
+	assert.False(t, hashWithoutCerts.IsEqual(block.Hash()))
+	assert.Equal(t, certs, block.GetViewChangeCertificates())
+
+	// the certificate does not time out the round on another branch
+	otherDataHash := blockchain.ViewChangeDataHash(e.ChainKey, common.HashH([]byte("other block 4")), 5, 2, proposer)
+	valid, err = blsmultisig.Verify(certs[0].AggSig, otherDataHash.GetBytes(), certs[0].ValidatorsIdx, e.RoundData.CommitteeBLS.ByteList)
+	assert.Nil(t, err)
+	assert.False(t, valid)
+	e.RoundData.PreviousBlockHash = common.HashH([]byte("other block 4"))
+	assert.Equal(t, 0, len(e.getViewChangeCerts(5, 3)))
+}
@@ -0,0 +1,400 @@
+package blshotstuff
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus"
+	"github.com/incognitochain/incognito-chain/consensus/blsbft"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metrics"
+)
+
+/*
+BLSHotStuff is a chained BFT: the votes of a block are certified by the proposer of the next block,
+which sends the certified block (its QC) together with its own proposal.
+
+	propose(h) -> vote(h) -> propose(h+1, QC(h)) -> vote(h+1) -> propose(h+2, QC(h+1)) ...
+
+Receiving a proposal commits the previous block and starts the vote on the next one, so a block takes
+one round trip instead of blsbft's propose, vote and commit by every validator.
+If the next proposer does not certify the voted block in qcTimeout, the validators commit it from the votes they received,
+a proposer which does not propose at all is replaced by the round robin of blsbft.
+*/
+type BLSHotStuff struct {
+	Chain    blockchain.ChainInterface
+	Node     consensus.NodeInterface
+	ChainKey string
+	PeerID   string
+
+	UserKeySet       *blsbft.MiningKey
+	ProposeMessageCh chan BFTPropose
+	VoteMessageCh    chan BFTVote
+
+	RoundData struct {
+		Block             common.BlockInterface
+		BlockHash         common.Hash
+		BlockValidateData blsbft.ValidationData
+		TimeStart         time.Time
+		VoteTime          time.Time
+		Votes             map[string]vote // verified votes of Block
+		Round             int
+		NextHeight        uint64
+		State             string
+		NotYetSendVote    bool
+		Committee         []incognitokey.CommitteePublicKey
+		CommitteeBLS      struct {
+			StringList []string
+			ByteList   []blsmultisig.PublicKey
+		}
+		LastProposerIndex int
+	}
+	Blocks  map[string]common.BlockInterface // proposals by round key
+	Votes   map[string]map[string]vote       // received votes by round key, they are verified when the voted block is certified
+	QCBlock common.BlockInterface            // last block certified by this node, sent with its next proposal
+	// clock is the system clock unless the consensus runs in a simulation,
+	// asyncTasks are the messages being pushed
+	clock      blsbft.Clock
+	asyncTasks sync.WaitGroup
+	isOngoing  bool
+	isStarted  bool
+	StopCh     chan struct{}
+	logger     common.Logger
+}
+
+func (e *BLSHotStuff) IsOngoing() bool {
+	return e.isOngoing
+}
+
+func (e *BLSHotStuff) GetConsensusName() string {
+	return consensusName
+}
+
+func (e *BLSHotStuff) Stop() error {
+	if e.isStarted {
+		select {
+		case <-e.StopCh:
+			return nil
+		default:
+			close(e.StopCh)
+		}
+		e.isStarted = false
+		e.isOngoing = false
+	}
+	return consensus.NewConsensusError(consensus.ConsensusAlreadyStoppedError, errors.New(e.ChainKey))
+}
+
+func (e *BLSHotStuff) Start() error {
+	if e.isStarted {
+		return consensus.NewConsensusError(consensus.ConsensusAlreadyStartedError, errors.New(e.ChainKey))
+	}
+	e.isStarted = true
+	e.isOngoing = false
+	e.StopCh = make(chan struct{})
+	e.Blocks = make(map[string]common.BlockInterface)
+	e.Votes = make(map[string]map[string]vote)
+	e.QCBlock = nil
+	e.ProposeMessageCh = make(chan BFTPropose)
+	e.VoteMessageCh = make(chan BFTVote)
+	e.InitRoundData()
+
+	ticker := time.Tick(tickInterval)
+	e.logger.Info("start bls-hotstuff consensus for chain", e.ChainKey)
+	go func() {
+		for { //actor loop
+			select {
+			case <-e.StopCh:
+				return
+			case proposeMsg := <-e.ProposeMessageCh:
+				e.processProposeMsg(proposeMsg)
+			case voteMsg := <-e.VoteMessageCh:
+				e.processVoteMsg(voteMsg)
+			case <-ticker:
+				e.processTick()
+			}
+		}
+	}()
+	return nil
+}
+
+func (e *BLSHotStuff) processProposeMsg(proposeMsg BFTPropose) {
+	if len(proposeMsg.QCBlock) != 0 {
+		e.commitQCBlock(proposeMsg.QCBlock)
+	}
+	block, err := e.Chain.UnmarshalBlock(proposeMsg.Block)
+	if err != nil {
+		e.logger.Info(err)
+		return
+	}
+	blockRoundKey := getRoundKey(block.GetHeight(), block.GetRound())
+	e.logger.Info("receive block", blockRoundKey, getRoundKey(e.RoundData.NextHeight, e.RoundData.Round))
+	if block.GetHeight() <= e.Chain.CurrentHeight() {
+		return
+	}
+	if _, ok := e.Blocks[blockRoundKey]; !ok {
+		e.Blocks[blockRoundKey] = block
+	}
+}
+
+// commitQCBlock commits the block certified by the proposer of the next block
+func (e *BLSHotStuff) commitQCBlock(qcBlockData []byte) {
+	qcBlock, err := e.Chain.UnmarshalBlock(qcBlockData)
+	if err != nil {
+		e.logger.Info(err)
+		return
+	}
+	if qcBlock.GetHeight() != e.Chain.CurrentHeight()+1 {
+		return
+	}
+	// a block this node did not vote is checked as a new proposal first
+	if !qcBlock.Hash().IsEqual(&e.RoundData.BlockHash) {
+		if err := e.Chain.ValidatePreSignBlock(qcBlock); err != nil {
+			e.logger.Error(err)
+			return
+		}
+	}
+	if err := e.ValidateCommitteeSig(qcBlock, e.Chain.GetCommittee()); err != nil {
+		e.logger.Error(err)
+		return
+	}
+	if err := e.insertBlock(qcBlock); err != nil {
+		return
+	}
+	e.logger.Infof("Commit certified block %+v hash=%+v", qcBlock.GetHeight(), qcBlock.Hash().String())
+}
+
+func (e *BLSHotStuff) processVoteMsg(voteMsg BFTVote) {
+	e.logger.Info("Receive vote", voteMsg.RoundKey, getRoundKey(e.RoundData.NextHeight, e.RoundData.Round))
+	if common.IndexOfStr(voteMsg.Validator, e.RoundData.CommitteeBLS.StringList) == -1 {
+		return
+	}
+	height, _ := parseRoundKey(voteMsg.RoundKey)
+	if height < e.RoundData.NextHeight {
+		return
+	}
+	e.addVote(voteMsg.RoundKey, voteMsg.Validator, voteMsg.Vote)
+}
+
+func (e *BLSHotStuff) processTick() {
+	metrics.SetGlobalParam("RoundKey", getRoundKey(e.RoundData.NextHeight, e.RoundData.Round), "Phase", e.RoundData.State)
+
+	pubKey := e.UserKeySet.GetPublicKey()
+	if common.IndexOfStr(pubKey.GetMiningKeyBase58(consensusName), e.RoundData.CommitteeBLS.StringList) == -1 {
+		e.enterNewRound()
+		return
+	}
+
+	if !e.Chain.IsReady() {
+		e.isOngoing = false
+		return
+	}
+
+	if !e.isInTimeFrame() || e.RoundData.State == "" {
+		e.enterNewRound()
+	}
+
+	switch e.RoundData.State {
+	case listenPhase:
+		if e.Chain.CurrentHeight() == e.RoundData.NextHeight {
+			e.enterNewRound()
+			return
+		}
+		roundKey := getRoundKey(e.RoundData.NextHeight, e.RoundData.Round)
+		block, ok := e.Blocks[roundKey]
+		if !ok {
+			return
+		}
+		metrics.SetGlobalParam("ReceiveBlockTime", e.now().Sub(e.RoundData.TimeStart).Seconds())
+		if err := e.validatePreSignBlock(block); err != nil {
+			delete(e.Blocks, roundKey)
+			e.logger.Error(err)
+			return
+		}
+		valData, err := blsbft.DecodeValidationData(block.GetValidationField())
+		if err != nil {
+			e.logger.Error(err)
+			return
+		}
+		e.RoundData.Block = block
+		e.RoundData.BlockHash = *block.Hash()
+		e.RoundData.BlockValidateData = *valData
+		e.enterVotePhase()
+	case votePhase:
+		if e.RoundData.NotYetSendVote {
+			if err := e.sendVote(); err != nil {
+				e.logger.Error(err)
+				return
+			}
+		}
+		// the next proposer certifies the block, the other validators wait for its proposal
+		if !e.isNextProposer() && e.now().Sub(e.RoundData.VoteTime) < qcTimeout {
+			return
+		}
+		if e.RoundData.Block == nil || !e.hasMajorityVotes() {
+			return
+		}
+		e.certifyBlock()
+	}
+}
+
+// certifyBlock aggregates the votes of the voted block into its validation data and commits it
+func (e *BLSHotStuff) certifyBlock() {
+	aggSig, brigSigs, validatorIdx, err := combineVotes(e.RoundData.Votes, e.RoundData.CommitteeBLS.StringList)
+	if err != nil {
+		e.logger.Error(err)
+		return
+	}
+	e.RoundData.BlockValidateData.AggSig = aggSig
+	e.RoundData.BlockValidateData.BridgeSig = brigSigs
+	e.RoundData.BlockValidateData.ValidatiorsIdx = validatorIdx
+
+	validationDataString, _ := blsbft.EncodeValidationData(e.RoundData.BlockValidateData)
+	e.RoundData.Block.(blockValidation).AddValidationField(validationDataString)
+	if err := e.ValidateCommitteeSig(e.RoundData.Block, e.RoundData.Committee); err != nil {
+		e.logger.Error(err)
+		return
+	}
+	if err := e.insertBlock(e.RoundData.Block); err != nil {
+		return
+	}
+	e.QCBlock = e.RoundData.Block
+	e.logger.Infof("Certify block (%d votes) %+v hash=%+v \n Wait for next round", len(e.RoundData.Votes), e.RoundData.Block.GetHeight(), e.RoundData.Block.Hash().String())
+	e.enterNewRound()
+}
+
+func (e *BLSHotStuff) insertBlock(block common.BlockInterface) error {
+	if err := e.Chain.InsertAndBroadcastBlock(block); err != nil {
+		if blockchainError, ok := err.(*blockchain.BlockChainError); !ok || blockchainError.Code != blockchain.ErrCodeMessage[blockchain.DuplicateShardBlockError].Code {
+			e.logger.Error(err)
+		}
+		return err
+	}
+	metrics.SetGlobalParam("CommitTime", e.getTimeSinceLastBlock().Seconds())
+	return nil
+}
+
+func (e *BLSHotStuff) enterProposePhase() {
+	if !e.isInTimeFrame() || e.RoundData.State == proposePhase {
+		return
+	}
+	e.setState(proposePhase)
+	e.isOngoing = true
+	block, err := e.createNewBlock()
+	metrics.SetGlobalParam("CreateTime", e.now().Sub(e.RoundData.TimeStart).Seconds())
+	if err != nil {
+		e.isOngoing = false
+		e.logger.Error("can't create block", err)
+		return
+	}
+
+	if e.Chain.CurrentHeight()+1 != block.GetHeight() {
+		return
+	}
+	validationData := e.CreateValidationData(block)
+	validationDataString, _ := blsbft.EncodeValidationData(validationData)
+	block.(blockValidation).AddValidationField(validationDataString)
+
+	e.RoundData.Block = block
+	e.RoundData.BlockHash = *block.Hash()
+	e.RoundData.BlockValidateData = validationData
+
+	blockData, _ := json.Marshal(e.RoundData.Block)
+	var qcBlockData []byte
+	if e.QCBlock != nil && e.QCBlock.GetHeight()+1 == block.GetHeight() {
+		qcBlockData, _ = json.Marshal(e.QCBlock)
+	}
+	msg, err := MakeBFTProposeMsg(blockData, qcBlockData, e.ChainKey)
+	if err != nil {
+		e.logger.Error(err)
+		return
+	}
+	e.pushMessage(msg)
+	e.enterVotePhase()
+}
+
+func (e *BLSHotStuff) enterListenPhase() {
+	if !e.isInTimeFrame() || e.RoundData.State == listenPhase {
+		return
+	}
+	e.setState(listenPhase)
+}
+
+func (e *BLSHotStuff) enterVotePhase() {
+	e.logger.Info("enter voting phase")
+	if !e.isInTimeFrame() || e.RoundData.State == votePhase {
+		return
+	}
+	e.isOngoing = true
+	e.setState(votePhase)
+	e.RoundData.VoteTime = e.now()
+	if err := e.sendVote(); err != nil {
+		e.logger.Error(err)
+	}
+}
+
+func (e *BLSHotStuff) enterNewRound() {
+	//if chain is not ready,  return
+	if !e.Chain.IsReady() {
+		e.RoundData.State = ""
+		return
+	}
+	//if already running a round for current timeframe
+	if e.isInTimeFrame() && e.RoundData.State != newround {
+		return
+	}
+	e.isOngoing = false
+	e.setState(newround)
+	if e.waitForNextRound() {
+		return
+	}
+	e.InitRoundData()
+	pubKey := e.UserKeySet.GetPublicKey()
+	if e.Chain.GetPubKeyCommitteeIndex(pubKey.GetMiningKeyBase58(consensusName)) == (e.Chain.GetLastProposerIndex()+e.RoundData.Round)%e.Chain.GetCommitteeSize() {
+		e.logger.Info("HotStuff: new round => PROPOSE", e.RoundData.NextHeight, e.RoundData.Round)
+		e.enterProposePhase()
+	} else {
+		e.logger.Info("HotStuff: new round => LISTEN", e.RoundData.NextHeight, e.RoundData.Round)
+		e.enterListenPhase()
+	}
+}
+
+func (e *BLSHotStuff) createNewBlock() (common.BlockInterface, error) {
+	var block common.BlockInterface
+	errCh := make(chan error, 1)
+	timeout := time.NewTimer(e.Chain.GetMaxBlkCreateTime()).C
+
+	go func() {
+		time1 := time.Now()
+		var err error
+		block, err = e.Chain.CreateNewBlock(int(e.RoundData.Round))
+		if block != nil {
+			e.logger.Info("create block", block.GetHeight(), time.Since(time1).Seconds())
+		}
+		errCh <- err
+	}()
+	select {
+	case err := <-errCh:
+		return block, err
+	case <-timeout:
+		return nil, consensus.NewConsensusError(consensus.BlockCreationError, errors.New("block creation timeout"))
+	}
+}
+
+func (e *BLSHotStuff) NewInstance(chain blockchain.ChainInterface, chainKey string, node consensus.NodeInterface, logger common.Logger) consensus.ConsensusInterface {
+	var newInstance BLSHotStuff
+	newInstance.Chain = chain
+	newInstance.ChainKey = chainKey
+	newInstance.Node = node
+	newInstance.UserKeySet = e.UserKeySet
+	newInstance.logger = logger
+	return &newInstance
+}
+
+func init() {
+	consensus.RegisterConsensus(common.BlsHotStuffConsensus, &BLSHotStuff{})
+}
@@ -0,0 +1,21 @@
+package blshotstuff
+
+import (
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+const (
+	proposePhase  = "PROPOSE"
+	listenPhase   = "LISTEN"
+	votePhase     = "VOTE"
+	newround      = "NEWROUND"
+	consensusName = common.BlsHotStuffConsensus
+)
+
+const (
+	timeout      = 40 * time.Second       // must be at least twice the time of block interval
+	qcTimeout    = 10 * time.Second       // time a validator waits for the next proposer to certify the block before committing it by itself
+	tickInterval = 100 * time.Millisecond // the actor checks its round this often, shorter than blsbft to keep the pipeline busy
+)
@@ -0,0 +1,62 @@
+package blshotstuff
+
+import (
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus"
+	"github.com/incognitochain/incognito-chain/consensus/blsbft"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+// BLSHotStuff mines with the same keys as blsbft, a node can switch between them without new keys
+
+func (e *BLSHotStuff) LoadUserKey(privateSeed string) error {
+	var blsKeyLoader blsbft.BLSBFT
+	if err := blsKeyLoader.LoadUserKey(privateSeed); err != nil {
+		return err
+	}
+	e.UserKeySet = blsKeyLoader.UserKeySet
+	return nil
+}
+
+func (e *BLSHotStuff) LoadUserKeyFromIncPrivateKey(privateKey string) (string, error) {
+	var blsKeyLoader blsbft.BLSBFT
+	return blsKeyLoader.LoadUserKeyFromIncPrivateKey(privateKey)
+}
+
+func (e *BLSHotStuff) GetUserPublicKey() *incognitokey.CommitteePublicKey {
+	if e.UserKeySet != nil {
+		key := e.UserKeySet.GetPublicKey()
+		return &key
+	}
+	return nil
+}
+
+func (e *BLSHotStuff) SignData(data []byte) (string, error) {
+	result, err := e.UserKeySet.BriSignData(data)
+	if err != nil {
+		return "", consensus.NewConsensusError(consensus.SignDataError, err)
+	}
+	return base58.Base58Check{}.Encode(result, common.Base58Version), nil
+}
+
+func combineVotes(votes map[string]vote, committee []string) (aggSig []byte, brigSigs [][]byte, validatorIdx []int, err error) {
+	var blsSigList [][]byte
+	for validator := range votes {
+		validatorIdx = append(validatorIdx, common.IndexOfStr(validator, committee))
+	}
+	sort.Ints(validatorIdx)
+	for _, idx := range validatorIdx {
+		blsSigList = append(blsSigList, votes[committee[idx]].BLS)
+		brigSigs = append(brigSigs, votes[committee[idx]].BRI)
+	}
+
+	aggSig, err = blsmultisig.Combine(blsSigList)
+	if err != nil {
+		return nil, nil, nil, consensus.NewConsensusError(consensus.CombineSignatureError, err)
+	}
+	return
+}
@@ -0,0 +1,148 @@
+package blshotstuff
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/wire"
+)
+
+const (
+	MSG_PROPOSE = "propose"
+	MSG_VOTE    = "vote"
+)
+
+// BFTPropose is a proposed block, QCBlock is the previous block certified by the aggregated votes
+// the proposer collected for it. Receiving a proposal commits the previous block and starts the vote on the next one.
+type BFTPropose struct {
+	Block   json.RawMessage
+	QCBlock json.RawMessage
+}
+
+type BFTVote struct {
+	RoundKey  string
+	Validator string
+	Vote      vote
+}
+
+func MakeBFTProposeMsg(block []byte, qcBlock []byte, chainKey string) (wire.Message, error) {
+	var proposeCtn BFTPropose
+	proposeCtn.Block = block
+	proposeCtn.QCBlock = qcBlock
+	proposeCtnBytes, err := json.Marshal(proposeCtn)
+	if err != nil {
+		return nil, consensus.NewConsensusError(consensus.UnExpectedError, err)
+	}
+	msg, _ := wire.MakeEmptyMessage(wire.CmdBFT)
+	msg.(*wire.MessageBFT).ChainKey = chainKey
+	msg.(*wire.MessageBFT).Content = proposeCtnBytes
+	msg.(*wire.MessageBFT).Type = MSG_PROPOSE
+	return msg, nil
+}
+
+func MakeBFTVoteMsg(userPublicKey string, chainKey, roundKey string, vote vote) (wire.Message, error) {
+	var voteCtn BFTVote
+	voteCtn.RoundKey = roundKey
+	voteCtn.Validator = userPublicKey
+	voteCtn.Vote = vote
+	voteCtnBytes, err := json.Marshal(voteCtn)
+	if err != nil {
+		return nil, consensus.NewConsensusError(consensus.UnExpectedError, err)
+	}
+	msg, _ := wire.MakeEmptyMessage(wire.CmdBFT)
+	msg.(*wire.MessageBFT).ChainKey = chainKey
+	msg.(*wire.MessageBFT).Content = voteCtnBytes
+	msg.(*wire.MessageBFT).Type = MSG_VOTE
+	return msg, nil
+}
+
+func (e *BLSHotStuff) ProcessBFTMsg(msg *wire.MessageBFT) {
+	bftMsg, err := decodeBFTMsg(msg)
+	if err != nil {
+		e.logger.Error(err)
+		return
+	}
+	switch bftMsg := bftMsg.(type) {
+	case BFTPropose:
+		e.ProposeMessageCh <- bftMsg
+	case BFTVote:
+		e.VoteMessageCh <- bftMsg
+	default:
+		e.logger.Critical("Unknown BFT message type", msg.Type)
+		return
+	}
+}
+
+// decodeBFTMsg returns the BFTPropose or BFTVote in the content of a BFT message
+func decodeBFTMsg(msg *wire.MessageBFT) (interface{}, error) {
+	switch msg.Type {
+	case MSG_PROPOSE:
+		var msgPropose BFTPropose
+		err := json.Unmarshal(msg.Content, &msgPropose)
+		return msgPropose, err
+	case MSG_VOTE:
+		var msgVote BFTVote
+		err := json.Unmarshal(msg.Content, &msgVote)
+		return msgVote, err
+	}
+	return nil, nil
+}
+
+func (e *BLSHotStuff) confirmVote(Vote *vote) error {
+	data := e.RoundData.Block.Hash().GetBytes()
+	data = append(data, Vote.BLS...)
+	data = append(data, Vote.BRI...)
+	data = common.HashB(data)
+	var err error
+	Vote.Confirmation, err = e.UserKeySet.BriSignData(data)
+	return err
+}
+
+func (e *BLSHotStuff) preValidateVote(blockHash []byte, Vote *vote, candidate []byte) error {
+	data := []byte{}
+	data = append(data, blockHash...)
+	data = append(data, Vote.BLS...)
+	data = append(data, Vote.BRI...)
+	dataHash := common.HashH(data)
+	err := validateSingleBriSig(&dataHash, Vote.Confirmation, candidate)
+	return err
+}
+
+func (e *BLSHotStuff) sendVote() error {
+	var Vote vote
+
+	pubKey := e.UserKeySet.GetPublicKey()
+	selfIdx := common.IndexOfStr(pubKey.GetMiningKeyBase58(consensusName), e.RoundData.CommitteeBLS.StringList)
+
+	blsSig, err := e.UserKeySet.BLSSignData(e.RoundData.Block.Hash().GetBytes(), selfIdx, e.RoundData.CommitteeBLS.ByteList)
+	if err != nil {
+		return consensus.NewConsensusError(consensus.UnExpectedError, err)
+	}
+	bridgeSig := []byte{}
+	if metadata.HasBridgeInstructions(e.RoundData.Block.GetInstructions()) {
+		bridgeSig, err = e.UserKeySet.BriSignData(e.RoundData.Block.Hash().GetBytes())
+		if err != nil {
+			return consensus.NewConsensusError(consensus.UnExpectedError, err)
+		}
+	}
+
+	Vote.BLS = blsSig
+	Vote.BRI = bridgeSig
+	err = e.confirmVote(&Vote)
+	if err != nil {
+		return consensus.NewConsensusError(consensus.UnExpectedError, err)
+	}
+
+	roundKey := getRoundKey(e.RoundData.NextHeight, e.RoundData.Round)
+	msg, err := MakeBFTVoteMsg(pubKey.GetMiningKeyBase58(consensusName), e.ChainKey, roundKey, Vote)
+	if err != nil {
+		return consensus.NewConsensusError(consensus.UnExpectedError, err)
+	}
+	e.addVote(roundKey, pubKey.GetMiningKeyBase58(consensusName), Vote)
+	e.logger.Info("sending vote...", roundKey)
+	e.pushMessage(msg)
+	e.RoundData.NotYetSendVote = false
+	return nil
+}
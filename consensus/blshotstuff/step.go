@@ -0,0 +1,68 @@
+package blshotstuff
+
+import (
+	"errors"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus"
+	"github.com/incognitochain/incognito-chain/consensus/blsbft"
+	"github.com/incognitochain/incognito-chain/wire"
+)
+
+func (e *BLSHotStuff) now() time.Time {
+	if e.clock == nil {
+		return time.Now()
+	}
+	return e.clock.Now()
+}
+
+func (e *BLSHotStuff) pushMessage(msg wire.Message) {
+	e.asyncTasks.Add(1)
+	go func() {
+		defer e.asyncTasks.Done()
+		e.Node.PushMessageToChain(msg, e.Chain)
+	}()
+}
+
+/*
+	StartStepByStep starts the consensus without its actor loop and ticker,
+	time is given by clock and the consensus only runs on HandleBFTMsg and HandleTick,
+	as blsbft does in a simulation
+*/
+func (e *BLSHotStuff) StartStepByStep(clock blsbft.Clock) error {
+	if e.isStarted {
+		return consensus.NewConsensusError(consensus.ConsensusAlreadyStartedError, errors.New(e.ChainKey))
+	}
+	e.isStarted = true
+	e.isOngoing = false
+	e.StopCh = make(chan struct{})
+	e.clock = clock
+	e.Blocks = make(map[string]common.BlockInterface)
+	e.Votes = make(map[string]map[string]vote)
+	e.QCBlock = nil
+	e.InitRoundData()
+	return nil
+}
+
+// HandleBFTMsg processes a BFT message and returns when all the messages it makes are pushed
+func (e *BLSHotStuff) HandleBFTMsg(msg *wire.MessageBFT) {
+	bftMsg, err := decodeBFTMsg(msg)
+	if err != nil {
+		e.logger.Error(err)
+		return
+	}
+	switch bftMsg := bftMsg.(type) {
+	case BFTPropose:
+		e.processProposeMsg(bftMsg)
+	case BFTVote:
+		e.processVoteMsg(bftMsg)
+	}
+	e.asyncTasks.Wait()
+}
+
+// HandleTick runs a tick of the consensus and returns when all the messages it makes are pushed
+func (e *BLSHotStuff) HandleTick() {
+	e.processTick()
+	e.asyncTasks.Wait()
+}
@@ -0,0 +1,160 @@
+package blshotstuff
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metrics"
+)
+
+func (e *BLSHotStuff) getTimeSinceLastBlock() time.Duration {
+	return e.now().Sub(time.Unix(int64(e.Chain.GetLastBlockTimeStamp()), 0))
+}
+
+func (e *BLSHotStuff) waitForNextRound() bool {
+	return e.getTimeSinceLastBlock() < e.Chain.GetMinBlkInterval()
+}
+
+func (e *BLSHotStuff) setState(state string) {
+	e.RoundData.State = state
+}
+
+func (e *BLSHotStuff) getCurrentRound() int {
+	round := int((e.getTimeSinceLastBlock().Seconds() - float64(e.Chain.GetMinBlkInterval().Seconds())) / timeout.Seconds())
+	if round < 0 {
+		return 1
+	}
+	return round + 1
+}
+
+func (e *BLSHotStuff) isInTimeFrame() bool {
+	if e.Chain.CurrentHeight()+1 != e.RoundData.NextHeight {
+		return false
+	}
+	if e.getCurrentRound() != e.RoundData.Round {
+		return false
+	}
+	return true
+}
+
+// nextProposerIndex returns the committee index of the proposer of the block after the block voted in this round,
+// the chain moves the proposer index by the round of each inserted block
+func nextProposerIndex(lastProposerIndex int, blockRound int, nextRound int, committeeSize int) int {
+	if committeeSize == 0 {
+		return -1
+	}
+	return (lastProposerIndex + blockRound + nextRound) % committeeSize
+}
+
+// isNextProposer returns true if this node proposes the next block right after the voted block is committed,
+// it is the one which certifies the voted block
+func (e *BLSHotStuff) isNextProposer() bool {
+	if e.RoundData.Block == nil {
+		return false
+	}
+	pubKey := e.UserKeySet.GetPublicKey()
+	selfIdx := common.IndexOfStr(pubKey.GetMiningKeyBase58(consensusName), e.RoundData.CommitteeBLS.StringList)
+	return selfIdx != -1 && selfIdx == nextProposerIndex(e.RoundData.LastProposerIndex, e.RoundData.Block.GetRound(), 1, len(e.RoundData.Committee))
+}
+
+func (e *BLSHotStuff) addVote(roundKey string, validator string, voteData vote) {
+	if _, ok := e.Votes[roundKey]; !ok {
+		e.Votes[roundKey] = make(map[string]vote)
+	}
+	e.Votes[roundKey][validator] = voteData
+}
+
+// hasMajorityVotes verifies the received votes of the voted block and returns true if more than 2/3 of the committee voted it
+func (e *BLSHotStuff) hasMajorityVotes() bool {
+	roundKey := getRoundKey(e.RoundData.NextHeight, e.RoundData.Round)
+	blockHashBytes := e.RoundData.BlockHash.GetBytes()
+	for validatorKey, voteData := range e.Votes[roundKey] {
+		if _, ok := e.RoundData.Votes[validatorKey]; ok {
+			continue
+		}
+		validatorIdx := common.IndexOfStr(validatorKey, e.RoundData.CommitteeBLS.StringList)
+		if validatorIdx == -1 {
+			delete(e.Votes[roundKey], validatorKey)
+			continue
+		}
+		if err := e.preValidateVote(blockHashBytes, &voteData, e.RoundData.Committee[validatorIdx].MiningPubKey[common.BridgeConsensus]); err != nil {
+			e.logger.Error(err)
+			delete(e.Votes[roundKey], validatorKey)
+			continue
+		}
+		e.RoundData.Votes[validatorKey] = voteData
+	}
+	metrics.SetGlobalParam("NVote", len(e.RoundData.Votes))
+	return len(e.RoundData.Votes) > 2*len(e.RoundData.Committee)/3
+}
+
+func getRoundKey(nextHeight uint64, round int) string {
+	return fmt.Sprint(nextHeight, "_", round)
+}
+
+func parseRoundKey(roundKey string) (uint64, int) {
+	stringArray := strings.Split(roundKey, "_")
+	if len(stringArray) != 2 {
+		return 0, 0
+	}
+	height, err := strconv.Atoi(stringArray[0])
+	if err != nil {
+		return 0, 0
+	}
+	round, err := strconv.Atoi(stringArray[1])
+	if err != nil {
+		return 0, 0
+	}
+	return uint64(height), round
+}
+
+func (e *BLSHotStuff) UpdateCommitteeBLSList() {
+	committee := e.Chain.GetCommittee()
+	if !reflect.DeepEqual(e.RoundData.Committee, committee) {
+		e.RoundData.Committee = committee
+		e.RoundData.CommitteeBLS.ByteList = []blsmultisig.PublicKey{}
+		e.RoundData.CommitteeBLS.StringList = []string{}
+		for _, member := range e.RoundData.Committee {
+			e.RoundData.CommitteeBLS.ByteList = append(e.RoundData.CommitteeBLS.ByteList, member.MiningPubKey[common.GetMiningKeySchemeName(consensusName)])
+		}
+		committeeBLSString, err := incognitokey.ExtractPublickeysFromCommitteeKeyList(e.RoundData.Committee, consensusName)
+		if err != nil {
+			e.logger.Error(err)
+			return
+		}
+		e.RoundData.CommitteeBLS.StringList = committeeBLSString
+	}
+}
+
+// pruneRoundData drops the proposals and votes of the heights which are already committed
+func (e *BLSHotStuff) pruneRoundData() {
+	for roundKey := range e.Blocks {
+		if height, _ := parseRoundKey(roundKey); height < e.RoundData.NextHeight {
+			delete(e.Blocks, roundKey)
+		}
+	}
+	for roundKey := range e.Votes {
+		if height, _ := parseRoundKey(roundKey); height < e.RoundData.NextHeight {
+			delete(e.Votes, roundKey)
+		}
+	}
+}
+
+func (e *BLSHotStuff) InitRoundData() {
+	e.RoundData.NextHeight = e.Chain.CurrentHeight() + 1
+	e.RoundData.Round = e.getCurrentRound()
+	e.RoundData.Votes = make(map[string]vote)
+	e.RoundData.Block = nil
+	e.RoundData.BlockHash = common.Hash{}
+	e.RoundData.NotYetSendVote = true
+	e.RoundData.LastProposerIndex = e.Chain.GetLastProposerIndex()
+	e.RoundData.TimeStart = e.now()
+	e.UpdateCommitteeBLSList()
+	e.pruneRoundData()
+}
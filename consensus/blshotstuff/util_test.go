@@ -0,0 +1,67 @@
+package blshotstuff
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus/blsbft"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextProposerIndex(t *testing.T) {
+	// the block of round 1 is proposed by member 2, the next block of round 1 by member 3
+	assert.Equal(t, 3, nextProposerIndex(1, 1, 1, 4))
+	// the block of round 2 is proposed by member 3, the next block of round 2 by member 1
+	assert.Equal(t, 1, nextProposerIndex(1, 2, 2, 4))
+	assert.Equal(t, -1, nextProposerIndex(1, 1, 1, 0))
+
+	height, round := parseRoundKey(getRoundKey(10, 3))
+	assert.Equal(t, uint64(10), height)
+	assert.Equal(t, 3, round)
+}
+
+func TestCertifiedBlockIsValidForBLSBFT(t *testing.T) {
+	committee := []incognitokey.CommitteePublicKey{}
+	keySets := []*BLSHotStuff{}
+	for i := 0; i < 4; i++ {
+		seed := common.HashB([]byte{byte(i)})
+		engine := &BLSHotStuff{}
+		assert.Nil(t, engine.LoadUserKey(base58.Base58Check{}.Encode(seed, common.Base58Version)))
+		key, err := incognitokey.NewCommitteeKeyFromSeed(seed, []byte{byte(i)})
+		assert.Nil(t, err)
+		committee = append(committee, key)
+		keySets = append(keySets, engine)
+	}
+	committeeBLS := []blsmultisig.PublicKey{}
+	for _, member := range committee {
+		committeeBLS = append(committeeBLS, member.MiningPubKey[common.BlsConsensus])
+	}
+	committeeString, err := incognitokey.ExtractPublickeysFromCommitteeKeyList(committee, consensusName)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(committeeString))
+	assert.Equal(t, committee[0].GetMiningKeyBase58(common.BlsConsensus), committeeString[0])
+
+	block := &blockchain.ShardBlock{}
+	block.Header.Height = 2
+	votes := map[string]vote{}
+	for i, engine := range keySets[:3] {
+		sig, err := engine.UserKeySet.BLSSignData(block.Hash().GetBytes(), i, committeeBLS)
+		assert.Nil(t, err)
+		votes[committeeString[i]] = vote{BLS: sig}
+	}
+	aggSig, bridgeSigs, validatorIdx, err := combineVotes(votes, committeeString)
+	assert.Nil(t, err)
+	valData, err := blsbft.EncodeValidationData(blsbft.ValidationData{AggSig: aggSig, BridgeSig: bridgeSigs, ValidatiorsIdx: validatorIdx})
+	assert.Nil(t, err)
+	assert.Nil(t, block.AddValidationField(valData))
+
+	assert.Nil(t, (&BLSHotStuff{}).ValidateCommitteeSig(block, committee))
+	assert.Nil(t, blsbft.BLSBFT{}.ValidateCommitteeSig(block, committee))
+	// the votes do not certify another block
+	block.Header.Height = 3
+	assert.NotNil(t, (&BLSHotStuff{}).ValidateCommitteeSig(block, committee))
+}
@@ -0,0 +1,93 @@
+package blshotstuff
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus"
+	"github.com/incognitochain/incognito-chain/consensus/blsbft"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+// A block certified by BLSHotStuff carries the same validation data as a blsbft block:
+// the producer signature and the BLS multisig of more than 2/3 of the committee, so the signatures
+// are checked by blsbft and the blocks of both consensuses can be verified by any node.
+
+type vote struct {
+	BLS          []byte
+	BRI          []byte
+	Confirmation []byte
+}
+
+type blockValidation interface {
+	common.BlockInterface
+	AddValidationField(validationData string) error
+}
+
+func (e *BLSHotStuff) CreateValidationData(block common.BlockInterface) blsbft.ValidationData {
+	var valData blsbft.ValidationData
+	valData.ProducerBLSSig, _ = e.UserKeySet.BriSignData(block.Hash().GetBytes())
+	return valData
+}
+
+func (e *BLSHotStuff) validatePreSignBlock(block common.BlockInterface) error {
+	e.logger.Info("verifying block...")
+	if err := e.ValidateProducerPosition(block, e.RoundData.LastProposerIndex, e.RoundData.Committee); err != nil {
+		return consensus.NewConsensusError(consensus.UnExpectedError, err)
+	}
+	if err := e.ValidateProducerSig(block); err != nil {
+		return consensus.NewConsensusError(consensus.ProducerSignatureError, err)
+	}
+	if err := e.Chain.ValidatePreSignBlock(block); err != nil {
+		return consensus.NewConsensusError(consensus.UnExpectedError, err)
+	}
+	e.logger.Info("done verify block...")
+	return nil
+}
+
+func (e *BLSHotStuff) ValidateProducerPosition(block common.BlockInterface, lastProposerIndex int, committee []incognitokey.CommitteePublicKey) error {
+	if len(committee) == 0 {
+		return consensus.NewConsensusError(consensus.UnExpectedError, errors.New("committee is empty"))
+	}
+	producerPosition := (lastProposerIndex + block.GetRound()) % len(committee)
+	tempProducer, err := committee[producerPosition].ToBase58()
+	if err != nil {
+		return err
+	}
+	if tempProducer == block.GetProducer() {
+		return nil
+	}
+	return consensus.NewConsensusError(consensus.UnExpectedError, errors.New("Producer should be should be :"+tempProducer))
+}
+
+func (e *BLSHotStuff) ValidateProducerSig(block common.BlockInterface) error {
+	return blsbft.BLSBFT{}.ValidateProducerSig(block)
+}
+
+func (e *BLSHotStuff) ValidateCommitteeSig(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error {
+	return blsbft.BLSBFT{}.ValidateCommitteeSig(block, committee)
+}
+
+func (e *BLSHotStuff) ValidateData(data []byte, sig string, publicKey string) error {
+	return blsbft.BLSBFT{}.ValidateData(data, sig, publicKey)
+}
+
+func (e *BLSHotStuff) ExtractBridgeValidationData(block common.BlockInterface) ([][]byte, []int, error) {
+	return (&blsbft.BLSBFT{}).ExtractBridgeValidationData(block)
+}
+
+func validateSingleBriSig(
+	dataHash *common.Hash,
+	briSig []byte,
+	candidate []byte,
+) error {
+	result, err := bridgesig.Verify(candidate, dataHash.GetBytes(), briSig)
+	if err != nil {
+		return consensus.NewConsensusError(consensus.UnExpectedError, err)
+	}
+	if !result {
+		return consensus.NewConsensusError(consensus.UnExpectedError, errors.New("invalid BRI Signature"))
+	}
+	return nil
+}
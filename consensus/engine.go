@@ -12,6 +12,7 @@ import (
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/incognitochain/incognito-chain/wire"
 )
@@ -359,6 +360,17 @@ func (engine *Engine) IsOngoing(chainName string) bool {
 	return false
 }
 
+// GetEquivocationProofs returns the equivocation proofs detected by the consensus of all chains
+func (engine *Engine) GetEquivocationProofs() []metadata.EquivocationProofMetadata {
+	proofs := []metadata.EquivocationProofMetadata{}
+	for _, consensusModule := range engine.ChainConsensusList {
+		if detector, ok := consensusModule.(equivocationDetector); ok {
+			proofs = append(proofs, detector.GetEquivocationProofs()...)
+		}
+	}
+	return proofs
+}
+
 func (engine *Engine) OnBFTMsg(msg *wire.MessageBFT) {
 	if engine.CurrentMiningChain == msg.ChainKey {
 		engine.ChainConsensusList[msg.ChainKey].ProcessBFTMsg(msg)
@@ -531,6 +543,11 @@ func (engine *Engine) updateUserState(keySet *incognitokey.CommitteePublicKey, l
 		for keyType := range keySet.MiningPubKey {
 			engine.userCurrentState.KeysBase58[keyType] = keySet.GetMiningKeyBase58(keyType)
 		}
+		for consensusName := range AvailableConsensus {
+			if key := keySet.GetMiningKeyBase58(consensusName); key != "" {
+				engine.userCurrentState.KeysBase58[consensusName] = key
+			}
+		}
 	}
 
 	engine.updateConsensusState()
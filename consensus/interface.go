@@ -4,6 +4,7 @@ import (
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/wire"
 )
 
@@ -50,6 +51,12 @@ type ConsensusInterface interface {
 	ExtractBridgeValidationData(block common.BlockInterface) ([][]byte, []int, error)
 }
 
+// equivocationDetector is implemented by consensus which detects validators voting for two blocks of the same round
+type equivocationDetector interface {
+	// GetEquivocationProofs - get equivocation proofs detected by this consensus
+	GetEquivocationProofs() []metadata.EquivocationProofMetadata
+}
+
 type BeaconInterface interface {
 	blockchain.ChainInterface
 	GetAllCommittees() map[string]map[string][]incognitokey.CommitteePublicKey
@@ -30,6 +30,19 @@ func (engine *Engine) LoadMiningKeys(keysString string) error {
 						return errors.New("Key for this consensus can not load - " + keyConsensus)
 					}
 					engine.userMiningPublicKeys[availableConsensus] = *AvailableConsensus[availableConsensus].GetUserPublicKey()
+					// the consensuses signing with the same scheme mine with this key too, unless they have their own key
+					for consensusName, consensus := range AvailableConsensus {
+						if consensusName == availableConsensus || common.GetMiningKeySchemeName(consensusName) != common.GetMiningKeySchemeName(availableConsensus) {
+							continue
+						}
+						if _, ok := engine.userMiningPublicKeys[consensusName]; ok {
+							continue
+						}
+						if err := consensus.LoadUserKey(keyConsensus); err != nil {
+							return errors.New("Key for this consensus can not load - " + keyConsensus)
+						}
+						engine.userMiningPublicKeys[consensusName] = *consensus.GetUserPublicKey()
+					}
 				} else {
 					return errors.New("Consensus type for this key isn't exist " + availableConsensus)
 				}
@@ -59,7 +72,7 @@ func (engine *Engine) GetMiningPublicKeyByConsensus(consensusName string) (publi
 			if !exist {
 				return "", NewConsensusError(LoadKeyError, errors.New("Lightweight key not found"))
 			}
-			keyBytes[keytype], exist = engine.userMiningPublicKeys[keytype].MiningPubKey[keytype]
+			keyBytes[keytype], exist = engine.userMiningPublicKeys[keytype].MiningPubKey[common.GetMiningKeySchemeName(keytype)]
 			if !exist {
 				return "", NewConsensusError(LoadKeyError, errors.New("Key not found"))
 			}
@@ -0,0 +1,311 @@
+package simulation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+// Block is the block of a simulated chain, its hash does not cover the validation data
+type Block struct {
+	Height         uint64
+	Round          int
+	PreviousHash   common.Hash
+	Timestamp      int64
+	Producer       string
+	ValidationData string
+}
+
+func (block *Block) GetHeight() uint64 {
+	return block.Height
+}
+
+func (block *Block) Hash() *common.Hash {
+	hash := common.HashH([]byte(fmt.Sprint(block.Height, block.Round, block.PreviousHash.String(), block.Timestamp, block.Producer)))
+	return &hash
+}
+
+func (block *Block) GetProducer() string {
+	return block.Producer
+}
+
+func (block *Block) GetValidationField() string {
+	return block.ValidationData
+}
+
+func (block *Block) GetRound() int {
+	return block.Round
+}
+
+func (block *Block) GetRoundKey() string {
+	return fmt.Sprint(block.Height, "_", block.Round)
+}
+
+func (block *Block) GetInstructions() [][]string {
+	return [][]string{}
+}
+
+func (block *Block) GetConsensusType() string {
+	return common.BlsConsensus
+}
+
+func (block *Block) GetCurrentEpoch() uint64 {
+	return 1
+}
+
+func (block *Block) AddValidationField(validationData string) error {
+	block.ValidationData = validationData
+	return nil
+}
+
+/*
+	Chain is the copy of a simulated chain kept by a node, it implements blockchain.ChainInterface.
+	A block is inserted once its committee signature is valid,
+	blocks received ahead of the chain are kept until the missing blocks arrive
+*/
+type Chain struct {
+	name              string
+	shardID           int
+	consensusName     string
+	clock             *VirtualClock
+	committee         []incognitokey.CommitteePublicKey
+	userKey           incognitokey.CommitteePublicKey
+	minBlkInterval    time.Duration
+	maxBlkCreateTime  time.Duration
+	blocks            []*Block
+	orphanBlocks      map[uint64]*Block
+	lastProposerIndex int
+	validator         func(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error
+	broadcaster       func(block *Block)
+	mtx               sync.RWMutex
+}
+
+func newChain(name string, shardID int, consensusName string, clock *VirtualClock, committee []incognitokey.CommitteePublicKey, userKey incognitokey.CommitteePublicKey, minBlkInterval time.Duration, maxBlkCreateTime time.Duration) *Chain {
+	genesisBlock := &Block{Timestamp: clock.Now().Unix()}
+	return &Chain{
+		name:             name,
+		shardID:          shardID,
+		consensusName:    consensusName,
+		clock:            clock,
+		committee:        committee,
+		userKey:          userKey,
+		minBlkInterval:   minBlkInterval,
+		maxBlkCreateTime: maxBlkCreateTime,
+		blocks:           []*Block{genesisBlock},
+		orphanBlocks:     make(map[uint64]*Block),
+	}
+}
+
+func (chain *Chain) GetChainName() string {
+	return chain.name
+}
+
+func (chain *Chain) GetConsensusType() string {
+	return chain.consensusName
+}
+
+func (chain *Chain) GetLastBlockTimeStamp() int64 {
+	chain.mtx.RLock()
+	defer chain.mtx.RUnlock()
+	return chain.blocks[len(chain.blocks)-1].Timestamp
+}
+
+func (chain *Chain) GetMinBlkInterval() time.Duration {
+	return chain.minBlkInterval
+}
+
+func (chain *Chain) GetMaxBlkCreateTime() time.Duration {
+	return chain.maxBlkCreateTime
+}
+
+func (chain *Chain) IsReady() bool {
+	return true
+}
+
+func (chain *Chain) GetActiveShardNumber() int {
+	return 1
+}
+
+func (chain *Chain) GetPubkeyRole(pubkey string, round int) (string, byte) {
+	index := chain.GetPubKeyCommitteeIndex(pubkey)
+	if index == -1 {
+		return common.EmptyString, byte(chain.shardID)
+	}
+	if index == (chain.GetLastProposerIndex()+round)%len(chain.committee) {
+		return common.ProposerRole, byte(chain.shardID)
+	}
+	return common.ValidatorRole, byte(chain.shardID)
+}
+
+func (chain *Chain) CurrentHeight() uint64 {
+	chain.mtx.RLock()
+	defer chain.mtx.RUnlock()
+	return chain.blocks[len(chain.blocks)-1].Height
+}
+
+func (chain *Chain) GetBestBlockHash() common.Hash {
+	chain.mtx.RLock()
+	defer chain.mtx.RUnlock()
+	return *chain.blocks[len(chain.blocks)-1].Hash()
+}
+
+func (chain *Chain) GetCommitteeSize() int {
+	return len(chain.committee)
+}
+
+func (chain *Chain) GetCommittee() []incognitokey.CommitteePublicKey {
+	return append([]incognitokey.CommitteePublicKey{}, chain.committee...)
+}
+
+func (chain *Chain) GetPubKeyCommitteeIndex(pubkey string) int {
+	for index, key := range chain.committee {
+		if key.GetMiningKeyBase58(common.BlsConsensus) == pubkey {
+			return index
+		}
+	}
+	return -1
+}
+
+func (chain *Chain) GetLastProposerIndex() int {
+	chain.mtx.RLock()
+	defer chain.mtx.RUnlock()
+	return chain.lastProposerIndex
+}
+
+func (chain *Chain) UnmarshalBlock(blockString []byte) (common.BlockInterface, error) {
+	block := &Block{}
+	if err := json.Unmarshal(blockString, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (chain *Chain) CreateNewBlock(round int) (common.BlockInterface, error) {
+	chain.mtx.RLock()
+	defer chain.mtx.RUnlock()
+	producer, err := chain.userKey.ToBase58()
+	if err != nil {
+		return nil, err
+	}
+	bestBlock := chain.blocks[len(chain.blocks)-1]
+	return &Block{
+		Height:       bestBlock.Height + 1,
+		Round:        round,
+		PreviousHash: *bestBlock.Hash(),
+		Timestamp:    chain.clock.Now().Unix(),
+		Producer:     producer,
+	}, nil
+}
+
+// InsertBlk inserts a block with a valid committee signature on top of the chain,
+// or keeps it until the blocks before it are inserted
+func (chain *Chain) InsertBlk(block common.BlockInterface) error {
+	simBlock, ok := block.(*Block)
+	if !ok {
+		return fmt.Errorf("Expect block type to be *simulation.Block but get %T", block)
+	}
+	if err := chain.ValidateBlockSignatures(simBlock, chain.committee); err != nil {
+		return err
+	}
+	chain.mtx.Lock()
+	defer chain.mtx.Unlock()
+	bestHeight := chain.blocks[len(chain.blocks)-1].Height
+	if simBlock.Height <= bestHeight {
+		if !chain.blocks[simBlock.Height].Hash().IsEqual(simBlock.Hash()) {
+			return fmt.Errorf("Block %+v conflicts with block %+v of height %+v", simBlock.Hash(), chain.blocks[simBlock.Height].Hash(), simBlock.Height)
+		}
+		return errors.New("Duplicate block")
+	}
+	if simBlock.Height > bestHeight+1 {
+		chain.orphanBlocks[simBlock.Height] = simBlock
+		return nil
+	}
+	if err := chain.insertBlock(simBlock); err != nil {
+		return err
+	}
+	for {
+		orphanBlock, ok := chain.orphanBlocks[chain.blocks[len(chain.blocks)-1].Height+1]
+		if !ok {
+			break
+		}
+		delete(chain.orphanBlocks, orphanBlock.Height)
+		if err := chain.insertBlock(orphanBlock); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (chain *Chain) insertBlock(block *Block) error {
+	bestBlock := chain.blocks[len(chain.blocks)-1]
+	if !block.PreviousHash.IsEqual(bestBlock.Hash()) {
+		return fmt.Errorf("Expect previous hash of block %+v to be %+v but get %+v", block.Height, bestBlock.Hash(), block.PreviousHash)
+	}
+	chain.blocks = append(chain.blocks, block)
+	chain.lastProposerIndex = (chain.lastProposerIndex + block.Round) % len(chain.committee)
+	return nil
+}
+
+func (chain *Chain) InsertAndBroadcastBlock(block common.BlockInterface) error {
+	if err := chain.InsertBlk(block); err != nil {
+		return err
+	}
+	if chain.broadcaster != nil {
+		chain.broadcaster(block.(*Block))
+	}
+	return nil
+}
+
+func (chain *Chain) ValidateBlockSignatures(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error {
+	if chain.validator == nil {
+		return nil
+	}
+	return chain.validator(block, committee)
+}
+
+func (chain *Chain) ValidatePreSignBlock(block common.BlockInterface) error {
+	simBlock, ok := block.(*Block)
+	if !ok {
+		return fmt.Errorf("Expect block type to be *simulation.Block but get %T", block)
+	}
+	chain.mtx.RLock()
+	defer chain.mtx.RUnlock()
+	bestBlock := chain.blocks[len(chain.blocks)-1]
+	if simBlock.Height != bestBlock.Height+1 {
+		return fmt.Errorf("Expect block height to be %+v but get %+v", bestBlock.Height+1, simBlock.Height)
+	}
+	if !simBlock.PreviousHash.IsEqual(bestBlock.Hash()) {
+		return fmt.Errorf("Expect previous hash of block %+v to be %+v but get %+v", simBlock.Height, bestBlock.Hash(), simBlock.PreviousHash)
+	}
+	if simBlock.Timestamp < bestBlock.Timestamp {
+		return fmt.Errorf("Block %+v has timestamp %+v before its previous block %+v", simBlock.Height, simBlock.Timestamp, bestBlock.Timestamp)
+	}
+	return nil
+}
+
+func (chain *Chain) GetShardID() int {
+	return chain.shardID
+}
+
+// GetBlocks returns the blocks of the chain from genesis
+func (chain *Chain) GetBlocks() []*Block {
+	chain.mtx.RLock()
+	defer chain.mtx.RUnlock()
+	return append([]*Block{}, chain.blocks...)
+}
+
+// getBlocksFromHeight returns at most limit blocks of the chain from a height
+func (chain *Chain) getBlocksFromHeight(height uint64, limit int) []*Block {
+	chain.mtx.RLock()
+	defer chain.mtx.RUnlock()
+	blocks := []*Block{}
+	for h := height; h < uint64(len(chain.blocks)) && len(blocks) < limit; h++ {
+		blocks = append(blocks, chain.blocks[h])
+	}
+	return blocks
+}
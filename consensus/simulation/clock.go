@@ -0,0 +1,31 @@
+package simulation
+
+import (
+	"sync"
+	"time"
+)
+
+// VirtualClock is the clock of a simulation, it only moves when the simulator processes an event
+type VirtualClock struct {
+	now time.Time
+	mtx sync.RWMutex
+}
+
+func NewVirtualClock(start time.Time) *VirtualClock {
+	return &VirtualClock{now: start}
+}
+
+func (clock *VirtualClock) Now() time.Time {
+	clock.mtx.RLock()
+	defer clock.mtx.RUnlock()
+	return clock.now
+}
+
+// set moves the clock to a time, the clock never goes back
+func (clock *VirtualClock) set(now time.Time) {
+	clock.mtx.Lock()
+	defer clock.mtx.Unlock()
+	if now.After(clock.now) {
+		clock.now = now
+	}
+}
@@ -0,0 +1,95 @@
+package simulation
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+/*
+	Network decides if and when a message sent by a node is delivered to another node:
+	- every message takes Latency plus a random jitter up to Jitter
+	- a message is lost with probability LossRate
+	- a node may be slow, its messages take an extra delay
+	- nodes in different partitions never get messages from each other
+	All random draws come from the seed of the simulation, so the same simulation always delivers the same messages
+*/
+type Network struct {
+	random     *rand.Rand
+	latency    time.Duration
+	jitter     time.Duration
+	lossRate   float64
+	slowNodes  map[int]time.Duration
+	partitions map[int]int
+	mtx        sync.Mutex
+}
+
+func NewNetwork(seed int64, latency time.Duration, jitter time.Duration, lossRate float64) *Network {
+	return &Network{
+		random:     rand.New(rand.NewSource(seed)),
+		latency:    latency,
+		jitter:     jitter,
+		lossRate:   lossRate,
+		slowNodes:  make(map[int]time.Duration),
+		partitions: make(map[int]int),
+	}
+}
+
+// SetLossRate sets the probability for a message to be lost
+func (network *Network) SetLossRate(lossRate float64) {
+	network.mtx.Lock()
+	defer network.mtx.Unlock()
+	network.lossRate = lossRate
+}
+
+// SetSlowNode delays all messages sent by a node, a zero delay makes the node normal again
+func (network *Network) SetSlowNode(node int, delay time.Duration) {
+	network.mtx.Lock()
+	defer network.mtx.Unlock()
+	if delay == 0 {
+		delete(network.slowNodes, node)
+		return
+	}
+	network.slowNodes[node] = delay
+}
+
+// Partition splits the nodes into groups which can not reach each other, nodes not in any group make a group together
+func (network *Network) Partition(groups ...[]int) {
+	network.mtx.Lock()
+	defer network.mtx.Unlock()
+	network.partitions = make(map[int]int)
+	for i, group := range groups {
+		for _, node := range group {
+			network.partitions[node] = i + 1
+		}
+	}
+}
+
+// Heal removes all partitions
+func (network *Network) Heal() {
+	network.Partition()
+}
+
+// IsConnected returns whether two nodes are in the same partition
+func (network *Network) IsConnected(from int, to int) bool {
+	network.mtx.Lock()
+	defer network.mtx.Unlock()
+	return network.partitions[from] == network.partitions[to]
+}
+
+// delay returns the delay of a message from a node to another, false if the message is not delivered
+func (network *Network) delay(from int, to int) (time.Duration, bool) {
+	network.mtx.Lock()
+	defer network.mtx.Unlock()
+	if network.partitions[from] != network.partitions[to] {
+		return 0, false
+	}
+	if network.lossRate > 0 && network.random.Float64() < network.lossRate {
+		return 0, false
+	}
+	delay := network.latency + network.slowNodes[from]
+	if network.jitter > 0 {
+		delay += time.Duration(network.random.Int63n(int64(network.jitter)))
+	}
+	return delay, true
+}
@@ -0,0 +1,78 @@
+package simulation
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/blsbft"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/wire"
+)
+
+// Consensus is a consensus which the simulator runs step by step with its virtual clock
+type Consensus interface {
+	StartStepByStep(clock blsbft.Clock) error
+	HandleBFTMsg(msg *wire.MessageBFT)
+	HandleTick()
+	LoadUserKey(miningKey string) error
+	ValidateCommitteeSig(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error
+}
+
+// Node is an in-process committee member, it implements consensus.NodeInterface and keeps the messages pushed by its consensus
+type Node struct {
+	Index     int
+	Consensus Consensus
+	Chain     *Chain
+	UserKey   incognitokey.CommitteePublicKey
+	miningKey string
+	isOnline  bool
+	outbox    []*wire.MessageBFT
+	mtx       sync.Mutex
+}
+
+func (node *Node) PushMessageToChain(msg wire.Message, chain blockchain.ChainInterface) error {
+	node.mtx.Lock()
+	defer node.mtx.Unlock()
+	if bftMsg, ok := msg.(*wire.MessageBFT); ok {
+		node.outbox = append(node.outbox, bftMsg)
+	}
+	return nil
+}
+
+func (node *Node) UpdateConsensusState(role string, userPbk string, currentShard *byte, beaconCommittee []string, shardCommittee map[byte][]string) {
+}
+
+func (node *Node) IsEnableMining() bool {
+	return true
+}
+
+func (node *Node) GetMiningKeys() string {
+	return node.miningKey
+}
+
+func (node *Node) GetPrivateKey() string {
+	return ""
+}
+
+func (node *Node) DropAllConnections() {
+}
+
+var bftMsgTypeOrder = map[string]int{
+	blsbft.MSG_PROPOSE: 0,
+	blsbft.MSG_VOTE:    1,
+	blsbft.MSG_TIMEOUT: 2,
+}
+
+// takeOutbox returns the pushed messages in an order which does not depend on the goroutines pushing them
+func (node *Node) takeOutbox() []*wire.MessageBFT {
+	node.mtx.Lock()
+	defer node.mtx.Unlock()
+	outbox := node.outbox
+	node.outbox = nil
+	sort.SliceStable(outbox, func(i, j int) bool {
+		return bftMsgTypeOrder[outbox[i].Type] < bftMsgTypeOrder[outbox[j].Type]
+	})
+	return outbox
+}
@@ -0,0 +1,311 @@
+/*
+	Package simulation runs the committee members of a chain in process, with a virtual clock and a simulated network,
+	so the liveness and safety of BLSBFT and BLSHotStuff can be tested deterministically, e.g. under partitions, message loss and slow proposers.
+
+	A simulation is a queue of events processed in order of time: node ticks, BFT messages, blocks and block syncs.
+	The virtual clock only moves to the time of the processed event, so minutes of consensus run in seconds.
+*/
+package simulation
+
+import (
+	"container/heap"
+	"fmt"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus"
+	"github.com/incognitochain/incognito-chain/consensus/blsbft"
+	"github.com/incognitochain/incognito-chain/consensus/blshotstuff"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/wire"
+)
+
+const (
+	tickInterval     = 500 * time.Millisecond // ticker of the actor loop of the consensus
+	syncInterval     = 2 * time.Second
+	maxSyncBlocks    = 10
+	maxBlkCreateTime = 5 * time.Second
+)
+
+// Config of a simulation, zero values take the defaults of DefaultConfig
+type Config struct {
+	Consensus      string
+	CommitteeSize  int
+	Seed           int64
+	StartTime      time.Time
+	MinBlkInterval time.Duration
+	Latency        time.Duration
+	Jitter         time.Duration
+	LossRate       float64
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Consensus:      common.BlsConsensus,
+		CommitteeSize:  4,
+		Seed:           1,
+		StartTime:      time.Unix(1577836800, 0),
+		MinBlkInterval: 10 * time.Second,
+		Latency:        100 * time.Millisecond,
+		Jitter:         50 * time.Millisecond,
+	}
+}
+
+const (
+	tickEvent = iota
+	messageEvent
+	blockEvent
+	syncEvent
+)
+
+type event struct {
+	at    time.Time
+	seq   uint64
+	kind  int
+	node  int
+	msg   *wire.MessageBFT
+	block *Block
+}
+
+type eventQueue []*event
+
+func (queue eventQueue) Len() int {
+	return len(queue)
+}
+
+func (queue eventQueue) Less(i, j int) bool {
+	if !queue[i].at.Equal(queue[j].at) {
+		return queue[i].at.Before(queue[j].at)
+	}
+	return queue[i].seq < queue[j].seq
+}
+
+func (queue eventQueue) Swap(i, j int) {
+	queue[i], queue[j] = queue[j], queue[i]
+}
+
+func (queue *eventQueue) Push(x interface{}) {
+	*queue = append(*queue, x.(*event))
+}
+
+func (queue *eventQueue) Pop() interface{} {
+	old := *queue
+	item := old[len(old)-1]
+	*queue = old[:len(old)-1]
+	return item
+}
+
+// Simulator runs the committee members of a shard chain with BLSBFT or BLSHotStuff
+type Simulator struct {
+	Clock   *VirtualClock
+	Network *Network
+	Nodes   []*Node
+	events  eventQueue
+	seq     uint64
+}
+
+func NewSimulator(config Config) (*Simulator, error) {
+	defaultConfig := DefaultConfig()
+	if config.Consensus == "" {
+		config.Consensus = defaultConfig.Consensus
+	}
+	if config.CommitteeSize == 0 {
+		config.CommitteeSize = defaultConfig.CommitteeSize
+	}
+	if config.StartTime.IsZero() {
+		config.StartTime = defaultConfig.StartTime
+	}
+	if config.MinBlkInterval == 0 {
+		config.MinBlkInterval = defaultConfig.MinBlkInterval
+	}
+	if config.Latency == 0 {
+		config.Latency = defaultConfig.Latency
+	}
+	simulator := &Simulator{
+		Clock:   NewVirtualClock(config.StartTime),
+		Network: NewNetwork(config.Seed, config.Latency, config.Jitter, config.LossRate),
+	}
+	committee := []incognitokey.CommitteePublicKey{}
+	miningKeys := []string{}
+	for i := 0; i < config.CommitteeSize; i++ {
+		seed := common.HashB([]byte(fmt.Sprint("simulation", config.Seed, i)))
+		key, err := incognitokey.NewCommitteeKeyFromSeed(seed, common.HashB(seed))
+		if err != nil {
+			return nil, err
+		}
+		committee = append(committee, key)
+		miningKeys = append(miningKeys, base58.Base58Check{}.Encode(seed, common.Base58Version))
+	}
+	logger := common.NewBackend(nil).Logger("Simulation log ", true)
+	for i := 0; i < config.CommitteeSize; i++ {
+		node := &Node{
+			Index:     i,
+			UserKey:   committee[i],
+			miningKey: miningKeys[i],
+			isOnline:  true,
+		}
+		node.Chain = newChain(common.GetShardChainKey(0), 0, config.Consensus, simulator.Clock, committee, committee[i], config.MinBlkInterval, maxBlkCreateTime)
+		consensus, err := newConsensus(config.Consensus, node.Chain, node, logger)
+		if err != nil {
+			return nil, err
+		}
+		if err := consensus.LoadUserKey(miningKeys[i]); err != nil {
+			return nil, err
+		}
+		node.Consensus = consensus
+		node.Chain.validator = func(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error {
+			return consensus.ValidateCommitteeSig(block, committee)
+		}
+		node.Chain.broadcaster = func(block *Block) {
+			simulator.broadcastBlock(node.Index, block)
+		}
+		simulator.Nodes = append(simulator.Nodes, node)
+	}
+	for _, node := range simulator.Nodes {
+		if err := node.Consensus.StartStepByStep(simulator.Clock); err != nil {
+			return nil, err
+		}
+		simulator.schedule(&event{at: config.StartTime.Add(tickInterval), kind: tickEvent, node: node.Index})
+		simulator.schedule(&event{at: config.StartTime.Add(syncInterval), kind: syncEvent, node: node.Index})
+	}
+	return simulator, nil
+}
+
+// newConsensus returns the consensus of a node, it must be able to run step by step
+func newConsensus(consensusName string, chain *Chain, node *Node, logger common.Logger) (Consensus, error) {
+	var instance consensus.ConsensusInterface
+	switch consensusName {
+	case common.BlsConsensus:
+		instance = blsbft.BLSBFT{}.NewInstance(chain, chain.GetChainName(), node, logger)
+	case common.BlsHotStuffConsensus:
+		instance = (&blshotstuff.BLSHotStuff{}).NewInstance(chain, chain.GetChainName(), node, logger)
+	default:
+		return nil, fmt.Errorf("Unknown consensus %+v", consensusName)
+	}
+	stepConsensus, ok := instance.(Consensus)
+	if !ok {
+		return nil, fmt.Errorf("Expect consensus %+v to run step by step but get %T", consensusName, instance)
+	}
+	return stepConsensus, nil
+}
+
+func (simulator *Simulator) schedule(e *event) {
+	simulator.seq++
+	e.seq = simulator.seq
+	heap.Push(&simulator.events, e)
+}
+
+// Run processes the events of the simulation for a duration of virtual time
+func (simulator *Simulator) Run(duration time.Duration) {
+	end := simulator.Clock.Now().Add(duration)
+	for simulator.events.Len() > 0 && !simulator.events[0].at.After(end) {
+		e := heap.Pop(&simulator.events).(*event)
+		simulator.Clock.set(e.at)
+		simulator.process(e)
+	}
+	simulator.Clock.set(end)
+}
+
+func (simulator *Simulator) process(e *event) {
+	node := simulator.Nodes[e.node]
+	switch e.kind {
+	case tickEvent:
+		simulator.schedule(&event{at: e.at.Add(tickInterval), kind: tickEvent, node: e.node})
+		if node.isOnline {
+			node.Consensus.HandleTick()
+		}
+	case messageEvent:
+		if node.isOnline {
+			node.Consensus.HandleBFTMsg(e.msg)
+		}
+	case blockEvent:
+		if node.isOnline {
+			node.Chain.InsertBlk(e.block)
+		}
+	case syncEvent:
+		simulator.schedule(&event{at: e.at.Add(syncInterval), kind: syncEvent, node: e.node})
+		if node.isOnline {
+			simulator.syncBlocks(node)
+		}
+	}
+	simulator.sendMessages(node)
+}
+
+// sendMessages sends the messages pushed by a node to the other nodes
+func (simulator *Simulator) sendMessages(from *Node) {
+	for _, msg := range from.takeOutbox() {
+		for _, to := range simulator.Nodes {
+			if to.Index == from.Index {
+				continue
+			}
+			if delay, ok := simulator.Network.delay(from.Index, to.Index); ok {
+				simulator.schedule(&event{at: simulator.Clock.Now().Add(delay), kind: messageEvent, node: to.Index, msg: msg})
+			}
+		}
+	}
+}
+
+func (simulator *Simulator) broadcastBlock(from int, block *Block) {
+	for _, to := range simulator.Nodes {
+		if to.Index == from {
+			continue
+		}
+		if delay, ok := simulator.Network.delay(from, to.Index); ok {
+			blockCopy := *block
+			simulator.schedule(&event{at: simulator.Clock.Now().Add(delay), kind: blockEvent, node: to.Index, block: &blockCopy})
+		}
+	}
+}
+
+// syncBlocks gets the missing blocks of a node from the first connected peer which has them
+func (simulator *Simulator) syncBlocks(node *Node) {
+	height := node.Chain.CurrentHeight()
+	for _, peer := range simulator.Nodes {
+		if peer.Index == node.Index || !peer.isOnline || !simulator.Network.IsConnected(peer.Index, node.Index) {
+			continue
+		}
+		if peer.Chain.CurrentHeight() <= height {
+			continue
+		}
+		for _, block := range peer.Chain.getBlocksFromHeight(height+1, maxSyncBlocks) {
+			if delay, ok := simulator.Network.delay(peer.Index, node.Index); ok {
+				blockCopy := *block
+				simulator.schedule(&event{at: simulator.Clock.Now().Add(delay), kind: blockEvent, node: node.Index, block: &blockCopy})
+			}
+		}
+		return
+	}
+}
+
+// SetOnline crashes a node or brings it back, an offline node neither runs its consensus nor gets messages
+func (simulator *Simulator) SetOnline(node int, isOnline bool) {
+	simulator.Nodes[node].isOnline = isOnline
+}
+
+// Heights returns the current height of the chain of each node
+func (simulator *Simulator) Heights() []uint64 {
+	heights := []uint64{}
+	for _, node := range simulator.Nodes {
+		heights = append(heights, node.Chain.CurrentHeight())
+	}
+	return heights
+}
+
+// CheckSafety returns an error if two nodes inserted different blocks at the same height
+func (simulator *Simulator) CheckSafety() error {
+	blocksByHeight := make(map[uint64]*Block)
+	for _, node := range simulator.Nodes {
+		for _, block := range node.Chain.GetBlocks() {
+			otherBlock, ok := blocksByHeight[block.Height]
+			if !ok {
+				blocksByHeight[block.Height] = block
+				continue
+			}
+			if !otherBlock.Hash().IsEqual(block.Hash()) {
+				return fmt.Errorf("node %+v inserted block %+v at height %+v but another node inserted block %+v", node.Index, block.Hash(), block.Height, otherBlock.Hash())
+			}
+		}
+	}
+	return nil
+}
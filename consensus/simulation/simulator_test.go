@@ -0,0 +1,131 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSimulator(t *testing.T, config Config) *Simulator {
+	simulator, err := NewSimulator(config)
+	assert.Nil(t, err)
+	return simulator
+}
+
+func TestSimulatorProgress(t *testing.T) {
+	simulator := newTestSimulator(t, DefaultConfig())
+	simulator.Run(2 * time.Minute)
+	t.Log(simulator.Heights())
+	for _, height := range simulator.Heights() {
+		assert.True(t, height >= 5, "height %+v", height)
+	}
+	assert.Nil(t, simulator.CheckSafety())
+}
+
+func TestSimulatorPartition(t *testing.T) {
+	simulator := newTestSimulator(t, DefaultConfig())
+	simulator.Run(time.Minute)
+	// no side of a 2-2 partition has more than 2/3 of the committee
+	simulator.Network.Partition([]int{0, 1}, []int{2, 3})
+	simulator.Run(20 * time.Second)
+	partitionHeights := simulator.Heights()
+	simulator.Run(3 * time.Minute)
+	assert.Equal(t, partitionHeights, simulator.Heights())
+	simulator.Network.Heal()
+	simulator.Run(3 * time.Minute)
+	t.Log(partitionHeights, simulator.Heights())
+	for i, height := range simulator.Heights() {
+		assert.True(t, height > partitionHeights[i], "node %+v height %+v", i, height)
+	}
+	assert.Nil(t, simulator.CheckSafety())
+}
+
+func TestSimulatorSlowProposer(t *testing.T) {
+	simulator := newTestSimulator(t, DefaultConfig())
+	// the blocks of node 1 always arrive after the round times out
+	simulator.Network.SetSlowNode(1, time.Minute)
+	simulator.Run(5 * time.Minute)
+	t.Log(simulator.Heights())
+	blocks := simulator.Nodes[0].Chain.GetBlocks()
+	assert.True(t, len(blocks) > 5)
+	producer, _ := simulator.Nodes[1].UserKey.ToBase58()
+	viewChanges := 0
+	for _, block := range blocks[1:] {
+		assert.NotEqual(t, producer, block.Producer)
+		if block.Round > 1 {
+			viewChanges++
+		}
+	}
+	assert.True(t, viewChanges > 0)
+	assert.Nil(t, simulator.CheckSafety())
+}
+
+func TestSimulatorLossAndCrash(t *testing.T) {
+	config := DefaultConfig()
+	config.LossRate = 0.1
+	simulator := newTestSimulator(t, config)
+	simulator.SetOnline(3, false)
+	simulator.Run(5 * time.Minute)
+	// every lost vote and every round of the crashed proposer times out, but the chain still grows
+	height := simulator.Heights()[0]
+	assert.True(t, height >= 3)
+	assert.Equal(t, uint64(0), simulator.Heights()[3])
+	// the crashed node syncs the blocks it missed
+	simulator.SetOnline(3, true)
+	simulator.Run(time.Minute)
+	assert.True(t, simulator.Heights()[3] >= height)
+	assert.Nil(t, simulator.CheckSafety())
+}
+
+func TestSimulatorDeterminism(t *testing.T) {
+	config := DefaultConfig()
+	config.LossRate = 0.05
+	blockHashes := [2][]string{}
+	for i := range blockHashes {
+		simulator := newTestSimulator(t, config)
+		simulator.Network.SetSlowNode(2, time.Minute)
+		simulator.Run(3 * time.Minute)
+		for _, block := range simulator.Nodes[0].Chain.GetBlocks() {
+			blockHashes[i] = append(blockHashes[i], block.Hash().String())
+		}
+	}
+	assert.True(t, len(blockHashes[0]) > 1)
+	assert.Equal(t, blockHashes[0], blockHashes[1])
+}
+
+func newHotStuffTestConfig() Config {
+	config := DefaultConfig()
+	config.Consensus = common.BlsHotStuffConsensus
+	return config
+}
+
+func TestSimulatorHotStuffProgress(t *testing.T) {
+	simulator := newTestSimulator(t, newHotStuffTestConfig())
+	simulator.Run(2 * time.Minute)
+	t.Log(simulator.Heights())
+	for _, height := range simulator.Heights() {
+		assert.True(t, height >= 5, "height %+v", height)
+	}
+	assert.Nil(t, simulator.CheckSafety())
+}
+
+func TestSimulatorHotStuffCrashedProposer(t *testing.T) {
+	simulator := newTestSimulator(t, newHotStuffTestConfig())
+	simulator.SetOnline(3, false)
+	simulator.Run(5 * time.Minute)
+	t.Log(simulator.Heights())
+	// the rounds of the crashed node time out, the other 3 nodes are more than 2/3 of the committee
+	height := simulator.Heights()[0]
+	assert.True(t, height >= 5)
+	blocks := simulator.Nodes[0].Chain.GetBlocks()
+	producer, _ := simulator.Nodes[3].UserKey.ToBase58()
+	for _, block := range blocks[1:] {
+		assert.NotEqual(t, producer, block.Producer)
+	}
+	simulator.SetOnline(3, true)
+	simulator.Run(time.Minute)
+	assert.True(t, simulator.Heights()[3] >= height)
+	assert.Nil(t, simulator.CheckSafety())
+}
@@ -0,0 +1,119 @@
+package badgerdb
+
+import (
+	"github.com/dgraph-io/badger"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+const (
+	// maxTableSize is the size of the badger tables and memtables. A whole block is committed in one badger
+	// transaction (see storeTransaction) and badger limits a transaction to 15% of maxTableSize in bytes
+	// and to that size divided by the 96 bytes of a skiplist node in writes: with 128MB tables a transaction
+	// holds about 19MB and 209715 writes, twice the default limits.
+	// Values of at least 32 bytes are kept in the value log, only their keys count in the size.
+	maxTableSize = 128 << 20
+	// numMemtables keeps the memory of the memtables close to the default 5 memtables of 64MB
+	numMemtables = 3
+)
+
+func newOptions(dbPath string) badger.Options {
+	return badger.DefaultOptions(dbPath).WithLogger(nil).WithMaxTableSize(maxTableSize).WithNumMemtables(numMemtables)
+}
+
+// store adapts a badger database to lvdb.Store so that the whole chain schema
+// implemented by lvdb can be kept in badger.
+type store struct {
+	bdb *badger.DB
+}
+
+func open(dbPath string) (database.DatabaseInterface, error) {
+	bdb, err := badger.Open(newOptions(dbPath))
+	if err != nil {
+		return nil, database.NewDatabaseError(database.OpenDbErr, errors.Wrapf(err, "badger.Open %s", dbPath))
+	}
+	return lvdb.NewWithStore(&store{bdb: bdb}), nil
+}
+
+func (s *store) Close() error {
+	return s.bdb.Close()
+}
+
+func (s *store) Get(key []byte, ro *opt.ReadOptions) ([]byte, error) {
+	var value []byte
+	err := s.bdb.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		value, err = item.ValueCopy(nil)
+		return err
+	})
+	if err == badger.ErrKeyNotFound || err == badger.ErrEmptyKey {
+		return nil, lvdberr.ErrNotFound
+	}
+	return value, err
+}
+
+func (s *store) Has(key []byte, ro *opt.ReadOptions) (bool, error) {
+	_, err := s.Get(key, ro)
+	if err == lvdberr.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *store) Put(key, value []byte, wo *opt.WriteOptions) error {
+	return s.bdb.Update(func(txn *badger.Txn) error {
+		return txn.Set(key, value)
+	})
+}
+
+func (s *store) Delete(key []byte, wo *opt.WriteOptions) error {
+	return s.bdb.Update(func(txn *badger.Txn) error {
+		return txn.Delete(key)
+	})
+}
+
+// Write applies every record of batch in a single badger transaction, so the batch is
+// committed atomically like a leveldb batch write.
+func (s *store) Write(batch *leveldb.Batch, wo *opt.WriteOptions) error {
+	return s.bdb.Update(func(txn *badger.Txn) error {
+		replay := &batchReplay{txn: txn}
+		if err := batch.Replay(replay); err != nil {
+			return err
+		}
+		return replay.err
+	})
+}
+
+func (s *store) NewIterator(slice *util.Range, ro *opt.ReadOptions) iterator.Iterator {
+	return newIterator(s.bdb.NewTransaction(false), slice)
+}
+
+// batchReplay copies the records of a leveldb batch into a badger transaction.
+type batchReplay struct {
+	txn *badger.Txn
+	err error
+}
+
+func (r *batchReplay) Put(key, value []byte) {
+	if r.err == nil {
+		r.err = r.txn.Set(key, value)
+	}
+}
+
+func (r *batchReplay) Delete(key []byte) {
+	if r.err == nil {
+		r.err = r.txn.Delete(key)
+	}
+}
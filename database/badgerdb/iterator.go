@@ -0,0 +1,213 @@
+package badgerdb
+
+import (
+	"bytes"
+
+	"github.com/dgraph-io/badger"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// dbIterator implements iterator.Iterator on top of a badger transaction.
+// Badger iterators only move in one direction, so a forward and a reverse iterator are
+// opened lazily and the current key is used to switch between them. Only one of them is
+// kept open at a time, as read-write transactions allow a single open iterator.
+type dbIterator struct {
+	util.BasicReleaser
+	txn    *badger.Txn
+	shared bool // txn belongs to a storeTransaction and is not discarded on Release
+	start  []byte
+	limit  []byte
+
+	forward *badger.Iterator
+	reverse *badger.Iterator
+	cur     *badger.Iterator
+
+	key   []byte
+	value []byte
+	err   error
+}
+
+func newIterator(txn *badger.Txn, slice *util.Range) *dbIterator {
+	it := &dbIterator{txn: txn}
+	if slice != nil {
+		it.start = slice.Start
+		it.limit = slice.Limit
+	}
+	return it
+}
+
+func (it *dbIterator) getForward() *badger.Iterator {
+	if it.reverse != nil {
+		it.reverse.Close()
+		it.reverse = nil
+	}
+	if it.forward == nil {
+		it.forward = it.txn.NewIterator(badger.DefaultIteratorOptions)
+	}
+	return it.forward
+}
+
+func (it *dbIterator) getReverse() *badger.Iterator {
+	if it.forward != nil {
+		it.forward.Close()
+		it.forward = nil
+	}
+	if it.reverse == nil {
+		opts := badger.DefaultIteratorOptions
+		opts.Reverse = true
+		it.reverse = it.txn.NewIterator(opts)
+	}
+	return it.reverse
+}
+
+// load copies the item under the active badger iterator if it is inside the range.
+func (it *dbIterator) load() bool {
+	it.key, it.value = nil, nil
+	if it.cur == nil || !it.cur.Valid() {
+		return false
+	}
+	item := it.cur.Item()
+	key := item.KeyCopy(nil)
+	if it.start != nil && bytes.Compare(key, it.start) < 0 {
+		return false
+	}
+	if it.limit != nil && bytes.Compare(key, it.limit) >= 0 {
+		return false
+	}
+	value, err := item.ValueCopy(nil)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.key, it.value = key, value
+	return true
+}
+
+func (it *dbIterator) First() bool {
+	if it.Released() {
+		it.err = iterator.ErrIterReleased
+		return false
+	}
+	it.cur = it.getForward()
+	if it.start != nil {
+		it.cur.Seek(it.start)
+	} else {
+		it.cur.Rewind()
+	}
+	return it.load()
+}
+
+func (it *dbIterator) Last() bool {
+	if it.Released() {
+		it.err = iterator.ErrIterReleased
+		return false
+	}
+	it.cur = it.getReverse()
+	if it.limit != nil {
+		it.cur.Seek(it.limit)
+		if it.cur.Valid() && bytes.Equal(it.cur.Item().Key(), it.limit) {
+			it.cur.Next()
+		}
+	} else {
+		it.cur.Rewind()
+	}
+	return it.load()
+}
+
+func (it *dbIterator) Seek(key []byte) bool {
+	if it.Released() {
+		it.err = iterator.ErrIterReleased
+		return false
+	}
+	if it.start != nil && bytes.Compare(key, it.start) < 0 {
+		key = it.start
+	}
+	it.cur = it.getForward()
+	it.cur.Seek(key)
+	return it.load()
+}
+
+func (it *dbIterator) Next() bool {
+	if it.Released() {
+		it.err = iterator.ErrIterReleased
+		return false
+	}
+	if it.cur == nil {
+		return it.First()
+	}
+	if it.key == nil {
+		return false
+	}
+	if it.cur == it.reverse {
+		key := it.key
+		it.cur = it.getForward()
+		it.cur.Seek(key)
+		if it.cur.Valid() && bytes.Equal(it.cur.Item().Key(), key) {
+			it.cur.Next()
+		}
+		return it.load()
+	}
+	it.cur.Next()
+	return it.load()
+}
+
+func (it *dbIterator) Prev() bool {
+	if it.Released() {
+		it.err = iterator.ErrIterReleased
+		return false
+	}
+	if it.cur == nil {
+		return it.Last()
+	}
+	if it.key == nil {
+		return false
+	}
+	if it.cur == it.forward {
+		key := it.key
+		it.cur = it.getReverse()
+		it.cur.Seek(key)
+		if it.cur.Valid() && bytes.Equal(it.cur.Item().Key(), key) {
+			it.cur.Next()
+		}
+		return it.load()
+	}
+	it.cur.Next()
+	return it.load()
+}
+
+func (it *dbIterator) Key() []byte {
+	return it.key
+}
+
+func (it *dbIterator) Value() []byte {
+	return it.value
+}
+
+func (it *dbIterator) Valid() bool {
+	return it.key != nil
+}
+
+func (it *dbIterator) Error() error {
+	return it.err
+}
+
+// Release closes the badger iterators and discards the read transaction unless it is
+// shared with a storeTransaction. It is safe to call Release more than once.
+func (it *dbIterator) Release() {
+	if it.Released() {
+		return
+	}
+	if it.forward != nil {
+		it.forward.Close()
+	}
+	if it.reverse != nil {
+		it.reverse.Close()
+	}
+	it.forward, it.reverse, it.cur = nil, nil, nil
+	it.key, it.value = nil, nil
+	if !it.shared {
+		it.txn.Discard()
+	}
+	it.BasicReleaser.Release()
+}
@@ -0,0 +1,111 @@
+package badgerdb
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dgraph-io/badger"
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+func openTestStore(t *testing.T) (*store, func()) {
+	return openTestStoreWithOptions(t, newOptions)
+}
+
+func openTestStoreWithOptions(t *testing.T, options func(dbPath string) badger.Options) (*store, func()) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_badger_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %+v", err)
+	}
+	bdb, err := badger.Open(options(dbPath))
+	if err != nil {
+		t.Fatalf("could not open db path: %s, %+v", dbPath, err)
+	}
+	return &store{bdb: bdb}, func() {
+		bdb.Close()
+		os.RemoveAll(dbPath)
+	}
+}
+
+func TestStore_GetPutDelete(t *testing.T) {
+	s, closeFn := openTestStore(t)
+	defer closeFn()
+
+	_, err := s.Get([]byte("a"), nil)
+	assert.Equal(t, lvdberr.ErrNotFound, err)
+
+	assert.Nil(t, s.Put([]byte("a"), []byte("1"), nil))
+	value, err := s.Get([]byte("a"), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("1"), value)
+	has, err := s.Has([]byte("a"), nil)
+	assert.Nil(t, err)
+	assert.True(t, has)
+
+	assert.Nil(t, s.Delete([]byte("a"), nil))
+	assert.Nil(t, s.Delete([]byte("b"), nil))
+	has, err = s.Has([]byte("a"), nil)
+	assert.Nil(t, err)
+	assert.False(t, has)
+}
+
+func TestStore_Write(t *testing.T) {
+	s, closeFn := openTestStore(t)
+	defer closeFn()
+
+	assert.Nil(t, s.Put([]byte("c"), []byte("3"), nil))
+	batch := new(leveldb.Batch)
+	batch.Put([]byte("a"), []byte("1"))
+	batch.Put([]byte("b"), []byte("2"))
+	batch.Delete([]byte("c"))
+	assert.Nil(t, s.Write(batch, nil))
+
+	value, err := s.Get([]byte("b"), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("2"), value)
+	_, err = s.Get([]byte("c"), nil)
+	assert.Equal(t, lvdberr.ErrNotFound, err)
+}
+
+func TestStore_NewIterator(t *testing.T) {
+	s, closeFn := openTestStore(t)
+	defer closeFn()
+
+	for _, key := range []string{"a-1", "b-1", "b-2", "b-3", "c-1"} {
+		assert.Nil(t, s.Put([]byte(key), []byte(key), nil))
+	}
+
+	iter := s.NewIterator(util.BytesPrefix([]byte("b-")), nil)
+	keys := []string{}
+	for iter.Next() {
+		keys = append(keys, string(iter.Key()))
+		assert.Equal(t, iter.Key(), iter.Value())
+	}
+	assert.Equal(t, []string{"b-1", "b-2", "b-3"}, keys)
+	assert.Nil(t, iter.Error())
+	iter.Release()
+
+	iter = s.NewIterator(util.BytesPrefix([]byte("b-")), nil)
+	assert.True(t, iter.Last())
+	assert.Equal(t, []byte("b-3"), iter.Key())
+	assert.True(t, iter.Prev())
+	assert.Equal(t, []byte("b-2"), iter.Key())
+	assert.True(t, iter.Next())
+	assert.Equal(t, []byte("b-3"), iter.Key())
+	assert.False(t, iter.Next())
+	assert.True(t, iter.Seek([]byte("a")))
+	assert.Equal(t, []byte("b-1"), iter.Key())
+	assert.False(t, iter.Prev())
+	iter.Release()
+	iter.Release()
+	assert.False(t, iter.First())
+
+	iter = s.NewIterator(util.BytesPrefix([]byte("d-")), nil)
+	assert.False(t, iter.Last())
+	assert.False(t, iter.Next())
+	iter.Release()
+}
@@ -0,0 +1,86 @@
+package badgerdb
+
+import (
+	"github.com/dgraph-io/badger"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// storeTransaction implements lvdb.StoreTransaction on top of a read-write badger transaction.
+// Badger allows a single open iterator per read-write transaction, so iterators must be
+// released before the next one is created.
+// Badger keeps every write of the transaction in memory until Commit and rejects the writes over
+// the limits set by maxTableSize with badger.ErrTxnTooBig, the transaction must be discarded then.
+type storeTransaction struct {
+	bdb *badger.DB
+	txn *badger.Txn
+}
+
+func (s *store) OpenStoreTransaction() (lvdb.StoreTransaction, error) {
+	return &storeTransaction{bdb: s.bdb, txn: s.bdb.NewTransaction(true)}, nil
+}
+
+// checkTxnSize explains badger.ErrTxnTooBig with the limits of the transaction
+func (tr *storeTransaction) checkTxnSize(err error) error {
+	if err == badger.ErrTxnTooBig {
+		return errors.Wrapf(err, "badger transaction holds less than %d writes and %d bytes", tr.bdb.MaxBatchCount(), tr.bdb.MaxBatchSize())
+	}
+	return err
+}
+
+func (tr *storeTransaction) Get(key []byte, ro *opt.ReadOptions) ([]byte, error) {
+	item, err := tr.txn.Get(key)
+	if err == badger.ErrKeyNotFound || err == badger.ErrEmptyKey {
+		return nil, lvdberr.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return item.ValueCopy(nil)
+}
+
+func (tr *storeTransaction) Has(key []byte, ro *opt.ReadOptions) (bool, error) {
+	_, err := tr.Get(key, ro)
+	if err == lvdberr.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (tr *storeTransaction) Put(key, value []byte, wo *opt.WriteOptions) error {
+	return tr.checkTxnSize(tr.txn.Set(key, value))
+}
+
+func (tr *storeTransaction) Delete(key []byte, wo *opt.WriteOptions) error {
+	return tr.checkTxnSize(tr.txn.Delete(key))
+}
+
+func (tr *storeTransaction) Write(batch *leveldb.Batch, wo *opt.WriteOptions) error {
+	replay := &batchReplay{txn: tr.txn}
+	if err := batch.Replay(replay); err != nil {
+		return err
+	}
+	return tr.checkTxnSize(replay.err)
+}
+
+func (tr *storeTransaction) NewIterator(slice *util.Range, ro *opt.ReadOptions) iterator.Iterator {
+	it := newIterator(tr.txn, slice)
+	it.shared = true
+	return it
+}
+
+func (tr *storeTransaction) Commit() error {
+	return tr.txn.Commit()
+}
+
+func (tr *storeTransaction) Discard() {
+	tr.txn.Discard()
+}
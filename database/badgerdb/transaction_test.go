@@ -0,0 +1,65 @@
+package badgerdb
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/dgraph-io/badger"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+)
+
+func testKey(i int) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(i))
+	return key
+}
+
+func TestStoreTransaction_Limit(t *testing.T) {
+	s, closeFn := openTestStore(t)
+	defer closeFn()
+	assert.Equal(t, int64(20132659), s.bdb.MaxBatchSize())
+	assert.Equal(t, int64(209715), s.bdb.MaxBatchCount())
+
+	// more writes than the default limit of 104857 writes fit in one transaction
+	tr, err := s.OpenStoreTransaction()
+	assert.Nil(t, err)
+	batch := new(leveldb.Batch)
+	for i := 0; i < 150000; i++ {
+		batch.Put(testKey(i), []byte{1})
+	}
+	assert.Nil(t, tr.Write(batch, nil))
+	assert.Nil(t, tr.Commit())
+	value, err := s.Get(testKey(149999), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1}, value)
+}
+
+func TestStoreTransaction_TooBig(t *testing.T) {
+	s, closeFn := openTestStoreWithOptions(t, func(dbPath string) badger.Options {
+		return newOptions(dbPath).WithMaxTableSize(1 << 20)
+	})
+	defer closeFn()
+
+	tr, err := s.OpenStoreTransaction()
+	assert.Nil(t, err)
+	for i := 0; ; i++ {
+		err = tr.Put(testKey(i), []byte{1}, nil)
+		if err != nil {
+			assert.True(t, int64(i) < s.bdb.MaxBatchCount())
+			break
+		}
+	}
+	assert.Equal(t, badger.ErrTxnTooBig, errors.Cause(err))
+	assert.Contains(t, err.Error(), "badger transaction holds less than 1638 writes and 157286 bytes")
+	batch := new(leveldb.Batch)
+	batch.Put([]byte("a"), []byte{1})
+	assert.Equal(t, badger.ErrTxnTooBig, errors.Cause(tr.Write(batch, nil)))
+
+	// nothing is written when the transaction is discarded
+	tr.Discard()
+	_, err = s.Get(testKey(0), nil)
+	assert.Equal(t, lvdberr.ErrNotFound, err)
+}
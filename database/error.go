@@ -14,6 +14,8 @@ const (
 	OpenDbErr
 	NotExistValue
 	LvDbNotFound
+	BeginTransactionError
+	CommitTransactionError
 
 	// BlockChain err
 	NotImplHashMethod
@@ -74,6 +76,9 @@ const (
 	HasShardCommitteeByHeightError
 	StoreAutoStakingByHeightError
 	FetchAutoStakingByHeightError
+	StorePendingBlockCommitError
+	DeletePendingBlockCommitError
+	FetchPendingBlockCommitsError
 
 	// Bridge
 	BridgeUnexpectedError
@@ -87,6 +92,8 @@ const (
 	// slash
 	GetProducersBlackListError
 	StoreProducersBlackListError
+	GetSlashedEquivocationError
+	StoreSlashedEquivocationError
 
 	// pde
 	GetWaitingPDEContributionByPairIDError
@@ -103,6 +110,39 @@ const (
 	DeduceShareError
 	TrackPDEStatusError
 	GetPDEStatusError
+
+	// schema
+	GetSchemaVersionError
+	StoreSchemaVersionError
+	UnknownSchemaVersionError
+	OutdatedSchemaVersionError
+	RegisterMigrationError
+	MigrateSchemaError
+
+	// prune
+	DeleteBlockBodyError
+	DeletePDEStateByHeightError
+	StorePrunedHeightError
+	GetPrunedHeightError
+
+	// snapshot
+	ExportSnapshotError
+	ImportSnapshotError
+	VerifySnapshotError
+	IncompleteSnapshotImportError
+
+	// integrity
+	ListCommitmentTokenIDsError
+	ForEachTransactionIndexError
+
+	// metadata tx index
+	StoreMetadataTxIndexError
+	DeleteMetadataTxIndexError
+	ListMetadataTxIndicesError
+
+	// cursor
+	InvalidCursorError
+	IterateByCursorError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -114,9 +154,11 @@ var ErrCodeMessage = map[int]struct {
 	DriverNotRegisterErr: {-1001, "Driver is not registered"},
 
 	// -2xxx levelDb
-	OpenDbErr:     {-2000, "Open database error"},
-	NotExistValue: {-2001, "H is not existed"},
-	LvDbNotFound:  {-2002, "lvdb not found"},
+	OpenDbErr:              {-2000, "Open database error"},
+	NotExistValue:          {-2001, "H is not existed"},
+	LvDbNotFound:           {-2002, "lvdb not found"},
+	BeginTransactionError:  {-2003, "Begin transaction error"},
+	CommitTransactionError: {-2004, "Commit transaction error"},
 
 	// -3xxx blockchain
 	NotImplHashMethod: {-3000, "Data does not implement Hash() method"},
@@ -177,6 +219,9 @@ var ErrCodeMessage = map[int]struct {
 	HasShardCommitteeByHeightError:    {-9019, "Has committee shard by height error"},
 	StoreAutoStakingByHeightError:     {-9020, "Store Auto Staking By Height Error"},
 	FetchAutoStakingByHeightError:     {-9021, "Fetch Auto Staking By Height Error"},
+	StorePendingBlockCommitError:      {-9022, "Store pending block commit error"},
+	DeletePendingBlockCommitError:     {-9023, "Delete pending block commit error"},
+	FetchPendingBlockCommitsError:     {-9024, "Fetch pending block commits error"},
 
 	// -10xxx bridge
 	BridgeUnexpectedError:      {-10000, "Insert ETH tx hash issued error"},
@@ -188,8 +233,10 @@ var ErrCodeMessage = map[int]struct {
 	RemoveCommitteeRewardError: {-11001, "Remove committee reward error"},
 
 	// -12xxx Slash
-	GetProducersBlackListError:   {-12000, "Get producers black list error"},
-	StoreProducersBlackListError: {-12001, "Store producers black list error"},
+	GetProducersBlackListError:    {-12000, "Get producers black list error"},
+	StoreProducersBlackListError:  {-12001, "Store producers black list error"},
+	GetSlashedEquivocationError:   {-12002, "Get slashed equivocation error"},
+	StoreSlashedEquivocationError: {-12003, "Store slashed equivocation error"},
 
 	// -13xxx PDE
 	GetWaitingPDEContributionByPairIDError: {-13001, "Get waiting pde contribution by pair id error"},
@@ -206,6 +253,39 @@ var ErrCodeMessage = map[int]struct {
 	DeduceShareError:                       {-13012, "Deduce share error"},
 	TrackPDEStatusError:                    {-13013, "Track pde status error"},
 	GetPDEStatusError:                      {-13014, "Get pde status error"},
+
+	// -14xxx schema
+	GetSchemaVersionError:      {-14000, "Get schema version error"},
+	StoreSchemaVersionError:    {-14001, "Store schema version error"},
+	UnknownSchemaVersionError:  {-14002, "Unknown schema version"},
+	OutdatedSchemaVersionError: {-14003, "Outdated schema version, upgrade the database with incognitoctl --cmd upgradedb"},
+	RegisterMigrationError:     {-14004, "Register migration error"},
+	MigrateSchemaError:         {-14005, "Migrate schema error"},
+
+	// -15xxx prune
+	DeleteBlockBodyError:        {-15000, "Delete block body error"},
+	DeletePDEStateByHeightError: {-15001, "Delete pde state by beacon height error"},
+	StorePrunedHeightError:      {-15002, "Store pruned height error"},
+	GetPrunedHeightError:        {-15003, "Get pruned height error"},
+
+	// -16xxx snapshot
+	ExportSnapshotError:           {-16000, "Export snapshot error"},
+	ImportSnapshotError:           {-16001, "Import snapshot error"},
+	VerifySnapshotError:           {-16002, "Verify snapshot error"},
+	IncompleteSnapshotImportError: {-16003, "Incomplete snapshot import, remove the database and import the snapshot again"},
+
+	// -17xxx integrity
+	ListCommitmentTokenIDsError:  {-17000, "List commitment token ids error"},
+	ForEachTransactionIndexError: {-17001, "Iterate transaction indices error"},
+
+	// -18xxx metadata tx index
+	StoreMetadataTxIndexError:  {-18000, "Store metadata tx index error"},
+	DeleteMetadataTxIndexError: {-18001, "Delete metadata tx index error"},
+	ListMetadataTxIndicesError: {-18002, "List metadata tx indices error"},
+
+	// -19xxx cursor
+	InvalidCursorError:   {-19000, "Invalid cursor"},
+	IterateByCursorError: {-19001, "Iterate by cursor error"},
 }
 
 type DatabaseError struct {
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"io"
 	"math/big"
 
 	"github.com/incognitochain/incognito-chain/common"
@@ -11,8 +12,16 @@ type BatchData struct {
 	Value []byte
 }
 
-// DatabaseInterface provides the interface that is used to store blocks, txs, or any data of Incognito network.
-type DatabaseInterface interface {
+// BlockCommit records a block whose data is being committed to the database.
+type BlockCommit struct {
+	IsBeacon bool
+	ShardID  byte
+	Height   uint64
+	Hash     common.Hash
+}
+
+// KeyValueStore provides raw access to the key-value storage of a backend.
+type KeyValueStore interface {
 	// basic function
 	Put(key, value []byte) error
 	PutBatch(data []BatchData) error
@@ -21,35 +30,24 @@ type DatabaseInterface interface {
 	HasValue(key []byte) (bool, error)
 	Close() error
 
+	// Transaction
+	BeginTransaction() (Transaction, error)
+}
+
+// BlockStore stores shard and beacon blocks and indexes them by height.
+type BlockStore interface {
 	// Process on Block data
 	StoreShardBlock(v interface{}, hash common.Hash, shardID byte, bd *[]BatchData) error
 	FetchBlock(hash common.Hash) ([]byte, error)
 	HasBlock(hash common.Hash) (bool, error)
 	DeleteBlock(hash common.Hash, idx uint64, shardID byte) error
 
-	// Process on Incomming Cross shard data
-	StoreIncomingCrossShard(shardID byte, crossShardID byte, blkHeight uint64, crossBlkHash common.Hash, bd *[]BatchData) error
-	HasIncomingCrossShard(shardID byte, crossShardID byte, crossBlkHash common.Hash) error
-	GetIncomingCrossShard(shardID byte, crossShardID byte, crossBlkHash common.Hash) (uint64, error)
-	DeleteIncomingCrossShard(shardID byte, crossShardID byte, crossBlkHash common.Hash) error
-
-	// Process on Shard -> Beacon
-	StoreAcceptedShardToBeacon(shardID byte, blkHeight uint64, shardBlkHash common.Hash) error
-	HasAcceptedShardToBeacon(shardID byte, shardBlkHash common.Hash) error
-	GetAcceptedShardToBeacon(shardID byte, shardBlkHash common.Hash) (uint64, error)
-	DeleteAcceptedShardToBeacon(shardID byte, shardBlkHash common.Hash) error
-
 	// Beacon
 	StoreBeaconBlock(v interface{}, hash common.Hash, bd *[]BatchData) error
 	FetchBeaconBlock(hash common.Hash) ([]byte, error)
 	HasBeaconBlock(hash common.Hash) (bool, error)
 	DeleteBeaconBlock(hash common.Hash, idx uint64) error
 
-	//Crossshard
-	StoreCrossShardNextHeight(fromShard byte, toShard byte, curHeight uint64, nextHeight uint64) error
-	FetchCrossShardNextHeight(fromShard, toShard byte, curHeight uint64) (uint64, error)
-	RestoreCrossShardNextHeights(fromShard byte, toShard byte, curHeight uint64) error
-
 	// Block index
 	StoreShardBlockIndex(hash common.Hash, idx uint64, shardID byte, bd *[]BatchData) error
 	GetIndexOfBlock(hash common.Hash) (uint64, byte, error)
@@ -59,12 +57,30 @@ type DatabaseInterface interface {
 	StoreBeaconBlockIndex(hash common.Hash, idx uint64) error
 	GetIndexOfBeaconBlock(hash common.Hash) (uint64, error)
 	GetBeaconBlockHashByIndex(idx uint64) (common.Hash, error)
+}
 
-	// Transaction index
-	StoreTransactionIndex(txId common.Hash, blockHash common.Hash, indexInBlock int, bd *[]BatchData) error
-	GetTransactionIndexById(txId common.Hash) (common.Hash, int, error)
-	DeleteTransactionIndex(txId common.Hash) error
+// CrossShardStore keeps track of cross shard and shard to beacon blocks which have been processed.
+type CrossShardStore interface {
+	// Process on Incomming Cross shard data
+	StoreIncomingCrossShard(shardID byte, crossShardID byte, blkHeight uint64, crossBlkHash common.Hash, bd *[]BatchData) error
+	HasIncomingCrossShard(shardID byte, crossShardID byte, crossBlkHash common.Hash) error
+	GetIncomingCrossShard(shardID byte, crossShardID byte, crossBlkHash common.Hash) (uint64, error)
+	DeleteIncomingCrossShard(shardID byte, crossShardID byte, crossBlkHash common.Hash) error
+
+	// Process on Shard -> Beacon
+	StoreAcceptedShardToBeacon(shardID byte, blkHeight uint64, shardBlkHash common.Hash) error
+	HasAcceptedShardToBeacon(shardID byte, shardBlkHash common.Hash) error
+	GetAcceptedShardToBeacon(shardID byte, shardBlkHash common.Hash) (uint64, error)
+	DeleteAcceptedShardToBeacon(shardID byte, shardBlkHash common.Hash) error
+
+	//Crossshard
+	StoreCrossShardNextHeight(fromShard byte, toShard byte, curHeight uint64, nextHeight uint64) error
+	FetchCrossShardNextHeight(fromShard, toShard byte, curHeight uint64) (uint64, error)
+	RestoreCrossShardNextHeights(fromShard byte, toShard byte, curHeight uint64) error
+}
 
+// BestStateStore stores the best states of shard and beacon chains and their backups.
+type BestStateStore interface {
 	// Best state of Prev
 	StorePrevBestState(val []byte, isBeacon bool, shardID byte) error
 	FetchPrevBestState(isBeacon bool, shardID byte) ([]byte, error)
@@ -80,6 +96,14 @@ type DatabaseInterface interface {
 	FetchBeaconBestState() ([]byte, error)
 	CleanBeaconBestState() error
 
+	// Block commit
+	StorePendingBlockCommit(blockCommit BlockCommit) error
+	DeletePendingBlockCommit(isBeacon bool, shardID byte) error
+	FetchPendingBlockCommits() ([]BlockCommit, error)
+}
+
+// CommitteeStore stores committees, reward receivers and auto staking lists by beacon height.
+type CommitteeStore interface {
 	// Commitee with epoch
 	StoreShardCommitteeByHeight(height uint64, v interface{}) error
 	StoreRewardReceiverByHeight(height uint64, v interface{}) error
@@ -91,7 +115,10 @@ type DatabaseInterface interface {
 	FetchBeaconCommitteeByHeight(height uint64) ([]byte, error)
 	FetchAutoStakingByHeight(height uint64) ([]byte, error)
 	HasShardCommitteeByHeight(height uint64) (bool, error)
+}
 
+// CoinStore stores serial numbers, commitments, output coins and SNDerivators of every token.
+type CoinStore interface {
 	// SerialNumber
 	StoreSerialNumbers(tokenID common.Hash, serialNumber [][]byte, shardID byte) error
 	HasSerialNumber(tokenID common.Hash, data []byte, shardID byte) (bool, error)
@@ -121,16 +148,30 @@ type DatabaseInterface interface {
 	HasSNDerivator(tokenID common.Hash, data []byte) (bool, error)
 	CleanSNDerivator() error
 	ListSNDerivator(tokenID common.Hash) ([][]byte, error)
+}
+
+// TransactionStore indexes transactions by hash and by public key.
+type TransactionStore interface {
+	// Transaction index
+	StoreTransactionIndex(txId common.Hash, blockHash common.Hash, indexInBlock int, bd *[]BatchData) error
+	GetTransactionIndexById(txId common.Hash) (common.Hash, int, error)
+	DeleteTransactionIndex(txId common.Hash) error
 
 	// Tx for Public key
 	StoreTxByPublicKey(publicKey []byte, txID common.Hash, shardID byte) error
 	GetTxByPublicKey(publicKey []byte) (map[byte][]common.Hash, error)
+}
 
+// FeeEstimatorStore persists the state of the fee estimator of each shard.
+type FeeEstimatorStore interface {
 	// Fee estimator
 	StoreFeeEstimator(val []byte, shardID byte) error
 	GetFeeEstimator(shardID byte) ([]byte, error)
 	CleanFeeEstimator() error
+}
 
+// TokenStore stores normal tokens, privacy tokens and their transactions.
+type TokenStore interface {
 	// Normal token
 	StoreNormalToken(tokenID common.Hash, data []byte) error // store normal token. Param: tokenID, txInitToken-id, data tx
 	DeleteNormalToken(tokenID common.Hash) error
@@ -156,7 +197,10 @@ type DatabaseInterface interface {
 	ListPrivacyTokenCrossShard() ([][]byte, error)
 	PrivacyTokenIDCrossShardExisted(tokenID common.Hash) bool
 	DeletePrivacyTokenCrossShard(tokenID common.Hash) error
+}
 
+// BridgeStore stores centralized and decentralized bridge data and Incognito -> Ethereum burning confirms.
+type BridgeStore interface {
 	// Centralized bridge
 	BackupBridgedTokenByTokenID(tokenID common.Hash) error
 	RestoreBridgedTokenByTokenID(tokenID common.Hash) error
@@ -175,7 +219,10 @@ type DatabaseInterface interface {
 	GetAllBridgeTokens() ([]byte, error)
 	TrackBridgeReqWithStatus(txReqID common.Hash, status byte, bd *[]BatchData) error
 	GetBridgeReqWithStatus(txReqID common.Hash) (byte, error)
+}
 
+// RewardStore stores block rewards of shards and committee members.
+type RewardStore interface {
 	// Block reward
 	AddShardRewardRequest(epoch uint64, shardID byte, amount uint64, tokenID common.Hash, bd *[]BatchData) error
 	GetRewardOfShardByEpoch(epoch uint64, shardID byte, tokenID common.Hash) (uint64, error)
@@ -188,11 +235,19 @@ type DatabaseInterface interface {
 	BackupCommitteeReward(committeeAddress []byte, tokenID common.Hash) error        //shard
 	RestoreShardRewardRequest(epoch uint64, shardID byte, tokenID common.Hash) error //beacon
 	RestoreCommitteeReward(committeeAddress []byte, tokenID common.Hash) error       //shard
+}
 
+// SlashStore stores the black list of producers and the equivocations which have been slashed.
+type SlashStore interface {
 	// slash
 	GetProducersBlackList(beaconHeight uint64) (map[string]uint8, error)
 	StoreProducersBlackList(beaconHeight uint64, producersBlackList map[string]uint8) error
+	StoreSlashedEquivocation(chainID int, committeePublicKey string, height uint64, round int, beaconHeight uint64) error
+	GetSlashedEquivocation(chainID int, committeePublicKey string, height uint64, round int) (uint64, bool, error)
+}
 
+// PDEStore stores the state of the pDEX: contributions, pools, shares, trade fees and request statuses.
+type PDEStore interface {
 	// pde
 	DeleteWaitingPDEContributionByPairID(beaconHeight uint64, pairID string) error
 	ContributeToPDE(beaconHeight uint64, pairID string, contributorAddressStr string, tokenIDStr string, contributedAmount uint64) error
@@ -210,3 +265,121 @@ type DatabaseInterface interface {
 	TrackPDEContributionStatus(prefix []byte, suffix []byte, statusContent []byte) error
 	GetPDEContributionStatus(prefix []byte, suffix []byte) ([]byte, error)
 }
+
+// SchemaStore records the version of the key layout used by the stored data.
+type SchemaStore interface {
+	GetSchemaVersion() (uint32, error)
+	CheckSchemaVersion() error
+	UpgradeSchema() error
+}
+
+// PruneStore deletes data which is no longer needed to validate new blocks.
+// Commitments, serial numbers and SND derivators are never pruned.
+type PruneStore interface {
+	DeleteBlockBody(hash common.Hash) error
+	DeletePDEStateByHeight(beaconHeight uint64) error
+	StorePrunedHeight(isBeacon bool, shardID byte, height uint64) error
+	GetPrunedHeight(isBeacon bool, shardID byte) (uint64, error)
+}
+
+// SnapshotSection is the number of records and the hash of one part of the chain state in a snapshot.
+type SnapshotSection struct {
+	Name    string
+	Records uint64
+	Hash    common.Hash
+}
+
+// SnapshotStore exports the chain state without the history of blocks and imports it into an empty database.
+type SnapshotStore interface {
+	ExportSnapshot(writer io.Writer, blockHashes []common.Hash) ([]SnapshotSection, error)
+	ImportSnapshot(reader io.Reader, size int64, schemaVersion uint32, sections []SnapshotSection, verify func() error) error
+}
+
+// IntegrityStore lists the records which are checked by the database integrity checker.
+type IntegrityStore interface {
+	ListCommitmentTokenIDs(shardID byte) ([]common.Hash, error)
+	ForEachTransactionIndex(fn func(txID common.Hash, blockHash common.Hash, indexInBlock int) error) error
+}
+
+// MetadataTxIndex locates a transaction with metadata in a shard block.
+type MetadataTxIndex struct {
+	Height  uint64
+	TxIndex int
+	TxHash  common.Hash
+}
+
+// MetadataTxIndexStore indexes the transactions of shard blocks by metadata type, shard and height.
+type MetadataTxIndexStore interface {
+	StoreMetadataTxIndex(metaType int, shardID byte, height uint64, txIndex int, txHash common.Hash) error
+	DeleteMetadataTxIndex(metaType int, shardID byte, height uint64, txIndex int) error
+	ListMetadataTxIndices(metaType int, shardID byte, fromHeight uint64, fromTxIndex int, toHeight uint64, limit int) ([]MetadataTxIndex, error)
+}
+
+// SerialNumberItem is a serial number with its index in the list of its token and shard.
+type SerialNumberItem struct {
+	SerialNumber []byte
+	Index        uint64
+}
+
+// CommitmentItem is a commitment with its index in the list of its token and shard.
+type CommitmentItem struct {
+	Commitment []byte
+	Index      uint64
+}
+
+// CommitteeRewardItem is the reward in one token of a committee member.
+type CommitteeRewardItem struct {
+	PublicKey []byte
+	TokenID   common.Hash
+	Amount    uint64
+}
+
+// TokenHolderBalance is the unspent balance of a payment address (base58 check encoded) in a normal token.
+type TokenHolderBalance struct {
+	PaymentAddress string
+	Balance        uint64
+}
+
+// CursorStore lists large sets of records page by page, in key order.
+// cursor is the continuation token returned with the previous page, empty for the first page.
+// The continuation token returned with the last page is empty.
+type CursorStore interface {
+	ListSerialNumberByCursor(tokenID common.Hash, shardID byte, cursor []byte, limit int) ([]SerialNumberItem, []byte, error)
+	ListCommitmentByCursor(tokenID common.Hash, shardID byte, cursor []byte, limit int) ([]CommitmentItem, []byte, error)
+	ListCommitmentIndicesByCursor(tokenID common.Hash, shardID byte, cursor []byte, limit int) ([]CommitmentItem, []byte, error)
+	ListSNDerivatorByCursor(tokenID common.Hash, cursor []byte, limit int) ([][]byte, []byte, error)
+	ListCommitteeRewardByCursor(cursor []byte, limit int) ([]CommitteeRewardItem, []byte, error)
+	GetNormalTokenPaymentAddressesBalanceByCursor(tokenID common.Hash, cursor []byte, limit int) ([]TokenHolderBalance, []byte, error)
+}
+
+// DatabaseInterface provides the interface that is used to store blocks, txs, or any data of Incognito network.
+// It is composed of the per-domain store interfaces so that consumers can depend only on what they use.
+type DatabaseInterface interface {
+	KeyValueStore
+	BlockStore
+	CrossShardStore
+	BestStateStore
+	CommitteeStore
+	CoinStore
+	TransactionStore
+	FeeEstimatorStore
+	TokenStore
+	BridgeStore
+	RewardStore
+	SlashStore
+	PDEStore
+	SchemaStore
+	PruneStore
+	SnapshotStore
+	IntegrityStore
+	MetadataTxIndexStore
+	CursorStore
+}
+
+// Transaction is a DatabaseInterface whose writes are kept pending and applied atomically on Commit.
+// Reads through a Transaction see its own pending writes.
+type Transaction interface {
+	DatabaseInterface
+	Commit() error
+	Discard()
+}
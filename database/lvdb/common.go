@@ -19,6 +19,10 @@ var (
 	nextCrossShardKeyPrefix  = []byte("ncsh-")
 	shardPrefix              = []byte("shd-")
 	autoStakingPrefix        = []byte("aust-")
+	blockCommitPrefix        = []byte("blkcommit-")
+	schemaVersionKey         = []byte("schemaversion")
+	prunedHeightPrefix       = []byte("pruned-")
+	snapshotImportKey        = []byte("snapshotimport")
 
 	shardToBeaconKeyPrefix       = []byte("stb-")
 	transactionKeyPrefix         = []byte("tx-")
@@ -34,6 +38,7 @@ var (
 	privacyTokenCrossShardPrefix = []byte("privacy-cross-token-")
 	tokenInitPrefix              = []byte("token-init-")
 	privacyTokenInitPrefix       = []byte("privacy-token-init-")
+	metadataTxIndexPrefix        = []byte("metatx-")
 
 	// multisigs
 	multisigsPrefix = []byte("multisigs")
@@ -56,7 +61,8 @@ var (
 	Splitter                  = []byte("-[-]-")
 
 	// slash
-	producersBlackListPrefix = []byte("producersblacklist-")
+	producersBlackListPrefix  = []byte("producersblacklist-")
+	slashedEquivocationPrefix = []byte("slashedequivocation-")
 
 	// PDE
 	WaitingPDEContributionPrefix = []byte("waitingpdecontribution-")
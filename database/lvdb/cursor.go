@@ -0,0 +1,217 @@
+package lvdb
+
+import (
+	"bytes"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// iterateByCursor calls fn with the records with prefix in key order, starting from the record with key cursor,
+// or from the first record if cursor is empty. When fn returns true the iteration stops before the record,
+// its key is returned as the cursor of the next call. The returned cursor is empty once every record has been visited.
+// fn must copy the key and the value to keep them.
+func (db *db) iterateByCursor(prefix []byte, cursor []byte, limit int, fn func(key []byte, value []byte) (bool, error)) ([]byte, error) {
+	if limit <= 0 {
+		return nil, database.NewDatabaseError(database.InvalidCursorError, errors.Errorf("limit %d is not positive", limit))
+	}
+	start := prefix
+	if len(cursor) > 0 {
+		if !bytes.HasPrefix(cursor, prefix) {
+			return nil, database.NewDatabaseError(database.InvalidCursorError, errors.Errorf("cursor %x is not a key of the listed records", cursor))
+		}
+		start = cursor
+	}
+	iter := db.lvdb.NewIterator(&util.Range{Start: start, Limit: util.BytesPrefix(prefix).Limit}, nil)
+	defer iter.Release()
+	for iter.Next() {
+		stop, err := fn(iter.Key(), iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		if stop {
+			return append([]byte{}, iter.Key()...), nil
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, database.NewDatabaseError(database.IterateByCursorError, errors.Wrap(err, "iter.Error"))
+	}
+	return nil, nil
+}
+
+// ListSerialNumberByCursor returns at most limit serial numbers of tokenID in shardID, see ListSerialNumber
+func (db *db) ListSerialNumberByCursor(tokenID common.Hash, shardID byte, cursor []byte, limit int) ([]database.SerialNumberItem, []byte, error) {
+	result := []database.SerialNumberItem{}
+	prefix := append(addPrefixToKeyHash(string(serialNumbersPrefix), tokenID), shardID)
+	nextCursor, err := db.iterateByCursor(prefix, cursor, limit, func(key []byte, value []byte) (bool, error) {
+		if len(key) != len(prefix)+privacy.Ed25519KeySize {
+			// last index of the list
+			return false, nil
+		}
+		if len(result) == limit {
+			return true, nil
+		}
+		result = append(result, database.SerialNumberItem{
+			SerialNumber: append([]byte{}, key[len(prefix):]...),
+			Index:        new(big.Int).SetBytes(value).Uint64(),
+		})
+		return false, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nextCursor, nil
+}
+
+// ListCommitmentByCursor returns at most limit commitments of tokenID in shardID, see ListCommitment
+func (db *db) ListCommitmentByCursor(tokenID common.Hash, shardID byte, cursor []byte, limit int) ([]database.CommitmentItem, []byte, error) {
+	result := []database.CommitmentItem{}
+	prefix := append(addPrefixToKeyHash(string(commitmentsPrefix), tokenID), shardID)
+	nextCursor, err := db.iterateByCursor(prefix, cursor, limit, func(key []byte, value []byte) (bool, error) {
+		// skip the records by index and the last index of the list
+		if len(key) != len(prefix)+privacy.Ed25519KeySize {
+			return false, nil
+		}
+		if len(result) == limit {
+			return true, nil
+		}
+		result = append(result, database.CommitmentItem{
+			Commitment: append([]byte{}, key[len(prefix):]...),
+			Index:      new(big.Int).SetBytes(value).Uint64(),
+		})
+		return false, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nextCursor, nil
+}
+
+// ListCommitmentIndicesByCursor returns at most limit commitments of tokenID in shardID with their index, see ListCommitmentIndices.
+// The commitments are in the order of the big endian bytes of their index, not in the order of their index.
+func (db *db) ListCommitmentIndicesByCursor(tokenID common.Hash, shardID byte, cursor []byte, limit int) ([]database.CommitmentItem, []byte, error) {
+	result := []database.CommitmentItem{}
+	prefix := append(addPrefixToKeyHash(string(commitmentsPrefix), tokenID), shardID)
+	nextCursor, err := db.iterateByCursor(prefix, cursor, limit, func(key []byte, value []byte) (bool, error) {
+		// skip the records by commitment and the last index of the list
+		if len(key) == len(prefix)+privacy.Ed25519KeySize || bytes.Equal(key[len(prefix):], []byte("len")) || len(value) != privacy.Ed25519KeySize {
+			return false, nil
+		}
+		if len(result) == limit {
+			return true, nil
+		}
+		result = append(result, database.CommitmentItem{
+			Commitment: append([]byte{}, value...),
+			Index:      new(big.Int).SetBytes(key[len(prefix):]).Uint64(),
+		})
+		return false, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nextCursor, nil
+}
+
+// ListSNDerivatorByCursor returns at most limit SND derivators of tokenID, see ListSNDerivator
+func (db *db) ListSNDerivatorByCursor(tokenID common.Hash, cursor []byte, limit int) ([][]byte, []byte, error) {
+	result := [][]byte{}
+	prefix := addPrefixToKeyHash(string(snderivatorsPrefix), tokenID)
+	nextCursor, err := db.iterateByCursor(prefix, cursor, limit, func(key []byte, value []byte) (bool, error) {
+		if len(result) == limit {
+			return true, nil
+		}
+		result = append(result, append([]byte{}, key[len(prefix):]...))
+		return false, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nextCursor, nil
+}
+
+// ListCommitteeRewardByCursor returns at most limit rewards of committee members, see ListCommitteeReward
+func (db *db) ListCommitteeRewardByCursor(cursor []byte, limit int) ([]database.CommitteeRewardItem, []byte, error) {
+	result := []database.CommitteeRewardItem{}
+	nextCursor, err := db.iterateByCursor(committeeRewardPrefix, cursor, limit, func(key []byte, value []byte) (bool, error) {
+		if len(key) != len(committeeRewardPrefix)+common.PublicKeySize+common.HashSize {
+			return false, nil
+		}
+		if len(result) == limit {
+			return true, nil
+		}
+		amount, err := common.BytesToUint64(value)
+		if err != nil {
+			return false, database.NewDatabaseError(database.IterateByCursorError, errors.Wrapf(err, "committee reward %x", key))
+		}
+		item := database.CommitteeRewardItem{
+			PublicKey: append([]byte{}, key[len(committeeRewardPrefix):len(committeeRewardPrefix)+common.PublicKeySize]...),
+			Amount:    amount,
+		}
+		copy(item.TokenID[:], key[len(key)-common.HashSize:])
+		result = append(result, item)
+		return false, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nextCursor, nil
+}
+
+// GetNormalTokenPaymentAddressesBalanceByCursor returns the balance of at most limit payment addresses holding tokenID,
+// see GetNormalTokenPaymentAddressesBalance. A page never splits the utxos of a payment address.
+func (db *db) GetNormalTokenPaymentAddressesBalanceByCursor(tokenID common.Hash, cursor []byte, limit int) ([]database.TokenHolderBalance, []byte, error) {
+	result := []database.TokenHolderBalance{}
+	prefix := append([]byte{}, TokenPaymentAddressPrefix...)
+	prefix = append(prefix, Splitter...)
+	prefix = append(prefix, []byte(tokenID.String())...)
+	current := database.TokenHolderBalance{}
+	nextCursor, err := db.iterateByCursor(prefix, cursor, limit, func(key []byte, value []byte) (bool, error) {
+		// token-paymentAddress  -[-]-  {tokenId}  -[-]-  {paymentAddress}  -[-]-  {txHash}  -[-]-  {voutIndex}
+		keys := strings.Split(string(key), string(Splitter))
+		values := strings.Split(string(value), string(Splitter))
+		if len(keys) < 3 || len(values) < 2 {
+			return false, database.NewDatabaseError(database.IterateByCursorError, errors.Errorf("token utxo %s has value %s", key, value))
+		}
+		paymentAddress := privacy.PaymentAddress{}
+		paymentAddressInBytes, _, err := base58.Base58Check{}.Decode(keys[2])
+		if err != nil {
+			return false, database.NewDatabaseError(database.IterateByCursorError, errors.Wrapf(err, "token utxo %s", key))
+		}
+		if len(paymentAddressInBytes) < privacy.Ed25519KeySize {
+			return false, database.NewDatabaseError(database.IterateByCursorError, errors.Errorf("token utxo %s has an invalid payment address", key))
+		}
+		paymentAddress.SetBytes(paymentAddressInBytes)
+		paymentAddressStr := base58.Base58Check{}.Encode(paymentAddress.Bytes(), 0x00)
+		if paymentAddressStr != current.PaymentAddress {
+			if current.Balance > 0 {
+				result = append(result, current)
+			}
+			if len(result) == limit {
+				return true, nil
+			}
+			current = database.TokenHolderBalance{PaymentAddress: paymentAddressStr}
+		}
+		if values[1] == string(Unspent) {
+			balance, err := strconv.Atoi(values[0])
+			if err != nil {
+				return false, database.NewDatabaseError(database.IterateByCursorError, errors.Wrapf(err, "token utxo %s", key))
+			}
+			current.Balance += uint64(balance)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(nextCursor) == 0 && current.Balance > 0 {
+		result = append(result, current)
+	}
+	return result, nextCursor, nil
+}
@@ -0,0 +1,212 @@
+package lvdb
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/database"
+)
+
+func testKeys(n int, size int, seed byte) [][]byte {
+	keys := [][]byte{}
+	for i := 0; i < n; i++ {
+		key := bytes.Repeat([]byte{seed}, size)
+		key[size-1] = byte(i)
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func Test_db_ListSerialNumberByCursor(t *testing.T) {
+	target, closeFn := newSnapshotTestDB(t, "Test_db_ListSerialNumberByCursor")
+	defer closeFn()
+	serialNumbers := testKeys(5, 32, 1)
+	if err := target.StoreSerialNumbers(common.PRVCoinID, serialNumbers, 0); err != nil {
+		t.Fatal(err)
+	}
+	// another shard is not listed
+	if err := target.StoreSerialNumbers(common.PRVCoinID, testKeys(3, 32, 2), 1); err != nil {
+		t.Fatal(err)
+	}
+	got := []database.SerialNumberItem{}
+	var cursor []byte
+	for pages := 1; ; pages++ {
+		page, nextCursor, err := target.ListSerialNumberByCursor(common.PRVCoinID, 0, cursor, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) > 2 {
+			t.Fatalf("page has %d serial numbers, limit is 2", len(page))
+		}
+		got = append(got, page...)
+		if len(nextCursor) == 0 {
+			if pages != 3 {
+				t.Errorf("listed %d pages, want 3", pages)
+			}
+			break
+		}
+		cursor = nextCursor
+	}
+	if len(got) != len(serialNumbers) {
+		t.Fatalf("listed %d serial numbers, want %d", len(got), len(serialNumbers))
+	}
+	for i, item := range got {
+		if !bytes.Equal(item.SerialNumber, serialNumbers[i]) || item.Index != uint64(i) {
+			t.Errorf("serial number %d = %x at index %d, want %x at index %d", i, item.SerialNumber, item.Index, serialNumbers[i], i)
+		}
+	}
+
+	if _, _, err := target.ListSerialNumberByCursor(common.PRVCoinID, 0, []byte("other-key"), 2); err == nil {
+		t.Error("ListSerialNumberByCursor() with a cursor outside the list succeeded")
+	}
+	if _, _, err := target.ListSerialNumberByCursor(common.PRVCoinID, 0, nil, 0); err == nil {
+		t.Error("ListSerialNumberByCursor() with limit 0 succeeded")
+	}
+}
+
+func Test_db_ListCommitmentByCursor(t *testing.T) {
+	target, closeFn := newSnapshotTestDB(t, "Test_db_ListCommitmentByCursor")
+	defer closeFn()
+	commitments := testKeys(4, 32, 3)
+	if err := target.StoreCommitments(common.PRVCoinID, []byte("pubkey"), commitments, 0); err != nil {
+		t.Fatal(err)
+	}
+	list := map[string]func(cursor []byte) ([]database.CommitmentItem, []byte, error){
+		"ListCommitmentByCursor": func(cursor []byte) ([]database.CommitmentItem, []byte, error) {
+			return target.ListCommitmentByCursor(common.PRVCoinID, 0, cursor, 3)
+		},
+		"ListCommitmentIndicesByCursor": func(cursor []byte) ([]database.CommitmentItem, []byte, error) {
+			return target.ListCommitmentIndicesByCursor(common.PRVCoinID, 0, cursor, 3)
+		},
+	}
+	for name, listFn := range list {
+		t.Run(name, func(t *testing.T) {
+			got := map[uint64][]byte{}
+			var cursor []byte
+			for {
+				page, nextCursor, err := listFn(cursor)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, item := range page {
+					got[item.Index] = item.Commitment
+				}
+				if len(nextCursor) == 0 {
+					break
+				}
+				cursor = nextCursor
+			}
+			if len(got) != len(commitments) {
+				t.Fatalf("listed %d commitments, want %d", len(got), len(commitments))
+			}
+			for i, commitment := range commitments {
+				if !bytes.Equal(got[uint64(i)], commitment) {
+					t.Errorf("commitment at index %d = %x, want %x", i, got[uint64(i)], commitment)
+				}
+			}
+		})
+	}
+}
+
+func Test_db_ListSNDerivatorByCursor(t *testing.T) {
+	target, closeFn := newSnapshotTestDB(t, "Test_db_ListSNDerivatorByCursor")
+	defer closeFn()
+	snds := testKeys(3, 32, 4)
+	if err := target.StoreSNDerivators(common.PRVCoinID, snds); err != nil {
+		t.Fatal(err)
+	}
+	page, cursor, err := target.ListSNDerivatorByCursor(common.PRVCoinID, nil, 2)
+	if err != nil || len(page) != 2 || len(cursor) == 0 {
+		t.Fatalf("ListSNDerivatorByCursor() = %x, %x, %v", page, cursor, err)
+	}
+	lastPage, cursor, err := target.ListSNDerivatorByCursor(common.PRVCoinID, cursor, 2)
+	if err != nil || len(lastPage) != 1 || len(cursor) != 0 {
+		t.Fatalf("ListSNDerivatorByCursor() last page = %x, %x, %v", lastPage, cursor, err)
+	}
+	for i, snd := range append(page, lastPage...) {
+		if !bytes.Equal(snd, snds[i]) {
+			t.Errorf("snd %d = %x, want %x", i, snd, snds[i])
+		}
+	}
+}
+
+func Test_db_ListCommitteeRewardByCursor(t *testing.T) {
+	target, closeFn := newSnapshotTestDB(t, "Test_db_ListCommitteeRewardByCursor")
+	defer closeFn()
+	publicKeys := testKeys(3, common.PublicKeySize, 5)
+	for i, publicKey := range publicKeys {
+		if err := target.AddCommitteeReward(publicKey, uint64(i+1), common.PRVCoinID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	page, cursor, err := target.ListCommitteeRewardByCursor(nil, 2)
+	if err != nil || len(page) != 2 || len(cursor) == 0 {
+		t.Fatalf("ListCommitteeRewardByCursor() = %+v, %x, %v", page, cursor, err)
+	}
+	lastPage, cursor, err := target.ListCommitteeRewardByCursor(cursor, 2)
+	if err != nil || len(lastPage) != 1 || len(cursor) != 0 {
+		t.Fatalf("ListCommitteeRewardByCursor() last page = %+v, %x, %v", lastPage, cursor, err)
+	}
+	for i, item := range append(page, lastPage...) {
+		if !bytes.Equal(item.PublicKey, publicKeys[i]) || item.Amount != uint64(i+1) || !item.TokenID.IsEqual(&common.PRVCoinID) {
+			t.Errorf("reward %d = %+v", i, item)
+		}
+	}
+}
+
+func Test_db_GetNormalTokenPaymentAddressesBalanceByCursor(t *testing.T) {
+	target, closeFn := newSnapshotTestDB(t, "Test_db_GetNormalTokenPaymentAddressesBalanceByCursor")
+	defer closeFn()
+	tokenID := common.HashH([]byte("token"))
+	putUTXO := func(paymentAddress string, txHash string, amount int, status []byte) {
+		key := append([]byte{}, TokenPaymentAddressPrefix...)
+		for _, part := range []string{tokenID.String(), paymentAddress, txHash, "0"} {
+			key = append(key, Splitter...)
+			key = append(key, part...)
+		}
+		value := strconv.Itoa(amount) + string(Splitter) + string(status) + string(Splitter)
+		if err := target.Put(key, []byte(value)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	paymentAddresses := []string{}
+	for _, seed := range []byte{1, 2, 3} {
+		paymentAddresses = append(paymentAddresses, base58.Base58Check{}.Encode(bytes.Repeat([]byte{seed}, 64), 0x00))
+	}
+	// the first address has two unspent utxos, the second one has only spent utxos
+	putUTXO(paymentAddresses[0], "tx1", 10, Unspent)
+	putUTXO(paymentAddresses[0], "tx2", 5, Unspent)
+	putUTXO(paymentAddresses[1], "tx3", 7, Spent)
+	putUTXO(paymentAddresses[2], "tx4", 3, Unspent)
+
+	got := map[string]uint64{}
+	var cursor []byte
+	for {
+		page, nextCursor, err := target.GetNormalTokenPaymentAddressesBalanceByCursor(tokenID, cursor, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) > 1 {
+			t.Fatalf("page has %d payment addresses, limit is 1", len(page))
+		}
+		for _, item := range page {
+			got[item.PaymentAddress] += item.Balance
+		}
+		if len(nextCursor) == 0 {
+			break
+		}
+		cursor = nextCursor
+	}
+	want := map[string]uint64{paymentAddresses[0]: 15, paymentAddresses[2]: 3}
+	if len(got) != len(want) {
+		t.Fatalf("GetNormalTokenPaymentAddressesBalanceByCursor() = %v, want %v", got, want)
+	}
+	for paymentAddress, balance := range want {
+		if got[paymentAddress] != balance {
+			t.Errorf("balance of %s = %d, want %d", paymentAddress, got[paymentAddress], balance)
+		}
+	}
+}
@@ -7,7 +7,7 @@ import (
 )
 
 type db struct {
-	lvdb *leveldb.DB
+	lvdb Store
 }
 
 func open(dbPath string) (database.DatabaseInterface, error) {
@@ -12,8 +12,10 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/database"
+	_ "github.com/incognitochain/incognito-chain/database/badgerdb"
 	"github.com/incognitochain/incognito-chain/database/lvdb"
 	_ "github.com/incognitochain/incognito-chain/database/lvdb"
+	_ "github.com/incognitochain/incognito-chain/database/memdb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/transaction"
@@ -22,13 +24,22 @@ import (
 
 var db database.DatabaseInterface
 
+// dbType is the registered driver the suite runs against, every driver must pass it.
+// Run e.g. `TEST_DB_TYPE=memdb go test ./database/lvdb/` to check another backend.
+var dbType = func() string {
+	if typ := os.Getenv("TEST_DB_TYPE"); typ != "" {
+		return typ
+	}
+	return "leveldb"
+}()
+
 var _ = func() (_ struct{}) {
 	dbPath, err := ioutil.TempDir(os.TempDir(), "test_")
 	if err != nil {
 		log.Fatalf("failed to create temp dir: %+v", err)
 	}
 	log.Println(dbPath)
-	db, err = database.Open("leveldb", dbPath)
+	db, err = database.Open(dbType, dbPath)
 	if err != nil {
 		log.Fatalf("could not open db path: %s, %+v", dbPath, err)
 	}
@@ -42,7 +53,7 @@ func TestDb_Setup(t *testing.T) {
 		t.Fatalf("failed to create temp dir: %+v", err)
 	}
 	t.Log(dbPath)
-	db, err := database.Open("leveldb", dbPath)
+	db, err := database.Open(dbType, dbPath)
 	if err != nil {
 		t.Fatalf("could not open db path: %s, %+v", dbPath, err)
 	}
@@ -93,6 +104,61 @@ func TestDb_Base(t *testing.T) {
 	}
 }
 
+func TestDb_Transaction(t *testing.T) {
+	if db != nil {
+		tx, err := db.BeginTransaction()
+		assert.Equal(t, nil, err)
+		err = tx.Put([]byte("tx1"), []byte("tx1"))
+		assert.Equal(t, nil, err)
+		v, err := tx.Get([]byte("tx1"))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "tx1", string(v))
+		has, err := db.HasValue([]byte("tx1"))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, false, has)
+		err = tx.Commit()
+		assert.Equal(t, nil, err)
+		tx.Discard()
+		v, err = db.Get([]byte("tx1"))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "tx1", string(v))
+
+		tx, err = db.BeginTransaction()
+		assert.Equal(t, nil, err)
+		err = tx.Put([]byte("tx2"), []byte("tx2"))
+		assert.Equal(t, nil, err)
+		tx.Discard()
+		has, err = db.HasValue([]byte("tx2"))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, false, has)
+	} else {
+		t.Error("DB is not open")
+	}
+}
+
+func TestDb_StorePendingBlockCommit(t *testing.T) {
+	if db != nil {
+		blockCommit := database.BlockCommit{ShardID: 2, Height: 10, Hash: common.HashH([]byte("block"))}
+		err := db.StorePendingBlockCommit(blockCommit)
+		assert.Equal(t, nil, err)
+		blockCommits, err := db.FetchPendingBlockCommits()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []database.BlockCommit{blockCommit}, blockCommits)
+
+		tx, err := db.BeginTransaction()
+		assert.Equal(t, nil, err)
+		err = tx.DeletePendingBlockCommit(false, 2)
+		assert.Equal(t, nil, err)
+		err = tx.Commit()
+		assert.Equal(t, nil, err)
+		blockCommits, err = db.FetchPendingBlockCommits()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 0, len(blockCommits))
+	} else {
+		t.Error("DB is not open")
+	}
+}
+
 // Process on Block data
 func TestDb_StoreShardBlock(t *testing.T) {
 	if db != nil {
@@ -104,7 +170,7 @@ func TestDb_StoreShardBlock(t *testing.T) {
 			},
 		}
 		// test store block
-		err := db.StoreShardBlock(block, *block.Hash(), block.Header.ShardID)
+		err := db.StoreShardBlock(block, *block.Hash(), block.Header.ShardID, nil)
 		assert.Equal(t, err, nil)
 
 		// test Fetch block
@@ -143,7 +209,7 @@ func TestDb_StoreShardBlockIndex(t *testing.T) {
 			},
 		}
 		// test store block
-		err := db.StoreShardBlockIndex(*block.Hash(), block.Header.Height, block.Header.ShardID)
+		err := db.StoreShardBlockIndex(*block.Hash(), block.Header.Height, block.Header.ShardID, nil)
 		assert.Equal(t, err, nil)
 
 		// test GetIndexOfBlock
@@ -171,7 +237,7 @@ func TestDb_StoreBeaconBlock(t *testing.T) {
 			},
 		}
 		// test store block
-		err := db.StoreBeaconBlock(beaconBlock, *beaconBlock.Hash())
+		err := db.StoreBeaconBlock(beaconBlock, *beaconBlock.Hash(), nil)
 		assert.Equal(t, err, nil)
 
 		// test Fetch block
@@ -274,7 +340,7 @@ func TestDb_StoreTxIndex(t *testing.T) {
 			Version: 1,
 			Info:    []byte("Test 2"),
 		})
-		err := db.StoreTransactionIndex(*block.Body.Transactions[1].Hash(), *block.Hash(), 1)
+		err := db.StoreTransactionIndex(*block.Body.Transactions[1].Hash(), *block.Hash(), 1, nil)
 		assert.Equal(t, err, nil)
 
 		blockHash, index, err := db.GetTransactionIndexById(*block.Body.Transactions[1].Hash())
@@ -328,7 +394,7 @@ func TestDb_StoreShardBestState(t *testing.T) {
 			Epoch: 100,
 		}
 		besState.Shard[0] = &bestStateShard
-		err := db.StoreShardBestState(bestStateShard, 0)
+		err := db.StoreShardBestState(bestStateShard, 0, nil)
 		assert.Equal(t, err, nil)
 
 		temp, err := db.FetchShardBestState(0)
@@ -355,7 +421,7 @@ func TestDb_StoreBeaconBestState(t *testing.T) {
 				Epoch: 100,
 			},
 		}
-		err := db.StoreBeaconBestState(bestState)
+		err := db.StoreBeaconBestState(bestState, nil)
 		assert.Equal(t, err, nil)
 		temp, err := db.FetchBeaconBestState()
 		assert.Equal(t, err, nil)
@@ -697,7 +763,7 @@ func TestDb_StorePrivacyCustomTokenCrossShard(t *testing.T) {
 }
 
 func TestDb_StoreIncomingCrossShard(t *testing.T) {
-	err := db.StoreIncomingCrossShard(0, 1, 1000, common.Hash{})
+	err := db.StoreIncomingCrossShard(0, 1, 1000, common.Hash{}, nil)
 	assert.Equal(t, nil, err)
 
 	err = db.HasIncomingCrossShard(0, 1, common.Hash{})
@@ -0,0 +1,70 @@
+package lvdb
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// ListCommitmentTokenIDs returns the tokens which have commitments stored for shardID.
+// key: [commitmentsPrefix][tokenID][shardID]...
+func (db *db) ListCommitmentTokenIDs(shardID byte) ([]common.Hash, error) {
+	tokenIDs := []common.Hash{}
+	iter := db.lvdb.NewIterator(util.BytesPrefix(commitmentsPrefix), nil)
+	defer iter.Release()
+	for ok := iter.First(); ok; {
+		key := iter.Key()
+		if len(key) <= len(commitmentsPrefix)+common.HashSize {
+			ok = iter.Next()
+			continue
+		}
+		tokenAndShardID := key[len(commitmentsPrefix) : len(commitmentsPrefix)+common.HashSize+1]
+		if tokenAndShardID[common.HashSize] == shardID {
+			tokenID := common.Hash{}
+			copy(tokenID[:], tokenAndShardID[:common.HashSize])
+			tokenIDs = append(tokenIDs, tokenID)
+		}
+		// skip the other records of this token and shard
+		ok = iter.Seek(util.BytesPrefix(key[:len(commitmentsPrefix)+common.HashSize+1]).Limit)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, database.NewDatabaseError(database.ListCommitmentTokenIDsError, errors.Wrap(err, "iter.Error"))
+	}
+	return tokenIDs, nil
+}
+
+// ForEachTransactionIndex calls fn with every stored transaction index, it stops at the first error returned by fn.
+// key: tx-{txId}, value: {blockHash}-[-]-{indexInBlock}
+func (db *db) ForEachTransactionIndex(fn func(txID common.Hash, blockHash common.Hash, indexInBlock int) error) error {
+	iter := db.lvdb.NewIterator(util.BytesPrefix(transactionKeyPrefix), nil)
+	defer iter.Release()
+	for iter.Next() {
+		txID, err := common.Hash{}.NewHashFromStr(string(iter.Key()[len(transactionKeyPrefix):]))
+		if err != nil {
+			return database.NewDatabaseError(database.ForEachTransactionIndexError, errors.Wrapf(err, "tx index key %s", iter.Key()))
+		}
+		values := strings.Split(string(iter.Value()), string(Splitter))
+		if len(values) != 2 {
+			return database.NewDatabaseError(database.ForEachTransactionIndexError, errors.Errorf("tx index %s has value %s", txID.String(), iter.Value()))
+		}
+		blockHash, err := common.Hash{}.NewHashFromStr(values[0])
+		if err != nil {
+			return database.NewDatabaseError(database.ForEachTransactionIndexError, errors.Wrapf(err, "tx index %s", txID.String()))
+		}
+		indexInBlock, err := strconv.Atoi(values[1])
+		if err != nil {
+			return database.NewDatabaseError(database.ForEachTransactionIndexError, errors.Wrapf(err, "tx index %s", txID.String()))
+		}
+		if err := fn(*txID, *blockHash, indexInBlock); err != nil {
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return database.NewDatabaseError(database.ForEachTransactionIndexError, errors.Wrap(err, "iter.Error"))
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package lvdb
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func Test_db_ListCommitmentTokenIDs(t *testing.T) {
+	target, closeFn := newSnapshotTestDB(t, "Test_db_ListCommitmentTokenIDs")
+	defer closeFn()
+	tokenID := common.HashH([]byte("token"))
+	if err := target.StoreCommitments(common.PRVCoinID, []byte("pubkey"), [][]byte{[]byte("c1"), []byte("c2")}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := target.StoreCommitments(tokenID, []byte("pubkey"), [][]byte{[]byte("c3")}, 1); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		shardID byte
+		want    []common.Hash
+	}{
+		{0, []common.Hash{common.PRVCoinID}},
+		{1, []common.Hash{tokenID}},
+		{2, []common.Hash{}},
+	}
+	for _, tt := range tests {
+		got, err := target.ListCommitmentTokenIDs(tt.shardID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(tt.want) {
+			t.Fatalf("ListCommitmentTokenIDs(%d) = %v, want %v", tt.shardID, got, tt.want)
+		}
+		for i := range got {
+			if !got[i].IsEqual(&tt.want[i]) {
+				t.Errorf("ListCommitmentTokenIDs(%d) = %v, want %v", tt.shardID, got, tt.want)
+			}
+		}
+	}
+}
+
+func Test_db_ForEachTransactionIndex(t *testing.T) {
+	target, closeFn := newSnapshotTestDB(t, "Test_db_ForEachTransactionIndex")
+	defer closeFn()
+	blockHash := common.HashH([]byte("block"))
+	want := map[common.Hash]int{
+		common.HashH([]byte("tx1")): 0,
+		common.HashH([]byte("tx2")): 3,
+	}
+	for txID, index := range want {
+		if err := target.StoreTransactionIndex(txID, blockHash, index, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := make(map[common.Hash]int)
+	err := target.ForEachTransactionIndex(func(txID common.Hash, hash common.Hash, indexInBlock int) error {
+		if !hash.IsEqual(&blockHash) {
+			t.Errorf("tx %v in block %v, want %v", txID, hash, blockHash)
+		}
+		got[txID] = indexInBlock
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ForEachTransactionIndex() visited %v, want %v", got, want)
+	}
+	for txID, index := range want {
+		if got[txID] != index {
+			t.Errorf("tx %v at index %v, want %v", txID, got[txID], index)
+		}
+	}
+}
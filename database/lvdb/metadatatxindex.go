@@ -0,0 +1,81 @@
+package lvdb
+
+import (
+	"encoding/binary"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// getMetadataTxIndexPrefix returns the prefix of the indices of metaType in shardID, key: metatx-{metaType}{shardID}
+func getMetadataTxIndexPrefix(metaType int, shardID byte) []byte {
+	key := append([]byte{}, metadataTxIndexPrefix...)
+	metaTypeBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(metaTypeBytes, uint32(metaType))
+	key = append(key, metaTypeBytes...)
+	return append(key, shardID)
+}
+
+// getMetadataTxIndexKey returns the key metatx-{metaType}{shardID}{height}{txIndex},
+// numbers are big endian so that the indices are sorted by height then by index in block
+func getMetadataTxIndexKey(metaType int, shardID byte, height uint64, txIndex int) []byte {
+	key := getMetadataTxIndexPrefix(metaType, shardID)
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, height)
+	key = append(key, heightBytes...)
+	txIndexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(txIndexBytes, uint32(txIndex))
+	return append(key, txIndexBytes...)
+}
+
+// StoreMetadataTxIndex indexes the transaction txHash with metadata type metaType at index txIndex of the shard block at height
+func (db *db) StoreMetadataTxIndex(metaType int, shardID byte, height uint64, txIndex int, txHash common.Hash) error {
+	key := getMetadataTxIndexKey(metaType, shardID, height, txIndex)
+	if err := db.lvdb.Put(key, txHash[:], nil); err != nil {
+		return database.NewDatabaseError(database.StoreMetadataTxIndexError, errors.Wrap(err, "db.lvdb.Put"))
+	}
+	return nil
+}
+
+// DeleteMetadataTxIndex removes the index of the transaction at index txIndex of the shard block at height
+func (db *db) DeleteMetadataTxIndex(metaType int, shardID byte, height uint64, txIndex int) error {
+	key := getMetadataTxIndexKey(metaType, shardID, height, txIndex)
+	if err := db.lvdb.Delete(key, nil); err != nil {
+		return database.NewDatabaseError(database.DeleteMetadataTxIndexError, errors.Wrap(err, "db.lvdb.Delete"))
+	}
+	return nil
+}
+
+// ListMetadataTxIndices returns at most limit transactions with metadata type metaType in shardID,
+// from the index fromTxIndex of the block at fromHeight up to the last transaction of the block at toHeight
+func (db *db) ListMetadataTxIndices(metaType int, shardID byte, fromHeight uint64, fromTxIndex int, toHeight uint64, limit int) ([]database.MetadataTxIndex, error) {
+	if limit <= 0 || fromHeight > toHeight {
+		return []database.MetadataTxIndex{}, nil
+	}
+	prefix := getMetadataTxIndexPrefix(metaType, shardID)
+	keyRange := &util.Range{
+		Start: getMetadataTxIndexKey(metaType, shardID, fromHeight, fromTxIndex),
+		Limit: util.BytesPrefix(getMetadataTxIndexKey(metaType, shardID, toHeight, 0)[:len(prefix)+8]).Limit,
+	}
+	result := []database.MetadataTxIndex{}
+	iter := db.lvdb.NewIterator(keyRange, nil)
+	defer iter.Release()
+	for iter.Next() && len(result) < limit {
+		key := iter.Key()
+		if len(key) != len(prefix)+12 || len(iter.Value()) != common.HashSize {
+			return nil, database.NewDatabaseError(database.ListMetadataTxIndicesError, errors.Errorf("invalid metadata tx index %x", key))
+		}
+		index := database.MetadataTxIndex{
+			Height:  binary.BigEndian.Uint64(key[len(prefix):]),
+			TxIndex: int(binary.BigEndian.Uint32(key[len(prefix)+8:])),
+		}
+		copy(index.TxHash[:], iter.Value())
+		result = append(result, index)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, database.NewDatabaseError(database.ListMetadataTxIndicesError, errors.Wrap(err, "iter.Error"))
+	}
+	return result, nil
+}
@@ -0,0 +1,73 @@
+package lvdb
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+)
+
+func Test_db_ListMetadataTxIndices(t *testing.T) {
+	target, closeFn := newSnapshotTestDB(t, "Test_db_ListMetadataTxIndices")
+	defer closeFn()
+	metaType := 91
+	indices := []database.MetadataTxIndex{
+		{Height: 2, TxIndex: 1, TxHash: common.HashH([]byte("tx1"))},
+		{Height: 2, TxIndex: 300, TxHash: common.HashH([]byte("tx2"))},
+		{Height: 10, TxIndex: 0, TxHash: common.HashH([]byte("tx3"))},
+		{Height: 256, TxIndex: 2, TxHash: common.HashH([]byte("tx4"))},
+	}
+	for _, index := range indices {
+		if err := target.StoreMetadataTxIndex(metaType, 3, index.Height, index.TxIndex, index.TxHash); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// other metadata types and shards are not listed
+	if err := target.StoreMetadataTxIndex(metaType+1, 3, 5, 0, common.HashH([]byte("other type"))); err != nil {
+		t.Fatal(err)
+	}
+	if err := target.StoreMetadataTxIndex(metaType, 4, 5, 0, common.HashH([]byte("other shard"))); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		fromHeight  uint64
+		fromTxIndex int
+		toHeight    uint64
+		limit       int
+		want        []database.MetadataTxIndex
+	}{
+		{"all", 0, 0, 1000, 10, indices},
+		{"limit", 0, 0, 1000, 2, indices[:2]},
+		{"from tx index", 2, 2, 1000, 10, indices[1:]},
+		{"to height", 2, 0, 10, 10, indices[:3]},
+		{"empty range", 11, 0, 255, 10, []database.MetadataTxIndex{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := target.ListMetadataTxIndices(metaType, 3, tt.fromHeight, tt.fromTxIndex, tt.toHeight, tt.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ListMetadataTxIndices() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("ListMetadataTxIndices()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	if err := target.DeleteMetadataTxIndex(metaType, 3, 10, 0); err != nil {
+		t.Fatal(err)
+	}
+	got, err := target.ListMetadataTxIndices(metaType, 3, 0, 0, 1000, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(indices)-1 {
+		t.Errorf("ListMetadataTxIndices() after delete = %+v", got)
+	}
+}
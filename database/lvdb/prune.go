@@ -0,0 +1,90 @@
+package lvdb
+
+import (
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// pdeStatePrefixes are the PDE records which are snapshotted for every beacon height.
+var pdeStatePrefixes = [][]byte{
+	WaitingPDEContributionPrefix,
+	PDEPoolPrefix,
+	PDESharePrefix,
+	PDETradeFeePrefix,
+}
+
+func getPrunedHeightKey(isBeacon bool, shardID byte) []byte {
+	key := append([]byte{}, prunedHeightPrefix...)
+	if isBeacon {
+		return append(key, beaconPrefix...)
+	}
+	return append(key, append(shardPrefix, shardID)...)
+}
+
+/*
+	Delete the body of a shard or beacon block, key: b-{hash}
+	The block indexes (hash <-> height) and tx indexes are kept, so the block is still known to have been inserted
+*/
+func (db *db) DeleteBlockBody(hash common.Hash) error {
+	keyBlockHash := addPrefixToKeyHash(string(blockKeyPrefix), hash)
+	if err := db.lvdb.Delete(keyBlockHash, nil); err != nil {
+		return database.NewDatabaseError(database.DeleteBlockBodyError, errors.Wrap(err, "db.lvdb.Delete"))
+	}
+	return nil
+}
+
+// DeletePDEStateByHeight deletes the waiting contributions, pools, shares and trade fees
+// snapshotted at beaconHeight.
+func (db *db) DeletePDEStateByHeight(beaconHeight uint64) error {
+	beaconHeightBytes := []byte(fmt.Sprintf("%d-", beaconHeight))
+	for _, prefix := range pdeStatePrefixes {
+		prefixByBeaconHeight := append(append([]byte{}, prefix...), beaconHeightBytes...)
+		iter := db.lvdb.NewIterator(util.BytesPrefix(prefixByBeaconHeight), nil)
+		keys := [][]byte{}
+		for iter.Next() {
+			key := make([]byte, len(iter.Key()))
+			copy(key, iter.Key())
+			keys = append(keys, key)
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return database.NewDatabaseError(database.DeletePDEStateByHeightError, errors.Wrap(err, "iter.Error"))
+		}
+		for _, key := range keys {
+			if err := db.lvdb.Delete(key, nil); err != nil {
+				return database.NewDatabaseError(database.DeletePDEStateByHeightError, errors.Wrap(err, "db.lvdb.Delete"))
+			}
+		}
+	}
+	return nil
+}
+
+// StorePrunedHeight records that the data of the beacon chain (isBeacon) or of a shard has been
+// pruned up to and including height.
+func (db *db) StorePrunedHeight(isBeacon bool, shardID byte, height uint64) error {
+	if err := db.lvdb.Put(getPrunedHeightKey(isBeacon, shardID), common.Uint64ToBytes(height), nil); err != nil {
+		return database.NewDatabaseError(database.StorePrunedHeightError, errors.Wrap(err, "db.lvdb.Put"))
+	}
+	return nil
+}
+
+// GetPrunedHeight returns the height up to which a chain has been pruned, 0 means nothing has been pruned.
+func (db *db) GetPrunedHeight(isBeacon bool, shardID byte) (uint64, error) {
+	value, err := db.lvdb.Get(getPrunedHeightKey(isBeacon, shardID), nil)
+	if err == lvdberr.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, database.NewDatabaseError(database.GetPrunedHeightError, errors.Wrap(err, "db.lvdb.Get"))
+	}
+	height, err := common.BytesToUint64(value)
+	if err != nil {
+		return 0, database.NewDatabaseError(database.GetPrunedHeightError, errors.Wrap(err, "common.BytesToUint64"))
+	}
+	return height, nil
+}
@@ -0,0 +1,95 @@
+package lvdb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func Test_db_DeleteBlockBody(t *testing.T) {
+	testDB, err := openTestDB("Test_db_DeleteBlockBody")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testDB.Close()
+	db := &db{lvdb: testDB}
+	hash := common.HashH([]byte("block"))
+	if err := db.StoreShardBlock(map[string]string{"block": "body"}, hash, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.StoreShardBlockIndex(hash, 2, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.DeleteBlockBody(hash); err != nil {
+		t.Fatal(err)
+	}
+	if has, err := db.HasBlock(hash); err != nil || has {
+		t.Errorf("HasBlock() = %v, %v, want false, nil", has, err)
+	}
+	if got, err := db.GetBlockByIndex(2, 0); err != nil || got != hash {
+		t.Errorf("GetBlockByIndex() = %v, %v, want %v, nil", got, err, hash)
+	}
+}
+
+func Test_db_DeletePDEStateByHeight(t *testing.T) {
+	testDB, err := openTestDB("Test_db_DeletePDEStateByHeight")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testDB.Close()
+	db := &db{lvdb: testDB}
+	for _, beaconHeight := range []uint64{1, 10, 11} {
+		// a new waiting contribution is stored for the next beacon height
+		if err := db.ContributeToPDE(beaconHeight-1, fmt.Sprintf("pair-%d", beaconHeight), "address", "token", 100); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.UpdatePDEPoolForPair(beaconHeight, "token1", "token2", []byte("pool")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.DeletePDEStateByHeight(1); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		beaconHeight uint64
+		want         int
+	}{{1, 0}, {10, 1}, {11, 1}} {
+		for _, prefix := range [][]byte{WaitingPDEContributionPrefix, PDEPoolPrefix} {
+			keys, _, err := db.GetAllRecordsByPrefix(tt.beaconHeight, prefix)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(keys) != tt.want {
+				t.Errorf("GetAllRecordsByPrefix(%d, %s) returned %d records, want %d", tt.beaconHeight, prefix, len(keys), tt.want)
+			}
+		}
+	}
+}
+
+func Test_db_PrunedHeight(t *testing.T) {
+	testDB, err := openTestDB("Test_db_PrunedHeight")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testDB.Close()
+	db := &db{lvdb: testDB}
+	if height, err := db.GetPrunedHeight(true, 0); err != nil || height != 0 {
+		t.Errorf("GetPrunedHeight() = %v, %v, want 0, nil", height, err)
+	}
+	if err := db.StorePrunedHeight(true, 0, 100); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.StorePrunedHeight(false, 1, 50); err != nil {
+		t.Fatal(err)
+	}
+	if height, err := db.GetPrunedHeight(true, 0); err != nil || height != 100 {
+		t.Errorf("GetPrunedHeight(beacon) = %v, %v, want 100, nil", height, err)
+	}
+	if height, err := db.GetPrunedHeight(false, 1); err != nil || height != 50 {
+		t.Errorf("GetPrunedHeight(shard 1) = %v, %v, want 50, nil", height, err)
+	}
+	if height, err := db.GetPrunedHeight(false, 0); err != nil || height != 0 {
+		t.Errorf("GetPrunedHeight(shard 0) = %v, %v, want 0, nil", height, err)
+	}
+}
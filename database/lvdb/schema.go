@@ -0,0 +1,139 @@
+package lvdb
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+)
+
+// Migration upgrades the stored data from schema version Version-1 to Version.
+type Migration struct {
+	Version     uint32
+	Description string
+	Migrate     func(store Store) error
+}
+
+// migrations[i] upgrades the data from version i to version i+1.
+var migrations []Migration
+
+func init() {
+	err := RegisterMigration(Migration{
+		Version:     1,
+		Description: "record the schema version of data stored before versions were recorded",
+		Migrate:     func(store Store) error { return nil },
+	})
+	if err != nil {
+		panic("failed to register migration")
+	}
+}
+
+// RegisterMigration adds a migration to the registry, migrations must be registered in version order.
+func RegisterMigration(migration Migration) error {
+	if migration.Version != uint32(len(migrations)+1) {
+		return database.NewDatabaseError(database.RegisterMigrationError, errors.Errorf("expected migration to version %d, got %d", len(migrations)+1, migration.Version))
+	}
+	if migration.Migrate == nil {
+		return database.NewDatabaseError(database.RegisterMigrationError, errors.Errorf("migration to version %d has no Migrate function", migration.Version))
+	}
+	migrations = append(migrations, migration)
+	return nil
+}
+
+// SchemaVersion is the version of the key layout written by this package.
+func SchemaVersion() uint32 {
+	return uint32(len(migrations))
+}
+
+// GetSchemaVersion returns the schema version of the stored data, 0 means the data was stored
+// before schema versions were recorded.
+func (db *db) GetSchemaVersion() (uint32, error) {
+	value, err := db.lvdb.Get(schemaVersionKey, nil)
+	if err == lvdberr.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, database.NewDatabaseError(database.GetSchemaVersionError, errors.Wrap(err, "db.lvdb.Get"))
+	}
+	version, err := common.BytesToUint32(value)
+	if err != nil {
+		return 0, database.NewDatabaseError(database.GetSchemaVersionError, errors.Wrap(err, "common.BytesToUint32"))
+	}
+	return version, nil
+}
+
+func (db *db) storeSchemaVersion(version uint32) error {
+	if err := db.lvdb.Put(schemaVersionKey, common.Uint32ToBytes(version), nil); err != nil {
+		return database.NewDatabaseError(database.StoreSchemaVersionError, errors.Wrap(err, "db.lvdb.Put"))
+	}
+	return nil
+}
+
+func (db *db) isEmpty() bool {
+	iter := db.lvdb.NewIterator(nil, nil)
+	defer iter.Release()
+	return !iter.First()
+}
+
+// CheckSchemaVersion makes sure the stored data can be read with the current key layout.
+// A new database is stamped with the current version, data stored before versions were recorded
+// is stamped with version 1 as it already has the key layout of version 1.
+func (db *db) CheckSchemaVersion() error {
+	if importing, err := db.lvdb.Has(snapshotImportKey, nil); err != nil {
+		return database.NewDatabaseError(database.GetSchemaVersionError, errors.Wrap(err, "db.lvdb.Has"))
+	} else if importing {
+		return database.NewDatabaseError(database.IncompleteSnapshotImportError, errors.New("snapshot import has not been completed"))
+	}
+	version, err := db.GetSchemaVersion()
+	if err != nil {
+		return err
+	}
+	if version == 0 && db.isEmpty() {
+		return db.storeSchemaVersion(SchemaVersion())
+	}
+	if version == 0 {
+		if err := db.storeSchemaVersion(1); err != nil {
+			return err
+		}
+		version = 1
+	}
+	if version > SchemaVersion() {
+		return database.NewDatabaseError(database.UnknownSchemaVersionError, errors.Errorf("data has schema version %d, this node knows up to version %d", version, SchemaVersion()))
+	}
+	if version < SchemaVersion() {
+		return database.NewDatabaseError(database.OutdatedSchemaVersionError, errors.Errorf("data has schema version %d, expected version %d", version, SchemaVersion()))
+	}
+	return nil
+}
+
+// UpgradeSchema runs every migration from the stored schema version up to the current one.
+// Each migration is committed in one transaction together with its version record.
+func (db *db) UpgradeSchema() error {
+	version, err := db.GetSchemaVersion()
+	if err != nil {
+		return err
+	}
+	if version > SchemaVersion() {
+		return database.NewDatabaseError(database.UnknownSchemaVersionError, errors.Errorf("data has schema version %d, this node knows up to version %d", version, SchemaVersion()))
+	}
+	for _, migration := range migrations[version:] {
+		database.Logger.Log.Infof("Migrate schema to version %d: %s", migration.Version, migration.Description)
+		tx, err := db.BeginTransaction()
+		if err != nil {
+			return err
+		}
+		txDB := &tx.(*transaction).db
+		if err := migration.Migrate(txDB.lvdb); err != nil {
+			tx.Discard()
+			return database.NewDatabaseError(database.MigrateSchemaError, errors.Wrapf(err, "migrate to version %d", migration.Version))
+		}
+		if err := txDB.storeSchemaVersion(migration.Version); err != nil {
+			tx.Discard()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package lvdb
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/database"
+)
+
+func Test_db_CheckSchemaVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		stored   []byte
+		version  uint32
+		wantCode int
+	}{
+		{"empty", nil, 0, 0},
+		{"unversioned", []byte("data"), 0, 0},
+		{"current", []byte("data"), SchemaVersion(), 0},
+		{"unknown", []byte("data"), SchemaVersion() + 1, database.ErrCodeMessage[database.UnknownSchemaVersionError].Code},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testDB, err := openTestDB("Test_db_CheckSchemaVersion")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer testDB.Close()
+			db := &db{lvdb: testDB}
+			if tt.stored != nil {
+				if err := db.Put([]byte("key"), tt.stored); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.version != 0 {
+				if err := db.storeSchemaVersion(tt.version); err != nil {
+					t.Fatal(err)
+				}
+			}
+			err = db.CheckSchemaVersion()
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("db.CheckSchemaVersion() error = %v", err)
+				}
+			} else if dbErr, ok := err.(*database.DatabaseError); !ok || dbErr.Code != tt.wantCode {
+				t.Errorf("db.CheckSchemaVersion() error = %v, want code %v", err, tt.wantCode)
+			}
+			if err == nil {
+				if version, _ := db.GetSchemaVersion(); version != SchemaVersion() {
+					t.Errorf("db.GetSchemaVersion() = %v, want %v", version, SchemaVersion())
+				}
+			}
+		})
+	}
+}
+
+func Test_db_UpgradeSchema(t *testing.T) {
+	testDB, err := openTestDB("Test_db_UpgradeSchema")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testDB.Close()
+	db := &db{lvdb: testDB}
+	if err := db.Put([]byte("key"), []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpgradeSchema(); err != nil {
+		t.Fatalf("db.UpgradeSchema() error = %v", err)
+	}
+	if err := db.CheckSchemaVersion(); err != nil {
+		t.Errorf("db.CheckSchemaVersion() error = %v", err)
+	}
+	if err := db.storeSchemaVersion(SchemaVersion() + 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpgradeSchema(); err == nil {
+		t.Errorf("db.UpgradeSchema() expected error on unknown version")
+	}
+}
@@ -1,6 +1,7 @@
 package lvdb
 
 import (
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 
@@ -40,3 +41,37 @@ func (db *db) StoreProducersBlackList(beaconHeight uint64, producersBlackList ma
 	}
 	return nil
 }
+
+// key: slashedequivocation-{chainID}-{committeePublicKey}-{height}-{round}
+func getSlashedEquivocationKey(chainID int, committeePublicKey string, height uint64, round int) []byte {
+	key := append([]byte{}, slashedEquivocationPrefix...)
+	return append(key, []byte(fmt.Sprintf("%d-%s-%d-%d", chainID, committeePublicKey, height, round))...)
+}
+
+// StoreSlashedEquivocation records that the votes of a committee member for two blocks at height and round of a chain
+// have been slashed by the beacon block at beaconHeight
+func (db *db) StoreSlashedEquivocation(chainID int, committeePublicKey string, height uint64, round int, beaconHeight uint64) error {
+	key := getSlashedEquivocationKey(chainID, committeePublicKey, height, round)
+	value := make([]byte, 8)
+	binary.LittleEndian.PutUint64(value, beaconHeight)
+	if err := db.Put(key, value); err != nil {
+		return database.NewDatabaseError(database.StoreSlashedEquivocationError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+// GetSlashedEquivocation returns the height of the beacon block which slashed the votes of a committee member
+// at height and round of a chain, false if they have not been slashed
+func (db *db) GetSlashedEquivocation(chainID int, committeePublicKey string, height uint64, round int) (uint64, bool, error) {
+	value, err := db.lvdb.Get(getSlashedEquivocationKey(chainID, committeePublicKey, height, round), nil)
+	if err == lvdberr.ErrNotFound {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, database.NewDatabaseError(database.GetSlashedEquivocationError, err)
+	}
+	if len(value) != 8 {
+		return 0, false, database.NewDatabaseError(database.GetSlashedEquivocationError, errors.Errorf("invalid value of %d bytes", len(value)))
+	}
+	return binary.LittleEndian.Uint64(value), true, nil
+}
@@ -0,0 +1,271 @@
+package lvdb
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"io"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// snapshotBatchSize is the number of records written to the store at once during an import.
+const snapshotBatchSize = 1000
+
+// maxSnapshotFieldSize is the largest key or value accepted from a snapshot.
+const maxSnapshotFieldSize = 1 << 26
+
+// snapshotSections groups the records of a snapshot by key prefix, a record belongs to the first
+// section with a matching prefix. Records matching none of them (blocks, indexes, committees,
+// cross shard...) belong to the "other" section.
+var snapshotSections = []struct {
+	name     string
+	prefixes [][]byte
+}{
+	{"beststate", [][]byte{beaconBestBlockkeyPrefix, bestBlockKeyPrefix}},
+	{"commitment", [][]byte{commitmentsPrefix}},
+	{"serialnumber", [][]byte{serialNumbersPrefix}},
+	{"snderivator", [][]byte{snderivatorsPrefix}},
+	{"outputcoin", [][]byte{outcoinsPrefix}},
+	{"token", [][]byte{tokenPrefix, privacyTokenPrefix, privacyTokenCrossShardPrefix}},
+	{"bridge", [][]byte{bridgePrefix, centralizedBridgePrefix, decentralizedBridgePrefix, ethTxHashIssuedPrefix, burnConfirmPrefix}},
+	{"reward", [][]byte{shardRequestRewardPrefix, committeeRewardPrefix, rewardReceiverPrefix}},
+	{"pde", [][]byte{WaitingPDEContributionPrefix, PDEPoolPrefix, PDESharePrefix, PDETradeFeePrefix, PDEContributionStatusPrefix, PDETradeStatusPrefix, PDEWithdrawalStatusPrefix}},
+	{"other", nil},
+}
+
+// snapshotSkippedPrefixes are the records which only make sense for the database they were written in.
+var snapshotSkippedPrefixes = [][]byte{
+	prevShardPrefix,
+	prevBeaconPrefix,
+	blockCommitPrefix,
+	prunedHeightPrefix,
+	schemaVersionKey,
+	snapshotImportKey,
+}
+
+func getSnapshotSectionIndex(key []byte) int {
+	for i, section := range snapshotSections {
+		for _, prefix := range section.prefixes {
+			if bytes.HasPrefix(key, prefix) {
+				return i
+			}
+		}
+	}
+	return len(snapshotSections) - 1
+}
+
+// isLocalRecord tells if the record only makes sense for the database it was written in.
+func isLocalRecord(key []byte) bool {
+	for _, prefix := range snapshotSkippedPrefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBlockBody tells if the record is the body of a shard or beacon block, key: b-{hash}
+func isBlockBody(key []byte) bool {
+	return len(key) == len(blockKeyPrefix)+common.HashSize && bytes.HasPrefix(key, blockKeyPrefix)
+}
+
+// snapshotDigest keeps the number of records and the running hash of every section.
+type snapshotDigest struct {
+	records []uint64
+	hashes  []hash.Hash
+}
+
+func newSnapshotDigest() *snapshotDigest {
+	digest := &snapshotDigest{
+		records: make([]uint64, len(snapshotSections)),
+		hashes:  make([]hash.Hash, len(snapshotSections)),
+	}
+	for i := range digest.hashes {
+		digest.hashes[i] = sha256.New()
+	}
+	return digest
+}
+
+func (digest *snapshotDigest) add(record []byte, key []byte) {
+	i := getSnapshotSectionIndex(key)
+	digest.records[i]++
+	digest.hashes[i].Write(record)
+}
+
+func (digest *snapshotDigest) sections() []database.SnapshotSection {
+	sections := make([]database.SnapshotSection, len(snapshotSections))
+	for i, section := range snapshotSections {
+		sections[i].Name = section.name
+		sections[i].Records = digest.records[i]
+		copy(sections[i].Hash[:], digest.hashes[i].Sum(nil))
+	}
+	return sections
+}
+
+// encodeSnapshotRecord frames a record as {len(key)}{key}{len(value)}{value}, lengths are 4 bytes little endian.
+func encodeSnapshotRecord(key []byte, value []byte) []byte {
+	record := make([]byte, 8+len(key)+len(value))
+	binary.LittleEndian.PutUint32(record, uint32(len(key)))
+	copy(record[4:], key)
+	binary.LittleEndian.PutUint32(record[4+len(key):], uint32(len(value)))
+	copy(record[8+len(key):], value)
+	return record
+}
+
+// snapshotReader reads the records of a snapshot of a known size.
+type snapshotReader struct {
+	reader    *bufio.Reader
+	remaining int64
+}
+
+// readField reads a key or a value, its length must fit in the rest of the snapshot.
+// It returns io.EOF at the end of the snapshot.
+func (reader *snapshotReader) readField() ([]byte, error) {
+	length := make([]byte, 4)
+	if _, err := io.ReadFull(reader.reader, length); err != nil {
+		return nil, err
+	}
+	reader.remaining -= int64(len(length))
+	fieldLength := int64(binary.LittleEndian.Uint32(length))
+	if fieldLength > maxSnapshotFieldSize || fieldLength > reader.remaining {
+		return nil, errors.Errorf("field of %d bytes, %d bytes left in snapshot", fieldLength, reader.remaining)
+	}
+	field := make([]byte, fieldLength)
+	if _, err := io.ReadFull(reader.reader, field); err != nil {
+		return nil, err
+	}
+	reader.remaining -= fieldLength
+	return field, nil
+}
+
+// ExportSnapshot writes every record of the chain state to writer and returns the sections of the snapshot.
+// Block bodies are left out except the ones of blockHashes, block indexes are kept.
+// Backups, pending block commits and pruning progress are specific to this database and are left out too.
+func (db *db) ExportSnapshot(writer io.Writer, blockHashes []common.Hash) ([]database.SnapshotSection, error) {
+	blockBodies := make(map[string]bool)
+	for _, blockHash := range blockHashes {
+		blockBodies[string(addPrefixToKeyHash(string(blockKeyPrefix), blockHash))] = true
+	}
+	bufWriter := bufio.NewWriter(writer)
+	digest := newSnapshotDigest()
+	iter := db.lvdb.NewIterator(nil, nil)
+	defer iter.Release()
+	for iter.Next() {
+		if isLocalRecord(iter.Key()) || (isBlockBody(iter.Key()) && !blockBodies[string(iter.Key())]) {
+			continue
+		}
+		record := encodeSnapshotRecord(iter.Key(), iter.Value())
+		if _, err := bufWriter.Write(record); err != nil {
+			return nil, database.NewDatabaseError(database.ExportSnapshotError, errors.Wrap(err, "bufWriter.Write"))
+		}
+		digest.add(record, iter.Key())
+	}
+	if err := iter.Error(); err != nil {
+		return nil, database.NewDatabaseError(database.ExportSnapshotError, errors.Wrap(err, "iter.Error"))
+	}
+	if err := bufWriter.Flush(); err != nil {
+		return nil, database.NewDatabaseError(database.ExportSnapshotError, errors.Wrap(err, "bufWriter.Flush"))
+	}
+	return digest.sections(), nil
+}
+
+// ImportSnapshot writes the records read from reader into an empty database, size is the number of bytes of the snapshot.
+// The snapshot must have the schema version of this package and match sections, verify is called
+// once every record is written to check the imported chain state.
+// If anything fails the database is cleared. An import interrupted by a crash leaves a mark which
+// makes CheckSchemaVersion fail, the database has to be removed before importing again.
+func (db *db) ImportSnapshot(reader io.Reader, size int64, schemaVersion uint32, sections []database.SnapshotSection, verify func() error) error {
+	if schemaVersion != SchemaVersion() {
+		return database.NewDatabaseError(database.ImportSnapshotError, errors.Errorf("snapshot has schema version %d, expected version %d", schemaVersion, SchemaVersion()))
+	}
+	if !db.isEmpty() {
+		return database.NewDatabaseError(database.ImportSnapshotError, errors.New("database is not empty"))
+	}
+	if err := db.lvdb.Put(snapshotImportKey, []byte{}, nil); err != nil {
+		return database.NewDatabaseError(database.ImportSnapshotError, errors.Wrap(err, "db.lvdb.Put"))
+	}
+	if err := db.importSnapshotRecords(&snapshotReader{reader: bufio.NewReader(reader), remaining: size}, sections, verify); err != nil {
+		if clearErr := db.clear(); clearErr != nil {
+			database.Logger.Log.Errorf("Clear database after failed snapshot import: %+v", clearErr)
+		}
+		return err
+	}
+	if err := db.storeSchemaVersion(SchemaVersion()); err != nil {
+		return err
+	}
+	if err := db.lvdb.Delete(snapshotImportKey, nil); err != nil {
+		return database.NewDatabaseError(database.ImportSnapshotError, errors.Wrap(err, "db.lvdb.Delete"))
+	}
+	return nil
+}
+
+func (db *db) importSnapshotRecords(reader *snapshotReader, sections []database.SnapshotSection, verify func() error) error {
+	digest := newSnapshotDigest()
+	batch := new(leveldb.Batch)
+	for {
+		key, err := reader.readField()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return database.NewDatabaseError(database.ImportSnapshotError, errors.Wrap(err, "read key"))
+		}
+		value, err := reader.readField()
+		if err != nil {
+			return database.NewDatabaseError(database.ImportSnapshotError, errors.Wrap(err, "read value"))
+		}
+		if isLocalRecord(key) {
+			return database.NewDatabaseError(database.ImportSnapshotError, errors.Errorf("unexpected record %s", key))
+		}
+		digest.add(encodeSnapshotRecord(key, value), key)
+		batch.Put(key, value)
+		if batch.Len() >= snapshotBatchSize {
+			if err := db.lvdb.Write(batch, nil); err != nil {
+				return database.NewDatabaseError(database.ImportSnapshotError, errors.Wrap(err, "db.lvdb.Write"))
+			}
+			batch.Reset()
+		}
+	}
+	if err := db.lvdb.Write(batch, nil); err != nil {
+		return database.NewDatabaseError(database.ImportSnapshotError, errors.Wrap(err, "db.lvdb.Write"))
+	}
+	imported := digest.sections()
+	if len(imported) != len(sections) {
+		return database.NewDatabaseError(database.VerifySnapshotError, errors.Errorf("snapshot has %d sections, expected %d", len(sections), len(imported)))
+	}
+	for i := range imported {
+		if imported[i] != sections[i] {
+			return database.NewDatabaseError(database.VerifySnapshotError, errors.Errorf("section %s has %d records with hash %s, expected %d records with hash %s", sections[i].Name, imported[i].Records, imported[i].Hash.String(), sections[i].Records, sections[i].Hash.String()))
+		}
+	}
+	if err := verify(); err != nil {
+		return database.NewDatabaseError(database.VerifySnapshotError, err)
+	}
+	return nil
+}
+
+// clear deletes every record of the database.
+func (db *db) clear() error {
+	iter := db.lvdb.NewIterator(nil, nil)
+	defer iter.Release()
+	batch := new(leveldb.Batch)
+	for iter.Next() {
+		batch.Delete(iter.Key())
+		if batch.Len() >= snapshotBatchSize {
+			if err := db.lvdb.Write(batch, nil); err != nil {
+				return errors.Wrap(err, "db.lvdb.Write")
+			}
+			batch.Reset()
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return errors.Wrap(err, "iter.Error")
+	}
+	return errors.Wrap(db.lvdb.Write(batch, nil), "db.lvdb.Write")
+}
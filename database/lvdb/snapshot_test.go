@@ -0,0 +1,142 @@
+package lvdb
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+)
+
+func newSnapshotTestDB(t *testing.T, name string) (*db, func()) {
+	testDB, err := openTestDB(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &db{lvdb: testDB}, func() { testDB.Close() }
+}
+
+func exportTestSnapshot(t *testing.T) ([]byte, []database.SnapshotSection, common.Hash, common.Hash) {
+	source, closeFn := newSnapshotTestDB(t, "exportTestSnapshot")
+	defer closeFn()
+	keptHash := common.HashH([]byte("kept"))
+	prunedHash := common.HashH([]byte("pruned"))
+	if err := source.CheckSchemaVersion(); err != nil {
+		t.Fatal(err)
+	}
+	if err := source.StoreShardBlock(map[string]string{"block": "kept"}, keptHash, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := source.StoreShardBlock(map[string]string{"block": "pruned"}, prunedHash, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := source.StoreSerialNumbers(common.PRVCoinID, [][]byte{[]byte("sn")}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := source.UpdatePDEPoolForPair(10, "token1", "token2", []byte("pool")); err != nil {
+		t.Fatal(err)
+	}
+	if err := source.StorePrunedHeight(true, 0, 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := source.Put(append(append([]byte{}, prevBeaconPrefix...), []byte("backup")...), []byte("backup")); err != nil {
+		t.Fatal(err)
+	}
+	snapshot := &bytes.Buffer{}
+	sections, err := source.ExportSnapshot(snapshot, []common.Hash{keptHash})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return snapshot.Bytes(), sections, keptHash, prunedHash
+}
+
+func Test_db_ExportImportSnapshot(t *testing.T) {
+	snapshot, sections, keptHash, prunedHash := exportTestSnapshot(t)
+	for _, section := range sections {
+		if (section.Name == "serialnumber" || section.Name == "pde") && section.Records == 0 {
+			t.Errorf("section %s has no records", section.Name)
+		}
+	}
+
+	target, closeFn := newSnapshotTestDB(t, "Test_db_ExportImportSnapshot")
+	defer closeFn()
+	verified := false
+	verify := func() error {
+		verified = true
+		return nil
+	}
+	if err := target.ImportSnapshot(bytes.NewReader(snapshot), int64(len(snapshot)), SchemaVersion(), sections, verify); err != nil {
+		t.Fatal(err)
+	}
+	if !verified {
+		t.Error("verify was not called")
+	}
+	if err := target.CheckSchemaVersion(); err != nil {
+		t.Errorf("CheckSchemaVersion() error = %v", err)
+	}
+	if has, err := target.HasSerialNumber(common.PRVCoinID, []byte("sn"), 0); err != nil || !has {
+		t.Errorf("HasSerialNumber() = %v, %v, want true, nil", has, err)
+	}
+	if has, err := target.HasBlock(keptHash); err != nil || !has {
+		t.Errorf("HasBlock(kept) = %v, %v, want true, nil", has, err)
+	}
+	if has, err := target.HasBlock(prunedHash); err != nil || has {
+		t.Errorf("HasBlock(pruned) = %v, %v, want false, nil", has, err)
+	}
+	if height, err := target.GetPrunedHeight(true, 0); err != nil || height != 0 {
+		t.Errorf("GetPrunedHeight() = %v, %v, want 0, nil", height, err)
+	}
+	if err := target.ImportSnapshot(bytes.NewReader(snapshot), int64(len(snapshot)), SchemaVersion(), sections, verify); err == nil {
+		t.Error("ImportSnapshot() into a non empty database succeeded")
+	}
+}
+
+func Test_db_ImportSnapshotFailure(t *testing.T) {
+	snapshot, sections, _, _ := exportTestSnapshot(t)
+	tamperedSections := append([]database.SnapshotSection{}, sections...)
+	tamperedSections[0].Records++
+	// a record claiming a key longer than the rest of the snapshot
+	oversized := append(append([]byte{}, snapshot...), 0xff, 0xff, 0xff, 0x00)
+	size := int64(len(snapshot))
+	tests := []struct {
+		name          string
+		snapshot      []byte
+		size          int64
+		schemaVersion uint32
+		sections      []database.SnapshotSection
+		verifyErr     error
+	}{
+		{"schema version", snapshot, size, SchemaVersion() + 1, sections, nil},
+		{"truncated", snapshot[:len(snapshot)-1], size - 1, SchemaVersion(), sections, nil},
+		{"oversized field", oversized, int64(len(oversized)), SchemaVersion(), sections, nil},
+		{"size", snapshot, size - 1, SchemaVersion(), sections, nil},
+		{"tampered sections", snapshot, size, SchemaVersion(), tamperedSections, nil},
+		{"verify", snapshot, size, SchemaVersion(), sections, errors.New("best state mismatch")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, closeFn := newSnapshotTestDB(t, "Test_db_ImportSnapshotFailure")
+			defer closeFn()
+			verify := func() error { return tt.verifyErr }
+			if err := target.ImportSnapshot(bytes.NewReader(tt.snapshot), tt.size, tt.schemaVersion, tt.sections, verify); err == nil {
+				t.Fatal("ImportSnapshot() succeeded")
+			}
+			if !target.isEmpty() {
+				t.Error("database is not empty after a failed import")
+			}
+		})
+	}
+}
+
+func Test_db_CheckSchemaVersionIncompleteImport(t *testing.T) {
+	target, closeFn := newSnapshotTestDB(t, "Test_db_CheckSchemaVersionIncompleteImport")
+	defer closeFn()
+	if err := target.Put(snapshotImportKey, []byte{}); err != nil {
+		t.Fatal(err)
+	}
+	err := target.CheckSchemaVersion()
+	if dbErr, ok := err.(*database.DatabaseError); !ok || dbErr.Code != database.ErrCodeMessage[database.IncompleteSnapshotImportError].Code {
+		t.Errorf("CheckSchemaVersion() error = %v, want IncompleteSnapshotImportError", err)
+	}
+}
@@ -0,0 +1,31 @@
+package lvdb
+
+import (
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// Store is the subset of the goleveldb API used by this package to persist chain data.
+// *leveldb.DB satisfies it directly; other key-value engines can be plugged in by
+// implementing it and wrapping it with NewWithStore.
+//
+// Implementations must return leveldb.ErrNotFound (github.com/syndtr/goleveldb/leveldb/errors.ErrNotFound)
+// when a key does not exist and iterate keys in bytewise ascending order.
+type Store interface {
+	Get(key []byte, ro *opt.ReadOptions) ([]byte, error)
+	Has(key []byte, ro *opt.ReadOptions) (bool, error)
+	Put(key, value []byte, wo *opt.WriteOptions) error
+	Delete(key []byte, wo *opt.WriteOptions) error
+	Write(batch *leveldb.Batch, wo *opt.WriteOptions) error
+	NewIterator(slice *util.Range, ro *opt.ReadOptions) iterator.Iterator
+	Close() error
+}
+
+// NewWithStore returns a DatabaseInterface which keeps chain data in the given store
+// using the same key layout as the leveldb driver.
+func NewWithStore(store Store) database.DatabaseInterface {
+	return &db{lvdb: store}
+}
@@ -0,0 +1,135 @@
+package lvdb
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// StoreTransaction is a Store whose writes are kept pending until Commit.
+// *leveldb.Transaction satisfies it.
+type StoreTransaction interface {
+	Get(key []byte, ro *opt.ReadOptions) ([]byte, error)
+	Has(key []byte, ro *opt.ReadOptions) (bool, error)
+	Put(key, value []byte, wo *opt.WriteOptions) error
+	Delete(key []byte, wo *opt.WriteOptions) error
+	Write(batch *leveldb.Batch, wo *opt.WriteOptions) error
+	NewIterator(slice *util.Range, ro *opt.ReadOptions) iterator.Iterator
+	Commit() error
+	Discard()
+}
+
+// TransactionalStore is implemented by stores other than *leveldb.DB which support transactions.
+type TransactionalStore interface {
+	OpenStoreTransaction() (StoreTransaction, error)
+}
+
+// txStore lets a StoreTransaction be used as the Store of a db. Closing it discards the transaction.
+type txStore struct {
+	StoreTransaction
+}
+
+func (store txStore) Close() error {
+	store.Discard()
+	return nil
+}
+
+// transaction runs every DatabaseInterface method against a StoreTransaction.
+type transaction struct {
+	db
+	tr StoreTransaction
+}
+
+// BeginTransaction opens a write transaction on the store. Only one transaction can be open at a time,
+// other writes to the database are blocked until it is committed or discarded.
+// On leveldb, OpenTransaction holds the write lock of the whole database: the beacon chain and every shard
+// share the chain database, so while one block is committed all chain database writes of the beacon and
+// of every other shard wait, including those which do not use a transaction.
+// On badger, the writes are kept in memory until Commit and a transaction which holds too many of them
+// fails with badger.ErrTxnTooBig (see badgerdb.maxTableSize).
+func (db *db) BeginTransaction() (database.Transaction, error) {
+	var tr StoreTransaction
+	switch store := db.lvdb.(type) {
+	case *leveldb.DB:
+		lvdbTr, err := store.OpenTransaction()
+		if err != nil {
+			return nil, database.NewDatabaseError(database.BeginTransactionError, errors.Wrap(err, "db.lvdb.OpenTransaction"))
+		}
+		tr = lvdbTr
+	case TransactionalStore:
+		storeTr, err := store.OpenStoreTransaction()
+		if err != nil {
+			return nil, database.NewDatabaseError(database.BeginTransactionError, errors.Wrap(err, "db.lvdb.OpenStoreTransaction"))
+		}
+		tr = storeTr
+	default:
+		return nil, database.NewDatabaseError(database.BeginTransactionError, errors.Errorf("store %T does not support transactions", db.lvdb))
+	}
+	return newTransaction(tr), nil
+}
+
+func newTransaction(tr StoreTransaction) *transaction {
+	return &transaction{db: db{lvdb: txStore{tr}}, tr: tr}
+}
+
+func (tx *transaction) Commit() error {
+	if err := tx.tr.Commit(); err != nil {
+		return database.NewDatabaseError(database.CommitTransactionError, errors.Wrap(err, "tx.tr.Commit"))
+	}
+	return nil
+}
+
+func (tx *transaction) Discard() {
+	tx.tr.Discard()
+}
+
+func getBlockCommitKey(isBeacon bool, shardID byte) []byte {
+	key := append([]byte{}, blockCommitPrefix...)
+	if isBeacon {
+		return append(key, beaconPrefix...)
+	}
+	return append(key, append(shardPrefix, shardID)...)
+}
+
+// StorePendingBlockCommit marks that the data of a block is about to be committed. The mark is
+// deleted in the same transaction which commits the block, so a mark left in the database means
+// the block was never committed.
+func (db *db) StorePendingBlockCommit(blockCommit database.BlockCommit) error {
+	value, err := json.Marshal(blockCommit)
+	if err != nil {
+		return database.NewDatabaseError(database.StorePendingBlockCommitError, errors.Wrap(err, "json.Marshal"))
+	}
+	if err := db.Put(getBlockCommitKey(blockCommit.IsBeacon, blockCommit.ShardID), value); err != nil {
+		return database.NewDatabaseError(database.StorePendingBlockCommitError, errors.Wrap(err, "db.Put"))
+	}
+	return nil
+}
+
+func (db *db) DeletePendingBlockCommit(isBeacon bool, shardID byte) error {
+	if err := db.Delete(getBlockCommitKey(isBeacon, shardID)); err != nil {
+		return database.NewDatabaseError(database.DeletePendingBlockCommitError, errors.Wrap(err, "db.Delete"))
+	}
+	return nil
+}
+
+func (db *db) FetchPendingBlockCommits() ([]database.BlockCommit, error) {
+	iter := db.lvdb.NewIterator(util.BytesPrefix(blockCommitPrefix), nil)
+	defer iter.Release()
+	blockCommits := []database.BlockCommit{}
+	for iter.Next() {
+		var blockCommit database.BlockCommit
+		if err := json.Unmarshal(iter.Value(), &blockCommit); err != nil {
+			return nil, database.NewDatabaseError(database.FetchPendingBlockCommitsError, errors.Wrap(err, "json.Unmarshal"))
+		}
+		blockCommits = append(blockCommits, blockCommit)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, database.NewDatabaseError(database.FetchPendingBlockCommitsError, errors.Wrap(err, "iter.Error"))
+	}
+	return blockCommits, nil
+}
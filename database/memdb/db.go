@@ -0,0 +1,19 @@
+package memdb
+
+import (
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+)
+
+// open returns a DatabaseInterface backed by an in-memory leveldb storage. Nothing is
+// written to disk and all data is lost when the database is closed.
+func open() (database.DatabaseInterface, error) {
+	memdb, err := leveldb.Open(storage.NewMemStorage(), nil)
+	if err != nil {
+		return nil, database.NewDatabaseError(database.OpenDbErr, errors.Wrap(err, "leveldb.Open mem storage"))
+	}
+	return lvdb.NewWithStore(memdb), nil
+}
@@ -0,0 +1,67 @@
+package memdb
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = func() (_ struct{}) {
+	database.Logger.Init(common.NewBackend(nil).Logger("test", true))
+	return
+}()
+
+func TestMemDB_Open(t *testing.T) {
+	db1, err := database.Open("memdb")
+	assert.Nil(t, err)
+	db2, err := database.Open("memdb", "ignored/path")
+	assert.Nil(t, err)
+
+	assert.Nil(t, db1.Put([]byte("a"), []byte{1}))
+	has, err := db2.HasValue([]byte("a"))
+	assert.Nil(t, err)
+	assert.False(t, has, "every opened memdb must be independent")
+
+	assert.Nil(t, db1.Close())
+	assert.Nil(t, db2.Close())
+}
+
+func TestMemDB_Families(t *testing.T) {
+	db, err := database.Open("memdb")
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// serial numbers
+	assert.Nil(t, db.StoreSerialNumbers(common.PRVCoinID, [][]byte{{1, 2, 3}}, 0))
+	has, err := db.HasSerialNumber(common.PRVCoinID, []byte{1, 2, 3}, 0)
+	assert.Nil(t, err)
+	assert.True(t, has)
+	has, err = db.HasSerialNumber(common.PRVCoinID, []byte{1, 2, 3}, 1)
+	assert.Nil(t, err)
+	assert.False(t, has)
+
+	// bridge
+	assert.Nil(t, db.InsertETHTxHashIssued([]byte("eth-tx")))
+	issued, err := db.IsETHTxHashIssued([]byte("eth-tx"))
+	assert.Nil(t, err)
+	assert.True(t, issued)
+
+	// reward
+	assert.Nil(t, db.AddCommitteeReward([]byte("committee"), 100, common.PRVCoinID))
+	assert.Nil(t, db.AddCommitteeReward([]byte("committee"), 50, common.PRVCoinID))
+	reward, err := db.GetCommitteeReward([]byte("committee"), common.PRVCoinID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(150), reward)
+
+	// pde
+	assert.Nil(t, db.UpdatePDEPoolForPair(10, "token1", "token2", []byte("pool-10")))
+	assert.Nil(t, db.UpdatePDEPoolForPair(11, "token1", "token2", []byte("pool-11")))
+	pool, err := db.GetPDEPoolForPair(10, "token1", "token2")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("pool-10"), pool)
+	pool, err = db.GetLatestPDEPoolForPair("token1", "token2")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("pool-11"), pool)
+}
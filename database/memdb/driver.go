@@ -0,0 +1,21 @@
+package memdb
+
+import (
+	"github.com/incognitochain/incognito-chain/database"
+)
+
+func init() {
+	driver := database.Driver{
+		DbType: "memdb",
+		Open:   openDriver,
+	}
+	if err := database.RegisterDriver(driver); err != nil {
+		panic("failed to register db driver")
+	}
+}
+
+// openDriver ignores its arguments so that callers can open the in-memory driver
+// the same way they open a disk driver, e.g. database.Open("memdb", dbPath).
+func openDriver(args ...interface{}) (database.DatabaseInterface, error) {
+	return open()
+}
@@ -46,7 +46,7 @@ func TestDb_AddTransaction(t *testing.T) {
 		IsPushMessage: false,
 		Height: uint64(1),
 		Fee: uint64(1),
-		FeePerKB: int32(1),
+		FeePerKB: uint64(1),
 	}
 	valueTx, _ := json.Marshal(&tx1)
 	valueTxDesc, _ := json.Marshal(&tempDesc)
@@ -61,7 +61,7 @@ func TestDb_HasTransaction(t *testing.T) {
 		IsPushMessage: false,
 		Height: uint64(1),
 		Fee: uint64(1),
-		FeePerKB: int32(1),
+		FeePerKB: uint64(1),
 	}
 	valueTx, _ := json.Marshal(&tx1)
 	valueTxDesc, _ := json.Marshal(&tempDesc)
@@ -91,7 +91,7 @@ func TestDb_GetTransaction(t *testing.T) {
 		IsPushMessage: false,
 		Height: uint64(1),
 		Fee: uint64(1),
-		FeePerKB: int32(1),
+		FeePerKB: uint64(1),
 	}
 	valueTx, err := json.Marshal(&tx1)
 	if err != nil {
@@ -145,7 +145,7 @@ func TestDb_RemoveTransaction(t *testing.T) {
 		IsPushMessage: false,
 		Height: uint64(1),
 		Fee: uint64(1),
-		FeePerKB: int32(1),
+		FeePerKB: uint64(1),
 	}
 	valueTx, err := json.Marshal(&tx1)
 	if err != nil {
@@ -172,7 +172,7 @@ func TestDb_Load(t *testing.T) {
 		IsPushMessage: false,
 		Height: uint64(1),
 		Fee: uint64(1),
-		FeePerKB: int32(1),
+		FeePerKB: uint64(1),
 	}
 	valueTxDesc, err := json.Marshal(&tempDesc)
 	if err != nil {
@@ -208,7 +208,7 @@ func TestDb_Reset(t *testing.T) {
 		IsPushMessage: false,
 		Height: uint64(1),
 		Fee: uint64(1),
-		FeePerKB: int32(1),
+		FeePerKB: uint64(1),
 	}
 	valueTxDesc, err := json.Marshal(&tempDesc)
 	if err != nil {
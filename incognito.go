@@ -16,6 +16,7 @@ import (
 	_ "net/http/pprof"
 
 	"github.com/incognitochain/incognito-chain/database"
+	_ "github.com/incognitochain/incognito-chain/database/badgerdb"
 	_ "github.com/incognitochain/incognito-chain/database/lvdb"
 	"github.com/incognitochain/incognito-chain/databasemp"
 	_ "github.com/incognitochain/incognito-chain/databasemp/lvdb"
@@ -23,6 +24,7 @@ import (
 	"github.com/incognitochain/incognito-chain/wallet"
 
 	_ "github.com/incognitochain/incognito-chain/consensus/blsbft"
+	_ "github.com/incognitochain/incognito-chain/consensus/blshotstuff"
 )
 
 //go:generate mockery -dir=database/ -name=DatabaseInterface
@@ -59,10 +61,16 @@ func mainMaster(serverChan chan<- *Server) error {
 	if interruptRequested(interrupt) {
 		return nil
 	}
-	db, err := database.Open("leveldb", filepath.Join(cfg.DataDir, cfg.DatabaseDir))
+	db, err := database.Open(cfg.DatabaseType, filepath.Join(cfg.DataDir, cfg.DatabaseDir))
 	// Create db and use it.
 	if err != nil {
-		Logger.log.Error("could not open connection to leveldb")
+		Logger.log.Errorf("could not open connection to %s", cfg.DatabaseType)
+		Logger.log.Error(err)
+		panic(err)
+	}
+	// Refuse to run on data stored with another key layout
+	if err := db.CheckSchemaVersion(); err != nil {
+		Logger.log.Error("could not use database")
 		Logger.log.Error(err)
 		panic(err)
 	}
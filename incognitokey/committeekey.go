@@ -82,7 +82,7 @@ func (pubKey *CommitteePublicKey) GetNormalKey() []byte {
 func (pubKey *CommitteePublicKey) GetMiningKey(schemeName string) ([]byte, error) {
 	allKey := map[string][]byte{}
 	var ok bool
-	allKey[schemeName], ok = pubKey.MiningPubKey[schemeName]
+	allKey[schemeName], ok = pubKey.MiningPubKey[common.GetMiningKeySchemeName(schemeName)]
 	if !ok {
 		return nil, errors.New("this schemeName doesn't exist")
 	}
@@ -106,7 +106,7 @@ func (pubKey *CommitteePublicKey) GetMiningKeyBase58(schemeName string) string {
 	if exist {
 		return value.(string)
 	}
-	keyBytes, ok := pubKey.MiningPubKey[schemeName]
+	keyBytes, ok := pubKey.MiningPubKey[common.GetMiningKeySchemeName(schemeName)]
 	if !ok {
 		return ""
 	}
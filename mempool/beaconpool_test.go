@@ -5,6 +5,7 @@ import (
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/pubsub"
+	"sync"
 	"testing"
 	"time"
 )
@@ -72,6 +73,7 @@ var InitBeaconPoolTest = func(pubsubManager *pubsub.PubSubManager) {
 		CacheSize:       beaconCacheSize,
 	}
 	beaconPoolTest.cache, _ = lru.New(beaconPool.config.CacheSize)
+	beaconPoolTest.mtx = new(sync.RWMutex)
 	beaconPoolTest.PubSubManager = pubsubManager
 	_, subChanRole, _ := beaconPoolTest.PubSubManager.RegisterNewSubscriber(pubsub.BeaconRoleTopic)
 	beaconPoolTest.RoleInCommitteesEvent = subChanRole
@@ -123,6 +125,7 @@ func ResetBeaconPool() {
 		CacheSize:       beaconCacheSize,
 	}
 	beaconPool.cache, _ = lru.New(beaconPool.config.CacheSize)
+	beaconPool.mtx = new(sync.RWMutex)
 	InitBeaconPool(pbBeaconPool)
 	// reset beacon pool test value
 	InitBeaconPoolTest(pbBeaconPool)
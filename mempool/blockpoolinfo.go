@@ -0,0 +1,158 @@
+package mempool
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// Status of a block in CrossShardPool or ShardToBeaconPool
+const (
+	BlockPoolStatusValid   = "valid"   // the block can be processed
+	BlockPoolStatusPending = "pending" // the block waits, BlockPoolBlockInfo.Reason tells why
+)
+
+// BlockPoolBlockInfo describes a block in CrossShardPool or ShardToBeaconPool
+type BlockPoolBlockInfo struct {
+	Height     uint64
+	Hash       common.Hash
+	Status     string
+	Reason     string
+	WaitHeight uint64 // height of the block which a pending block waits for, 0 if it does not wait for a block
+}
+
+// BlockPoolShardInfo lists the blocks of CrossShardPool or ShardToBeaconPool sent by a shard
+//   - LatestHeight: cross shard pool: latest block height of the shard processed by the pool shard,
+//     shard to beacon pool: latest valid block height of the shard in pool
+//   - ExpectedHeight: height of the block which stalls the pending blocks, 0 if it is unknown
+type BlockPoolShardInfo struct {
+	ShardID        byte
+	LatestHeight   uint64
+	ExpectedHeight uint64
+	Blocks         []BlockPoolBlockInfo
+}
+
+func sortedBlockPoolShardIDs(shardIDs map[byte]struct{}) []byte {
+	result := make([]byte, 0, len(shardIDs))
+	for shardID := range shardIDs {
+		result = append(result, shardID)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+	return result
+}
+
+// GetBlockPoolInfo returns the valid and pending blocks of the pool per source shard.
+// Pending blocks are checked the same way updatePool does to tell why they are not valid yet,
+// the first pending block which can not be promoted stalls the blocks after it.
+func (crossShardPool *CrossShardPool) GetBlockPoolInfo() []BlockPoolShardInfo {
+	crossShardPool.mtx.RLock()
+	defer crossShardPool.mtx.RUnlock()
+	shardIDs := make(map[byte]struct{})
+	for shardID, blks := range crossShardPool.validPool {
+		if len(blks) > 0 {
+			shardIDs[shardID] = struct{}{}
+		}
+	}
+	for shardID, blks := range crossShardPool.pendingPool {
+		if len(blks) > 0 {
+			shardIDs[shardID] = struct{}{}
+		}
+	}
+	result := []BlockPoolShardInfo{}
+	for _, shardID := range sortedBlockPoolShardIDs(shardIDs) {
+		info := BlockPoolShardInfo{ShardID: shardID, LatestHeight: crossShardPool.crossShardState[shardID]}
+		startHeight := info.LatestHeight
+		for _, blk := range crossShardPool.validPool[shardID] {
+			info.Blocks = append(info.Blocks, BlockPoolBlockInfo{Height: blk.Header.Height, Hash: *blk.Hash(), Status: BlockPoolStatusValid})
+			startHeight = blk.Header.Height
+		}
+		stalledBy := ""
+		for _, blk := range crossShardPool.pendingPool[shardID] {
+			blkInfo := BlockPoolBlockInfo{Height: blk.Header.Height, Hash: *blk.Hash(), Status: BlockPoolStatusPending}
+			if stalledBy != "" {
+				blkInfo.Reason = stalledBy
+				blkInfo.WaitHeight = info.ExpectedHeight
+				info.Blocks = append(info.Blocks, blkInfo)
+				continue
+			}
+			if err := crossShardPool.validateCrossShardBlockSignature(blk); err != nil {
+				blkInfo.Reason = fmt.Sprintf("invalid signature: %+v", err)
+				stalledBy = fmt.Sprintf("stalled by block %+v with invalid signature", blk.Header.Height)
+				info.Blocks = append(info.Blocks, blkInfo)
+				continue
+			}
+			waitHeight := crossShardPool.GetNextCrossShardHeight(shardID, crossShardPool.shardID, startHeight)
+			switch {
+			case waitHeight == 0:
+				blkInfo.Reason = fmt.Sprintf("beacon has not confirmed any cross shard block after height %+v", startHeight)
+				stalledBy = blkInfo.Reason
+			case waitHeight > blk.Header.Height:
+				blkInfo.Reason = fmt.Sprintf("beacon confirmed block %+v after height %+v, the block will be removed", waitHeight, startHeight)
+			case waitHeight == blk.Header.Height:
+				blkInfo.Reason = "confirmed by beacon, the block will be valid on the next pool update"
+				startHeight = waitHeight
+			default:
+				blkInfo.Reason = fmt.Sprintf("waiting for block %+v", waitHeight)
+				blkInfo.WaitHeight = waitHeight
+				info.ExpectedHeight = waitHeight
+				stalledBy = blkInfo.Reason
+			}
+			info.Blocks = append(info.Blocks, blkInfo)
+		}
+		result = append(result, info)
+	}
+	return result
+}
+
+// GetBlockPoolInfo returns the blocks of the pool per shard, blocks up to the latest valid height are valid.
+// A pending block waits for the block after the latest valid height, or for its next block which
+// links to it to be valid.
+func (shardToBeaconPool *ShardToBeaconPool) GetBlockPoolInfo() []BlockPoolShardInfo {
+	shardToBeaconPool.mtx.RLock()
+	defer shardToBeaconPool.mtx.RUnlock()
+	shardToBeaconPool.latestValidHeightMutex.RLock()
+	defer shardToBeaconPool.latestValidHeightMutex.RUnlock()
+	shardIDs := make(map[byte]struct{})
+	for shardID, blks := range shardToBeaconPool.pool {
+		if len(blks) > 0 {
+			shardIDs[shardID] = struct{}{}
+		}
+	}
+	result := []BlockPoolShardInfo{}
+	for _, shardID := range sortedBlockPoolShardIDs(shardIDs) {
+		blks := shardToBeaconPool.pool[shardID]
+		info := BlockPoolShardInfo{ShardID: shardID, LatestHeight: shardToBeaconPool.latestValidHeight[shardID]}
+		stalledBy := ""
+		for i, blk := range blks {
+			blkInfo := BlockPoolBlockInfo{Height: blk.Header.Height, Hash: *blk.Hash(), Status: BlockPoolStatusPending}
+			switch {
+			case blk.Header.Height <= info.LatestHeight:
+				blkInfo.Status = BlockPoolStatusValid
+			case stalledBy != "":
+				blkInfo.Reason = stalledBy
+				blkInfo.WaitHeight = info.ExpectedHeight
+			case blk.Header.Height > info.LatestHeight+1:
+				info.ExpectedHeight = info.LatestHeight + 1
+				blkInfo.Reason = fmt.Sprintf("waiting for block %+v", info.ExpectedHeight)
+				blkInfo.WaitHeight = info.ExpectedHeight
+				stalledBy = blkInfo.Reason
+			case i == len(blks)-1 || blks[i+1].Header.Height != blk.Header.Height+1:
+				info.ExpectedHeight = blk.Header.Height + 1
+				blkInfo.Reason = fmt.Sprintf("waiting for block %+v to link to it", info.ExpectedHeight)
+				blkInfo.WaitHeight = info.ExpectedHeight
+				stalledBy = fmt.Sprintf("stalled by block %+v", blk.Header.Height)
+			default:
+				info.ExpectedHeight = blk.Header.Height + 1
+				blkInfo.Reason = fmt.Sprintf("block %+v does not link to it", info.ExpectedHeight)
+				blkInfo.WaitHeight = info.ExpectedHeight
+				stalledBy = fmt.Sprintf("stalled by block %+v", blk.Header.Height)
+			}
+			info.Blocks = append(info.Blocks, blkInfo)
+		}
+		result = append(result, info)
+	}
+	return result
+}
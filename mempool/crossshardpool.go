@@ -303,6 +303,35 @@ func (crossShardPool *CrossShardPool) removeBlockByHeight(removeSinceBlkHeight m
 	}
 }
 
+// RemoveBlock drops the block of shard fromShardID at height from pool.
+// Dropping a valid block moves the valid blocks after it back to pending pool, they become valid again
+// only when the dropped block is received again.
+func (crossShardPool *CrossShardPool) RemoveBlock(fromShardID byte, height uint64) error {
+	crossShardPool.mtx.Lock()
+	defer crossShardPool.mtx.Unlock()
+	for index, blk := range crossShardPool.validPool[fromShardID] {
+		if blk.Header.Height != height {
+			continue
+		}
+		validBlks := crossShardPool.validPool[fromShardID]
+		pendingBlks := append([]*blockchain.CrossShardBlock{}, validBlks[index+1:]...)
+		crossShardPool.validPool[fromShardID] = validBlks[:index]
+		crossShardPool.pendingPool[fromShardID] = append(pendingBlks, crossShardPool.pendingPool[fromShardID]...)
+		Logger.log.Infof("Remove valid Cross Shard Block %+v from shard %+v, move %+v valid blocks to pending pool", height, fromShardID, len(pendingBlks))
+		return nil
+	}
+	for index, blk := range crossShardPool.pendingPool[fromShardID] {
+		if blk.Header.Height != height {
+			continue
+		}
+		pendingBlks := crossShardPool.pendingPool[fromShardID]
+		crossShardPool.pendingPool[fromShardID] = append(pendingBlks[:index:index], pendingBlks[index+1:]...)
+		Logger.log.Infof("Remove pending Cross Shard Block %+v from shard %+v", height, fromShardID)
+		return nil
+	}
+	return NewBlockPoolError(BlockNotFoundError, fmt.Errorf("Cross Shard Block %+v from shard %+v is not in pool of shard %+v", height, fromShardID, crossShardPool.shardID))
+}
+
 func (crossShardPool *CrossShardPool) GetValidBlock(limit map[byte]uint64) map[byte][]*blockchain.CrossShardBlock {
 	crossShardPool.mtx.RLock()
 	defer crossShardPool.mtx.RUnlock()
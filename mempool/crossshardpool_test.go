@@ -309,3 +309,53 @@ func TestCrossShardPoolv2AddCrossShardBlock(t *testing.T) {
 		}
 	}
 }
+func TestCrossShardPoolv2GetBlockPoolInfoAndRemoveBlock(t *testing.T) {
+	ResetCrossShardPoolTest()
+	fromShardID := byte(0)
+	toShardID := byte(1)
+	pool := crossShardPoolMapTest[toShardID]
+	pool.crossShardState = map[byte]uint64{fromShardID: 1}
+	pool.validPool[fromShardID] = []*blockchain.CrossShardBlock{crossShardBlock3, crossShardBlock4}
+	pool.pendingPool[fromShardID] = []*blockchain.CrossShardBlock{crossShardBlock5, crossShardBlock7, crossShardBlock8}
+	infos := pool.GetBlockPoolInfo()
+	if len(infos) != 1 || infos[0].ShardID != fromShardID || len(infos[0].Blocks) != 5 {
+		t.Fatalf("Expect info of 5 blocks from shard 0 but get %+v", infos)
+	}
+	for index, status := range []string{BlockPoolStatusValid, BlockPoolStatusValid, BlockPoolStatusPending, BlockPoolStatusPending, BlockPoolStatusPending} {
+		if infos[0].Blocks[index].Status != status {
+			t.Fatalf("Expect block %+v to be %+v but get %+v", infos[0].Blocks[index].Height, status, infos[0].Blocks[index])
+		}
+	}
+	// block 5 and 7 are confirmed by beacon, block 8 is not
+	if infos[0].ExpectedHeight != 0 || infos[0].Blocks[4].Reason == "" {
+		t.Fatalf("Expect block 8 to wait for beacon confirmation but get %+v", infos[0])
+	}
+
+	// dropping a valid block moves the valid blocks after it to pending pool
+	if err := pool.RemoveBlock(fromShardID, 3); err != nil {
+		t.Fatalf("Expect no error but get %+v", err)
+	}
+	if len(pool.validPool[fromShardID]) != 0 || len(pool.pendingPool[fromShardID]) != 4 {
+		t.Fatalf("Expect 0 valid block and 4 pending blocks but get %+v and %+v", len(pool.validPool[fromShardID]), len(pool.pendingPool[fromShardID]))
+	}
+	infos = pool.GetBlockPoolInfo()
+	if infos[0].ExpectedHeight != 3 {
+		t.Fatalf("Expect pool to wait for block 3 but get %+v", infos[0].ExpectedHeight)
+	}
+	for _, blkInfo := range infos[0].Blocks {
+		if blkInfo.Status != BlockPoolStatusPending || blkInfo.WaitHeight != 3 {
+			t.Fatalf("Expect block %+v to wait for block 3 but get %+v", blkInfo.Height, blkInfo)
+		}
+	}
+
+	if err := pool.RemoveBlock(fromShardID, 7); err != nil {
+		t.Fatalf("Expect no error but get %+v", err)
+	}
+	if len(pool.pendingPool[fromShardID]) != 3 || pool.pendingPool[fromShardID][2].Header.Height != 8 {
+		t.Fatalf("Expect pending blocks 4, 5, 8 but get %+v", pool.GetPendingBlockHeight())
+	}
+	err := pool.RemoveBlock(fromShardID, 7)
+	if err == nil || err.(*BlockPoolError).Code != ErrCodeMessage[BlockNotFoundError].Code {
+		t.Fatalf("Expect %+v error but get %+v", BlockNotFoundError, err)
+	}
+}
@@ -43,6 +43,11 @@ const (
 	ValidateAggSignatureForCrossShardBlockError
 	DuplicateSerialNumbersHashError
 	CouldNotGetExchangeRateError
+	RejectReplacementChainError
+	RejectMempoolMinFeeError
+	RejectSenderRateLimitError
+	RejectExpiredTxError
+	BlockNotFoundError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -83,6 +88,11 @@ var ErrCodeMessage = map[int]struct {
 	RejectDuplicateRequestStopAutoStaking:       {-1030, "Reject Duplicate Request Stop Auto Staking"},
 	DuplicateSerialNumbersHashError:             {-1031, "Duplicate Serial Numbers Hash Error"},
 	CouldNotGetExchangeRateError:                {-1032, "Could not get the exchange rate error"},
+	RejectReplacementChainError:                 {-1033, "Reject Replacement Of Too Long Chain Of Replacements"},
+	RejectMempoolMinFeeError:                    {-1034, "Reject Fee Not Higher Than Mempool Minimum Fee"},
+	RejectSenderRateLimitError:                  {-1035, "Reject Transaction Over Rate Limit Of Its Sender"},
+	RejectExpiredTxError:                        {-1036, "Reject Transaction Over Its Life Time In Pool"},
+	BlockNotFoundError:                          {-1037, "Block Not Found In Pool Error"},
 }
 
 type MempoolTxError struct {
@@ -18,6 +18,8 @@ import (
 
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/transaction"
 )
 
@@ -41,6 +43,10 @@ const (
 	// number of blocks which must be observed by the fee estimator before
 	// it will provide fee estimations.
 	DefaultEstimateFeeMinRegisteredBlocks = 3
+
+	// estimateFeeAllMetaTypes is the metadata type used to estimate fee
+	// from the txs of all metadata types.
+	estimateFeeAllMetaTypes = -1
 )
 
 var (
@@ -70,6 +76,12 @@ type observedTransaction struct {
 	// The token fee per kilobyte of the transaction in coins.
 	feeRateForToken map[common.Hash]CoinPerKilobyte
 
+	// The metadata type of the transaction, metadata.InvalidMeta if it has no metadata.
+	metaType int
+
+	// The size of the transaction in kilobytes.
+	size uint64
+
 	// The block height when it was observed.
 	observed uint64
 
@@ -81,7 +93,23 @@ type observedTransaction struct {
 func (o *observedTransaction) Serialize(w io.Writer) {
 	binary.Write(w, binary.BigEndian, o.hash)
 	binary.Write(w, binary.BigEndian, o.feeRate)
-	binary.Write(w, binary.BigEndian, o.feeRateForToken)
+
+	// Token fee rates are sorted by token ID so that a serialized state always comes out the same.
+	tokenIDs := make([]common.Hash, 0, len(o.feeRateForToken))
+	for tokenID := range o.feeRateForToken {
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	sort.Slice(tokenIDs, func(i, j int) bool {
+		return bytes.Compare(tokenIDs[i][:], tokenIDs[j][:]) < 0
+	})
+	binary.Write(w, binary.BigEndian, uint32(len(tokenIDs)))
+	for _, tokenID := range tokenIDs {
+		binary.Write(w, binary.BigEndian, tokenID)
+		binary.Write(w, binary.BigEndian, o.feeRateForToken[tokenID])
+	}
+
+	binary.Write(w, binary.BigEndian, int32(o.metaType))
+	binary.Write(w, binary.BigEndian, o.size)
 	binary.Write(w, binary.BigEndian, o.observed)
 	binary.Write(w, binary.BigEndian, o.mined)
 }
@@ -95,12 +123,36 @@ func deserializeObservedTransaction(r io.Reader) (*observedTransaction, error) {
 	// The next 8 are feeRate
 	binary.Read(r, binary.BigEndian, &ot.feeRate)
 
-	// The next 8 are feeRateForToken
-	binary.Read(r, binary.BigEndian, &ot.feeRateForToken)
+	// Then the number of token fee rates and the pairs of token ID and fee rate
+	var numTokens uint32
+	err := binary.Read(r, binary.BigEndian, &numTokens)
+	if err != nil {
+		return nil, err
+	}
+	ot.feeRateForToken = make(map[common.Hash]CoinPerKilobyte)
+	for i := uint32(0); i < numTokens; i++ {
+		var tokenID common.Hash
+		var feeRate CoinPerKilobyte
+		binary.Read(r, binary.BigEndian, &tokenID)
+		err := binary.Read(r, binary.BigEndian, &feeRate)
+		if err != nil {
+			return nil, err
+		}
+		ot.feeRateForToken[tokenID] = feeRate
+	}
+
+	// The metadata type and the size
+	var metaType int32
+	binary.Read(r, binary.BigEndian, &metaType)
+	ot.metaType = int(metaType)
+	binary.Read(r, binary.BigEndian, &ot.size)
 
-	// And next there are two uint32's.
+	// And next there are two uint64's.
 	binary.Read(r, binary.BigEndian, &ot.observed)
-	binary.Read(r, binary.BigEndian, &ot.mined)
+	err = binary.Read(r, binary.BigEndian, &ot.mined)
+	if err != nil {
+		return nil, err
+	}
 
 	return &ot, nil
 }
@@ -147,8 +199,8 @@ type FeeEstimator struct {
 	observed map[common.Hash]*observedTransaction
 	bin      [estimateFeeDepth][]*observedTransaction
 
-	// The cached estimates.
-	cached []CoinPerKilobyte
+	// The cached estimates for each token and metadata type.
+	cached map[estimateFeeKey][]CoinPerKilobyte
 
 	// Transactions that have been removed from the bins. This allows us to
 	// revert in case of an orphaned block.
@@ -196,10 +248,18 @@ func (ef *FeeEstimator) ObserveTransaction(t *TxDesc) {
 			feeRateForToken[*tokenID] = NewCoinPerKilobyte(tokenFee, size)
 		}
 
+		// FeePerKB of the mempool includes the token fee converted to PRV with the PDE exchange rate
+		feeRate := CoinPerKilobyte(t.Desc.FeePerKB)
+		if feeRate == 0 {
+			feeRate = NewCoinPerKilobyte(uint64(t.Desc.Fee), size)
+		}
+
 		ef.observed[hash] = &observedTransaction{
 			hash:            hash,
-			feeRate:         NewCoinPerKilobyte(uint64(t.Desc.Fee), size),
+			feeRate:         feeRate,
 			feeRateForToken: feeRateForToken,
+			metaType:        t.Desc.Tx.GetMetadataType(),
+			size:            size,
 			observed:        t.Desc.Height,
 			mined:           unminedHeight,
 		}
@@ -453,13 +513,19 @@ func (ef *FeeEstimator) rollback() {
 	ef.lastKnownHeight--
 }
 
+// estimateFeeKey identifies a set of fee estimates,
+// tokenID is the zero hash for the PRV fee.
+type estimateFeeKey struct {
+	tokenID  common.Hash
+	metaType int
+}
+
 // estimateFeeSet is a set of txs that can that is sorted
 // by the fee per kb rate.
 // inherit from golang sorter
 type estimateFeeSet struct {
-	feeRate         []CoinPerKilobyte
-	feeRateForToken map[common.Hash][]CoinPerKilobyte
-	bin             [estimateFeeDepth]uint32
+	feeRate []CoinPerKilobyte
+	bin     [estimateFeeDepth]uint32
 }
 
 func (b *estimateFeeSet) Len() int { return len(b.feeRate) }
@@ -506,135 +572,176 @@ func (b *estimateFeeSet) estimateFee(confirmations int) CoinPerKilobyte {
 	return b.feeRate[feeIndex]
 }
 
-// estimateFee returns the estimated fee for a transaction
-// to confirm in confirmations blocks from now, given
-// the data set we have collected.
-func (b *estimateFeeSet) estimateFeeForToken(confirmations int, tokenId *common.Hash) CoinPerKilobyte {
-	if confirmations <= 0 {
-		return CoinPerKilobyte(math.Inf(1))
-	}
-
-	if confirmations > estimateFeeDepth {
-		return 0
-	}
-
-	// We don't have any privacy token transactions!
-	if len(b.feeRateForToken[*tokenId]) == 0 {
-		return 0
-	}
-
-	// sort feeRateForToken
-
-	var min, max = 0, 0
-	for i := 0; i < confirmations-1; i++ {
-		min += int(b.bin[i])
-	}
-
-	max = min + int(b.bin[confirmations-1]) - 1
-	if max < min {
-		max = min
-	}
-	feeIndex := (min + max) / 2
-	if feeIndex >= len(b.feeRateForToken[*tokenId]) {
-		feeIndex = len(b.feeRateForToken[*tokenId]) - 1
-	}
-
-	return b.feeRateForToken[*tokenId][feeIndex]
-}
-
 // newEstimateFeeSet creates a temporary data structure that
-// can be used to find all fee estimates.
-func (ef *FeeEstimator) newEstimateFeeSet(tokenID *common.Hash) *estimateFeeSet {
+// can be used to find all fee estimates of the txs with the metadata type of key
+// (all txs for estimateFeeAllMetaTypes) which paid fee in the token of key.
+func (ef *FeeEstimator) newEstimateFeeSet(key estimateFeeKey) *estimateFeeSet {
 	set := &estimateFeeSet{}
+	isToken := key.tokenID != common.Hash{}
 
-	capacity := 0
 	for i, b := range ef.bin {
-		l := len(b)
-		set.bin[i] = uint32(l)
-		capacity += l
-	}
-
-	set.feeRate = make([]CoinPerKilobyte, capacity)
-	set.feeRateForToken = make(map[common.Hash][]CoinPerKilobyte)
-
-	i := 0
-	for _, b := range ef.bin {
 		for _, o := range b {
-			set.feeRate[i] = o.feeRate
-
-			if tokenID != nil {
-				for key, value := range o.feeRateForToken {
-					if key.IsEqual(tokenID) {
-						set.feeRateForToken[key] = append(set.feeRateForToken[key], value)
-					}
+			if key.metaType != estimateFeeAllMetaTypes && o.metaType != key.metaType {
+				continue
+			}
+			feeRate := o.feeRate
+			if isToken {
+				var ok bool
+				feeRate, ok = o.feeRateForToken[key.tokenID]
+				if !ok {
+					continue
 				}
 			}
-
-			i++
+			set.feeRate = append(set.feeRate, feeRate)
+			set.bin[i]++
 		}
 	}
 
-	if tokenID == nil {
-		sort.Sort(set)
-	} else {
-	}
+	sort.Sort(set)
 
 	return set
 }
 
 // estimates returns the set of all fee estimates from 1 to estimateFeeDepth
-// confirmations from now.
-func (ef *FeeEstimator) estimates(tokenID *common.Hash) []CoinPerKilobyte {
-	set := ef.newEstimateFeeSet(tokenID)
+// confirmations from now, nil if no tx of the set of key has been observed.
+func (ef *FeeEstimator) estimates(key estimateFeeKey) []CoinPerKilobyte {
+	if estimates, ok := ef.cached[key]; ok {
+		return estimates
+	}
+	if ef.cached == nil {
+		ef.cached = make(map[estimateFeeKey][]CoinPerKilobyte)
+	}
 
-	estimates := make([]CoinPerKilobyte, estimateFeeDepth)
-	for i := 0; i < estimateFeeDepth; i++ {
-		if tokenID != nil {
-			estimates[i] = set.estimateFeeForToken(i+1, tokenID)
-		} else {
+	set := ef.newEstimateFeeSet(key)
+	var estimates []CoinPerKilobyte
+	if set.Len() > 0 {
+		estimates = make([]CoinPerKilobyte, estimateFeeDepth)
+		for i := 0; i < estimateFeeDepth; i++ {
 			estimates[i] = set.estimateFee(i + 1)
 		}
 	}
+	ef.cached[key] = estimates
 
 	return estimates
 }
 
-// EstimateFee estimates the fee per byte to have a tx confirmed a given
-// number of blocks from now.
-func (ef *FeeEstimator) EstimateFee(numBlocks uint64, tokenId *common.Hash) (CoinPerKilobyte, error) {
-	ef.mtx.Lock()
-	defer ef.mtx.Unlock()
-
+// checkEstimateFee returns an error if the estimator can not estimate fee for numBlocks
+func (ef *FeeEstimator) checkEstimateFee(numBlocks uint64) error {
 	// If the number of registered blocks is below the minimum, return
 	// an error.
 	if ef.numBlocksRegistered < ef.minRegisteredBlocks {
-		return 0, errors.New("not enough blocks have been observed")
+		return errors.New("not enough blocks have been observed")
 	}
 
 	if numBlocks == 0 {
-		return 0, errors.New("cannot confirm transaction in zero blocks")
+		return errors.New("cannot confirm transaction in zero blocks")
 	}
 
 	if numBlocks > estimateFeeDepth {
-		return 0, fmt.Errorf(
+		return fmt.Errorf(
 			"can only estimate fees for up to %d blocks from now",
 			estimateFeeBinSize)
 	}
+	return nil
+}
 
-	// If there are no cached results, generate them.
-	if ef.cached == nil {
-		ef.cached = ef.estimates(tokenId)
+// EstimateFee estimates the fee per byte to have a tx confirmed a given
+// number of blocks from now.
+func (ef *FeeEstimator) EstimateFee(numBlocks uint64, tokenId *common.Hash) (CoinPerKilobyte, error) {
+	ef.mtx.Lock()
+	defer ef.mtx.Unlock()
+
+	if err := ef.checkEstimateFee(numBlocks); err != nil {
+		return 0, err
 	}
 
-	result := ef.cached[int(numBlocks)-1]
-	return result, nil
+	key := estimateFeeKey{metaType: estimateFeeAllMetaTypes}
+	if tokenId != nil {
+		key.tokenID = *tokenId
+	}
+	estimates := ef.estimates(key)
+	if estimates == nil {
+		return 0, nil
+	}
+	return estimates[int(numBlocks)-1], nil
+}
+
+// EstimateFeeForMetaType estimates the fee per kilobyte to have a tx with metadata type metaType
+// (metadata.InvalidMeta for a tx without metadata) confirmed a given number of blocks from now.
+// The estimate falls back to the txs of all metadata types if no tx of metaType has been observed.
+// If tokenID is not nil the fee is estimated in this token, when no tx paid fee in this token
+// the PRV estimate is converted with the exchange rate of the PDE pool at beaconHeight.
+func (ef *FeeEstimator) EstimateFeeForMetaType(
+	numBlocks uint64,
+	tokenID *common.Hash,
+	metaType int,
+	beaconHeight int64,
+	db database.PDEStore,
+) (CoinPerKilobyte, error) {
+	ef.mtx.Lock()
+	if err := ef.checkEstimateFee(numBlocks); err != nil {
+		ef.mtx.Unlock()
+		return 0, err
+	}
+	estimateFor := func(key estimateFeeKey) CoinPerKilobyte {
+		estimates := ef.estimates(key)
+		if estimates == nil && key.metaType != estimateFeeAllMetaTypes {
+			key.metaType = estimateFeeAllMetaTypes
+			estimates = ef.estimates(key)
+		}
+		if estimates == nil {
+			return 0
+		}
+		return estimates[int(numBlocks)-1]
+	}
+	feeRate := estimateFor(estimateFeeKey{metaType: metaType})
+	feeRateForToken := CoinPerKilobyte(0)
+	if tokenID != nil {
+		feeRateForToken = estimateFor(estimateFeeKey{tokenID: *tokenID, metaType: metaType})
+	}
+	ef.mtx.Unlock()
+
+	if tokenID == nil {
+		return feeRate, nil
+	}
+	if feeRateForToken > 0 || feeRate == 0 {
+		return feeRateForToken, nil
+	}
+	// no tx paid fee in this token, convert the PRV estimate
+	feeRateInToken, err := metadata.ConvertNativeTokenToPrivacyToken(uint64(feeRate), tokenID, beaconHeight, db)
+	if err != nil {
+		return 0, err
+	}
+	return CoinPerKilobyte(math.Ceil(feeRateInToken)), nil
+}
+
+// EstimateTxSizeForMetaType returns the average size in kilobytes of the observed txs with metadata type metaType
+// which have been mined, 0 if there is none
+func (ef *FeeEstimator) EstimateTxSizeForMetaType(metaType int) uint64 {
+	ef.mtx.Lock()
+	defer ef.mtx.Unlock()
+
+	count := uint64(0)
+	totalSize := uint64(0)
+	for _, b := range ef.bin {
+		for _, o := range b {
+			if o.metaType == metaType {
+				count++
+				totalSize += o.size
+			}
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return uint64(math.Ceil(float64(totalSize) / float64(count)))
 }
 
 // In case the format for the serialized version of the feeEstimator changes,
 // we use a version number. If the version number changes, it does not make
 // sense to try to upgrade a previous version to a new version. Instead, just
 // start fee estimation over.
-const estimateFeeSaveVersion = 1
+const estimateFeeSaveVersion = 2
 
 func deserializeRegisteredBlock(r io.Reader, txs map[uint32]*observedTransaction) (*registeredBlock, error) {
 	var lenTransactions uint32
@@ -0,0 +1,119 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEstimateFeeTestTxDesc(lockTime int64, feePerKB uint64, meta metadata.Metadata) *TxDesc {
+	return &TxDesc{
+		Desc: metadata.TxDesc{
+			Tx:       &transaction.Tx{Type: common.TxNormalType, LockTime: lockTime, Metadata: meta},
+			Height:   1,
+			FeePerKB: feePerKB,
+		},
+	}
+}
+
+func newEstimateFeeTestBlock(height uint64, txDescs ...*TxDesc) *blockchain.ShardBlock {
+	block := &blockchain.ShardBlock{}
+	block.Header.Height = height
+	for _, txD := range txDescs {
+		block.Body.Transactions = append(block.Body.Transactions, txD.Desc.Tx)
+	}
+	return block
+}
+
+// newEstimateFeeTestEstimator returns an estimator which observed 3 txs without metadata and 1 withdraw reward response tx
+// mined in the next block
+func newEstimateFeeTestEstimator(t *testing.T) *FeeEstimator {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	ef := NewFeeEstimator(DefaultEstimateFeeMaxRollback, 1, 1)
+	assert.Nil(t, ef.RegisterBlock(newEstimateFeeTestBlock(1)))
+
+	meta, err := metadata.NewWithDrawRewardResponse(&common.Hash{})
+	assert.Nil(t, err)
+	txDescs := []*TxDesc{
+		newEstimateFeeTestTxDesc(1, 10, nil),
+		newEstimateFeeTestTxDesc(2, 20, nil),
+		newEstimateFeeTestTxDesc(3, 30, nil),
+		newEstimateFeeTestTxDesc(4, 100, meta),
+	}
+	for _, txD := range txDescs {
+		ef.ObserveTransaction(txD)
+	}
+	assert.Nil(t, ef.RegisterBlock(newEstimateFeeTestBlock(2, txDescs...)))
+	return ef
+}
+
+func TestFeeEstimatorEstimateFeeForMetaType(t *testing.T) {
+	ef := newEstimateFeeTestEstimator(t)
+
+	feeRate, err := ef.EstimateFeeForMetaType(1, nil, metadata.InvalidMeta, -1, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, CoinPerKilobyte(20), feeRate)
+
+	feeRate, err = ef.EstimateFeeForMetaType(1, nil, metadata.WithDrawRewardResponseMeta, -1, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, CoinPerKilobyte(100), feeRate)
+
+	// no staking tx has been observed, the estimate falls back to all txs
+	feeRate, err = ef.EstimateFeeForMetaType(1, nil, metadata.ShardStakingMeta, -1, nil)
+	assert.Nil(t, err)
+	feeRateAll, err := ef.EstimateFee(1, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, feeRateAll, feeRate)
+	assert.Equal(t, CoinPerKilobyte(30), feeRate)
+
+	// txs which paid fee in the token are used for the token estimate
+	tokenID := common.Hash{1}
+	for _, o := range ef.bin[0] {
+		if o.metaType == metadata.InvalidMeta {
+			o.feeRateForToken[tokenID] = 7
+		}
+	}
+	ef.cached = nil
+	feeRate, err = ef.EstimateFeeForMetaType(1, &tokenID, metadata.InvalidMeta, -1, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, CoinPerKilobyte(7), feeRate)
+
+	assert.Equal(t, uint64(1), ef.EstimateTxSizeForMetaType(metadata.InvalidMeta))
+	assert.Equal(t, uint64(0), ef.EstimateTxSizeForMetaType(metadata.ShardStakingMeta))
+
+	_, err = ef.EstimateFeeForMetaType(0, nil, metadata.InvalidMeta, -1, nil)
+	assert.NotNil(t, err)
+}
+
+func TestFeeEstimatorSaveRestore(t *testing.T) {
+	ef := newEstimateFeeTestEstimator(t)
+	tokenID := common.Hash{1}
+	for _, o := range ef.bin[0] {
+		o.feeRateForToken[tokenID] = 7
+	}
+
+	restored, err := RestoreFeeEstimator(ef.Save())
+	assert.Nil(t, err)
+	assert.Equal(t, ef.Save(), restored.Save())
+	assert.Equal(t, len(ef.observed), len(restored.observed))
+	for hash, o := range ef.observed {
+		assert.Equal(t, *o, *restored.observed[hash])
+	}
+
+	feeRate, err := restored.EstimateFeeForMetaType(1, nil, metadata.WithDrawRewardResponseMeta, -1, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, CoinPerKilobyte(100), feeRate)
+	feeRate, err = restored.EstimateFeeForMetaType(1, &tokenID, metadata.InvalidMeta, -1, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, CoinPerKilobyte(7), feeRate)
+
+	// a state saved with another version is not restored
+	state := ef.Save()
+	state[3] = 1
+	_, err = RestoreFeeEstimator(state)
+	assert.NotNil(t, err)
+}
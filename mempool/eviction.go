@@ -0,0 +1,90 @@
+package mempool
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// rollingMinFeeHalfLife is the time it takes for the rolling minimum fee of a full pool to be halved,
+// it is divided by 2 when the pool is less than half full and by 4 when it is less than a quarter full
+const rollingMinFeeHalfLife = 10 * time.Minute
+
+// evictLowestFeeTxs drops the txs with the lowest fee per kilobyte until the pool has at most maxTx txs.
+// The rolling minimum fee becomes the highest fee per kilobyte of the evicted txs.
+func (tp *TxPool) evictLowestFeeTxs(maxTx uint64) {
+	for uint64(len(tp.pool)) > maxTx && len(tp.poolFeeRate.descs) > 0 {
+		txDesc := tp.poolFeeRate.descs[len(tp.poolFeeRate.descs)-1]
+		Logger.log.Infof("Evict transaction %+v with fee %+v per KB from full pool", txDesc.Desc.Tx.Hash().String(), txDesc.Desc.FeePerKB)
+		tp.dropTx(txDesc.Desc.Tx)
+		tp.publishTxEvent(&TxEvent{Tx: txDesc.Desc.Tx, State: TxEventEvicted, Reason: TxEvictedByMinFee})
+		tp.bumpRollingMinFee(txDesc.Desc.FeePerKB)
+	}
+}
+
+// lowestFeePerKB returns the lowest fee per kilobyte of the txs in pool
+func (tp *TxPool) lowestFeePerKB() uint64 {
+	if len(tp.poolFeeRate.descs) == 0 {
+		return 0
+	}
+	return tp.poolFeeRate.descs[len(tp.poolFeeRate.descs)-1].Desc.FeePerKB
+}
+
+func (tp *TxPool) bumpRollingMinFee(feePerKB uint64) {
+	tp.decayRollingMinFee(time.Now())
+	if float64(feePerKB) > tp.rollingMinFeePerKB {
+		tp.rollingMinFeePerKB = float64(feePerKB)
+	}
+}
+
+// decayRollingMinFee halves the rolling minimum fee every half life since its last update
+func (tp *TxPool) decayRollingMinFee(now time.Time) {
+	defer func() {
+		tp.rollingMinFeeUpdated = now
+	}()
+	if tp.rollingMinFeePerKB == 0 {
+		return
+	}
+	halfLife := rollingMinFeeHalfLife
+	if poolSize := uint64(len(tp.pool)); poolSize < tp.config.MaxTx/4 {
+		halfLife /= 4
+	} else if poolSize < tp.config.MaxTx/2 {
+		halfLife /= 2
+	}
+	elapsed := now.Sub(tp.rollingMinFeeUpdated)
+	tp.rollingMinFeePerKB *= math.Pow(0.5, float64(elapsed)/float64(halfLife))
+	if tp.rollingMinFeePerKB < 1 {
+		tp.rollingMinFeePerKB = 0
+	}
+}
+
+// rollingMinFee returns the fee per kilobyte a tx must pay more than to enter the pool, 0 if the pool was never full
+func (tp *TxPool) rollingMinFee() uint64 {
+	tp.decayRollingMinFee(time.Now())
+	return uint64(math.Ceil(tp.rollingMinFeePerKB))
+}
+
+// MinFeePerKB returns the rolling minimum fee per kilobyte of the pool,
+// a tx paying no more than it is rejected. It is 0 unless txs were evicted from the full pool recently.
+func (tp *TxPool) MinFeePerKB() uint64 {
+	tp.mtx.Lock()
+	defer tp.mtx.Unlock()
+	return tp.rollingMinFee()
+}
+
+// checkPoolFeeRate checks that tx pays enough to enter the pool: more than the rolling minimum fee
+// and, if the pool is full, more than the tx with the lowest fee per kilobyte which is evicted for it
+func (tp *TxPool) checkPoolFeeRate(tx metadata.Transaction, beaconHeight int64) error {
+	feePerKB := tp.calculateFeePerKB(tx, beaconHeight)
+	if minFee := tp.rollingMinFee(); minFee > 0 && feePerKB <= minFee {
+		return NewMempoolTxError(RejectMempoolMinFeeError, fmt.Errorf("Transaction %+v pays %+v per KB, expect more than the mempool minimum fee %+v per KB", tx.Hash().String(), feePerKB, minFee))
+	}
+	if uint64(len(tp.pool)) >= tp.config.MaxTx {
+		if lowestFee := tp.lowestFeePerKB(); len(tp.pool) == 0 || feePerKB <= lowestFee {
+			return NewMempoolTxError(MaxPoolSizeError, fmt.Errorf("Pool reach max number of transaction, transaction %+v pays %+v per KB, expect more than %+v per KB", tx.Hash().String(), feePerKB, lowestFee))
+		}
+	}
+	return nil
+}
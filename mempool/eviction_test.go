@@ -0,0 +1,83 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEvictionTestTxPool(maxTx uint64, txDescs ...*TxDesc) *TxPool {
+	pool := &TxPool{
+		config:               Config{MaxTx: maxTx},
+		pool:                 map[common.Hash]*TxDesc{},
+		poolSerialNumberHash: map[common.Hash]common.Hash{},
+		poolCandidate:        map[common.Hash]string{},
+		poolTokenID:          map[common.Hash]string{},
+	}
+	for _, txD := range txDescs {
+		pool.pool[*txD.Desc.Tx.Hash()] = txD
+		pool.poolFeeRate.add(txD)
+	}
+	return pool
+}
+
+func TestTxPoolEvictLowestFeeTxs(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	now := time.Now()
+	txD1 := newFeeRateTestTxDesc(1, 10, now)
+	txD2 := newFeeRateTestTxDesc(2, 30, now)
+	txD3 := newFeeRateTestTxDesc(3, 20, now)
+	pool := newEvictionTestTxPool(3, txD1, txD2, txD3)
+	pool.poolTokenID[*txD1.Desc.Tx.Hash()] = "token"
+
+	pool.evictLowestFeeTxs(3)
+	assert.Equal(t, 3, len(pool.pool))
+	assert.Equal(t, uint64(0), pool.MinFeePerKB())
+
+	pool.evictLowestFeeTxs(1)
+	assert.Equal(t, []*TxDesc{txD2}, pool.poolFeeRate.list())
+	assert.Equal(t, 1, len(pool.pool))
+	assert.Equal(t, 0, len(pool.poolTokenID))
+	assert.Equal(t, uint64(20), pool.MinFeePerKB())
+}
+
+func TestTxPoolDecayRollingMinFee(t *testing.T) {
+	now := time.Now()
+	pool := newEvictionTestTxPool(4, newFeeRateTestTxDesc(1, 10, now), newFeeRateTestTxDesc(2, 10, now))
+	pool.rollingMinFeePerKB = 1000
+	pool.rollingMinFeeUpdated = now
+
+	// the pool is half full
+	pool.decayRollingMinFee(now.Add(rollingMinFeeHalfLife))
+	assert.Equal(t, float64(500), pool.rollingMinFeePerKB)
+
+	// the pool is less than half full
+	delete(pool.pool, *pool.poolFeeRate.descs[0].Desc.Tx.Hash())
+	pool.decayRollingMinFee(now.Add(rollingMinFeeHalfLife * 3 / 2))
+	assert.Equal(t, float64(250), pool.rollingMinFeePerKB)
+
+	// a fee lower than 1 resets the rolling minimum fee
+	pool.decayRollingMinFee(now.Add(rollingMinFeeHalfLife * 10))
+	assert.Equal(t, float64(0), pool.rollingMinFeePerKB)
+}
+
+func TestTxPoolCheckPoolFeeRate(t *testing.T) {
+	now := time.Now()
+	pool := newEvictionTestTxPool(1, newFeeRateTestTxDesc(1, 10, now))
+
+	// the pool is full and the tx does not pay more than the lowest fee in pool
+	err := pool.checkPoolFeeRate(&transaction.Tx{Type: common.TxNormalType, Fee: 10, LockTime: 2}, -1)
+	assert.Equal(t, ErrCodeMessage[MaxPoolSizeError].Code, err.(*MempoolTxError).Code)
+	assert.Nil(t, pool.checkPoolFeeRate(&transaction.Tx{Type: common.TxNormalType, Fee: 11, LockTime: 2}, -1))
+
+	// the tx does not pay more than the rolling minimum fee
+	pool.config.MaxTx = 10
+	pool.rollingMinFeePerKB = 20
+	pool.rollingMinFeeUpdated = time.Now()
+	err = pool.checkPoolFeeRate(&transaction.Tx{Type: common.TxNormalType, Fee: 20, LockTime: 2}, -1)
+	assert.Equal(t, ErrCodeMessage[RejectMempoolMinFeeError].Code, err.(*MempoolTxError).Code)
+	assert.Nil(t, pool.checkPoolFeeRate(&transaction.Tx{Type: common.TxNormalType, Fee: 100, LockTime: 2}, -1))
+}
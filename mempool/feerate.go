@@ -0,0 +1,82 @@
+package mempool
+
+import (
+	"math"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// calculateFeePerKB returns the fee per kilobyte of tx in PRV,
+// the token fee of a privacy token tx is converted to PRV with the rate of the PDE pool at beaconHeight.
+// A token fee which can not be converted does not count.
+func (tp *TxPool) calculateFeePerKB(tx metadata.Transaction, beaconHeight int64) uint64 {
+	fee := tx.GetTxFee()
+	feeToken := tx.GetTxFeeToken()
+	if feeToken > 0 && tx.GetType() == common.TxCustomTokenPrivacyType {
+		feeTokenToNativeToken, err := metadata.ConvertPrivacyTokenToNativeToken(feeToken, tx.GetTokenID(), beaconHeight, tp.config.DataBase)
+		if err != nil {
+			Logger.log.Debugf("Can not convert fee token %+v of tx %+v to PRV, error %+v", feeToken, tx.Hash().String(), err)
+		} else {
+			fee += uint64(math.Floor(feeTokenToNativeToken))
+		}
+	}
+	size := tx.GetTxActualSize()
+	if size == 0 {
+		size = 1
+	}
+	return uint64(NewCoinPerKilobyte(fee, size))
+}
+
+// feeRateIndex keeps the transactions of the pool ordered by fee per kilobyte, highest first.
+// Transactions with the same fee per kilobyte are ordered by the time they entered the pool, then by hash.
+// It is not safe for concurrent access, TxPool protects it with its mutex.
+type feeRateIndex struct {
+	descs []*TxDesc
+}
+
+// hasHigherPriority returns true if a must be mined before b
+func hasHigherPriority(a *TxDesc, b *TxDesc) bool {
+	if a.Desc.FeePerKB != b.Desc.FeePerKB {
+		return a.Desc.FeePerKB > b.Desc.FeePerKB
+	}
+	if !a.StartTime.Equal(b.StartTime) {
+		return a.StartTime.Before(b.StartTime)
+	}
+	return a.Desc.Tx.Hash().String() < b.Desc.Tx.Hash().String()
+}
+
+// search returns the position of txD in the index, or the position it must be inserted at
+func (index *feeRateIndex) search(txD *TxDesc) int {
+	return sort.Search(len(index.descs), func(i int) bool {
+		return !hasHigherPriority(index.descs[i], txD)
+	})
+}
+
+func (index *feeRateIndex) add(txD *TxDesc) {
+	i := index.search(txD)
+	index.descs = append(index.descs, nil)
+	copy(index.descs[i+1:], index.descs[i:])
+	index.descs[i] = txD
+}
+
+func (index *feeRateIndex) remove(txD *TxDesc) {
+	i := index.search(txD)
+	if i < len(index.descs) && index.descs[i].Desc.Tx.Hash().IsEqual(txD.Desc.Tx.Hash()) {
+		copy(index.descs[i:], index.descs[i+1:])
+		index.descs[len(index.descs)-1] = nil
+		index.descs = index.descs[:len(index.descs)-1]
+	}
+}
+
+func (index *feeRateIndex) reset() {
+	index.descs = []*TxDesc{}
+}
+
+// list returns the transactions from the highest fee per kilobyte to the lowest
+func (index *feeRateIndex) list() []*TxDesc {
+	result := make([]*TxDesc, len(index.descs))
+	copy(result, index.descs)
+	return result
+}
@@ -0,0 +1,42 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFeeRateTestTxDesc(lockTime int64, feePerKB uint64, startTime time.Time) *TxDesc {
+	return &TxDesc{
+		Desc: metadata.TxDesc{
+			Tx:       &transaction.Tx{LockTime: lockTime},
+			FeePerKB: feePerKB,
+		},
+		StartTime: startTime,
+	}
+}
+
+func TestFeeRateIndex(t *testing.T) {
+	now := time.Now()
+	txD1 := newFeeRateTestTxDesc(1, 10, now)
+	txD2 := newFeeRateTestTxDesc(2, 30, now)
+	txD3 := newFeeRateTestTxDesc(3, 20, now)
+	txD4 := newFeeRateTestTxDesc(4, 20, now.Add(-time.Second))
+	index := feeRateIndex{}
+	for _, txD := range []*TxDesc{txD1, txD2, txD3, txD4} {
+		index.add(txD)
+	}
+	assert.Equal(t, []*TxDesc{txD2, txD4, txD3, txD1}, index.list())
+
+	index.remove(txD4)
+	assert.Equal(t, []*TxDesc{txD2, txD3, txD1}, index.list())
+	// removing a tx which is not in the index does nothing
+	index.remove(txD4)
+	assert.Equal(t, []*TxDesc{txD2, txD3, txD1}, index.list())
+
+	index.reset()
+	assert.Equal(t, 0, len(index.list()))
+}
@@ -0,0 +1,270 @@
+package mempool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+/*
+Journal of the txs in pool
+
+The journal is an append only file, each record is
+	| length of data (4 bytes) | crc32 of data (4 bytes) | data |
+data of a tx added to pool is
+	| journalAddTx | tx hash | length of tx type (1 byte) | tx type | length of desc (4 bytes) | desc (json) | tx (json) |
+data of a tx removed from pool is
+	| journalRemoveTx | tx hash |
+Replaying the records gives the txs in pool in the order they were added. A record cut by a crash ends the replay
+and is truncated. The journal is compacted by rewriting it with only the txs in pool once the removed txs make most of it.
+*/
+
+const (
+	journalAddTx    = byte(1)
+	journalRemoveTx = byte(2)
+
+	journalRecordHeaderSize = 8
+	journalMaxRecordSize    = 10 << 20
+	// the journal is compacted when it has more than journalCompactMinRecords records and less than half of them are txs in pool
+	journalCompactMinRecords = 1000
+	journalCompactInterval   = time.Minute
+)
+
+type txJournal struct {
+	path    string
+	file    *os.File
+	writer  *bufio.Writer
+	records int // records in the journal file
+}
+
+// journalEntry is a tx replayed from the journal, txDesc is nil if the tx could not be decoded
+type journalEntry struct {
+	txHash  common.Hash
+	txDesc  *TxDesc
+	loadErr error
+}
+
+func newTxJournal(path string) *txJournal {
+	return &txJournal{path: path}
+}
+
+// load replays the journal and returns the txs which are still in it, in the order they were added,
+// then opens the journal for appending
+func (journal *txJournal) load() ([]*journalEntry, error) {
+	file, err := os.OpenFile(journal.path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	entries := []*journalEntry{}
+	index := make(map[common.Hash]int)
+	reader := bufio.NewReader(file)
+	offset := int64(0)
+	records := 0
+	for {
+		data, err := readJournalRecord(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			Logger.log.Errorf("Journal %+v is corrupted at offset %+v, truncate it: %+v", journal.path, offset, err)
+			if err := file.Truncate(offset); err != nil {
+				file.Close()
+				return nil, err
+			}
+			break
+		}
+		offset += int64(journalRecordHeaderSize + len(data))
+		records++
+		entry, op, err := decodeJournalRecord(data)
+		if err != nil {
+			Logger.log.Errorf("Can not decode journal record at offset %+v: %+v", offset, err)
+			continue
+		}
+		switch op {
+		case journalAddTx:
+			if i, ok := index[entry.txHash]; ok {
+				entries[i] = entry
+				continue
+			}
+			index[entry.txHash] = len(entries)
+			entries = append(entries, entry)
+		case journalRemoveTx:
+			if i, ok := index[entry.txHash]; ok {
+				entries[i] = nil
+				delete(index, entry.txHash)
+			}
+		}
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	journal.file = file
+	journal.writer = bufio.NewWriter(file)
+	journal.records = records
+	liveEntries := []*journalEntry{}
+	for _, entry := range entries {
+		if entry != nil {
+			liveEntries = append(liveEntries, entry)
+		}
+	}
+	return liveEntries, nil
+}
+
+func readJournalRecord(reader io.Reader) ([]byte, error) {
+	header := make([]byte, journalRecordHeaderSize)
+	if n, err := io.ReadFull(reader, header); err != nil {
+		if err == io.EOF && n == 0 {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+	length := binary.LittleEndian.Uint32(header[:4])
+	if length == 0 || length > journalMaxRecordSize {
+		return nil, fmt.Errorf("invalid record length %+v", length)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return nil, err
+	}
+	if crc32.ChecksumIEEE(data) != binary.LittleEndian.Uint32(header[4:]) {
+		return nil, errors.New("invalid record checksum")
+	}
+	return data, nil
+}
+
+func decodeJournalRecord(data []byte) (*journalEntry, byte, error) {
+	if len(data) < 1+common.HashSize {
+		return nil, 0, errors.New("record is too short")
+	}
+	op := data[0]
+	entry := &journalEntry{}
+	copy(entry.txHash[:], data[1:1+common.HashSize])
+	if op == journalRemoveTx {
+		return entry, op, nil
+	}
+	if op != journalAddTx {
+		return nil, 0, fmt.Errorf("unknown record type %+v", op)
+	}
+	data = data[1+common.HashSize:]
+	if len(data) < 1 || len(data) < 1+int(data[0])+4 {
+		return nil, 0, errors.New("record is too short")
+	}
+	txType := string(data[1 : 1+int(data[0])])
+	data = data[1+int(data[0]):]
+	descLength := int(binary.LittleEndian.Uint32(data[:4]))
+	data = data[4:]
+	if len(data) < descLength {
+		return nil, 0, errors.New("record is too short")
+	}
+	entry.txDesc, entry.loadErr = unMarshallTxDescFromDatabase(txType, data[descLength:], data[:descLength])
+	if entry.loadErr == nil && entry.txDesc.Desc.Tx == nil {
+		entry.txDesc, entry.loadErr = nil, fmt.Errorf("unknown tx type %+v", txType)
+	}
+	return entry, op, nil
+}
+
+func encodeJournalAddTx(txHash *common.Hash, txType string, valueTx []byte, valueDesc []byte) []byte {
+	data := make([]byte, 0, 1+common.HashSize+1+len(txType)+4+len(valueDesc)+len(valueTx))
+	data = append(data, journalAddTx)
+	data = append(data, txHash[:]...)
+	data = append(data, byte(len(txType)))
+	data = append(data, []byte(txType)...)
+	descLength := make([]byte, 4)
+	binary.LittleEndian.PutUint32(descLength, uint32(len(valueDesc)))
+	data = append(data, descLength...)
+	data = append(data, valueDesc...)
+	data = append(data, valueTx...)
+	return data
+}
+
+func writeJournalRecord(writer io.Writer, data []byte) error {
+	header := make([]byte, journalRecordHeaderSize)
+	binary.LittleEndian.PutUint32(header[:4], uint32(len(data)))
+	binary.LittleEndian.PutUint32(header[4:], crc32.ChecksumIEEE(data))
+	if _, err := writer.Write(header); err != nil {
+		return err
+	}
+	_, err := writer.Write(data)
+	return err
+}
+
+func (journal *txJournal) append(data []byte) error {
+	if journal.writer == nil {
+		return errors.New("journal is not opened")
+	}
+	if err := writeJournalRecord(journal.writer, data); err != nil {
+		return err
+	}
+	journal.records++
+	return journal.writer.Flush()
+}
+
+func (journal *txJournal) insert(txHash *common.Hash, txType string, valueTx []byte, valueDesc []byte) error {
+	return journal.append(encodeJournalAddTx(txHash, txType, valueTx, valueDesc))
+}
+
+func (journal *txJournal) remove(txHash *common.Hash) error {
+	data := append([]byte{journalRemoveTx}, txHash[:]...)
+	return journal.append(data)
+}
+
+// needCompaction returns true if the journal has many records of txs which are not in pool any more
+func (journal *txJournal) needCompaction(poolSize int) bool {
+	return journal.records > journalCompactMinRecords && journal.records > 2*poolSize
+}
+
+// compact writes records into a new journal then replaces the journal with it
+func (journal *txJournal) compact(records [][]byte) error {
+	tempPath := journal.path + ".new"
+	file, err := os.OpenFile(tempPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	for _, data := range records {
+		if err := writeJournalRecord(writer, data); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if journal.file != nil {
+		journal.file.Close()
+	}
+	if err := os.Rename(tempPath, journal.path); err != nil {
+		file.Close()
+		journal.file, journal.writer = nil, nil
+		return err
+	}
+	journal.file = file
+	journal.writer = bufio.NewWriter(file)
+	journal.records = len(records)
+	return nil
+}
+
+func (journal *txJournal) close() error {
+	if journal.file == nil {
+		return nil
+	}
+	err := journal.writer.Flush()
+	if errClose := journal.file.Close(); err == nil {
+		err = errClose
+	}
+	journal.file, journal.writer = nil, nil
+	return err
+}
@@ -0,0 +1,108 @@
+package mempool
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func newJournalTestTxDesc(lockTime int64) *TxDesc {
+	tx := &transaction.Tx{Type: common.TxNormalType, Fee: 10, LockTime: lockTime}
+	txDesc := createTxDescMempool(tx, 1, tx.Fee, 0)
+	txDesc.StartTime = time.Unix(lockTime, 0).UTC()
+	return txDesc
+}
+
+func insertJournalTestTxDesc(t *testing.T, journal *txJournal, txDesc *TxDesc) {
+	txType, valueTx, valueDesc, err := marshalTxDesc(txDesc)
+	assert.Nil(t, err)
+	assert.Nil(t, journal.insert(txDesc.Desc.Tx.Hash(), txType, valueTx, valueDesc))
+}
+
+func TestTxJournal(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	dir, err := ioutil.TempDir("", "txjournal")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mempool.journal")
+
+	journal := newTxJournal(path)
+	entries, err := journal.load()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(entries))
+	txD1 := newJournalTestTxDesc(1)
+	txD2 := newJournalTestTxDesc(2)
+	txD3 := newJournalTestTxDesc(3)
+	insertJournalTestTxDesc(t, journal, txD1)
+	insertJournalTestTxDesc(t, journal, txD2)
+	insertJournalTestTxDesc(t, journal, txD3)
+	assert.Nil(t, journal.remove(txD2.Desc.Tx.Hash()))
+	assert.Nil(t, journal.close())
+
+	// a record cut by a crash is truncated
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	assert.Nil(t, err)
+	_, err = file.Write([]byte{100, 0, 0, 0, 1, 2})
+	assert.Nil(t, err)
+	assert.Nil(t, file.Close())
+
+	journal = newTxJournal(path)
+	entries, err = journal.load()
+	assert.Nil(t, err)
+	assert.Equal(t, 4, journal.records)
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, *txD1.Desc.Tx.Hash(), entries[0].txHash)
+	assert.Equal(t, *txD3.Desc.Tx.Hash(), entries[1].txHash)
+	assert.Nil(t, entries[0].loadErr)
+	assert.Equal(t, txD1.Desc.Tx.Hash(), entries[0].txDesc.Desc.Tx.Hash())
+	assert.Equal(t, txD1.StartTime, entries[0].txDesc.StartTime)
+	assert.Equal(t, txD1.Desc.Fee, entries[0].txDesc.Desc.Fee)
+
+	// records are appended after the truncated record
+	assert.Nil(t, journal.remove(txD1.Desc.Tx.Hash()))
+	assert.Nil(t, journal.close())
+	journal = newTxJournal(path)
+	entries, err = journal.load()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(entries))
+	assert.Equal(t, *txD3.Desc.Tx.Hash(), entries[0].txHash)
+
+	// compaction keeps only the given records
+	txType, valueTx, valueDesc, err := marshalTxDesc(txD3)
+	assert.Nil(t, err)
+	assert.Nil(t, journal.compact([][]byte{encodeJournalAddTx(txD3.Desc.Tx.Hash(), txType, valueTx, valueDesc)}))
+	assert.Equal(t, 1, journal.records)
+	insertJournalTestTxDesc(t, journal, txD2)
+	assert.Nil(t, journal.close())
+	journal = newTxJournal(path)
+	entries, err = journal.load()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, journal.records)
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, *txD3.Desc.Tx.Hash(), entries[0].txHash)
+	assert.Equal(t, *txD2.Desc.Tx.Hash(), entries[1].txHash)
+	assert.Nil(t, journal.close())
+}
+
+func TestTxJournalNeedCompaction(t *testing.T) {
+	journal := newTxJournal("")
+	journal.records = journalCompactMinRecords
+	assert.False(t, journal.needCompaction(0))
+	journal.records = journalCompactMinRecords + 1
+	assert.True(t, journal.needCompaction(journalCompactMinRecords/2))
+	assert.False(t, journal.needCompaction(journalCompactMinRecords))
+}
+
+func TestNewTxDropReport(t *testing.T) {
+	txHash := common.HashH([]byte{1})
+	report := newTxDropReport(txHash, NewMempoolTxError(RejectExpiredTxError, nil))
+	assert.Equal(t, txHash, report.TxHash)
+	assert.Equal(t, ErrCodeMessage[RejectExpiredTxError].Code, report.ErrorCode)
+	assert.NotEmpty(t, report.Reason)
+}
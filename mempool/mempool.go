@@ -29,20 +29,26 @@ const (
 	defaultRoleInCommittees  = -1
 	defaultIsTest            = false
 	defaultReplaceFeeRatio   = 1.1
+	defaultMaxReplacement    = 10
 )
 
 // config is a descriptor containing the memory pool configuration.
 type Config struct {
 	BlockChain        *blockchain.BlockChain       // Block chain of node
 	DataBase          database.DatabaseInterface   // main database of blockchain
-	DataBaseMempool   databasemp.DatabaseInterface // database is used for storage data in mempool into lvdb
+	DataBaseMempool   databasemp.DatabaseInterface // legacy database of persisted txs, its txs are moved into the journal when the pool is loaded
+	JournalPath       string                       // file of the journal of txs in pool, see journal.go
 	ChainParams       *blockchain.Params
 	FeeEstimator      map[byte]*FeeEstimator // FeeEstimatator provides a feeEstimator. If it is not nil, the mempool records all new transactions it observes into the feeEstimator.
 	TxLifeTime        uint                   // Transaction life time in pool
 	MaxTx             uint64                 //Max transaction pool may have
 	IsLoadFromMempool bool                   //Reset mempool database when run node
-	PersistMempool    bool
+	PersistMempool    bool                   //Persist txs in pool into the journal
 	RelayShards       []byte
+	ReplaceFeeBump    uint64  // minimum fee increase in percent to replace a tx in pool, default ReplaceFeeRatio if zero
+	MaxReplacement    uint    // maximum number of replacements in a chain of replacements, default if zero
+	SenderTxRate      float64 // transactions per second accepted from one sender public key of non privacy txs, no limit if zero
+	SenderTxBurst     uint    // transactions accepted at once from one sender public key
 	// UserKeyset            *incognitokey.KeySet
 	PubSubManager         *pubsub.PubSubManager
 	RoleInCommitteesEvent pubsub.EventChannel
@@ -50,9 +56,10 @@ type Config struct {
 
 // TxDesc is transaction message in mempool
 type TxDesc struct {
-	Desc            metadata.TxDesc // transaction details
-	StartTime       time.Time       //Unix Time that transaction enter mempool
-	IsFowardMessage bool
+	Desc             metadata.TxDesc // transaction details
+	StartTime        time.Time       //Unix Time that transaction enter mempool
+	IsFowardMessage  bool
+	ReplacementCount uint // number of txs replaced before this one in its chain of replacements
 }
 
 type TxPool struct {
@@ -62,6 +69,13 @@ type TxPool struct {
 	pool                      map[common.Hash]*TxDesc
 	poolSerialNumbersHashList map[common.Hash][]common.Hash // [txHash] -> list hash serialNumbers of input coin
 	poolSerialNumberHash      map[common.Hash]common.Hash   // [hash from list of serialNumber] -> txHash
+	poolFeeRate               feeRateIndex                  // txs in pool ordered by fee per kilobyte
+	rollingMinFeePerKB        float64                       // highest fee per kilobyte of the txs evicted from the full pool, decays over time
+	rollingMinFeeUpdated      time.Time
+	senderRateLimiter         *common.RateLimiter // limits the txs signed by each sender public key
+	journal                   *txJournal          // journal of txs in pool, nil if txs are not persisted
+	proofBatcher              *proofBatcher       // verifies the privacy proofs of new txs in batches
+	droppedOnRestart          []TxDropReport      // txs of the journal which were not loaded into pool
 	mtx                       sync.RWMutex
 	poolCandidate             map[common.Hash]string //Candidate List in mempool
 	candidateMtx              sync.RWMutex
@@ -77,6 +91,7 @@ type TxPool struct {
 	IsBlockGenStarted         bool
 	IsUnlockMempool           bool
 	ReplaceFeeRatio           float64
+	MaxReplacement            uint
 
 	//for testing
 	IsTest       bool
@@ -103,6 +118,15 @@ func (tp *TxPool) Init(cfg *Config) {
 	tp.RoleInCommittees = defaultRoleInCommittees
 	tp.IsTest = defaultIsTest
 	tp.ReplaceFeeRatio = defaultReplaceFeeRatio
+	if cfg.ReplaceFeeBump > 0 {
+		tp.ReplaceFeeRatio = 1 + float64(cfg.ReplaceFeeBump)/100
+	}
+	tp.MaxReplacement = defaultMaxReplacement
+	if cfg.MaxReplacement > 0 {
+		tp.MaxReplacement = cfg.MaxReplacement
+	}
+	tp.senderRateLimiter = common.NewRateLimiter(cfg.SenderTxRate, cfg.SenderTxBurst)
+	tp.proofBatcher = newProofBatcher(cfg.DataBase)
 }
 
 // InitChannelMempool - init channel
@@ -119,8 +143,22 @@ func (tp *TxPool) AnnouncePersisDatabaseMempool() {
 	}
 }
 
-// LoadOrResetDatabaseMempool - Load and reset database of mempool when start node
+// LoadOrResetDatabaseMempool - Load and reset the journal of mempool when start node
 func (tp *TxPool) LoadOrResetDatabaseMempool() error {
+	tp.mtx.Lock()
+	defer tp.mtx.Unlock()
+	if tp.config.JournalPath != "" && (tp.config.PersistMempool || tp.config.IsLoadFromMempool) {
+		tp.journal = newTxJournal(tp.config.JournalPath)
+	}
+	defer func() {
+		// the journal is only read when txs are not persisted
+		if tp.journal != nil && !tp.config.PersistMempool {
+			if err := tp.journal.close(); err != nil {
+				Logger.log.Error(err)
+			}
+			tp.journal = nil
+		}
+	}()
 	if !tp.config.IsLoadFromMempool {
 		err := tp.resetDatabaseMempool()
 		if err != nil {
@@ -135,7 +173,7 @@ func (tp *TxPool) LoadOrResetDatabaseMempool() error {
 			Logger.log.Errorf("Fail to load mempool database, error: %+v \n", err)
 			return NewMempoolTxError(DatabaseError, err)
 		} else {
-			Logger.log.Criticalf("Successfully load %+v from database \n", len(txDescs))
+			Logger.log.Criticalf("Successfully load %+v from database, drop %+v \n", len(txDescs), len(tp.droppedOnRestart))
 		}
 	}
 	return nil
@@ -144,10 +182,15 @@ func (tp *TxPool) LoadOrResetDatabaseMempool() error {
 // loop forever in mempool
 // receive data from other package
 func (tp *TxPool) Start(cQuit chan struct{}) {
+	compactTicker := time.NewTicker(journalCompactInterval)
+	defer compactTicker.Stop()
+	tp.proofBatcher.start(cQuit)
 	for {
 		select {
 		case <-cQuit:
 			return
+		case <-compactTicker.C:
+			tp.maybeCompactJournal()
 		case msg := <-tp.config.RoleInCommitteesEvent:
 			{
 				shardID, ok := msg.Value.(int)
@@ -179,6 +222,7 @@ func (tp *TxPool) MonitorPool() {
 			if time.Since(txDesc.StartTime) > ttl {
 				Logger.log.Infof("MonitorPool: Add to list removed tx with txHash=%+v", txDesc.Desc.Tx.Hash().String())
 				txsToBeRemoved = append(txsToBeRemoved, txDesc)
+				tp.publishTxEvent(&TxEvent{Tx: txDesc.Desc.Tx, State: TxEventEvicted, Reason: TxEvictedByTTL})
 			}
 		}
 		Logger.log.Infof("MonitorPool: End to collect timeout ttl tx - Count of txsToBeRemoved=%+v", len(txsToBeRemoved))
@@ -190,7 +234,7 @@ func (tp *TxPool) MonitorPool() {
 			tp.removeCandidateByTxHash(txHash)
 			tp.removeRequestStopStakingByTxHash(txHash)
 			tp.removeTokenIDByTxHash(txHash)
-			err := tp.config.DataBaseMempool.RemoveTransaction(txDesc.Desc.Tx.Hash())
+			err := tp.removeTransactionFromJournal(txDesc.Desc.Tx.Hash())
 			if err != nil {
 				Logger.log.Errorf("MonitorPool: RemoveTransaction tx hash=%+v with error %+v", txDesc.Desc.Tx.Hash().String(), err)
 				Logger.log.Error(err)
@@ -224,13 +268,25 @@ func (tp *TxPool) MonitorPool() {
 // This function is safe for concurrent access.
 // #1: tx
 // #2: default nil, contain input coins hash, which are used for creating this tx
-func (tp *TxPool) MaybeAcceptTransaction(tx metadata.Transaction, beaconHeight int64) (*common.Hash, *TxDesc, error) {
+func (tp *TxPool) MaybeAcceptTransaction(tx metadata.Transaction, beaconHeight int64) (hash *common.Hash, txDesc *TxDesc, err error) {
+	// privacy proofs of the txs which enter the pool at the same time are verified together before the pool is locked,
+	// validateTransaction does not verify them again
+	if tp.checkRelayShard(tx) || tp.checkPublicKeyRole(tx) {
+		if err := tp.proofBatcher.verifyTxProofs(tx); err != nil {
+			Logger.log.Error(err)
+			tp.publishTxAcceptance(tx, err)
+			return nil, nil, err
+		}
+	}
 	//tp.config.BlockChain.BestState.Beacon.BeaconHeight
 	tp.mtx.Lock()
 	defer tp.mtx.Unlock()
 	if tp.IsTest {
 		return &common.Hash{}, &TxDesc{}, nil
 	}
+	defer func() {
+		tp.publishTxAcceptance(tx, err)
+	}()
 	go func(txHash common.Hash) {
 		tp.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.TransactionHashEnterNodeTopic, txHash))
 	}(*tx.Hash())
@@ -256,11 +312,14 @@ func (tp *TxPool) MaybeAcceptTransaction(tx metadata.Transaction, beaconHeight i
 		metrics.Tag:              metrics.TxTypeTag,
 		metrics.TagValue:         txType})
 	//==========
-	if uint64(len(tp.pool)) >= tp.config.MaxTx {
-		return nil, nil, NewMempoolTxError(MaxPoolSizeError, errors.New("Pool reach max number of transaction"))
+	if err := tp.checkSenderRateLimit(tx); err != nil {
+		return nil, nil, err
+	}
+	if err := tp.checkPoolFeeRate(tx, beaconHeight); err != nil {
+		return nil, nil, err
 	}
 	startAdd := time.Now()
-	hash, txDesc, err := tp.maybeAcceptTransaction(tx, tp.config.PersistMempool, true, beaconHeight)
+	hash, txDesc, err = tp.maybeAcceptTransaction(tx, tp.config.PersistMempool, true, beaconHeight)
 	elapsed := float64(time.Since(startAdd).Seconds())
 	//==========
 	go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
@@ -295,6 +354,7 @@ func (tp *TxPool) MaybeAcceptTransaction(tx metadata.Transaction, beaconHeight i
 	if err != nil {
 		Logger.log.Error(err)
 	} else {
+		tp.evictLowestFeeTxs(tp.config.MaxTx)
 		if tp.IsBlockGenStarted {
 			if tp.IsUnlockMempool {
 				go func(tx metadata.Transaction) {
@@ -339,7 +399,7 @@ func (tp *TxPool) maybeAcceptTransaction(tx metadata.Transaction, isStore bool,
 	txSize := fmt.Sprintf("%d", tx.GetTxActualSize())
 	startValidate := time.Now()
 	// validate tx
-	err := tp.validateTransaction(tx, beaconHeight)
+	txDescToBeReplaced, err := tp.validateTransaction(tx, beaconHeight)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -360,8 +420,13 @@ func (tp *TxPool) maybeAcceptTransaction(tx metadata.Transaction, isStore bool,
 	txFee := tx.GetTxFee()
 	txFeeToken := tx.GetTxFeeToken()
 	txD := createTxDescMempool(tx, bestHeight, txFee, txFeeToken)
+	txD.Desc.FeePerKB = tp.calculateFeePerKB(tx, beaconHeight)
 	startAdd := time.Now()
-	err = tp.addTx(txD, isStore)
+	if txDescToBeReplaced != nil {
+		err = tp.replaceTx(txDescToBeReplaced, txD, isStore)
+	} else {
+		err = tp.addTx(txD, isStore)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -479,14 +544,20 @@ In Param#2: isStore: store transaction to persistence storage only work for tran
 3. Do not accept a salary tx
 4. Validate fee with tx size
 5. Validate with other txs in mempool
-5.1 Check for Replacement or Cancel transaction
+5.1 Check for Replacement or Cancel transaction, see the replace-by-fee policy in replacement.go
 6. Validate data in tx: privacy proof, metadata,...
 7. Validate tx with blockchain: douple spend, ...
 8. CustomInitToken: Check Custom Init Token try to init exist token ID
 9. Staking Transaction: Check Duplicate stake public key in pool ONLY with staking transaction
 10. RequestStopAutoStaking
+Return the tx in pool which is replaced by tx if any
 */
-func (tp *TxPool) validateTransaction(tx metadata.Transaction, beaconHeight int64) error {
+func (tp *TxPool) validateTransaction(tx metadata.Transaction, beaconHeight int64) (*TxDesc, error) {
+	return tp.validateTransactionWithReport(tx, beaconHeight, nil)
+}
+
+// validateTransactionWithReport works like validateTransaction and records the result of each condition in report if it is not nil
+func (tp *TxPool) validateTransactionWithReport(tx metadata.Transaction, beaconHeight int64, report *txValidationReport) (*TxDesc, error) {
 	var shardID byte
 	var err error
 	var now time.Time
@@ -510,8 +581,9 @@ func (tp *TxPool) validateTransaction(tx metadata.Transaction, beaconHeight int6
 		metrics.Tag:              metrics.ValidateConditionTag,
 	})
 	if !validated {
-		return NewMempoolTxError(RejectSansityTx, fmt.Errorf("transaction's sansity %v is error %v", txHash.String(), err))
+		return nil, report.reject(TxStageSanity, NewMempoolTxError(RejectSansityTx, fmt.Errorf("transaction's sansity %v is error %v", txHash.String(), err)))
 	}
+	report.pass(TxStageSanity)
 
 	// Condition 2: Don't accept the transaction if it already exists in the pool.
 	now = time.Now()
@@ -528,8 +600,9 @@ func (tp *TxPool) validateTransaction(tx metadata.Transaction, beaconHeight int6
 			metrics.Measurement:      metrics.TxPoolDuplicateTxs,
 			metrics.MeasurementValue: float64(1),
 		})
-		return NewMempoolTxError(RejectDuplicateTx, fmt.Errorf("%+v", str))
+		return nil, report.reject(TxStageDuplicate, NewMempoolTxError(RejectDuplicateTx, fmt.Errorf("%+v", str)))
 	}
+	report.pass(TxStageDuplicate)
 	// Condition 3: A standalone transaction must not be a salary transaction.
 	now = time.Now()
 	isSalaryTx := tx.IsSalaryTx()
@@ -540,16 +613,18 @@ func (tp *TxPool) validateTransaction(tx metadata.Transaction, beaconHeight int6
 		metrics.Tag:              metrics.ValidateConditionTag,
 	})
 	if isSalaryTx {
-		return NewMempoolTxError(RejectSalaryTx, fmt.Errorf("%+v is salary tx", txHash.String()))
+		return nil, report.reject(TxStageSalary, NewMempoolTxError(RejectSalaryTx, fmt.Errorf("%+v is salary tx", txHash.String())))
 	}
+	report.pass(TxStageSalary)
 	// Condition 4: check fee PRV of tx
 	now = time.Now()
 	validFee := tp.checkFees(tx, shardID, beaconHeight)
 	if !validFee {
-		return NewMempoolTxError(RejectInvalidFee,
+		return nil, report.reject(TxStageFee, NewMempoolTxError(RejectInvalidFee,
 			fmt.Errorf("Transaction %+v has invalid fees.",
-				tx.Hash().String()))
+				tx.Hash().String())))
 	}
+	report.pass(TxStageFee)
 	go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
 		metrics.Measurement:      metrics.TxPoolValidationDetails,
 		metrics.MeasurementValue: float64(time.Since(now).Seconds()),
@@ -566,25 +641,26 @@ func (tp *TxPool) validateTransaction(tx metadata.Transaction, beaconHeight int6
 		metrics.TagValue:         metrics.Condition5,
 		metrics.Tag:              metrics.ValidateConditionTag,
 	})
+	var txDescToBeReplaced *TxDesc
 	if err != nil {
 		now := time.Now()
-		replaceErr, isReplacedTx := tp.validateTransactionReplacement(tx)
+		var replaceErr error
+		txDescToBeReplaced, replaceErr = tp.validateTransactionReplacement(tx)
 		go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
 			metrics.Measurement:      metrics.TxPoolValidationDetails,
 			metrics.MeasurementValue: float64(time.Since(now).Seconds()),
 			metrics.TagValue:         metrics.ReplaceTxMetic,
 			metrics.Tag:              metrics.ValidateConditionTag,
 		})
-		// if replace tx success (no replace error found) then continue with next validate condition
-		if isReplacedTx {
-			if replaceErr != nil {
-				return replaceErr
-			}
-		} else {
-			// replace fail
-			return NewMempoolTxError(RejectDoubleSpendWithMempoolTx, err)
+		if replaceErr != nil {
+			return nil, report.reject(TxStageMempool, replaceErr)
+		}
+		if txDescToBeReplaced == nil {
+			// no tx to be replaced
+			return nil, report.reject(TxStageMempool, NewMempoolTxError(RejectDoubleSpendWithMempoolTx, err))
 		}
 	}
+	report.pass(TxStageMempool)
 	// Condition 6: ValidateTransaction tx by it self
 	shardID = common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
 	now = time.Now()
@@ -596,8 +672,9 @@ func (tp *TxPool) validateTransaction(tx metadata.Transaction, beaconHeight int6
 		metrics.Tag:              metrics.ValidateConditionTag,
 	})
 	if !validated {
-		return NewMempoolTxError(RejectInvalidTx, fmt.Errorf("Invalid tx - %+v", errValidateTxByItself))
+		return nil, report.reject(TxStageTxByItself, NewMempoolTxError(RejectInvalidTx, fmt.Errorf("Invalid tx - %+v", errValidateTxByItself)))
 	}
+	report.pass(TxStageTxByItself)
 
 	// Condition 7: validate tx with data of blockchain
 	now = time.Now()
@@ -609,7 +686,13 @@ func (tp *TxPool) validateTransaction(tx metadata.Transaction, beaconHeight int6
 		metrics.Tag:              metrics.ValidateConditionTag,
 	})
 	if err != nil {
-		return NewMempoolTxError(RejectDoubleSpendWithBlockchainTx, err)
+		return nil, report.reject(TxStageBlockChain, NewMempoolTxError(RejectDoubleSpendWithBlockchainTx, err))
+	}
+	report.pass(TxStageBlockChain)
+	// the tx to be replaced does not count as a duplicate for condition 8, 9 and 10
+	var txHashToBeReplaced *common.Hash
+	if txDescToBeReplaced != nil {
+		txHashToBeReplaced = txDescToBeReplaced.Desc.Tx.Hash()
 	}
 	// Condition 8: init exist custom token or not
 	now = time.Now()
@@ -620,7 +703,7 @@ func (tp *TxPool) validateTransaction(tx metadata.Transaction, beaconHeight int6
 		if customTokenTx.TxTokenData.Type == transaction.CustomTokenInit {
 			tokenID = customTokenTx.TxTokenData.PropertyID.String()
 			tp.tokenIDMtx.RLock()
-			foundTokenID = common.IndexOfStrInHashMap(tokenID, tp.poolTokenID)
+			foundTokenID = indexOfStrInPoolList(tokenID, tp.poolTokenID, txHashToBeReplaced)
 			tp.tokenIDMtx.RUnlock()
 		}
 	}
@@ -631,8 +714,9 @@ func (tp *TxPool) validateTransaction(tx metadata.Transaction, beaconHeight int6
 		metrics.Tag:              metrics.ValidateConditionTag,
 	})
 	if foundTokenID > 0 {
-		return NewMempoolTxError(RejectDuplicateInitTokenTx, fmt.Errorf("Init Transaction of this Token is in pool already %+v", tokenID))
+		return nil, report.reject(TxStageInitToken, NewMempoolTxError(RejectDuplicateInitTokenTx, fmt.Errorf("Init Transaction of this Token is in pool already %+v", tokenID)))
 	}
+	report.pass(TxStageInitToken)
 	// Condition 9: check duplicate stake public key ONLY with staking transaction
 	now = time.Now()
 	pubkey := ""
@@ -641,11 +725,11 @@ func (tp *TxPool) validateTransaction(tx metadata.Transaction, beaconHeight int6
 		if tx.GetMetadata().GetType() == metadata.ShardStakingMeta || tx.GetMetadata().GetType() == metadata.BeaconStakingMeta {
 			stakingMetadata, ok := tx.GetMetadata().(*metadata.StakingMetadata)
 			if !ok {
-				return NewMempoolTxError(GetStakingMetadataError, fmt.Errorf("Expect metadata type to be *metadata.StakingMetadata but get %+v", reflect.TypeOf(tx.GetMetadata())))
+				return nil, report.reject(TxStageStaking, NewMempoolTxError(GetStakingMetadataError, fmt.Errorf("Expect metadata type to be *metadata.StakingMetadata but get %+v", reflect.TypeOf(tx.GetMetadata()))))
 			}
 			pubkey = stakingMetadata.CommitteePublicKey
 			tp.candidateMtx.RLock()
-			foundPubkey = common.IndexOfStrInHashMap(stakingMetadata.CommitteePublicKey, tp.poolCandidate)
+			foundPubkey = indexOfStrInPoolList(stakingMetadata.CommitteePublicKey, tp.poolCandidate, txHashToBeReplaced)
 			tp.candidateMtx.RUnlock()
 		}
 	}
@@ -656,8 +740,9 @@ func (tp *TxPool) validateTransaction(tx metadata.Transaction, beaconHeight int6
 		metrics.Tag:              metrics.ValidateConditionTag,
 	})
 	if foundPubkey > 0 {
-		return NewMempoolTxError(RejectDuplicateStakePubkey, fmt.Errorf("This public key already stake and still in pool %+v", pubkey))
+		return nil, report.reject(TxStageStaking, NewMempoolTxError(RejectDuplicateStakePubkey, fmt.Errorf("This public key already stake and still in pool %+v", pubkey)))
 	}
+	report.pass(TxStageStaking)
 	// Condition 10: check duplicate request stop auto staking
 	now = time.Now()
 	requestedPublicKey := ""
@@ -666,11 +751,11 @@ func (tp *TxPool) validateTransaction(tx metadata.Transaction, beaconHeight int6
 		if tx.GetMetadata().GetType() == metadata.StopAutoStakingMeta {
 			stopAutoStakingMetadata, ok := tx.GetMetadata().(*metadata.StopAutoStakingMetadata)
 			if !ok {
-				return NewMempoolTxError(GetStakingMetadataError, fmt.Errorf("Expect metadata type to be *metadata.StopAutoStakingMetadata but get %+v", reflect.TypeOf(tx.GetMetadata())))
+				return nil, report.reject(TxStageStopAutoStaking, NewMempoolTxError(GetStakingMetadataError, fmt.Errorf("Expect metadata type to be *metadata.StopAutoStakingMetadata but get %+v", reflect.TypeOf(tx.GetMetadata()))))
 			}
 			requestedPublicKey = stopAutoStakingMetadata.CommitteePublicKey
 			tp.requestStopStakingMtx.RLock()
-			foundRequestStopAutoStaking = common.IndexOfStrInHashMap(stopAutoStakingMetadata.CommitteePublicKey, tp.poolRequestStopStaking)
+			foundRequestStopAutoStaking = indexOfStrInPoolList(stopAutoStakingMetadata.CommitteePublicKey, tp.poolRequestStopStaking, txHashToBeReplaced)
 			tp.requestStopStakingMtx.RUnlock()
 		}
 	}
@@ -681,9 +766,10 @@ func (tp *TxPool) validateTransaction(tx metadata.Transaction, beaconHeight int6
 		metrics.Tag:              metrics.ValidateConditionTag,
 	})
 	if foundRequestStopAutoStaking > 0 {
-		return NewMempoolTxError(RejectDuplicateRequestStopAutoStaking, fmt.Errorf("This public key already request to stop auto staking and still in pool %+v", requestedPublicKey))
+		return nil, report.reject(TxStageStopAutoStaking, NewMempoolTxError(RejectDuplicateRequestStopAutoStaking, fmt.Errorf("This public key already request to stop auto staking and still in pool %+v", requestedPublicKey)))
 	}
-	return nil
+	report.pass(TxStageStopAutoStaking)
+	return txDescToBeReplaced, nil
 }
 
 // check transaction in pool
@@ -694,71 +780,6 @@ func (tp *TxPool) isTxInPool(hash *common.Hash) bool {
 	return false
 }
 
-func (tp *TxPool) validateTransactionReplacement(tx metadata.Transaction) (error, bool) {
-	// calculate match serial number list in pool for replaced tx
-	serialNumberHashList := tx.ListSerialNumbersHashH()
-	hash := common.HashArrayOfHashArray(serialNumberHashList)
-	// find replace tx in pool
-	if txHashToBeReplaced, ok := tp.poolSerialNumberHash[hash]; ok {
-		if txDescToBeReplaced, ok := tp.pool[txHashToBeReplaced]; ok {
-			var baseReplaceFee float64
-			var baseReplaceFeeToken float64
-			var replaceFee float64
-			var replaceFeeToken float64
-			var isReplaced = false
-			if txDescToBeReplaced.Desc.Fee > 0 && txDescToBeReplaced.Desc.FeeToken == 0 {
-				// paid by prv fee only
-				baseReplaceFee = float64(txDescToBeReplaced.Desc.Fee)
-				replaceFee = float64(tx.GetTxFee())
-				// not a higher enough fee than return error
-				if baseReplaceFee*tp.ReplaceFeeRatio >= replaceFee {
-					return NewMempoolTxError(RejectReplacementTxError, fmt.Errorf("Expect fee to be greater than %+v but get %+v ", baseReplaceFee, replaceFee)), true
-				}
-				isReplaced = true
-			} else if txDescToBeReplaced.Desc.Fee == 0 && txDescToBeReplaced.Desc.FeeToken > 0 {
-				//paid by token fee only
-				baseReplaceFeeToken = float64(txDescToBeReplaced.Desc.FeeToken)
-				replaceFeeToken = float64(tx.GetTxFeeToken())
-				// not a higher enough fee than return error
-				if baseReplaceFeeToken*tp.ReplaceFeeRatio >= replaceFeeToken {
-					return NewMempoolTxError(RejectReplacementTxError, fmt.Errorf("Expect fee to be greater than %+v but get %+v ", baseReplaceFeeToken, replaceFeeToken)), true
-				}
-				isReplaced = true
-			} else if txDescToBeReplaced.Desc.Fee > 0 && txDescToBeReplaced.Desc.FeeToken > 0 {
-				// paid by both prv fee and token fee
-				// then only one of fee is higher then it will be accepted
-				baseReplaceFee = float64(txDescToBeReplaced.Desc.Fee)
-				replaceFee = float64(tx.GetTxFee())
-				baseReplaceFeeToken = float64(txDescToBeReplaced.Desc.FeeToken)
-				replaceFeeToken = float64(tx.GetTxFeeToken())
-				// not a higher enough fee than return error
-				if baseReplaceFee*tp.ReplaceFeeRatio >= replaceFee || baseReplaceFeeToken*tp.ReplaceFeeRatio >= replaceFeeToken {
-					return NewMempoolTxError(RejectReplacementTxError, fmt.Errorf("Expect fee to be greater than %+v but get %+v ", baseReplaceFee, replaceFee)), true
-				}
-				isReplaced = true
-			}
-			if isReplaced {
-				txToBeReplaced := txDescToBeReplaced.Desc.Tx
-				tp.removeTx(txToBeReplaced)
-				tp.TriggerCRemoveTxs(txToBeReplaced)
-				tp.removeRequestStopStakingByTxHash(*txToBeReplaced.Hash())
-				tp.removeTokenIDByTxHash(*txToBeReplaced.Hash())
-				// send tx into channel of CRmoveTxs
-				tp.TriggerCRemoveTxs(tx)
-				return nil, true
-			} else {
-				return NewMempoolTxError(RejectReplacementTxError, fmt.Errorf("Unexpected error occur")), true
-			}
-		} else {
-			//found no tx to be replaced
-			return nil, false
-		}
-	} else {
-		// no match serial number list to be replaced
-		return nil, false
-	}
-}
-
 // TriggerCRemoveTxs - send a tx channel into CRemoveTxs of tx mempool
 func (tp *TxPool) TriggerCRemoveTxs(tx metadata.Transaction) {
 	if tp.IsBlockGenStarted {
@@ -778,14 +799,16 @@ func (tp *TxPool) addTx(txD *TxDesc, isStore bool) error {
 	tx := txD.Desc.Tx
 	txHash := tx.Hash()
 	if isStore {
-		err := tp.addTransactionToDatabaseMempool(txHash, *txD)
+		err := tp.addTransactionToJournal(txHash, txD)
 		if err != nil {
-			Logger.log.Errorf("Fail to add tx %+v to mempool database %+v \n", *txHash, err)
-		} else {
-			Logger.log.Criticalf("Add tx %+v to mempool database success \n", *txHash)
+			Logger.log.Errorf("Fail to add tx %+v to mempool journal %+v \n", *txHash, err)
 		}
 	}
+	if oldTxD, exists := tp.pool[*txHash]; exists {
+		tp.poolFeeRate.remove(oldTxD)
+	}
 	tp.pool[*txHash] = txD
+	tp.poolFeeRate.add(txD)
 	var serialNumberList []common.Hash
 	serialNumberList = append(serialNumberList, txD.Desc.Tx.ListSerialNumbersHashH()...)
 	serialNumberListHash := common.HashArrayOfHashArray(serialNumberList)
@@ -895,7 +918,7 @@ func (tp *TxPool) RemoveTx(txs []metadata.Transaction, isInBlock bool) {
 		}
 		startTime := txDesc.StartTime
 		if tp.config.PersistMempool {
-			err := tp.removeTransactionFromDatabaseMP(tx.Hash())
+			err := tp.removeTransactionFromJournal(tx.Hash())
 			if err != nil {
 				Logger.log.Error(err)
 			}
@@ -968,7 +991,8 @@ func (tp *TxPool) RemoveTx(txs []metadata.Transaction, isInBlock bool) {
 */
 func (tp *TxPool) removeTx(tx metadata.Transaction) {
 	//Logger.log.Infof((*tx).Hash().String())
-	if _, exists := tp.pool[*tx.Hash()]; exists {
+	if txDesc, exists := tp.pool[*tx.Hash()]; exists {
+		tp.poolFeeRate.remove(txDesc)
 		delete(tp.pool, *tx.Hash())
 		atomic.StoreInt64(&tp.lastUpdated, time.Now().Unix())
 	}
@@ -981,7 +1005,8 @@ func (tp *TxPool) removeTx(tx metadata.Transaction) {
 		delete(tp.poolSerialNumberHash, hash)
 		// Using the same list serial number to delete new transaction out of pool
 		// this new transaction maybe not exist
-		if _, exists := tp.pool[hash]; exists {
+		if txDesc, exists := tp.pool[hash]; exists {
+			tp.poolFeeRate.remove(txDesc)
 			delete(tp.pool, hash)
 			atomic.StoreInt64(&tp.lastUpdated, time.Now().Unix())
 		}
@@ -991,6 +1016,22 @@ func (tp *TxPool) removeTx(tx metadata.Transaction) {
 	}
 }
 
+// dropTx removes tx from pool and from the mempool database with its serial numbers,
+// candidate, token ID and request to stop auto staking
+func (tp *TxPool) dropTx(tx metadata.Transaction) {
+	txHash := *tx.Hash()
+	if tp.config.PersistMempool {
+		if err := tp.removeTransactionFromJournal(&txHash); err != nil {
+			Logger.log.Error(err)
+		}
+	}
+	tp.removeTx(tx)
+	tp.TriggerCRemoveTxs(tx)
+	tp.removeCandidateByTxHash(txHash)
+	tp.removeRequestStopStakingByTxHash(txHash)
+	tp.removeTokenIDByTxHash(txHash)
+}
+
 func (tp *TxPool) addCandidateToList(txHash common.Hash, candidate string) {
 	tp.candidateMtx.Lock()
 	defer tp.candidateMtx.Unlock()
@@ -1087,7 +1128,7 @@ func (tp *TxPool) RemoveTokenIDList(tokenID []string) {
 func (tp *TxPool) SendTransactionToBlockGen() {
 	tp.mtx.RLock()
 	defer tp.mtx.RUnlock()
-	for _, txdesc := range tp.pool {
+	for _, txdesc := range tp.poolFeeRate.list() {
 		tp.CPendingTxs <- txdesc.Desc.Tx
 	}
 	tp.IsUnlockMempool = true
@@ -1119,13 +1160,13 @@ func (tp *TxPool) GetTx(txHash *common.Hash) (metadata.Transaction, error) {
 	return nil, err
 }
 
-// // MiningDescs returns a slice of mining descriptors for all the transactions
-// // in the pool.
+// MiningDescs returns a slice of mining descriptors for all the transactions
+// in the pool, from the highest fee per kilobyte to the lowest.
 func (tp *TxPool) MiningDescs() []*metadata.TxDesc {
 	tp.mtx.Lock()
 	defer tp.mtx.Unlock()
 	descs := []*metadata.TxDesc{}
-	for _, desc := range tp.pool {
+	for _, desc := range tp.poolFeeRate.list() {
 		descs = append(descs, &desc.Desc)
 	}
 	return descs
@@ -1263,6 +1304,7 @@ func (tp *TxPool) EmptyPool() bool {
 		return true
 	}
 	tp.pool = make(map[common.Hash]*TxDesc)
+	tp.poolFeeRate.reset()
 	tp.poolSerialNumbersHashList = make(map[common.Hash][]common.Hash)
 	tp.poolSerialNumberHash = make(map[common.Hash]common.Hash)
 	tp.poolCandidate = make(map[common.Hash]string)
@@ -131,6 +131,7 @@ var _ = func() (_ struct{}) {
 	tp.Init(&Config{
 		DataBase:          db,
 		DataBaseMempool:   dbp,
+		JournalPath:       filepath.Join("./", "./testdatabase/mempool.journal"),
 		BlockChain:        bc,
 		PubSubManager:     pbMempool,
 		IsLoadFromMempool: false,
@@ -150,7 +151,7 @@ var _ = func() (_ struct{}) {
 		Body: blockchain.ShardBody{
 			Transactions: transactions,
 		},
-	}, &[]database.BatchData{})
+	}, db)
 	transactions = []metadata.Transaction{}
 	for _, privateKey := range privateKeyShard0 {
 		txs := initTx(strconv.Itoa(maxAmount), privateKey, db)
@@ -161,7 +162,7 @@ var _ = func() (_ struct{}) {
 		Body: blockchain.ShardBody{
 			Transactions: transactions,
 		},
-	}, &[]database.BatchData{})
+	}, db)
 	if err != nil {
 		fmt.Println("Can not fetch transaction")
 		return
@@ -183,6 +184,7 @@ var _ = func() (_ struct{}) {
 
 func ResetMempoolTest() {
 	tp.pool = make(map[common.Hash]*TxDesc)
+	tp.poolFeeRate.reset()
 	tp.poolSerialNumbersHashList = make(map[common.Hash][]common.Hash)
 	tp.poolSerialNumberHash = make(map[common.Hash]common.Hash)
 	tp.poolTokenID = make(map[common.Hash]string)
@@ -196,7 +198,33 @@ func ResetMempoolTest() {
 	tp.IsTest = false
 	tp.CPendingTxs = cPendingTxs
 	tp.CRemoveTxs = cRemoveTxs
-	tp.config.DataBaseMempool.Reset()
+	if tp.journal != nil {
+		tp.journal.close()
+	}
+	tp.journal = newTxJournal(tp.config.JournalPath)
+	tp.journal.load()
+	tp.resetDatabaseMempool()
+}
+
+// getTransactionFromJournal returns the tx which is added to the journal of the pool and not removed from it
+func getTransactionFromJournal(txHash *common.Hash) (*TxDesc, error) {
+	journal := newTxJournal(tp.config.JournalPath)
+	defer journal.close()
+	entries, err := journal.load()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.txHash.IsEqual(txHash) {
+			return entry.txDesc, entry.loadErr
+		}
+	}
+	return nil, fmt.Errorf("transaction %+v not found in journal", txHash.String())
+}
+
+func hasTransactionInJournal(txHash *common.Hash) (bool, error) {
+	_, err := getTransactionFromJournal(txHash)
+	return err == nil, nil
 }
 func initTx(amount string, privateKey string, db database.DatabaseInterface) []metadata.Transaction {
 	var initTxs []metadata.Transaction
@@ -817,25 +845,25 @@ func TestTxPoolAddTx(t *testing.T) {
 	if len(tp.poolTokenID) != 1 {
 		t.Fatalf("Expect 1 but get %+v", len(tp.poolTokenID))
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(tx1.Hash()); !isOk || err != nil {
+	if isOk, err := hasTransactionInJournal(tx1.Hash()); !isOk || err != nil {
 		t.Fatalf("Expect tx hash %+v in database mempool but counter err", tx1.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(tx2.Hash()); !isOk || err != nil {
+	if isOk, err := hasTransactionInJournal(tx2.Hash()); !isOk || err != nil {
 		t.Fatalf("Expect tx hash %+v in database mempool but counter err", tx2.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(tx3.Hash()); !isOk || err != nil {
+	if isOk, err := hasTransactionInJournal(tx3.Hash()); !isOk || err != nil {
 		t.Fatalf("Expect tx hash %+v in database mempool but counter err", tx3.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(tx6.Hash()); !isOk && err != nil {
+	if isOk, err := hasTransactionInJournal(tx6.Hash()); !isOk && err != nil {
 		t.Fatalf("Expect tx hash %+v in database mempool but counter err", tx6.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(txInitCustomToken.Hash()); !isOk || err != nil {
+	if isOk, err := hasTransactionInJournal(txInitCustomToken.Hash()); !isOk || err != nil {
 		t.Fatalf("Expect tx hash %+v in database mempool but counter err", txInitCustomToken.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(txStakingBeacon.Hash()); !isOk || err != nil {
+	if isOk, err := hasTransactionInJournal(txStakingBeacon.Hash()); !isOk || err != nil {
 		t.Fatalf("Expect tx hash %+v in database mempool but counter err", txStakingBeacon.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(txStakingShard.Hash()); isOk && err == nil {
+	if isOk, err := hasTransactionInJournal(txStakingShard.Hash()); isOk && err == nil {
 		t.Fatalf("Expect tx hash %+v NOT in database mempool but counter err", txStakingShard.Hash())
 	}
 }
@@ -858,7 +886,8 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 	salaryTx := initTx("100", privateKeyShard0[0], db)
 	tx1 := CreateAndSaveTestNormalTransaction(privateKeyShard0[0], commonFee, false, maxAmount)
 	tx1Replace := CreateAndSaveTestNormalTransaction(privateKeyShard0[0], higherFee, false, maxAmount)
-	tx1DoubleSpend := CreateAndSaveTestNormalTransaction(privateKeyShard0[0], commonFee, false, int(sum)-maxAmount)
+	// tx1 spends both coins of the sender, tx1DoubleSpend spends only one of them so it can not replace tx1
+	tx1DoubleSpend := CreateAndSaveTestNormalTransaction(privateKeyShard0[0], commonFee, false, normalTranferAmount)
 	// get sender key set from private key
 	tx1ReplaceFailed := CreateAndSaveTestNormalTransaction(privateKeyShard0[0], lowerFee, false, maxAmount)
 	txInitCustomTokenPrivacy := CreateAndSaveTestInitCustomTokenTransactionPrivacy(privateKeyShard0[0], commonFee, defaultTokenParams, false)
@@ -877,7 +906,7 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 	// Check condition 1: Sanity - Max version error
 	ResetMempoolTest()
 	tx1.(*transaction.Tx).Version = 2
-	err1 := tp.validateTransaction(tx1)
+	_, err1 := tp.validateTransaction(tx1, 0)
 	if err1 == nil {
 		t.Fatal("Expect max version error error but no error")
 	} else {
@@ -890,7 +919,7 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 	ResetMempoolTest()
 	common.MaxTxSize = 0
 	common.MaxBlockSize = 2000
-	err2 := tp.validateTransaction(tx2)
+	_, err2 := tp.validateTransaction(tx2, 0)
 	if err2 == nil {
 		t.Fatal("Expect size error error but no error")
 	} else {
@@ -903,7 +932,7 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 	// Check Condition 1: Sanity Validate type
 	ResetMempoolTest()
 	tx3.(*transaction.Tx).Type = "abc"
-	err3 := tp.validateTransaction(tx3)
+	_, err3 := tp.validateTransaction(tx3, 0)
 	if err3 == nil {
 		t.Fatal("Expect type error error but no error")
 	} else {
@@ -916,7 +945,7 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 	ResetMempoolTest()
 	tempLockTime := tx4.(*transaction.Tx).LockTime
 	tx4.(*transaction.Tx).LockTime = time.Now().Unix() + 1000000
-	err4 := tp.validateTransaction(tx4)
+	_, err4 := tp.validateTransaction(tx4, 0)
 	if err4 == nil {
 		t.Fatal("Expect type error error but no error")
 	} else {
@@ -932,7 +961,7 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 		tempByte = append(tempByte, byte(i))
 	}
 	tx4.(*transaction.Tx).Info = tempByte
-	err5 := tp.validateTransaction(tx4)
+	_, err5 := tp.validateTransaction(tx4, 0)
 	if err5 == nil {
 		t.Fatal("Expect type error error but no error")
 	} else {
@@ -944,7 +973,7 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 	// Check condition 2: tx exist in pool
 	tp.pool[*tx1.Hash()] = txDesc1
 	tp.poolSerialNumbersHashList[*tx1.Hash()] = tx1.ListSerialNumbersHashH()
-	err6 := tp.validateTransaction(tx1)
+	_, err6 := tp.validateTransaction(tx1, 0)
 	if err6 == nil {
 		t.Fatal("Expect reject duplicate error but no error")
 	} else {
@@ -954,7 +983,7 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 	}
 	// Check Condition 3: Salary Transaction
 	ResetMempoolTest()
-	err7 := tp.validateTransaction(salaryTx[0])
+	_, err7 := tp.validateTransaction(salaryTx[0], 0)
 	if err7 == nil {
 		t.Fatal("Expect salary error error but no error")
 	} else {
@@ -964,7 +993,7 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 	}
 	// Check Condition 4: Validate fee
 	ResetMempoolTest()
-	err8 := tp.validateTransaction(tx4)
+	_, err8 := tp.validateTransaction(tx4, 0)
 	if err8 == nil {
 		t.Fatal("Expect fee error error but no error")
 	} else {
@@ -976,14 +1005,14 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 	// Check Condition 5: replace (normal tx)
 	ResetMempoolTest()
 	tp.addTx(txDesc1, false)
-	err9 := tp.validateTransaction(tx1Replace)
+	_, err9 := tp.validateTransaction(tx1Replace, 0)
 	if err9 != nil {
 		t.Fatal("Expect no error error but get ", err9)
 	}
 	// Check Condition 5: Check replace with mempool (normal tx)
 	ResetMempoolTest()
 	tp.addTx(txDesc1, false)
-	err91 := tp.validateTransaction(tx1ReplaceFailed)
+	_, err91 := tp.validateTransaction(tx1ReplaceFailed, 0)
 	if err91 == nil {
 		t.Fatal("Expect replace fail error in mempool error error but no error")
 	} else {
@@ -994,14 +1023,14 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 	// Check Condition 5: replace (custom token privacy tx)
 	ResetMempoolTest()
 	tp.addTx(txDesc1CustomTokenPrivacy, false)
-	err92 := tp.validateTransaction(txInitCustomTokenPrivacyReplace)
+	_, err92 := tp.validateTransaction(txInitCustomTokenPrivacyReplace, 0)
 	if err92 != nil {
 		t.Fatal("Expect no error error but get ", err92)
 	}
 	// Check Condition 5: Check replace with mempool (custom token privacy tx)
 	ResetMempoolTest()
 	tp.addTx(txDesc1CustomTokenPrivacy, false)
-	err93 := tp.validateTransaction(txInitCustomTokenPrivacyReplaceFailed)
+	_, err93 := tp.validateTransaction(txInitCustomTokenPrivacyReplaceFailed, 0)
 	if err93 == nil {
 		t.Fatal("Expect replace fail error in mempool error error but no error")
 	} else {
@@ -1016,7 +1045,7 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 	log.Println(tx1Replace.ListSerialNumbersHashH())
 	log.Println(tx1ReplaceFailed.ListSerialNumbersHashH())
 	log.Println(tx1DoubleSpend.ListSerialNumbersHashH())
-	err10 := tp.validateTransaction(tx1DoubleSpend)
+	_, err10 := tp.validateTransaction(tx1DoubleSpend, 0)
 	if err10 == nil {
 		t.Fatal("Expect double spend error in mempool error error but no error")
 	} else {
@@ -1031,12 +1060,12 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 		Body: blockchain.ShardBody{
 			Transactions: []metadata.Transaction{tx1},
 		},
-	}, &[]database.BatchData{})
+	}, db)
 	if err != nil {
 		t.Fatalf("Expect no error but get %+v", err)
 	}
 	// snd existed
-	err11 := tp.validateTransaction(tx1)
+	_, err11 := tp.validateTransaction(tx1, 0)
 	if err11 == nil {
 		t.Fatal("Expect double spend with blockchain error error but no error")
 	} else {
@@ -1048,7 +1077,7 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 	// check Condition 8: Check Init Custom Token
 	ResetMempoolTest()
 	tp.poolTokenID[*txInitCustomToken.Hash()] = normalTokenID
-	err12 := tp.validateTransaction(txInitCustomTokenFailed)
+	_, err12 := tp.validateTransaction(txInitCustomTokenFailed, 0)
 	if err12 == nil {
 		t.Fatal("Expect duplicate init token error error but no error")
 	} else {
@@ -1059,7 +1088,7 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 	// check Condition 9: Check Init Custom Token
 	ResetMempoolTest()
 	tp.poolCandidate[*txStakingShard.Hash()] = stakingPublicKey
-	err13 := tp.validateTransaction(txStakingShard)
+	_, err13 := tp.validateTransaction(txStakingShard, 0)
 	if err13 == nil {
 		t.Fatal("Expect duplicate staking pubkey error error but no error")
 	} else {
@@ -1067,7 +1096,7 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 			t.Fatalf("Expect Error %+v but get %+v", ErrCodeMessage[RejectDuplicateStakePubkey], err)
 		}
 	}
-	err13 = tp.validateTransaction(txStakingShard)
+	_, err13 = tp.validateTransaction(txStakingShard, 0)
 	if err13 == nil {
 		t.Fatal("Expect duplicate staking pubkey error error but no error")
 	} else {
@@ -1077,15 +1106,15 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 	}
 	ResetMempoolTest()
 	// Pass all case
-	err14 := tp.validateTransaction(txStakingShard)
+	_, err14 := tp.validateTransaction(txStakingShard, 0)
 	if err14 != nil {
 		t.Fatal("Expect no err but get ", err14)
 	}
-	err14 = tp.validateTransaction(tx3)
+	_, err14 = tp.validateTransaction(tx3, 0)
 	if err14 != nil {
 		t.Fatal("Expect no err but get ", err14)
 	}
-	err14 = tp.validateTransaction(txInitCustomToken)
+	_, err14 = tp.validateTransaction(txInitCustomToken, 0)
 	if err14 != nil {
 		t.Fatal("Expect no err but get ", err14)
 	}
@@ -1099,32 +1128,32 @@ func TestTxPoolmayBeAcceptTransaction(t *testing.T) {
 	txInitCustomTokenFailed := CreateAndSaveTestInitCustomTokenTransaction(privateKeyShard0[4], commonFee, defaultTokenParams, false)
 	txStakingBeacon := CreateAndSaveTestStakingTransaction(privateKeyShard0[4], miningSeedShard0[4], commonFee, true)
 	tx6 := CreateAndSaveTestNormalTransaction(privateKeyShard0[5], commonFee, true, 50)
-	_, _, err1 := tp.maybeAcceptTransaction(tx1, false, true)
+	_, _, err1 := tp.maybeAcceptTransaction(tx1, false, true, 0)
 	if err1 != nil {
 		t.Fatal("Expect no error but get ", err1)
 	}
-	_, _, err2 := tp.maybeAcceptTransaction(tx2, false, true)
+	_, _, err2 := tp.maybeAcceptTransaction(tx2, false, true, 0)
 	if err2 != nil {
 		t.Fatal("Expect no error but get ", err2)
 	}
-	_, _, err3 := tp.maybeAcceptTransaction(tx3, false, true)
+	_, _, err3 := tp.maybeAcceptTransaction(tx3, false, true, 0)
 	if err3 != nil {
 		t.Fatal("Expect no error but get ", err3)
 	}
-	_, _, err4 := tp.maybeAcceptTransaction(txInitCustomToken, false, true)
+	_, _, err4 := tp.maybeAcceptTransaction(txInitCustomToken, false, true, 0)
 	if err4 != nil {
 		t.Fatal("Expect no error but get ", err4)
 	}
 	/* can not stake beacon
-	_, _, err5 := tp.maybeAcceptTransaction(txStakingBeacon, false, true)
+	_, _, err5 := tp.maybeAcceptTransaction(txStakingBeacon, false, true, 0)
 	if err5 != nil {
 		t.Fatal("Expect no error but get ", err5)
 	}*/
-	_, _, err6 := tp.maybeAcceptTransaction(tx6, false, true)
+	_, _, err6 := tp.maybeAcceptTransaction(tx6, false, true, 0)
 	if err6 != nil {
 		t.Fatal("Expect no error but get ", err6)
 	}
-	_, _, err7 := tp.maybeAcceptTransaction(txInitCustomTokenFailed, false, true)
+	_, _, err7 := tp.maybeAcceptTransaction(txInitCustomTokenFailed, false, true, 0)
 	if err7 == nil {
 		t.Fatalf("Expect error %+v but get no error", err7)
 	}
@@ -1146,62 +1175,62 @@ func TestTxPoolmayBeAcceptTransaction(t *testing.T) {
 	if len(tp.poolTokenID) != 1 {
 		t.Fatalf("Expect 1 but get %+v", len(tp.poolTokenID))
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(tx1.Hash()); isOk && err == nil {
+	if isOk, err := hasTransactionInJournal(tx1.Hash()); isOk && err == nil {
 		t.Fatalf("Expect tx hash %+v NOT in database mempool but counter err", tx1.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(tx2.Hash()); isOk && err == nil {
+	if isOk, err := hasTransactionInJournal(tx2.Hash()); isOk && err == nil {
 		t.Fatalf("Expect tx hash %+v NOT in database mempool but counter err", tx2.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(tx3.Hash()); isOk && err == nil {
+	if isOk, err := hasTransactionInJournal(tx3.Hash()); isOk && err == nil {
 		t.Fatalf("Expect tx hash %+v NOT in database mempool but counter err", tx3.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(tx6.Hash()); isOk && err == nil {
+	if isOk, err := hasTransactionInJournal(tx6.Hash()); isOk && err == nil {
 		t.Fatalf("Expect tx hash %+v NOT in database mempool but counter err", tx6.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(txInitCustomToken.Hash()); isOk && err == nil {
+	if isOk, err := hasTransactionInJournal(txInitCustomToken.Hash()); isOk && err == nil {
 		t.Fatalf("Expect tx hash %+v NOT in database mempool but counter err", txInitCustomToken.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(txStakingBeacon.Hash()); isOk && err == nil {
+	if isOk, err := hasTransactionInJournal(txStakingBeacon.Hash()); isOk && err == nil {
 		t.Fatalf("Expect tx hash %+v NOT in database mempool but counter err", txStakingBeacon.Hash())
 	}
 	// persist mempool
 	ResetMempoolTest()
-	tp.maybeAcceptTransaction(tx1, true, true)
-	tp.maybeAcceptTransaction(tx2, true, true)
-	tp.maybeAcceptTransaction(tx3, true, true)
-	tp.maybeAcceptTransaction(txInitCustomToken, true, true)
-	tp.maybeAcceptTransaction(txStakingBeacon, true, true)
-	tp.maybeAcceptTransaction(tx6, true, true)
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(tx1.Hash()); !isOk || err != nil {
+	tp.maybeAcceptTransaction(tx1, true, true, 0)
+	tp.maybeAcceptTransaction(tx2, true, true, 0)
+	tp.maybeAcceptTransaction(tx3, true, true, 0)
+	tp.maybeAcceptTransaction(txInitCustomToken, true, true, 0)
+	tp.maybeAcceptTransaction(txStakingBeacon, true, true, 0)
+	tp.maybeAcceptTransaction(tx6, true, true, 0)
+	if isOk, err := hasTransactionInJournal(tx1.Hash()); !isOk || err != nil {
 		t.Fatalf("Expect tx hash %+v in database mempool but counter err", tx1.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(tx2.Hash()); !isOk || err != nil {
+	if isOk, err := hasTransactionInJournal(tx2.Hash()); !isOk || err != nil {
 		t.Fatalf("Expect tx hash %+v in database mempool but counter err", tx2.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(tx3.Hash()); !isOk || err != nil {
+	if isOk, err := hasTransactionInJournal(tx3.Hash()); !isOk || err != nil {
 		t.Fatalf("Expect tx hash %+v in database mempool but counter err", tx3.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(tx6.Hash()); !isOk || err != nil {
+	if isOk, err := hasTransactionInJournal(tx6.Hash()); !isOk || err != nil {
 		t.Fatalf("Expect tx hash %+v in database mempool but counter err", tx6.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(txInitCustomToken.Hash()); !isOk || err != nil {
+	if isOk, err := hasTransactionInJournal(txInitCustomToken.Hash()); !isOk || err != nil {
 		t.Fatalf("Expect tx hash %+v in database mempool but counter err", txInitCustomToken.Hash())
 	}
-	/*if isOk, err := tp.config.DataBaseMempool.HasTransaction(txStakingBeacon.Hash()); !isOk || err != nil {
+	/*if isOk, err := hasTransactionInJournal(txStakingBeacon.Hash()); !isOk || err != nil {
 		t.Fatalf("Expect tx hash %+v in database mempool but counter err", txStakingBeacon.Hash())
 	}*/
 
-	tx1Data, err := tp.getTransactionFromDatabaseMempool(tx1.Hash())
+	tx1Data, err := getTransactionFromJournal(tx1.Hash())
 	assert.Equal(t, nil, err)
 	assert.NotEqual(t, nil, tx1Data)
 	assert.Equal(t, tx1.Hash(), tx1Data.Desc.Tx.Hash())
 
-	tx1Data, err = tp.getTransactionFromDatabaseMempool(&common.Hash{})
+	tx1Data, err = getTransactionFromJournal(&common.Hash{})
 	assert.NotEqual(t, nil, err)
 
-	err = tp.removeTransactionFromDatabaseMP(tx1.Hash())
+	err = tp.removeTransactionFromJournal(tx1.Hash())
 	assert.Equal(t, nil, err)
-	isOk, err := tp.config.DataBaseMempool.HasTransaction(tx1.Hash())
+	isOk, err := hasTransactionInJournal(tx1.Hash())
 	assert.Equal(t, nil, err)
 	assert.Equal(t, false, isOk)
 
@@ -1261,12 +1290,12 @@ func TestTxPoolRemoveTx(t *testing.T) {
 	txStakingBeacon := CreateAndSaveTestStakingTransaction(privateKeyShard0[4], miningSeedShard0[4], commonFee, true)
 	tx6 := CreateAndSaveTestNormalTransaction(privateKeyShard0[5], commonFee, true, 50)
 	txs := []metadata.Transaction{tx1, tx2, tx3, txInitCustomToken, txStakingBeacon, tx6}
-	tp.maybeAcceptTransaction(tx1, false, true)
-	tp.maybeAcceptTransaction(tx2, false, true)
-	tp.maybeAcceptTransaction(tx3, false, true)
-	tp.maybeAcceptTransaction(txInitCustomToken, false, true)
-	tp.maybeAcceptTransaction(txStakingBeacon, false, true) // this is fail because can not stake beacon now
-	tp.maybeAcceptTransaction(tx6, false, true)
+	tp.maybeAcceptTransaction(tx1, false, true, 0)
+	tp.maybeAcceptTransaction(tx2, false, true, 0)
+	tp.maybeAcceptTransaction(tx3, false, true, 0)
+	tp.maybeAcceptTransaction(txInitCustomToken, false, true, 0)
+	tp.maybeAcceptTransaction(txStakingBeacon, false, true, 0) // this is fail because can not stake beacon now
+	tp.maybeAcceptTransaction(tx6, false, true, 0)
 	if len(tp.pool) != 5 {
 		t.Fatalf("Expect 5 transaction from pool but get %+v", len(tp.pool))
 	}
@@ -1327,29 +1356,29 @@ func TestTxPoolRemoveTx(t *testing.T) {
 	// no persist mempool
 	ResetMempoolTest()
 	tp.config.PersistMempool = true
-	tp.maybeAcceptTransaction(tx1, true, true)
-	tp.maybeAcceptTransaction(tx2, true, true)
-	tp.maybeAcceptTransaction(tx3, true, true)
-	tp.maybeAcceptTransaction(txInitCustomToken, true, true)
-	tp.maybeAcceptTransaction(txStakingBeacon, true, true)
-	tp.maybeAcceptTransaction(tx6, true, true)
+	tp.maybeAcceptTransaction(tx1, true, true, 0)
+	tp.maybeAcceptTransaction(tx2, true, true, 0)
+	tp.maybeAcceptTransaction(tx3, true, true, 0)
+	tp.maybeAcceptTransaction(txInitCustomToken, true, true, 0)
+	tp.maybeAcceptTransaction(txStakingBeacon, true, true, 0)
+	tp.maybeAcceptTransaction(tx6, true, true, 0)
 	tp.RemoveTx(txs, true)
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(tx1.Hash()); isOk && err == nil {
+	if isOk, err := hasTransactionInJournal(tx1.Hash()); isOk && err == nil {
 		t.Fatalf("Expect tx hash %+v NOT in database mempool but counter err", tx1.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(tx2.Hash()); isOk && err == nil {
+	if isOk, err := hasTransactionInJournal(tx2.Hash()); isOk && err == nil {
 		t.Fatalf("Expect tx hash %+v NOT in database mempool but counter err", tx2.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(tx3.Hash()); isOk && err == nil {
+	if isOk, err := hasTransactionInJournal(tx3.Hash()); isOk && err == nil {
 		t.Fatalf("Expect tx hash %+v NOT in database mempool but counter err", tx3.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(tx6.Hash()); isOk && err == nil {
+	if isOk, err := hasTransactionInJournal(tx6.Hash()); isOk && err == nil {
 		t.Fatalf("Expect tx hash %+v NOT in database mempool but counter err", tx6.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(txInitCustomToken.Hash()); isOk && err == nil {
+	if isOk, err := hasTransactionInJournal(txInitCustomToken.Hash()); isOk && err == nil {
 		t.Fatalf("Expect tx hash %+v NOT in database mempool but counter err", txInitCustomToken.Hash())
 	}
-	if isOk, err := tp.config.DataBaseMempool.HasTransaction(txStakingBeacon.Hash()); isOk && err == nil {
+	if isOk, err := hasTransactionInJournal(txStakingBeacon.Hash()); isOk && err == nil {
 		t.Fatalf("Expect tx hash %+v NOT in database mempool but counter err", txStakingBeacon.Hash())
 	}
 }
@@ -1359,7 +1388,7 @@ func TestTxPoolMaybeAcceptTransaction(t *testing.T) {
 	// test relay shard and role in committeess
 	tp.config.RelayShards = []byte{}
 	tp.RoleInCommittees = -1
-	_, _, err1 := tp.MaybeAcceptTransaction(tx1)
+	_, _, err1 := tp.MaybeAcceptTransaction(tx1, 0)
 	if err1 == nil {
 		t.Fatal("Expect unexpected transaction error error but no error")
 	} else {
@@ -1369,7 +1398,7 @@ func TestTxPoolMaybeAcceptTransaction(t *testing.T) {
 	}
 	// test size of mempool
 	tp.config.RelayShards = []byte{0}
-	_, _, err2 := tp.MaybeAcceptTransaction(tx1)
+	_, _, err2 := tp.MaybeAcceptTransaction(tx1, 0)
 	if err2 == nil {
 		t.Fatal("Expect max pool size error error but no error")
 	} else {
@@ -1378,7 +1407,7 @@ func TestTxPoolMaybeAcceptTransaction(t *testing.T) {
 		}
 	}
 	tp.RoleInCommittees = 0
-	_, _, err3 := tp.MaybeAcceptTransaction(tx1)
+	_, _, err3 := tp.MaybeAcceptTransaction(tx1, 0)
 	if err3 == nil {
 		t.Fatal("Expect max pool size error error but no error")
 	} else {
@@ -1387,7 +1416,7 @@ func TestTxPoolMaybeAcceptTransaction(t *testing.T) {
 		}
 	}
 	tp.config.MaxTx = 1
-	_, _, err4 := tp.MaybeAcceptTransaction(tx1)
+	_, _, err4 := tp.MaybeAcceptTransaction(tx1, 0)
 	if err4 != nil {
 		t.Fatal("Expect no error but get ", err4)
 	}
@@ -1398,7 +1427,7 @@ func TestTxPoolMaybeAcceptTransaction(t *testing.T) {
 	tp.config.RelayShards = []byte{0}
 	tp.RoleInCommittees = 0
 	// test push transaction to block gen
-	_, _, err5 := tp.MaybeAcceptTransaction(tx1)
+	_, _, err5 := tp.MaybeAcceptTransaction(tx1, 0)
 	if err5 != nil {
 		t.Fatal("Expect no error but get ", err5)
 	}
@@ -1412,7 +1441,7 @@ func TestTxPoolMaybeAcceptTransaction(t *testing.T) {
 func TestTxPoolMarkForwardedTransaction(t *testing.T) {
 	ResetMempoolTest()
 	tx1 := CreateAndSaveTestNormalTransaction(privateKeyShard0[0], 10, false, normalTranferAmount)
-	txHash1, txDesc1, err := tp.maybeAcceptTransaction(tx1, false, true)
+	txHash1, txDesc1, err := tp.maybeAcceptTransaction(tx1, false, true, 0)
 	if err != nil {
 		t.Fatal("Expect no error but get ", err)
 	}
@@ -1429,12 +1458,12 @@ func TestTxPoolEmptyPool(t *testing.T) {
 	txInitCustomToken := CreateAndSaveTestInitCustomTokenTransaction(privateKeyShard0[3], commonFee, defaultTokenParams, false)
 	txStakingBeacon := CreateAndSaveTestStakingTransaction(privateKeyShard0[4], miningSeedShard0[4], commonFee, true)
 	tx6 := CreateAndSaveTestNormalTransaction(privateKeyShard0[5], commonFee, true, 50)
-	tp.maybeAcceptTransaction(tx1, true, true)
-	tp.maybeAcceptTransaction(tx2, true, true)
-	tp.maybeAcceptTransaction(tx3, true, true)
-	tp.maybeAcceptTransaction(txInitCustomToken, true, true)
-	tp.maybeAcceptTransaction(txStakingBeacon, true, true) // this is fail because can not stake beacon now
-	tp.maybeAcceptTransaction(tx6, true, true)
+	tp.maybeAcceptTransaction(tx1, true, true, 0)
+	tp.maybeAcceptTransaction(tx2, true, true, 0)
+	tp.maybeAcceptTransaction(tx3, true, true, 0)
+	tp.maybeAcceptTransaction(txInitCustomToken, true, true, 0)
+	tp.maybeAcceptTransaction(txStakingBeacon, true, true, 0) // this is fail because can not stake beacon now
+	tp.maybeAcceptTransaction(tx6, true, true, 0)
 	if len(tp.pool) != 5 {
 		t.Fatalf("Expect 5 transaction from mempool but get %+v", len(tp.pool))
 	}
@@ -2,6 +2,8 @@ package mempool
 
 import (
 	"encoding/json"
+	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -15,11 +17,27 @@ type TempDesc struct {
 	IsPushMessage bool
 	Height        uint64
 	Fee           uint64
-	FeePerKB      int32
+	FeePerKB      uint64
+	Replacement   uint
 }
 
-// addTransactionToDatabaseMempool - Add a transaction data into mempool database
-func (tp *TxPool) addTransactionToDatabaseMempool(txHash *common.Hash, txDesc TxDesc) error {
+// TxDropReport tells why a tx persisted before the node stopped is not loaded into pool on restart
+type TxDropReport struct {
+	TxHash    common.Hash
+	ErrorCode int
+	Reason    string
+}
+
+func newTxDropReport(txHash common.Hash, err error) TxDropReport {
+	report := TxDropReport{TxHash: txHash, ErrorCode: ErrCodeMessage[UnexpectedTransactionError].Code, Reason: err.Error()}
+	if mempoolErr, ok := err.(*MempoolTxError); ok {
+		report.ErrorCode = mempoolErr.Code
+	}
+	return report
+}
+
+// marshalTxDesc - marshal a tx and its desc to be persisted, the tx type is empty for the txs which are not persisted
+func marshalTxDesc(txDesc *TxDesc) (string, []byte, []byte, error) {
 	tx := txDesc.Desc.Tx
 	tempDesc := TempDesc{
 		StartTime:     txDesc.StartTime,
@@ -27,140 +45,209 @@ func (tp *TxPool) addTransactionToDatabaseMempool(txHash *common.Hash, txDesc Tx
 		Height:        txDesc.Desc.Height,
 		Fee:           txDesc.Desc.Fee,
 		FeePerKB:      txDesc.Desc.FeePerKB,
+		Replacement:   txDesc.ReplacementCount,
 	}
+	var valueTx []byte
+	var err error
 	switch tx.GetType() {
 	//==================For PRV Transfer Only
 	case common.TxNormalType:
-		{
-			normalTx := tx.(*transaction.Tx)
-			valueTx, err := json.Marshal(normalTx)
-			if err != nil {
-				return err
-			}
-			valueDesc, err := json.Marshal(tempDesc)
-			if err != nil {
-				return err
-			}
-			err = tp.config.DataBaseMempool.AddTransaction(txHash, common.TxNormalType, valueTx, valueDesc)
-			if err != nil {
-				return err
-			}
-		}
+		valueTx, err = json.Marshal(tx.(*transaction.Tx))
 	//==================For PRV & TxNormalToken Transfer
 	case common.TxCustomTokenType:
-		{
-			customTokenTx := tx.(*transaction.TxNormalToken)
-			valueTx, err := json.Marshal(customTokenTx)
-			if err != nil {
-				return err
-			}
-			valueDesc, err := json.Marshal(tempDesc)
-			if err != nil {
-				return err
-			}
-			err = tp.config.DataBaseMempool.AddTransaction(txHash, common.TxCustomTokenType, valueTx, valueDesc)
-			if err != nil {
-				return err
-			}
-		}
+		valueTx, err = json.Marshal(tx.(*transaction.TxNormalToken))
 	case common.TxCustomTokenPrivacyType:
-		{
-			customTokenPrivacyTx := tx.(*transaction.TxCustomTokenPrivacy)
-			valueTx, err := json.Marshal(customTokenPrivacyTx)
-			if err != nil {
-				return err
-			}
-			valueDesc, err := json.Marshal(tempDesc)
-			if err != nil {
-				return err
-			}
-			err = tp.config.DataBaseMempool.AddTransaction(txHash, common.TxCustomTokenPrivacyType, valueTx, valueDesc)
-			if err != nil {
-				return err
-			}
+		valueTx, err = json.Marshal(tx.(*transaction.TxCustomTokenPrivacy))
+	default:
+		return "", nil, nil, nil
+	}
+	if err != nil {
+		return "", nil, nil, err
+	}
+	valueDesc, err := json.Marshal(tempDesc)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return tx.GetType(), valueTx, valueDesc, nil
+}
+
+// addTransactionToJournal - append a tx added to pool into the journal
+func (tp *TxPool) addTransactionToJournal(txHash *common.Hash, txDesc *TxDesc) error {
+	if tp.journal == nil {
+		return nil
+	}
+	txType, valueTx, valueDesc, err := marshalTxDesc(txDesc)
+	if err != nil || txType == "" {
+		return err
+	}
+	return tp.journal.insert(txHash, txType, valueTx, valueDesc)
+}
+
+// removeTransactionFromJournal - append a tx removed from pool into the journal
+func (tp *TxPool) removeTransactionFromJournal(txHash *common.Hash) error {
+	if tp.journal == nil {
+		return nil
+	}
+	return tp.journal.remove(txHash)
+}
+
+// compactJournal - rewrite the journal with only the txs in pool, ordered by the time they entered the pool.
+// This function MUST be called with the mempool lock held.
+func (tp *TxPool) compactJournal() error {
+	if tp.journal == nil {
+		return nil
+	}
+	txDescs := make([]*TxDesc, 0, len(tp.pool))
+	for _, txDesc := range tp.pool {
+		txDescs = append(txDescs, txDesc)
+	}
+	sort.Slice(txDescs, func(i, j int) bool {
+		return txDescs[i].StartTime.Before(txDescs[j].StartTime)
+	})
+	records := [][]byte{}
+	for _, txDesc := range txDescs {
+		txType, valueTx, valueDesc, err := marshalTxDesc(txDesc)
+		if err != nil {
+			return err
 		}
+		if txType == "" {
+			continue
+		}
+		records = append(records, encodeJournalAddTx(txDesc.Desc.Tx.Hash(), txType, valueTx, valueDesc))
 	}
-	return nil
+	return tp.journal.compact(records)
 }
 
-// getTransactionFromDatabaseMempool - get tx from mempool database
-func (tp *TxPool) getTransactionFromDatabaseMempool(txHash *common.Hash) (*TxDesc, error) {
-	value, err := tp.config.DataBaseMempool.GetTransaction(txHash)
-	values := strings.Split(string(value), string(lvdb.Splitter))
-	if len(values) != 3 {
-		return nil, err
+// maybeCompactJournal - compact the journal if most of its records are txs which left the pool
+func (tp *TxPool) maybeCompactJournal() {
+	tp.mtx.Lock()
+	defer tp.mtx.Unlock()
+	if tp.journal == nil || !tp.journal.needCompaction(len(tp.pool)) {
+		return
 	}
-	txDesc, err := unMarshallTxDescFromDatabase(values[0], []byte(values[1]), []byte(values[2]))
-	if err != nil {
-		return nil, err
+	records := tp.journal.records
+	if err := tp.compactJournal(); err != nil {
+		Logger.log.Errorf("Fail to compact mempool journal, error: %+v", err)
+		return
 	}
-	return txDesc, nil
+	Logger.log.Infof("Compact mempool journal from %+v to %+v records", records, tp.journal.records)
 }
 
-// resetDatabaseMempool - reset data in data mempool
+// resetDatabaseMempool - reset the journal and the legacy mempool database
 func (tp *TxPool) resetDatabaseMempool() error {
-	return tp.config.DataBaseMempool.Reset()
+	if tp.journal != nil {
+		if err := tp.journal.compact(nil); err != nil {
+			return err
+		}
+	}
+	if tp.config.DataBaseMempool != nil {
+		return tp.config.DataBaseMempool.Reset()
+	}
+	return nil
 }
 
-// loadDatabaseMP - Get all tx in mempool database persistence
+// loadDatabaseMP - replay the journal and the txs of the legacy mempool database into pool.
+// The txs which expired or became invalid while the node was down are dropped and reported,
+// the journal is compacted with the txs loaded into pool.
 func (tp *TxPool) loadDatabaseMP() ([]TxDesc, error) {
 	txDescs := []TxDesc{}
-	allTxHashes, allTxs, err := tp.config.DataBaseMempool.Load()
-	ttl := time.Duration(tp.config.TxLifeTime) * time.Second
-	if err != nil {
-		return txDescs, err
+	entries := []*journalEntry{}
+	if tp.journal != nil {
+		journalEntries, err := tp.journal.load()
+		if err != nil {
+			return txDescs, err
+		}
+		entries = append(entries, journalEntries...)
 	}
-	for index, tx := range allTxs {
-		values := strings.Split(string(tx), string(lvdb.Splitter))
-		txDesc, err := unMarshallTxDescFromDatabase(values[0], []byte(values[1]), []byte(values[2]))
+	if tp.config.DataBaseMempool != nil {
+		legacyEntries, err := tp.loadLegacyDatabaseMP()
 		if err != nil {
-			Logger.log.Error(err)
-			txHash, err := common.Hash{}.NewHash(allTxHashes[index][3:])
-			if err != nil {
-				Logger.log.Error(err)
-				continue
-			}
-			// fail to ummarshall transaction then remove
-			err1 := tp.removeTransactionFromDatabaseMP(txHash)
-			if err1 != nil {
-				Logger.log.Error(err1)
-			}
+			return txDescs, err
+		}
+		entries = append(entries, legacyEntries...)
+	}
+	ttl := time.Duration(tp.config.TxLifeTime) * time.Second
+	tp.droppedOnRestart = []TxDropReport{}
+	drop := func(txHash common.Hash, err error) {
+		report := newTxDropReport(txHash, err)
+		Logger.log.Warnf("Drop transaction %+v of mempool journal, code %+v: %+v", txHash.String(), report.ErrorCode, report.Reason)
+		tp.droppedOnRestart = append(tp.droppedOnRestart, report)
+	}
+	for _, entry := range entries {
+		if entry.loadErr != nil {
+			drop(entry.txHash, NewMempoolTxError(MarshalError, entry.loadErr))
 			continue
 		}
-		//if transaction is timeout then remove
-		if time.Since(txDesc.StartTime) > ttl {
-			err1 := tp.removeTransactionFromDatabaseMP(txDesc.Desc.Tx.Hash())
-			if err1 != nil {
-				Logger.log.Error(err1)
-			}
+		txDesc := entry.txDesc
+		if tp.isTxInPool(txDesc.Desc.Tx.Hash()) {
+			continue
+		}
+		//if transaction is timeout then drop
+		if ttl > 0 && time.Since(txDesc.StartTime) > ttl {
+			drop(entry.txHash, NewMempoolTxError(RejectExpiredTxError, fmt.Errorf("transaction entered pool at %+v", txDesc.StartTime)))
+			continue
 		}
-		//if not validated by current blockchain db then remove
-		err = tp.validateTransaction(txDesc.Desc.Tx, -1)
+		//if not validated by current blockchain db then drop
+		txDescToBeReplaced, err := tp.validateTransaction(txDesc.Desc.Tx, -1)
 		if err != nil {
-			Logger.log.Error(err)
-			err1 := tp.removeTransactionFromDatabaseMP(txDesc.Desc.Tx.Hash())
-			if err1 != nil {
-				Logger.log.Error(err1)
-			}
+			drop(entry.txHash, err)
 			continue
 		}
-
-		err = tp.addTx(txDesc, false)
+		if txDescToBeReplaced != nil {
+			err = tp.replaceTx(txDescToBeReplaced, txDesc, false)
+		} else {
+			err = tp.addTx(txDesc, false)
+		}
 		if err != nil {
 			Logger.log.Error(err)
 		}
 		txDescs = append(txDescs, *txDesc)
 	}
+	if err := tp.compactJournal(); err != nil {
+		return txDescs, err
+	}
+	if tp.config.DataBaseMempool != nil {
+		if err := tp.config.DataBaseMempool.Reset(); err != nil {
+			return txDescs, err
+		}
+	}
 	return txDescs, nil
 }
 
-// removeTransactionFromDatabaseMP - remove tx from mempool db persistence
-func (tp *TxPool) removeTransactionFromDatabaseMP(txHash *common.Hash) error {
-	if has, _ := tp.config.DataBaseMempool.HasTransaction(txHash); has {
-		err := tp.config.DataBaseMempool.RemoveTransaction(txHash)
-		return err
+// loadLegacyDatabaseMP - get all txs persisted in the mempool database by the nodes which did not have a journal
+func (tp *TxPool) loadLegacyDatabaseMP() ([]*journalEntry, error) {
+	entries := []*journalEntry{}
+	allTxHashes, allTxs, err := tp.config.DataBaseMempool.Load()
+	if err != nil {
+		return entries, err
 	}
-	return nil
+	for index, tx := range allTxs {
+		entry := &journalEntry{}
+		if len(allTxHashes[index]) > 3 {
+			copy(entry.txHash[:], allTxHashes[index][3:])
+		}
+		values := strings.Split(string(tx), string(lvdb.Splitter))
+		if len(values) != 3 {
+			entry.loadErr = fmt.Errorf("expect 3 values of transaction, have %+v", len(values))
+			entries = append(entries, entry)
+			continue
+		}
+		entry.txDesc, entry.loadErr = unMarshallTxDescFromDatabase(values[0], []byte(values[1]), []byte(values[2]))
+		if entry.loadErr == nil && entry.txDesc.Desc.Tx == nil {
+			entry.txDesc, entry.loadErr = nil, fmt.Errorf("unknown tx type %+v", values[0])
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// DroppedOnRestart returns the txs of the journal which were dropped when the pool was loaded
+func (tp *TxPool) DroppedOnRestart() []TxDropReport {
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+	reports := make([]TxDropReport, len(tp.droppedOnRestart))
+	copy(reports, tp.droppedOnRestart)
+	return reports
 }
 
 // unMarshallTxDescFromDatabase - convert tx data in mempool database persistence into TxDesc
@@ -206,5 +293,6 @@ func unMarshallTxDescFromDatabase(txType string, valueTx []byte, valueDesc []byt
 	txDesc.Desc.Height = tempDesc.Height
 	txDesc.Desc.Fee = tempDesc.Fee
 	txDesc.Desc.FeePerKB = tempDesc.FeePerKB
+	txDesc.ReplacementCount = tempDesc.Replacement
 	return &txDesc, nil
 }
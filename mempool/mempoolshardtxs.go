@@ -92,12 +92,26 @@ func (tp *TxPool) ValidateTxList(txs []metadata.Transaction) error {
 		txFee := tx.GetTxFee()
 		txFeeToken := tx.GetTxFeeToken()
 		txD := createTxDescMempool(tx, bestHeight, txFee, txFeeToken)
+		txD.Desc.FeePerKB = tp.calculateFeePerKB(tx, -1)
 		tp.addTx(txD, false)
 	}
 
 	return nil
 }
 
+// ValidateTxProofsInBatch verifies the privacy proofs of txs in parallel batches (see transaction.VerifyTxProofsInBatch),
+// it returns an error for the first tx with an invalid proof.
+// The valid proofs are not verified again when the txs are validated one by one.
+func (tp *TxPool) ValidateTxProofsInBatch(txs []metadata.Transaction) error {
+	errs := transaction.VerifyTxProofsInBatch(txs, tp.config.DataBase)
+	for index, err := range errs {
+		if err != nil {
+			return NewMempoolTxError(RejectInvalidTx, fmt.Errorf("Invalid tx %+v at index %+v - %+v", txs[index].Hash().String(), index, err))
+		}
+	}
+	return nil
+}
+
 /*
 SKIP salary transaction
 Verify Transaction with these condition:
@@ -0,0 +1,104 @@
+package mempool
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+const (
+	proofBatchInterval = 10 * time.Millisecond // longest time a new tx waits for other txs to verify its privacy proofs with them
+	maxProofBatchSize  = 256                   // the collected txs are verified right away when there are as many of them
+)
+
+// proofRequest is a new tx waiting for the verification of its privacy proofs in a batch
+type proofRequest struct {
+	tx     metadata.Transaction
+	result chan error
+}
+
+// proofBatcher collects the new txs which enter the pool at the same time and verifies their privacy proofs together
+// (see transaction.VerifyTxProofsInBatch), so a tx in the pool is validated one by one without verifying its proofs again.
+// A tx is not held back while the batcher is not running, its proofs are verified with the other validations then.
+type proofBatcher struct {
+	db       database.DatabaseInterface
+	cRequest chan *proofRequest
+	cQuit    chan struct{}
+	running  int32
+}
+
+func newProofBatcher(db database.DatabaseInterface) *proofBatcher {
+	return &proofBatcher{
+		db:       db,
+		cRequest: make(chan *proofRequest),
+	}
+}
+
+// start collects the requests and verifies them in batches until cQuit is closed, it must be called only once
+func (b *proofBatcher) start(cQuit chan struct{}) {
+	b.cQuit = cQuit
+	atomic.StoreInt32(&b.running, 1)
+	go b.run()
+}
+
+func (b *proofBatcher) run() {
+	cQuit := b.cQuit
+	defer atomic.StoreInt32(&b.running, 0)
+	for {
+		requests := []*proofRequest{}
+		select {
+		case <-cQuit:
+			return
+		case request := <-b.cRequest:
+			requests = append(requests, request)
+		}
+		timer := time.NewTimer(proofBatchInterval)
+	collect:
+		for len(requests) < maxProofBatchSize {
+			select {
+			case <-cQuit:
+				break collect
+			case <-timer.C:
+				break collect
+			case request := <-b.cRequest:
+				requests = append(requests, request)
+			}
+		}
+		timer.Stop()
+		// the next txs are collected while this batch is verified
+		go b.verify(requests)
+	}
+}
+
+func (b *proofBatcher) verify(requests []*proofRequest) {
+	txs := make([]metadata.Transaction, len(requests))
+	for i, request := range requests {
+		txs[i] = request.tx
+	}
+	errs := transaction.VerifyTxProofsInBatch(txs, b.db)
+	for i, request := range requests {
+		request.result <- errs[i]
+	}
+}
+
+// verifyTxProofs waits until the privacy proofs of tx are verified in a batch with the other new txs,
+// it returns an error if a proof of tx is invalid.
+func (b *proofBatcher) verifyTxProofs(tx metadata.Transaction) error {
+	if atomic.LoadInt32(&b.running) == 0 || !transaction.HasProofsToVerifyInBatch(tx) {
+		return nil
+	}
+	request := &proofRequest{tx: tx, result: make(chan error, 1)}
+	select {
+	case b.cRequest <- request:
+	case <-b.cQuit:
+		return nil
+	}
+	if err := <-request.result; err != nil {
+		return NewMempoolTxError(RejectInvalidTx, fmt.Errorf("Invalid privacy proof of transaction %+v - %+v", tx.Hash().String(), err))
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package mempool
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProofBatcherVerifyTxProofs(t *testing.T) {
+	ResetMempoolTest()
+	txs := []metadata.Transaction{}
+	for i := 5; i < 9; i++ {
+		txs = append(txs, CreateAndSaveTestNormalTransaction(privateKeyShard0[i], commonFee, true, 50))
+	}
+	// the one out of many proof of tx 1 is modified
+	oneOfManyProof := txs[1].(*transaction.Tx).Proof.GetOneOfManyProof()[0]
+	proofBytes := oneOfManyProof.Bytes()
+	offset := (5 * privacy.CommitmentRingSizeExp) * common.PublicKeySize
+	copy(proofBytes[offset:], privacy.RandomScalar().ToBytesS())
+	assert.Nil(t, oneOfManyProof.SetBytes(proofBytes))
+
+	// proofs are verified with the other validations while the batcher is not running
+	batcher := newProofBatcher(db)
+	assert.Nil(t, batcher.verifyTxProofs(txs[1]))
+
+	cQuit := make(chan struct{})
+	batcher.start(cQuit)
+	errs := make([]error, len(txs))
+	var wg sync.WaitGroup
+	for i, tx := range txs {
+		wg.Add(1)
+		go func(i int, tx metadata.Transaction) {
+			defer wg.Done()
+			errs[i] = batcher.verifyTxProofs(tx)
+		}(i, tx)
+	}
+	wg.Wait()
+	assert.Nil(t, errs[0])
+	assert.Equal(t, ErrCodeMessage[RejectInvalidTx].Code, errs[1].(*MempoolTxError).Code)
+	assert.Nil(t, errs[2])
+	assert.Nil(t, errs[3])
+	// the valid proofs are not verified again
+	shardID := common.GetShardIDFromLastByte(txs[0].GetSenderAddrLastByte())
+	isValid, err := txs[0].ValidateTransaction(true, db, shardID, nil)
+	assert.True(t, isValid)
+	assert.Nil(t, err)
+
+	// a tx is not held back after the batcher stops
+	close(cQuit)
+	assert.Nil(t, batcher.verifyTxProofs(txs[2]))
+}
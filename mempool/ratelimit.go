@@ -0,0 +1,32 @@
+package mempool
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/metrics"
+)
+
+// checkSenderRateLimit rejects tx when the public key which signed it sends more txs than Config.SenderTxRate,
+// the check is done before any proof verification so one sender can not keep the node busy with proofs.
+// A privacy tx is signed with a random key so it has no stable sender, only the limit of the relaying peer applies to it
+func (tp *TxPool) checkSenderRateLimit(tx metadata.Transaction) error {
+	if tx.IsPrivacy() {
+		return nil
+	}
+	sigPubKey := tx.GetSigPubKey()
+	if len(sigPubKey) == 0 {
+		return nil
+	}
+	if tp.senderRateLimiter.Allow(string(sigPubKey)) {
+		return nil
+	}
+	go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
+		metrics.Measurement:      metrics.TxRateLimitedBySender,
+		metrics.MeasurementValue: float64(1),
+		metrics.Tag:              metrics.TxHashTag,
+		metrics.TagValue:         tx.Hash().String(),
+	})
+	return NewMempoolTxError(RejectSenderRateLimitError, fmt.Errorf("Sender %+v of transaction %+v sends transactions over its rate limit", hex.EncodeToString(sigPubKey), tx.Hash().String()))
+}
@@ -0,0 +1,20 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxPoolCheckSenderRateLimit(t *testing.T) {
+	pool := &TxPool{senderRateLimiter: common.NewRateLimiter(1, 1)}
+	assert.Nil(t, pool.checkSenderRateLimit(&transaction.Tx{SigPubKey: []byte{1}, LockTime: 1}))
+	err := pool.checkSenderRateLimit(&transaction.Tx{SigPubKey: []byte{1}, LockTime: 2})
+	assert.Equal(t, ErrCodeMessage[RejectSenderRateLimitError].Code, err.(*MempoolTxError).Code)
+	// other sender
+	assert.Nil(t, pool.checkSenderRateLimit(&transaction.Tx{SigPubKey: []byte{2}, LockTime: 3}))
+	// tx without sender is not limited
+	assert.Nil(t, pool.checkSenderRateLimit(&transaction.Tx{LockTime: 4}))
+}
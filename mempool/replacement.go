@@ -0,0 +1,159 @@
+package mempool
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/pubsub"
+)
+
+/*
+Replace-by-fee policy
+
+A tx which spends exactly the same list of serial numbers as a tx in pool replaces it when:
+	1. The tx in pool paid its fee in PRV only: the fee PRV of the new tx is greater than ReplaceFeeRatio times its fee PRV.
+	2. The tx in pool paid a token fee: the new tx pays its token fee in the same token, its token fee is greater than
+	ReplaceFeeRatio times the token fee of the tx in pool and its fee PRV is not lower.
+	3. The tx in pool is not at the end of a chain of MaxReplacement replacements.
+The tx in pool is removed only after the new tx passes every other validation, then the replacement is
+announced on pubsub.MempoolInfoTopic with a TxReplacement message.
+*/
+
+// TxReplacement is published on pubsub.MempoolInfoTopic when a tx in pool is replaced
+type TxReplacement struct {
+	ReplacedTxHash   common.Hash
+	TxHash           common.Hash
+	Fee              uint64
+	FeeToken         uint64
+	ReplacementCount uint
+}
+
+// getTxDescToBeReplaced returns the tx in pool spending the same list of serial numbers as tx, nil if there is none
+func (tp *TxPool) getTxDescToBeReplaced(tx metadata.Transaction) *TxDesc {
+	hash := common.HashArrayOfHashArray(tx.ListSerialNumbersHashH())
+	txHashToBeReplaced, ok := tp.poolSerialNumberHash[hash]
+	if !ok {
+		return nil
+	}
+	txDescToBeReplaced, ok := tp.pool[txHashToBeReplaced]
+	if !ok {
+		return nil
+	}
+	return txDescToBeReplaced
+}
+
+// minReplacementFee returns the lowest fee PRV and token fee a tx needs to replace txDesc
+func (tp *TxPool) minReplacementFee(txDesc *TxDesc) (uint64, uint64) {
+	bump := func(fee uint64) uint64 {
+		return uint64(math.Floor(float64(fee)*tp.ReplaceFeeRatio)) + 1
+	}
+	if txDesc.Desc.FeeToken == 0 {
+		return bump(txDesc.Desc.Fee), 0
+	}
+	return txDesc.Desc.Fee, bump(txDesc.Desc.FeeToken)
+}
+
+// validateTransactionReplacement checks tx against the replace-by-fee policy
+// and returns the tx in pool it replaces, nil if tx does not replace any tx in pool
+func (tp *TxPool) validateTransactionReplacement(tx metadata.Transaction) (*TxDesc, error) {
+	txDescToBeReplaced := tp.getTxDescToBeReplaced(tx)
+	if txDescToBeReplaced == nil {
+		return nil, nil
+	}
+	if txDescToBeReplaced.ReplacementCount >= tp.MaxReplacement {
+		return nil, NewMempoolTxError(RejectReplacementChainError, fmt.Errorf("Transaction %+v already replaced %+v transactions, max %+v", txDescToBeReplaced.Desc.Tx.Hash().String(), txDescToBeReplaced.ReplacementCount, tp.MaxReplacement))
+	}
+	minFee, minFeeToken := tp.minReplacementFee(txDescToBeReplaced)
+	if txDescToBeReplaced.Desc.FeeToken == 0 {
+		// paid by prv fee only
+		if tx.GetTxFee() < minFee {
+			return nil, NewMempoolTxError(RejectReplacementTxError, fmt.Errorf("Expect fee to be at least %+v but get %+v", minFee, tx.GetTxFee()))
+		}
+		return txDescToBeReplaced, nil
+	}
+	// paid by token fee
+	tokenID := txDescToBeReplaced.Desc.Tx.GetTokenID()
+	if tx.GetType() != common.TxCustomTokenPrivacyType || !tx.GetTokenID().IsEqual(tokenID) {
+		return nil, NewMempoolTxError(RejectReplacementTxError, fmt.Errorf("Expect token fee to be paid in token %+v", tokenID.String()))
+	}
+	if tx.GetTxFeeToken() < minFeeToken {
+		return nil, NewMempoolTxError(RejectReplacementTxError, fmt.Errorf("Expect token fee to be at least %+v but get %+v", minFeeToken, tx.GetTxFeeToken()))
+	}
+	if tx.GetTxFee() < minFee {
+		return nil, NewMempoolTxError(RejectReplacementTxError, fmt.Errorf("Expect fee to be at least %+v but get %+v", minFee, tx.GetTxFee()))
+	}
+	return txDescToBeReplaced, nil
+}
+
+// replaceTx adds txDesc into pool then removes txDescToBeReplaced from pool,
+// txDescToBeReplaced stays in pool if txDesc can not be added.
+// txDesc must already be validated by validateTransactionReplacement
+func (tp *TxPool) replaceTx(txDescToBeReplaced *TxDesc, txDesc *TxDesc, isStore bool) error {
+	txHashToBeReplaced := *txDescToBeReplaced.Desc.Tx.Hash()
+	txDesc.ReplacementCount = txDescToBeReplaced.ReplacementCount + 1
+	if err := tp.addTx(txDesc, isStore); err != nil {
+		tp.restoreReplacedTx(txDescToBeReplaced, txDesc)
+		return err
+	}
+	// both txs spend the same serial numbers, removing the replaced tx also removes the index of txDesc
+	serialNumberListHash := common.HashArrayOfHashArray(txDesc.Desc.Tx.ListSerialNumbersHashH())
+	tp.dropTx(txDescToBeReplaced.Desc.Tx)
+	tp.poolSerialNumberHash[serialNumberListHash] = *txDesc.Desc.Tx.Hash()
+	Logger.log.Infof("Transaction %+v replaces transaction %+v", txDesc.Desc.Tx.Hash().String(), txHashToBeReplaced.String())
+	replacement := &TxReplacement{
+		ReplacedTxHash:   txHashToBeReplaced,
+		TxHash:           *txDesc.Desc.Tx.Hash(),
+		Fee:              txDesc.Desc.Fee,
+		FeeToken:         txDesc.Desc.FeeToken,
+		ReplacementCount: txDesc.ReplacementCount,
+	}
+	go tp.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.MempoolInfoTopic, replacement))
+	tp.publishTxEvent(&TxEvent{Tx: txDescToBeReplaced.Desc.Tx, State: TxEventReplaced, ReplacedBy: &replacement.TxHash})
+	return nil
+}
+
+// restoreReplacedTx removes what was added of txDesc into pool and makes txDescToBeReplaced
+// the tx spending its serial numbers again
+func (tp *TxPool) restoreReplacedTx(txDescToBeReplaced *TxDesc, txDesc *TxDesc) {
+	txHash := *txDesc.Desc.Tx.Hash()
+	if err := tp.removeTransactionFromJournal(&txHash); err != nil {
+		Logger.log.Error(err)
+	}
+	tp.removeTx(txDesc.Desc.Tx)
+	tp.removeCandidateByTxHash(txHash)
+	tp.removeRequestStopStakingByTxHash(txHash)
+	tp.removeTokenIDByTxHash(txHash)
+	serialNumberListHash := common.HashArrayOfHashArray(txDescToBeReplaced.Desc.Tx.ListSerialNumbersHashH())
+	tp.poolSerialNumberHash[serialNumberListHash] = *txDescToBeReplaced.Desc.Tx.Hash()
+}
+
+// MinReplacementFee returns the lowest fee PRV and token fee a tx needs to replace the tx txHash in pool
+func (tp *TxPool) MinReplacementFee(txHash common.Hash) (uint64, uint64, error) {
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+	txDesc, ok := tp.pool[txHash]
+	if !ok {
+		return 0, 0, NewMempoolTxError(TransactionNotFoundError, fmt.Errorf("Transaction %+v Not Found!", txHash.String()))
+	}
+	if txDesc.ReplacementCount >= tp.MaxReplacement {
+		return 0, 0, NewMempoolTxError(RejectReplacementChainError, fmt.Errorf("Transaction %+v already replaced %+v transactions, max %+v", txHash.String(), txDesc.ReplacementCount, tp.MaxReplacement))
+	}
+	fee, feeToken := tp.minReplacementFee(txDesc)
+	return fee, feeToken, nil
+}
+
+// indexOfStrInPoolList works like common.IndexOfStrInHashMap but skips the value of the tx exceptTxHash
+func indexOfStrInPoolList(v string, m map[common.Hash]string, exceptTxHash *common.Hash) int {
+	for txHash, value := range m {
+		if exceptTxHash != nil && txHash.IsEqual(exceptTxHash) {
+			continue
+		}
+		if strings.Compare(value, v) == 0 {
+			return 1
+		}
+	}
+	return -1
+}
@@ -0,0 +1,96 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func newReplacementTestTxPool(txD *TxDesc) *TxPool {
+	pool := &TxPool{
+		pool:                 map[common.Hash]*TxDesc{*txD.Desc.Tx.Hash(): txD},
+		poolSerialNumberHash: map[common.Hash]common.Hash{},
+		ReplaceFeeRatio:      defaultReplaceFeeRatio,
+		MaxReplacement:       2,
+	}
+	pool.poolSerialNumberHash[common.HashArrayOfHashArray(txD.Desc.Tx.ListSerialNumbersHashH())] = *txD.Desc.Tx.Hash()
+	return pool
+}
+
+func TestTxPoolValidateTransactionReplacement(t *testing.T) {
+	txInPool := &transaction.Tx{Type: common.TxNormalType, Fee: 100, LockTime: 1}
+	txD := createTxDescMempool(txInPool, 1, txInPool.Fee, 0)
+	pool := newReplacementTestTxPool(txD)
+
+	minFee, minFeeToken, err := pool.MinReplacementFee(*txInPool.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(111), minFee)
+	assert.Equal(t, uint64(0), minFeeToken)
+
+	// fee is not bumped enough
+	_, err = pool.validateTransactionReplacement(&transaction.Tx{Type: common.TxNormalType, Fee: 110, LockTime: 2})
+	assert.Equal(t, ErrCodeMessage[RejectReplacementTxError].Code, err.(*MempoolTxError).Code)
+
+	replaced, err := pool.validateTransactionReplacement(&transaction.Tx{Type: common.TxNormalType, Fee: 111, LockTime: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, txD, replaced)
+
+	// the chain of replacements is too long
+	txD.ReplacementCount = 2
+	_, err = pool.validateTransactionReplacement(&transaction.Tx{Type: common.TxNormalType, Fee: 1000, LockTime: 2})
+	assert.Equal(t, ErrCodeMessage[RejectReplacementChainError].Code, err.(*MempoolTxError).Code)
+	_, _, err = pool.MinReplacementFee(*txInPool.Hash())
+	assert.NotNil(t, err)
+}
+
+func TestTxPoolValidateTransactionReplacementTokenFee(t *testing.T) {
+	txInPool := &transaction.Tx{Type: common.TxNormalType, Fee: 100, LockTime: 1}
+	txD := createTxDescMempool(txInPool, 1, txInPool.Fee, 50)
+	pool := newReplacementTestTxPool(txD)
+
+	minFee, minFeeToken, err := pool.MinReplacementFee(*txInPool.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(100), minFee)
+	assert.Equal(t, uint64(56), minFeeToken)
+
+	// a tx paying its fee in PRV only can not replace a tx paying a token fee
+	_, err = pool.validateTransactionReplacement(&transaction.Tx{Type: common.TxNormalType, Fee: 1000, LockTime: 2})
+	assert.Equal(t, ErrCodeMessage[RejectReplacementTxError].Code, err.(*MempoolTxError).Code)
+}
+
+func TestTxPoolReplaceTx(t *testing.T) {
+	ResetMempoolTest()
+	txInPool := &transaction.Tx{Type: common.TxNormalType, Fee: 100, LockTime: 1}
+	txD := createTxDescMempool(txInPool, 1, txInPool.Fee, 0)
+	assert.Nil(t, tp.addTx(txD, false))
+	serialNumberListHash := common.HashArrayOfHashArray(txInPool.ListSerialNumbersHashH())
+
+	// the replacement can not be added, the tx in pool is kept
+	failedTx := &transaction.Tx{Type: common.TxNormalType, Fee: 1000, LockTime: 2, Metadata: &metadata.StopAutoStakingMetadata{MetadataBase: metadata.MetadataBase{Type: metadata.ShardStakingMeta}}}
+	err := tp.replaceTx(txD, createTxDescMempool(failedTx, 1, failedTx.Fee, 0), false)
+	assert.Equal(t, ErrCodeMessage[GetStakingMetadataError].Code, err.(*MempoolTxError).Code)
+	assert.Equal(t, 1, len(tp.pool))
+	assert.Equal(t, txD, tp.pool[*txInPool.Hash()])
+	assert.Equal(t, *txInPool.Hash(), tp.poolSerialNumberHash[serialNumberListHash])
+
+	replacementTx := &transaction.Tx{Type: common.TxNormalType, Fee: 111, LockTime: 3}
+	replacementTxD := createTxDescMempool(replacementTx, 1, replacementTx.Fee, 0)
+	assert.Nil(t, tp.replaceTx(txD, replacementTxD, false))
+	assert.Equal(t, 1, len(tp.pool))
+	assert.Equal(t, replacementTxD, tp.pool[*replacementTx.Hash()])
+	assert.Equal(t, uint(1), replacementTxD.ReplacementCount)
+	assert.Equal(t, *replacementTx.Hash(), tp.poolSerialNumberHash[serialNumberListHash])
+}
+
+func TestIndexOfStrInPoolList(t *testing.T) {
+	txHash1 := common.HashH([]byte{1})
+	txHash2 := common.HashH([]byte{2})
+	list := map[common.Hash]string{txHash1: "a", txHash2: "b"}
+	assert.Equal(t, 1, indexOfStrInPoolList("a", list, nil))
+	assert.Equal(t, -1, indexOfStrInPoolList("a", list, &txHash1))
+	assert.Equal(t, 1, indexOfStrInPoolList("b", list, &txHash1))
+	assert.Equal(t, -1, indexOfStrInPoolList("c", list, nil))
+}
@@ -297,6 +297,29 @@ func (shardToBeaconPool *ShardToBeaconPool) removeBlock(blockItems map[byte]uint
 	}
 }
 
+// RemoveBlockByHeight drops the block of shard shardID at height from pool.
+// Dropping a valid block lowers the latest valid height of the shard below it.
+func (shardToBeaconPool *ShardToBeaconPool) RemoveBlockByHeight(shardID byte, height uint64) error {
+	shardToBeaconPool.mtx.Lock()
+	defer shardToBeaconPool.mtx.Unlock()
+	shardToBeaconPool.latestValidHeightMutex.Lock()
+	defer shardToBeaconPool.latestValidHeightMutex.Unlock()
+	for index, blk := range shardToBeaconPool.pool[shardID] {
+		if blk.Header.Height != height {
+			continue
+		}
+		blks := shardToBeaconPool.pool[shardID]
+		shardToBeaconPool.pool[shardID] = append(blks[:index:index], blks[index+1:]...)
+		if height <= shardToBeaconPool.latestValidHeight[shardID] {
+			shardToBeaconPool.latestValidHeight[shardID] = height - 1
+			shardToBeaconPool.updateLatestShardState()
+		}
+		Logger.log.Infof("ShardToBeaconPool: Removed block %+v of shard %+v, LastValidHeight %+v", height, shardID, shardToBeaconPool.latestValidHeight[shardID])
+		return nil
+	}
+	return NewBlockPoolError(BlockNotFoundError, fmt.Errorf("Shard To Beacon Block %+v of shard %+v is not in pool", height, shardID))
+}
+
 func (shardToBeaconPool *ShardToBeaconPool) GetValidBlock(limit map[byte]uint64) map[byte][]*blockchain.ShardToBeaconBlock {
 	shardToBeaconPool.mtx.RLock()
 	defer shardToBeaconPool.mtx.RUnlock()
@@ -433,3 +433,41 @@ func TestShardToBeaconPoolGetValidBlock(t *testing.T) {
 		}
 	}
 }
+func TestShardToBeaconPoolGetBlockPoolInfoAndRemoveBlock(t *testing.T) {
+	InitShardToBeaconPoolTest()
+	shardToBeaconPoolTest.pool[0] = []*blockchain.ShardToBeaconBlock{shardToBeaconBlock2, shardToBeaconBlock3, shardToBeaconBlock4, shardToBeaconBlock6}
+	shardToBeaconPoolTest.latestValidHeight[0] = 1
+	shardToBeaconPoolTest.updateLatestShardState()
+	latestValidHeight := shardToBeaconPoolTest.latestValidHeight[0]
+	infos := shardToBeaconPoolTest.GetBlockPoolInfo()
+	if len(infos) != 1 || infos[0].ShardID != 0 || len(infos[0].Blocks) != 4 {
+		t.Fatalf("Expect info of 4 blocks from shard 0 but get %+v", infos)
+	}
+	if infos[0].LatestHeight != latestValidHeight || infos[0].ExpectedHeight != 5 {
+		t.Fatalf("Expect pool to wait for block 5 but get %+v", infos[0])
+	}
+	for _, blkInfo := range infos[0].Blocks {
+		if blkInfo.Height <= latestValidHeight && blkInfo.Status != BlockPoolStatusValid {
+			t.Fatalf("Expect block %+v to be valid but get %+v", blkInfo.Height, blkInfo)
+		}
+		if blkInfo.Height > latestValidHeight && (blkInfo.Status != BlockPoolStatusPending || blkInfo.WaitHeight != 5) {
+			t.Fatalf("Expect block %+v to wait for block 5 but get %+v", blkInfo.Height, blkInfo)
+		}
+	}
+
+	// dropping a valid block lowers the latest valid height
+	if err := shardToBeaconPoolTest.RemoveBlockByHeight(0, 3); err != nil {
+		t.Fatalf("Expect no error but get %+v", err)
+	}
+	if len(shardToBeaconPoolTest.pool[0]) != 3 || shardToBeaconPoolTest.latestValidHeight[0] != 2 {
+		t.Fatalf("Expect 3 blocks in pool and latest valid height 2 but get %+v and %+v", len(shardToBeaconPoolTest.pool[0]), shardToBeaconPoolTest.latestValidHeight[0])
+	}
+	infos = shardToBeaconPoolTest.GetBlockPoolInfo()
+	if infos[0].ExpectedHeight != 3 || infos[0].Blocks[1].WaitHeight != 3 {
+		t.Fatalf("Expect pool to wait for block 3 but get %+v", infos[0])
+	}
+	err := shardToBeaconPoolTest.RemoveBlockByHeight(0, 3)
+	if err == nil || err.(*BlockPoolError).Code != ErrCodeMessage[BlockNotFoundError].Code {
+		t.Fatalf("Expect %+v error but get %+v", BlockNotFoundError, err)
+	}
+}
@@ -0,0 +1,121 @@
+package mempool
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// Validation stages of a tx, in the order MaybeAcceptTransaction runs them
+const (
+	TxStageRelayShard      = "relayshard"
+	TxStagePoolFeeRate     = "poolfeerate"
+	TxStageSanity          = "sanity"
+	TxStageDuplicate       = "duplicate"
+	TxStageSalary          = "salary"
+	TxStageFee             = "fee"
+	TxStageMempool         = "mempool"
+	TxStageTxByItself      = "txbyitself"
+	TxStageBlockChain      = "blockchain"
+	TxStageInitToken       = "inittoken"
+	TxStageStaking         = "staking"
+	TxStageStopAutoStaking = "stopautostaking"
+)
+
+// TxValidationStage is the result of one validation stage, Err is nil if the tx passed it
+type TxValidationStage struct {
+	Stage  string
+	Passed bool
+	Err    error
+}
+
+// txValidationReport records the validation stages of a tx, a nil report records nothing
+type txValidationReport struct {
+	stages []TxValidationStage
+}
+
+func (report *txValidationReport) pass(stage string) {
+	if report == nil {
+		return
+	}
+	report.stages = append(report.stages, TxValidationStage{Stage: stage, Passed: true})
+}
+
+// reject records that the tx failed stage and returns err
+func (report *txValidationReport) reject(stage string, err error) error {
+	if report == nil {
+		return err
+	}
+	report.stages = append(report.stages, TxValidationStage{Stage: stage, Passed: false, Err: err})
+	return err
+}
+
+// TxAcceptResult is the result of TestMempoolAccept
+//   - Stages: the stages run until the first failure, the stages after it are not run
+//   - FeePerKB: the fee per kilobyte paid by the tx, a token fee is converted to PRV
+//   - MinFeePerKB, MinFee: the lowest fee per kilobyte and fee PRV the pool accepts for a tx of this size,
+//     metadata may require more
+type TxAcceptResult struct {
+	TxHash      common.Hash
+	Allowed     bool
+	Err         error
+	Stages      []TxValidationStage
+	FeePerKB    uint64
+	MinFeePerKB uint64
+	MinFee      uint64
+}
+
+// TestMempoolAccept runs the validation of MaybeAcceptTransaction on tx without adding it to pool or relaying it.
+// The sender rate limit is not checked so that a dry run does not use the rate of the sender.
+//
+// This function is safe for concurrent access.
+func (tp *TxPool) TestMempoolAccept(tx metadata.Transaction, beaconHeight int64) *TxAcceptResult {
+	tp.mtx.Lock()
+	defer tp.mtx.Unlock()
+	shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
+	result := &TxAcceptResult{
+		TxHash:   *tx.Hash(),
+		FeePerKB: tp.calculateFeePerKB(tx, beaconHeight),
+	}
+	result.MinFeePerKB = tp.minFeePerKB(shardID)
+	result.MinFee = result.MinFeePerKB * tx.GetTxActualSize()
+	report := &txValidationReport{}
+	result.Err = tp.testMempoolAccept(tx, beaconHeight, report)
+	result.Allowed = result.Err == nil
+	result.Stages = report.stages
+	return result
+}
+
+func (tp *TxPool) testMempoolAccept(tx metadata.Transaction, beaconHeight int64, report *txValidationReport) error {
+	if !tp.checkRelayShard(tx) && !tp.checkPublicKeyRole(tx) {
+		senderShardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
+		return report.reject(TxStageRelayShard, NewMempoolTxError(UnexpectedTransactionError, errors.New("Unexpected Transaction From Shard "+fmt.Sprintf("%d", senderShardID))))
+	}
+	report.pass(TxStageRelayShard)
+	if err := tp.checkPoolFeeRate(tx, beaconHeight); err != nil {
+		return report.reject(TxStagePoolFeeRate, err)
+	}
+	report.pass(TxStagePoolFeeRate)
+	_, err := tp.validateTransactionWithReport(tx, beaconHeight, report)
+	return err
+}
+
+// minFeePerKB returns the lowest fee per kilobyte the pool accepts from a tx of shard shardID:
+// the limit fee of the shard, more than the rolling minimum fee and, if the pool is full, more than the lowest fee in pool
+func (tp *TxPool) minFeePerKB(shardID byte) uint64 {
+	minFeePerKB := uint64(0)
+	if feeEstimator, ok := tp.config.FeeEstimator[shardID]; ok {
+		minFeePerKB = feeEstimator.limitFee
+	}
+	if rollingMinFee := tp.rollingMinFee(); rollingMinFee > 0 && rollingMinFee+1 > minFeePerKB {
+		minFeePerKB = rollingMinFee + 1
+	}
+	if uint64(len(tp.pool)) >= tp.config.MaxTx && len(tp.pool) > 0 {
+		if lowestFee := tp.lowestFeePerKB(); lowestFee+1 > minFeePerKB {
+			minFeePerKB = lowestFee + 1
+		}
+	}
+	return minFeePerKB
+}
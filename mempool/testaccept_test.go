@@ -0,0 +1,54 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxPoolTestMempoolAccept(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	now := time.Now()
+	pool := newEvictionTestTxPool(2, newFeeRateTestTxDesc(1, 10, now), newFeeRateTestTxDesc(2, 30, now))
+	pool.RoleInCommittees = -1
+	tx := &transaction.Tx{Type: common.TxNormalType, Fee: 1, LockTime: 3}
+
+	// the node does not relay txs of the shard of the sender
+	result := pool.TestMempoolAccept(tx, -1)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, *tx.Hash(), result.TxHash)
+	assert.Equal(t, 1, len(result.Stages))
+	assert.Equal(t, TxStageRelayShard, result.Stages[0].Stage)
+	assert.False(t, result.Stages[0].Passed)
+	assert.Equal(t, ErrCodeMessage[UnexpectedTransactionError].Code, result.Err.(*MempoolTxError).Code)
+
+	// the pool is full and the tx does not pay more than the lowest fee in pool
+	pool.config.RelayShards = []byte{0}
+	result = pool.TestMempoolAccept(tx, -1)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, []string{TxStageRelayShard, TxStagePoolFeeRate}, []string{result.Stages[0].Stage, result.Stages[1].Stage})
+	assert.True(t, result.Stages[0].Passed)
+	assert.False(t, result.Stages[1].Passed)
+	assert.Equal(t, ErrCodeMessage[MaxPoolSizeError].Code, result.Stages[1].Err.(*MempoolTxError).Code)
+	assert.Equal(t, pool.calculateFeePerKB(tx, -1), result.FeePerKB)
+	assert.Equal(t, uint64(11), result.MinFeePerKB)
+	assert.Equal(t, 11*tx.GetTxActualSize(), result.MinFee)
+
+	// nothing is added to pool
+	assert.Equal(t, 2, len(pool.pool))
+}
+
+func TestTxValidationReport(t *testing.T) {
+	var report *txValidationReport
+	report.pass(TxStageSanity)
+	err := NewMempoolTxError(RejectSalaryTx, nil)
+	assert.Equal(t, err, report.reject(TxStageSalary, err))
+
+	report = &txValidationReport{}
+	report.pass(TxStageSanity)
+	assert.Equal(t, err, report.reject(TxStageSalary, err))
+	assert.Equal(t, []TxValidationStage{{Stage: TxStageSanity, Passed: true}, {Stage: TxStageSalary, Passed: false, Err: err}}, report.stages)
+}
@@ -0,0 +1,54 @@
+package mempool
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/pubsub"
+)
+
+// States of a tx published on pubsub.MempoolTxEventTopic
+const (
+	TxEventAccepted = "accepted"
+	TxEventRejected = "rejected"
+	TxEventReplaced = "replaced"
+	TxEventEvicted  = "evicted"
+)
+
+// Reasons of a TxEventEvicted event
+const (
+	TxEvictedByTTL    = "ttl"
+	TxEvictedByMinFee = "minfee"
+)
+
+// TxEvent is published on pubsub.MempoolTxEventTopic when a tx enters or leaves the pool
+//   - TxEventAccepted: the tx is added to pool
+//   - TxEventRejected: the tx is not added to pool, ErrorCode is the code of the MempoolTxError
+//   - TxEventReplaced: the tx is removed from pool by the tx ReplacedBy
+//   - TxEventEvicted: the tx is removed from pool after its life time (TxEvictedByTTL) or because the pool is full (TxEvictedByMinFee)
+type TxEvent struct {
+	Tx           metadata.Transaction
+	State        string
+	ErrorCode    int
+	ErrorMessage string
+	ReplacedBy   *common.Hash
+	Reason       string
+}
+
+func (tp *TxPool) publishTxEvent(event *TxEvent) {
+	if tp.config.PubSubManager == nil {
+		return
+	}
+	go tp.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.MempoolTxEventTopic, event))
+}
+
+func (tp *TxPool) publishTxAcceptance(tx metadata.Transaction, err error) {
+	if err == nil {
+		tp.publishTxEvent(&TxEvent{Tx: tx, State: TxEventAccepted})
+		return
+	}
+	event := &TxEvent{Tx: tx, State: TxEventRejected, ErrorCode: ErrCodeMessage[UnexpectedTransactionError].Code, ErrorMessage: err.Error()}
+	if mempoolErr, ok := err.(*MempoolTxError); ok {
+		event.ErrorCode = mempoolErr.Code
+	}
+	tp.publishTxEvent(event)
+}
@@ -0,0 +1,47 @@
+package mempool
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func receiveTxEvent(t *testing.T, subChan pubsub.EventChannel) *TxEvent {
+	select {
+	case msg := <-subChan:
+		event, ok := msg.Value.(*TxEvent)
+		assert.True(t, ok)
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no tx event received")
+	}
+	return nil
+}
+
+func TestTxPoolPublishTxAcceptance(t *testing.T) {
+	pubSubManager := pubsub.NewPubSubManager()
+	go pubSubManager.Start()
+	_, subChan, err := pubSubManager.RegisterNewSubscriber(pubsub.MempoolTxEventTopic)
+	assert.Nil(t, err)
+	tp := &TxPool{config: Config{PubSubManager: pubSubManager}}
+	tx := &transaction.Tx{Type: common.TxNormalType, Fee: 100, LockTime: 1}
+
+	tp.publishTxAcceptance(tx, nil)
+	event := receiveTxEvent(t, subChan)
+	assert.Equal(t, TxEventAccepted, event.State)
+	assert.Equal(t, tx.Hash(), event.Tx.Hash())
+
+	tp.publishTxAcceptance(tx, NewMempoolTxError(RejectDoubleSpendWithMempoolTx, errors.New("double spend")))
+	event = receiveTxEvent(t, subChan)
+	assert.Equal(t, TxEventRejected, event.State)
+	assert.Equal(t, ErrCodeMessage[RejectDoubleSpendWithMempoolTx].Code, event.ErrorCode)
+	assert.NotEmpty(t, event.ErrorMessage)
+
+	// a pool without pubsub manager does not publish
+	(&TxPool{}).publishTxAcceptance(tx, nil)
+}
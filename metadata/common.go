@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"strconv"
 
+	"github.com/incognitochain/incognito-chain/common"
 	"github.com/pkg/errors"
 )
 
@@ -57,6 +58,8 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &WithDrawRewardResponse{}
 	case StopAutoStakingMeta:
 		md = &StopAutoStakingMetadata{}
+	case EquivocationProofMeta:
+		md = &EquivocationProofMetadata{}
 	case PDEContributionMeta:
 		md = &PDEContribution{}
 	case PDETradeRequestMeta:
@@ -97,3 +100,8 @@ func HasBridgeInstructions(instructions [][]string) bool {
 	}
 	return false
 }
+
+// trackBridgeReqProcessing marks the bridge request of txReqID as being processed
+func trackBridgeReqProcessing(dr DatabaseRetriever, txReqID common.Hash) error {
+	return dr.GetDatabase().TrackBridgeReqWithStatus(txReqID, byte(common.BridgeRequestProcessingStatus), nil)
+}
@@ -24,9 +24,10 @@ const (
 	WithDrawRewardResponseMeta   = 45
 
 	//statking
-	ShardStakingMeta    = 63
-	StopAutoStakingMeta = 127
-	BeaconStakingMeta   = 64
+	ShardStakingMeta      = 63
+	StopAutoStakingMeta   = 127
+	BeaconStakingMeta     = 64
+	EquivocationProofMeta = 128
 
 	// Incognito -> Ethereum bridge
 	BeaconSwapConfirmMeta = 70
@@ -72,5 +73,6 @@ const (
 	EthereumLightNodePort     = "8545"
 )
 const (
-	StopAutoStakingAmount = 0
+	StopAutoStakingAmount   = 0
+	EquivocationProofAmount = 0
 )
@@ -0,0 +1,130 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// EquivocationVote is the vote of a committee member for a block:
+// the json of the block header, the BLS and bridge signatures of the vote
+// and the confirmation of the vote signed with the bridge key of the member
+type EquivocationVote struct {
+	BlockHeader  json.RawMessage
+	BLS          []byte
+	BRI          []byte
+	Confirmation []byte
+}
+
+// EquivocationProofMetadata proves that a committee member voted for two different blocks of the same height and round.
+// ChainID is the shard ID of the blocks, BeaconOnly for beacon blocks
+type EquivocationProofMetadata struct {
+	MetadataBase
+	ChainID            int
+	CommitteePublicKey string
+	Votes              [2]EquivocationVote
+}
+
+func NewEquivocationProofMetadata(equivocationProofType int, chainID int, committeePublicKey string, votes [2]EquivocationVote) (*EquivocationProofMetadata, error) {
+	if equivocationProofType != EquivocationProofMeta {
+		return nil, errors.New("invalid equivocation proof type")
+	}
+	metadataBase := NewMetadataBase(equivocationProofType)
+	return &EquivocationProofMetadata{
+		MetadataBase:       *metadataBase,
+		ChainID:            chainID,
+		CommitteePublicKey: committeePublicKey,
+		Votes:              votes,
+	}, nil
+}
+
+func (equivocationProof *EquivocationProofMetadata) ValidateMetadataByItself() bool {
+	committeePublicKey := new(incognitokey.CommitteePublicKey)
+	if err := committeePublicKey.FromString(equivocationProof.CommitteePublicKey); err != nil {
+		return false
+	}
+	if !committeePublicKey.CheckSanityData() {
+		return false
+	}
+	if equivocationProof.ChainID != BeaconOnly && (equivocationProof.ChainID < 0 || equivocationProof.ChainID >= common.MaxShardNumber) {
+		return false
+	}
+	return equivocationProof.Type == EquivocationProofMeta
+}
+
+/*
+	Validate the proof with the committee of the chain in current beacon beststate:
+	- the offender is a committee member of the chain
+	- both votes are confirmed by the offender for two different blocks of the same height and round
+*/
+func (equivocationProof EquivocationProofMetadata) ValidateTxWithBlockChain(txr Transaction, bcr BlockchainRetriever, shardID byte, db database.DatabaseInterface) (bool, error) {
+	proof, ok := txr.GetMetadata().(*EquivocationProofMetadata)
+	if !ok {
+		return false, NewMetadataTxError(EquivocationProofTypeAssertionError, fmt.Errorf("Expect *EquivocationProofMetadata type but get %+v", reflect.TypeOf(txr.GetMetadata())))
+	}
+	if err := bcr.VerifyEquivocationProof(proof); err != nil {
+		return false, NewMetadataTxError(EquivocationProofInvalidError, err)
+	}
+	return true, nil
+}
+
+/*
+	// Have only one receiver
+	// Have only one amount corresponding to receiver
+	// Receiver Is Burning Address
+*/
+func (equivocationProof EquivocationProofMetadata) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	if txr.IsPrivacy() {
+		return false, false, errors.New("Equivocation Proof Transaction Is No Privacy Transaction")
+	}
+	onlyOne, pubkey, amount := txr.GetUniqueReceiver()
+	if !onlyOne {
+		return false, false, errors.New("Equivocation Proof Transaction Should Have 1 Output Amount crossponding to 1 Receiver")
+	}
+	keyWalletBurningAdd, err := wallet.Base58CheckDeserialize(common.BurningAddress)
+	if err != nil {
+		return false, false, err
+	}
+	if !bytes.Equal(pubkey, keyWalletBurningAdd.KeySet.PaymentAddress.Pk) {
+		return false, false, errors.New("receiver Should be Burning Address")
+	}
+	if amount != EquivocationProofAmount {
+		return false, false, errors.New("receiver amount should be zero")
+	}
+	for _, vote := range equivocationProof.Votes {
+		if len(vote.BlockHeader) == 0 || len(vote.BLS) == 0 || len(vote.Confirmation) == 0 {
+			return false, false, errors.New("Equivocation Proof Should Have 2 Confirmed Votes")
+		}
+	}
+	return true, true, nil
+}
+
+func (equivocationProof EquivocationProofMetadata) GetType() int {
+	return equivocationProof.Type
+}
+
+func (equivocationProof EquivocationProofMetadata) Hash() *common.Hash {
+	record := fmt.Sprint(equivocationProof.ChainID)
+	record += equivocationProof.CommitteePublicKey
+	for _, vote := range equivocationProof.Votes {
+		record += string(vote.BlockHeader)
+		record += string(vote.BLS)
+		record += string(vote.BRI)
+		record += string(vote.Confirmation)
+	}
+	// final hash
+	record += equivocationProof.MetadataBase.Hash().String()
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (equivocationProof *EquivocationProofMetadata) CalculateSize() uint64 {
+	return calculateSize(equivocationProof)
+}
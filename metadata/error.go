@@ -35,6 +35,9 @@ const (
 	StopAutoStakingRequestTypeAssertionError
 	StopAutoStakingRequestAlreadyStopError
 
+	EquivocationProofTypeAssertionError
+	EquivocationProofInvalidError
+
 	WrongIncognitoDAOPaymentAddressError
 
 	// pde
@@ -78,6 +81,8 @@ var ErrCodeMessage = map[int]struct {
 	StopAutoStakingRequestNoAutoStakingAvaiableError:      {-4003, "Stop Auto-Staking Request No Auto Staking Avaliable Error"},
 	StopAutoStakingRequestTypeAssertionError:              {-4004, "Stop Auto-Staking Request Type Assertion Error"},
 	StopAutoStakingRequestAlreadyStopError:                {-4005, "Stop Auto Staking Request Already Stop Error"},
+	EquivocationProofTypeAssertionError:                   {-4006, "Equivocation Proof Type Assertion Error"},
+	EquivocationProofInvalidError:                         {-4007, "Equivocation Proof Invalid Error"},
 
 	// -5xxx dev reward error
 	WrongIncognitoDAOPaymentAddressError: {-5001, "Invalid dev account"},
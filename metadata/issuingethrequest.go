@@ -175,7 +175,7 @@ func (iReq *IssuingETHRequest) BuildReqActions(tx Transaction, bcr BlockchainRet
 	action := []string{strconv.Itoa(IssuingETHRequestMeta), actionContentBase64Str}
 
 	Logger.log.Debug("hahaha txreqid: ", txReqID)
-	err = bcr.GetDatabase().TrackBridgeReqWithStatus(txReqID, byte(common.BridgeRequestProcessingStatus), nil)
+	err = trackBridgeReqProcessing(bcr, txReqID)
 	if err != nil {
 		return [][]string{}, NewMetadataTxError(IssuingEthRequestBuildReqActionsError, err)
 	}
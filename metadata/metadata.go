@@ -41,8 +41,8 @@ type TxDesc struct {
 	// FeeToken is zero if tx is PRV transaction
 	FeeToken uint64
 
-	// FeePerKB is the fee the transaction pays in coin per 1000 bytes.
-	FeePerKB int32
+	// FeePerKB is the fee the transaction pays in PRV per kilobyte, including its token fee converted to PRV.
+	FeePerKB uint64
 }
 
 // Interface for mempool which is used in metadata
@@ -51,25 +51,46 @@ type MempoolRetriever interface {
 	GetTxsInMem() map[common.Hash]TxDesc
 }
 
-// Interface for blockchain which is used in metadata
-type BlockchainRetriever interface {
-	GetStakingAmountShard() uint64
-	GetTxChainHeight(tx Transaction) (uint64, error)
-	GetChainHeight(byte) uint64
-	GetBeaconHeight() uint64
+// TransactionRetriever looks up transactions stored in the chain.
+type TransactionRetriever interface {
 	GetCustomTokenTxs(*common.Hash) (map[common.Hash]Transaction, error)
 	GetTransactionByHash(common.Hash) (byte, common.Hash, int, Transaction, error)
-	GetCurrentBeaconBlockHeight(byte) uint64
+	GetTxValue(txid string) (uint64, error)
+	GetShardIDFromTx(txid string) (byte, error)
+}
+
+// CommitteeRetriever provides committees, candidates and staking data of the chain.
+type CommitteeRetriever interface {
+	GetStakingAmountShard() uint64
 	GetAllCommitteeValidatorCandidate() (map[byte][]incognitokey.CommitteePublicKey, map[byte][]incognitokey.CommitteePublicKey, []incognitokey.CommitteePublicKey, []incognitokey.CommitteePublicKey, []incognitokey.CommitteePublicKey, []incognitokey.CommitteePublicKey, []incognitokey.CommitteePublicKey, []incognitokey.CommitteePublicKey, error)
 	GetAllCommitteeValidatorCandidateFlattenListFromDatabase() ([]string, error)
 	GetStakingTx(byte) map[string]string
 	GetAutoStakingList() map[string]bool
-	GetDatabase() database.DatabaseInterface
-	GetTxValue(txid string) (uint64, error)
-	GetShardIDFromTx(txid string) (byte, error)
+}
+
+// ParamsRetriever provides the chain params used by metadata.
+type ParamsRetriever interface {
 	GetCentralizedWebsitePaymentAddress() string
 }
 
+// DatabaseRetriever provides the database of the chain.
+type DatabaseRetriever interface {
+	GetDatabase() database.DatabaseInterface
+}
+
+// Interface for blockchain which is used in metadata
+type BlockchainRetriever interface {
+	TransactionRetriever
+	CommitteeRetriever
+	ParamsRetriever
+	DatabaseRetriever
+	GetTxChainHeight(tx Transaction) (uint64, error)
+	GetChainHeight(byte) uint64
+	GetBeaconHeight() uint64
+	GetCurrentBeaconBlockHeight(byte) uint64
+	VerifyEquivocationProof(proof *EquivocationProofMetadata) error
+}
+
 // Interface for all type of transaction
 type Transaction interface {
 	// GET/SET FUNC
@@ -122,7 +143,7 @@ type Transaction interface {
 func getPDEPoolPair(
 	prvIDStr, tokenIDStr string,
 	beaconHeight int64,
-	db database.DatabaseInterface,
+	db database.PDEStore,
 ) (*lvdb.PDEPoolForPair, error) {
 	var pdePoolForPair lvdb.PDEPoolForPair
 	var err error
@@ -166,7 +187,7 @@ func convertValueBetweenCurrencies(
 	currentCurrencyIDStr string,
 	tokenID *common.Hash,
 	beaconHeight int64,
-	db database.DatabaseInterface,
+	db database.PDEStore,
 ) (float64, error) {
 	prvIDStr := common.PRVCoinID.String()
 	tokenIDStr := tokenID.String()
@@ -202,7 +223,7 @@ func ConvertNativeTokenToPrivacyToken(
 	nativeTokenAmount uint64,
 	tokenID *common.Hash,
 	beaconHeight int64,
-	db database.DatabaseInterface,
+	db database.PDEStore,
 ) (float64, error) {
 	return convertValueBetweenCurrencies(
 		nativeTokenAmount,
@@ -219,7 +240,7 @@ func ConvertPrivacyTokenToNativeToken(
 	privacyTokenAmount uint64,
 	tokenID *common.Hash,
 	beaconHeight int64,
-	db database.DatabaseInterface,
+	db database.PDEStore,
 ) (float64, error) {
 	return convertValueBetweenCurrencies(
 		privacyTokenAmount,
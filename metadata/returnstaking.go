@@ -37,7 +37,12 @@ func (sbsRes ReturnStakingMetadata) CheckTransactionFee(tr Transaction, minFee u
 }
 
 func (sbsRes ReturnStakingMetadata) ValidateTxWithBlockChain(txr Transaction, bcr BlockchainRetriever, shardID byte, db database.DatabaseInterface) (bool, error) {
-	stakingTx := bcr.GetStakingTx(shardID)
+	return sbsRes.validateWithStakingTx(bcr, shardID)
+}
+
+// validateWithStakingTx checks that the staker has a staking tx in the shard and did not ask to re-stake
+func (sbsRes ReturnStakingMetadata) validateWithStakingTx(cr CommitteeRetriever, shardID byte) (bool, error) {
+	stakingTx := cr.GetStakingTx(shardID)
 	for key, value := range stakingTx {
 		committeePublicKey := incognitokey.CommitteePublicKey{}
 		err := committeePublicKey.FromString(key)
@@ -45,7 +50,7 @@ func (sbsRes ReturnStakingMetadata) ValidateTxWithBlockChain(txr Transaction, bc
 			return false, err
 		}
 		if reflect.DeepEqual(sbsRes.StakerAddress.Pk, committeePublicKey.IncPubKey) && (sbsRes.TxID == value) {
-			autoStakingList := bcr.GetAutoStakingList()
+			autoStakingList := cr.GetAutoStakingList()
 			if autoStakingList[key] {
 				return false, errors.New("Can not return staking amount for candidate, who want to restaking.")
 			}
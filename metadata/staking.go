@@ -79,7 +79,12 @@ func (stakingMetadata StakingMetadata) ValidateTxWithBlockChain(
 	bool,
 	error,
 ) {
-	SC, SPV, BC, BPV, CBWFCR, CBWFNR, CSWFCR, CSWFNR, err := bcr.GetAllCommitteeValidatorCandidate()
+	return stakingMetadata.validateStakerWithCommittees(bcr)
+}
+
+// validateStakerWithCommittees checks that the staker is not in any committee, pending validator or candidate list yet
+func (stakingMetadata StakingMetadata) validateStakerWithCommittees(cr CommitteeRetriever) (bool, error) {
+	SC, SPV, BC, BPV, CBWFCR, CBWFNR, CSWFCR, CSWFNR, err := cr.GetAllCommitteeValidatorCandidate()
 	if err != nil {
 		return false, err
 	}
@@ -134,11 +139,8 @@ func (stakingMetadata StakingMetadata) ValidateSanityData(
 		return false, false, errors.New("receiver Should be Burning Address")
 	}
 
-	if stakingMetadata.Type == ShardStakingMeta && amount != bcr.GetStakingAmountShard() {
-		return false, false, errors.New("invalid Stake Shard Amount")
-	}
-	if stakingMetadata.Type == BeaconStakingMeta && amount != bcr.GetStakingAmountShard()*3 {
-		return false, false, errors.New("invalid Stake Beacon Amount")
+	if err := stakingMetadata.validateStakingAmount(bcr, amount); err != nil {
+		return false, false, err
 	}
 
 	rewardReceiverPaymentAddress := stakingMetadata.RewardReceiverPaymentAddress
@@ -181,3 +183,14 @@ func (stakingMetadata StakingMetadata) GetBeaconStakeAmount() uint64 {
 func (stakingMetadata StakingMetadata) GetShardStateAmount() uint64 {
 	return stakingMetadata.StakingAmountShard
 }
+
+// validateStakingAmount checks that a beacon staker burns three times the shard staking amount and a shard staker burns exactly it
+func (stakingMetadata StakingMetadata) validateStakingAmount(cr CommitteeRetriever, amount uint64) error {
+	if stakingMetadata.Type == ShardStakingMeta && amount != cr.GetStakingAmountShard() {
+		return errors.New("invalid Stake Shard Amount")
+	}
+	if stakingMetadata.Type == BeaconStakingMeta && amount != cr.GetStakingAmountShard()*3 {
+		return errors.New("invalid Stake Beacon Amount")
+	}
+	return nil
+}
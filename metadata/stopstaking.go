@@ -49,12 +49,16 @@ func (stopAutoStakingMetadata *StopAutoStakingMetadata) ValidateMetadataByItself
 	- Not yet requested to stop auto-restaking
 */
 func (stopAutoStakingMetadata StopAutoStakingMetadata) ValidateTxWithBlockChain(txr Transaction, bcr BlockchainRetriever, shardID byte, db database.DatabaseInterface) (bool, error) {
+	return stopAutoStakingMetadata.validateWithCommittees(txr, bcr, bcr, shardID)
+}
+
+func (stopAutoStakingMetadata StopAutoStakingMetadata) validateWithCommittees(txr Transaction, cr CommitteeRetriever, tr TransactionRetriever, shardID byte) (bool, error) {
 	stopStakingMetadata, ok := txr.GetMetadata().(*StopAutoStakingMetadata)
 	if !ok {
 		return false, NewMetadataTxError(StopAutoStakingRequestTypeAssertionError, fmt.Errorf("Expect *StopAutoStakingMetadata type but get %+v", reflect.TypeOf(txr.GetMetadata())))
 	}
 	requestedPublicKey := stopStakingMetadata.CommitteePublicKey
-	committees, err := bcr.GetAllCommitteeValidatorCandidateFlattenListFromDatabase()
+	committees, err := cr.GetAllCommitteeValidatorCandidateFlattenListFromDatabase()
 	if err != nil {
 		return false, NewMetadataTxError(StopAutoStakingRequestNotInCommitteeListError, err)
 	}
@@ -62,7 +66,7 @@ func (stopAutoStakingMetadata StopAutoStakingMetadata) ValidateTxWithBlockChain(
 	if !(common.IndexOfStr(requestedPublicKey, committees) > -1) {
 		return false, NewMetadataTxError(StopAutoStakingRequestNotInCommitteeListError, fmt.Errorf("Committee Publickey %+v not found in any committee list of current beacon beststate", requestedPublicKey))
 	}
-	stakingTx := bcr.GetStakingTx(shardID)
+	stakingTx := cr.GetStakingTx(shardID)
 	if tempStakingTxHash, ok := stakingTx[requestedPublicKey]; !ok {
 		return false, NewMetadataTxError(StopAutoStakingRequestStakingTransactionNotFoundError, fmt.Errorf("No Committe Publickey %+v found in StakingTx of Shard %+v", requestedPublicKey, shardID))
 	} else {
@@ -70,7 +74,7 @@ func (stopAutoStakingMetadata StopAutoStakingMetadata) ValidateTxWithBlockChain(
 		if err != nil {
 			return false, err
 		}
-		_, _, _, stakingTx, err := bcr.GetTransactionByHash(*stakingTxHash)
+		_, _, _, stakingTx, err := tr.GetTransactionByHash(*stakingTxHash)
 		if err != nil {
 			return false, NewMetadataTxError(StopAutoStakingRequestStakingTransactionNotFoundError, err)
 		}
@@ -78,7 +82,7 @@ func (stopAutoStakingMetadata StopAutoStakingMetadata) ValidateTxWithBlockChain(
 			return false, NewMetadataTxError(StopAutoStakingRequestInvalidTransactionSenderError, fmt.Errorf("Expect %+v to send stop auto staking request but get %+v", stakingTx.GetSender(), txr.GetSender()))
 		}
 	}
-	autoStakingList := bcr.GetAutoStakingList()
+	autoStakingList := cr.GetAutoStakingList()
 	if isAutoStaking, ok := autoStakingList[stopStakingMetadata.CommitteePublicKey]; !ok {
 		return false, NewMetadataTxError(StopAutoStakingRequestNoAutoStakingAvaiableError, fmt.Errorf("Committe Publickey %+v already request stop auto re-staking", stopStakingMetadata.CommitteePublicKey))
 	} else {
@@ -23,6 +23,9 @@ const (
 	TxAddedIntoPoolType              = "TxAddedIntoPoolType"
 	TxPoolPrivacyOrNot               = "TxPoolPrivacyOrNot"
 	TxEnterNetSyncSuccess            = "TxEnterNetSyncSuccess"
+	TxRateLimitedByPeer              = "TxRateLimitedByPeer"
+	TxRateLimitedBySender            = "TxRateLimitedBySender"
+	PeerBannedForTxSpam              = "PeerBannedForTxSpam"
 	PoolSize                         = "PoolSize"
 	TxInOneBlock                     = "TxInOneBlock"
 	TxPoolDuplicateTxs               = "DuplicateTxs"
@@ -66,6 +69,7 @@ const (
 	ShardIDTag                  = "shardid"
 	NodeIDTag                   = "node"
 	TxHashTag                   = "txhash"
+	PeerIDTag                   = "peerid"
 	FuncTag                     = "func"
 	ExternalAddressTag          = "externaladdresstag"
 	NewShardBlockProcessingStep = "newshardblockprocessingstep"
@@ -5,6 +5,7 @@ package mocks
 import big "math/big"
 import common "github.com/incognitochain/incognito-chain/common"
 import database "github.com/incognitochain/incognito-chain/database"
+import io "io"
 import mock "github.com/stretchr/testify/mock"
 
 // DatabaseInterface is an autogenerated mock type for the DatabaseInterface type
@@ -26,13 +27,27 @@ func (_m *DatabaseInterface) AddCommitteeReward(committeeAddress []byte, amount
 	return r0
 }
 
-// AddShardRewardRequest provides a mock function with given fields: epoch, shardID, amount, tokenID
-func (_m *DatabaseInterface) AddShardRewardRequest(epoch uint64, shardID byte, amount uint64, tokenID common.Hash) error {
-	ret := _m.Called(epoch, shardID, amount, tokenID)
+// AddShardRewardRequest provides a mock function with given fields: epoch, shardID, amount, tokenID, bd
+func (_m *DatabaseInterface) AddShardRewardRequest(epoch uint64, shardID byte, amount uint64, tokenID common.Hash, bd *[]database.BatchData) error {
+	ret := _m.Called(epoch, shardID, amount, tokenID, bd)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64, byte, uint64, common.Hash) error); ok {
-		r0 = rf(epoch, shardID, amount, tokenID)
+	if rf, ok := ret.Get(0).(func(uint64, byte, uint64, common.Hash, *[]database.BatchData) error); ok {
+		r0 = rf(epoch, shardID, amount, tokenID, bd)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddTradeFeeUp provides a mock function with given fields: beaconHeight, token1IDStr, token2IDStr, tokenIDToBuyStr, amt
+func (_m *DatabaseInterface) AddTradeFeeUp(beaconHeight uint64, token1IDStr string, token2IDStr string, tokenIDToBuyStr string, amt uint64) error {
+	ret := _m.Called(beaconHeight, token1IDStr, token2IDStr, tokenIDToBuyStr, amt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, string, string, string, uint64) error); ok {
+		r0 = rf(beaconHeight, token1IDStr, token2IDStr, tokenIDToBuyStr, amt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -110,6 +125,29 @@ func (_m *DatabaseInterface) BackupShardRewardRequest(epoch uint64, shardID byte
 	return r0
 }
 
+// BeginTransaction provides a mock function with given fields:
+func (_m *DatabaseInterface) BeginTransaction() (database.Transaction, error) {
+	ret := _m.Called()
+
+	var r0 database.Transaction
+	if rf, ok := ret.Get(0).(func() database.Transaction); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(database.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CanProcessCIncToken provides a mock function with given fields: incTokenID
 func (_m *DatabaseInterface) CanProcessCIncToken(incTokenID common.Hash) (bool, error) {
 	ret := _m.Called(incTokenID)
@@ -152,6 +190,20 @@ func (_m *DatabaseInterface) CanProcessTokenPair(externalTokenID []byte, incToke
 	return r0, r1
 }
 
+// CheckSchemaVersion provides a mock function with given fields:
+func (_m *DatabaseInterface) CheckSchemaVersion() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CleanBackup provides a mock function with given fields: isBeacon, shardID
 func (_m *DatabaseInterface) CleanBackup(isBeacon bool, shardID byte) error {
 	ret := _m.Called(isBeacon, shardID)
@@ -264,6 +316,48 @@ func (_m *DatabaseInterface) Close() error {
 	return r0
 }
 
+// ContributeToPDE provides a mock function with given fields: beaconHeight, pairID, contributorAddressStr, tokenIDStr, contributedAmount
+func (_m *DatabaseInterface) ContributeToPDE(beaconHeight uint64, pairID string, contributorAddressStr string, tokenIDStr string, contributedAmount uint64) error {
+	ret := _m.Called(beaconHeight, pairID, contributorAddressStr, tokenIDStr, contributedAmount)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, string, string, string, uint64) error); ok {
+		r0 = rf(beaconHeight, pairID, contributorAddressStr, tokenIDStr, contributedAmount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeductSharesForWithdrawal provides a mock function with given fields: beaconHeight, token1IDStr, token2IDStr, targetingTokenIDStr, withdrawerAddressStr, amt
+func (_m *DatabaseInterface) DeductSharesForWithdrawal(beaconHeight uint64, token1IDStr string, token2IDStr string, targetingTokenIDStr string, withdrawerAddressStr string, amt uint64) error {
+	ret := _m.Called(beaconHeight, token1IDStr, token2IDStr, targetingTokenIDStr, withdrawerAddressStr, amt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, string, string, string, string, uint64) error); ok {
+		r0 = rf(beaconHeight, token1IDStr, token2IDStr, targetingTokenIDStr, withdrawerAddressStr, amt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeductTradeFee provides a mock function with given fields: beaconHeight, token1IDStr, token2IDStr, tokenIDToBuyStr, amt
+func (_m *DatabaseInterface) DeductTradeFee(beaconHeight uint64, token1IDStr string, token2IDStr string, tokenIDToBuyStr string, amt uint64) error {
+	ret := _m.Called(beaconHeight, token1IDStr, token2IDStr, tokenIDToBuyStr, amt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, string, string, string, uint64) error); ok {
+		r0 = rf(beaconHeight, token1IDStr, token2IDStr, tokenIDToBuyStr, amt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: key
 func (_m *DatabaseInterface) Delete(key []byte) error {
 	ret := _m.Called(key)
@@ -320,13 +414,27 @@ func (_m *DatabaseInterface) DeleteBlock(hash common.Hash, idx uint64, shardID b
 	return r0
 }
 
-// DeleteCommitteeByHeight provides a mock function with given fields: _a0
-func (_m *DatabaseInterface) DeleteCommitteeByHeight(_a0 uint64) error {
-	ret := _m.Called(_a0)
+// DeleteBlockBody provides a mock function with given fields: hash
+func (_m *DatabaseInterface) DeleteBlockBody(hash common.Hash) error {
+	ret := _m.Called(hash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash) error); ok {
+		r0 = rf(hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCommitteeByHeight provides a mock function with given fields: blkEpoch
+func (_m *DatabaseInterface) DeleteCommitteeByHeight(blkEpoch uint64) error {
+	ret := _m.Called(blkEpoch)
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(uint64) error); ok {
-		r0 = rf(_a0)
+		r0 = rf(blkEpoch)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -348,6 +456,20 @@ func (_m *DatabaseInterface) DeleteIncomingCrossShard(shardID byte, crossShardID
 	return r0
 }
 
+// DeleteMetadataTxIndex provides a mock function with given fields: metaType, shardID, height, txIndex
+func (_m *DatabaseInterface) DeleteMetadataTxIndex(metaType int, shardID byte, height uint64, txIndex int) error {
+	ret := _m.Called(metaType, shardID, height, txIndex)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, byte, uint64, int) error); ok {
+		r0 = rf(metaType, shardID, height, txIndex)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteNormalToken provides a mock function with given fields: tokenID
 func (_m *DatabaseInterface) DeleteNormalToken(tokenID common.Hash) error {
 	ret := _m.Called(tokenID)
@@ -390,6 +512,34 @@ func (_m *DatabaseInterface) DeleteOutputCoin(tokenID common.Hash, publicKey []b
 	return r0
 }
 
+// DeletePDEStateByHeight provides a mock function with given fields: beaconHeight
+func (_m *DatabaseInterface) DeletePDEStateByHeight(beaconHeight uint64) error {
+	ret := _m.Called(beaconHeight)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64) error); ok {
+		r0 = rf(beaconHeight)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePendingBlockCommit provides a mock function with given fields: isBeacon, shardID
+func (_m *DatabaseInterface) DeletePendingBlockCommit(isBeacon bool, shardID byte) error {
+	ret := _m.Called(isBeacon, shardID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(bool, byte) error); ok {
+		r0 = rf(isBeacon, shardID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeletePrivacyToken provides a mock function with given fields: tokenID
 func (_m *DatabaseInterface) DeletePrivacyToken(tokenID common.Hash) error {
 	ret := _m.Called(tokenID)
@@ -446,13 +596,50 @@ func (_m *DatabaseInterface) DeleteTransactionIndex(txId common.Hash) error {
 	return r0
 }
 
-// FetchAutoStakingByHeight provides a mock function with given fields: _a0
-func (_m *DatabaseInterface) FetchAutoStakingByHeight(_a0 uint64) ([]byte, error) {
-	ret := _m.Called(_a0)
+// DeleteWaitingPDEContributionByPairID provides a mock function with given fields: beaconHeight, pairID
+func (_m *DatabaseInterface) DeleteWaitingPDEContributionByPairID(beaconHeight uint64, pairID string) error {
+	ret := _m.Called(beaconHeight, pairID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, string) error); ok {
+		r0 = rf(beaconHeight, pairID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportSnapshot provides a mock function with given fields: writer, blockHashes
+func (_m *DatabaseInterface) ExportSnapshot(writer io.Writer, blockHashes []common.Hash) ([]database.SnapshotSection, error) {
+	ret := _m.Called(writer, blockHashes)
+
+	var r0 []database.SnapshotSection
+	if rf, ok := ret.Get(0).(func(io.Writer, []common.Hash) []database.SnapshotSection); ok {
+		r0 = rf(writer, blockHashes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.SnapshotSection)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(io.Writer, []common.Hash) error); ok {
+		r1 = rf(writer, blockHashes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchAutoStakingByHeight provides a mock function with given fields: height
+func (_m *DatabaseInterface) FetchAutoStakingByHeight(height uint64) ([]byte, error) {
+	ret := _m.Called(height)
 
 	var r0 []byte
 	if rf, ok := ret.Get(0).(func(uint64) []byte); ok {
-		r0 = rf(_a0)
+		r0 = rf(height)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
@@ -461,7 +648,7 @@ func (_m *DatabaseInterface) FetchAutoStakingByHeight(_a0 uint64) ([]byte, error
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(_a0)
+		r1 = rf(height)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -515,13 +702,13 @@ func (_m *DatabaseInterface) FetchBeaconBlock(hash common.Hash) ([]byte, error)
 	return r0, r1
 }
 
-// FetchBeaconCommitteeByHeight provides a mock function with given fields: _a0
-func (_m *DatabaseInterface) FetchBeaconCommitteeByHeight(_a0 uint64) ([]byte, error) {
-	ret := _m.Called(_a0)
+// FetchBeaconCommitteeByHeight provides a mock function with given fields: height
+func (_m *DatabaseInterface) FetchBeaconCommitteeByHeight(height uint64) ([]byte, error) {
+	ret := _m.Called(height)
 
 	var r0 []byte
 	if rf, ok := ret.Get(0).(func(uint64) []byte); ok {
-		r0 = rf(_a0)
+		r0 = rf(height)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
@@ -530,7 +717,7 @@ func (_m *DatabaseInterface) FetchBeaconCommitteeByHeight(_a0 uint64) ([]byte, e
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(_a0)
+		r1 = rf(height)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -582,6 +769,29 @@ func (_m *DatabaseInterface) FetchCrossShardNextHeight(fromShard byte, toShard b
 	return r0, r1
 }
 
+// FetchPendingBlockCommits provides a mock function with given fields:
+func (_m *DatabaseInterface) FetchPendingBlockCommits() ([]database.BlockCommit, error) {
+	ret := _m.Called()
+
+	var r0 []database.BlockCommit
+	if rf, ok := ret.Get(0).(func() []database.BlockCommit); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.BlockCommit)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FetchPrevBestState provides a mock function with given fields: isBeacon, shardID
 func (_m *DatabaseInterface) FetchPrevBestState(isBeacon bool, shardID byte) ([]byte, error) {
 	ret := _m.Called(isBeacon, shardID)
@@ -605,13 +815,13 @@ func (_m *DatabaseInterface) FetchPrevBestState(isBeacon bool, shardID byte) ([]
 	return r0, r1
 }
 
-// FetchRewardReceiverByHeight provides a mock function with given fields: _a0
-func (_m *DatabaseInterface) FetchRewardReceiverByHeight(_a0 uint64) ([]byte, error) {
-	ret := _m.Called(_a0)
+// FetchRewardReceiverByHeight provides a mock function with given fields: height
+func (_m *DatabaseInterface) FetchRewardReceiverByHeight(height uint64) ([]byte, error) {
+	ret := _m.Called(height)
 
 	var r0 []byte
 	if rf, ok := ret.Get(0).(func(uint64) []byte); ok {
-		r0 = rf(_a0)
+		r0 = rf(height)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
@@ -620,7 +830,7 @@ func (_m *DatabaseInterface) FetchRewardReceiverByHeight(_a0 uint64) ([]byte, er
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(_a0)
+		r1 = rf(height)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -651,13 +861,13 @@ func (_m *DatabaseInterface) FetchShardBestState(shardID byte) ([]byte, error) {
 	return r0, r1
 }
 
-// FetchShardCommitteeByHeight provides a mock function with given fields: _a0
-func (_m *DatabaseInterface) FetchShardCommitteeByHeight(_a0 uint64) ([]byte, error) {
-	ret := _m.Called(_a0)
+// FetchShardCommitteeByHeight provides a mock function with given fields: height
+func (_m *DatabaseInterface) FetchShardCommitteeByHeight(height uint64) ([]byte, error) {
+	ret := _m.Called(height)
 
 	var r0 []byte
 	if rf, ok := ret.Get(0).(func(uint64) []byte); ok {
-		r0 = rf(_a0)
+		r0 = rf(height)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
@@ -666,7 +876,7 @@ func (_m *DatabaseInterface) FetchShardCommitteeByHeight(_a0 uint64) ([]byte, er
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(_a0)
+		r1 = rf(height)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -674,6 +884,20 @@ func (_m *DatabaseInterface) FetchShardCommitteeByHeight(_a0 uint64) ([]byte, er
 	return r0, r1
 }
 
+// ForEachTransactionIndex provides a mock function with given fields: fn
+func (_m *DatabaseInterface) ForEachTransactionIndex(fn func(txID common.Hash, blockHash common.Hash, indexInBlock int) error) error {
+	ret := _m.Called(fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(func(txID common.Hash, blockHash common.Hash, indexInBlock int) error) error); ok {
+		r0 = rf(fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: key
 func (_m *DatabaseInterface) Get(key []byte) ([]byte, error) {
 	ret := _m.Called(key)
@@ -741,6 +965,38 @@ func (_m *DatabaseInterface) GetAllBridgeTokens() ([]byte, error) {
 	return r0, r1
 }
 
+// GetAllRecordsByPrefix provides a mock function with given fields: beaconHeight, prefix
+func (_m *DatabaseInterface) GetAllRecordsByPrefix(beaconHeight uint64, prefix []byte) ([][]byte, [][]byte, error) {
+	ret := _m.Called(beaconHeight, prefix)
+
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func(uint64, []byte) [][]byte); ok {
+		r0 = rf(beaconHeight, prefix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
+	}
+
+	var r1 [][]byte
+	if rf, ok := ret.Get(1).(func(uint64, []byte) [][]byte); ok {
+		r1 = rf(beaconHeight, prefix)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([][]byte)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(uint64, []byte) error); ok {
+		r2 = rf(beaconHeight, prefix)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetBeaconBlockHashByIndex provides a mock function with given fields: idx
 func (_m *DatabaseInterface) GetBeaconBlockHashByIndex(idx uint64) (common.Hash, error) {
 	ret := _m.Called(idx)
@@ -749,9 +1005,7 @@ func (_m *DatabaseInterface) GetBeaconBlockHashByIndex(idx uint64) (common.Hash,
 	if rf, ok := ret.Get(0).(func(uint64) common.Hash); ok {
 		r0 = rf(idx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(common.Hash)
-		}
+		r0 = ret.Get(0).(common.Hash)
 	}
 
 	var r1 error
@@ -772,9 +1026,7 @@ func (_m *DatabaseInterface) GetBlockByIndex(idx uint64, shardID byte) (common.H
 	if rf, ok := ret.Get(0).(func(uint64, byte) common.Hash); ok {
 		r0 = rf(idx, shardID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(common.Hash)
-		}
+		r0 = ret.Get(0).(common.Hash)
 	}
 
 	var r1 error
@@ -1012,6 +1264,29 @@ func (_m *DatabaseInterface) GetIndexOfBlock(hash common.Hash) (uint64, byte, er
 	return r0, r1, r2
 }
 
+// GetLatestPDEPoolForPair provides a mock function with given fields: tokenIDToBuyStr, tokenIDToSellStr
+func (_m *DatabaseInterface) GetLatestPDEPoolForPair(tokenIDToBuyStr string, tokenIDToSellStr string) ([]byte, error) {
+	ret := _m.Called(tokenIDToBuyStr, tokenIDToSellStr)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(string, string) []byte); ok {
+		r0 = rf(tokenIDToBuyStr, tokenIDToSellStr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(tokenIDToBuyStr, tokenIDToSellStr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNormalTokenPaymentAddressUTXO provides a mock function with given fields: tokenID, paymentAddress
 func (_m *DatabaseInterface) GetNormalTokenPaymentAddressUTXO(tokenID common.Hash, paymentAddress []byte) (map[string]string, error) {
 	ret := _m.Called(tokenID, paymentAddress)
@@ -1058,6 +1333,38 @@ func (_m *DatabaseInterface) GetNormalTokenPaymentAddressesBalance(tokenID commo
 	return r0, r1
 }
 
+// GetNormalTokenPaymentAddressesBalanceByCursor provides a mock function with given fields: tokenID, cursor, limit
+func (_m *DatabaseInterface) GetNormalTokenPaymentAddressesBalanceByCursor(tokenID common.Hash, cursor []byte, limit int) ([]database.TokenHolderBalance, []byte, error) {
+	ret := _m.Called(tokenID, cursor, limit)
+
+	var r0 []database.TokenHolderBalance
+	if rf, ok := ret.Get(0).(func(common.Hash, []byte, int) []database.TokenHolderBalance); ok {
+		r0 = rf(tokenID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.TokenHolderBalance)
+		}
+	}
+
+	var r1 []byte
+	if rf, ok := ret.Get(1).(func(common.Hash, []byte, int) []byte); ok {
+		r1 = rf(tokenID, cursor, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(common.Hash, []byte, int) error); ok {
+		r2 = rf(tokenID, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetOutcoinsByPubkey provides a mock function with given fields: tokenID, pubkey, shardID
 func (_m *DatabaseInterface) GetOutcoinsByPubkey(tokenID common.Hash, pubkey []byte, shardID byte) ([][]byte, error) {
 	ret := _m.Called(tokenID, pubkey, shardID)
@@ -1081,20 +1388,22 @@ func (_m *DatabaseInterface) GetOutcoinsByPubkey(tokenID common.Hash, pubkey []b
 	return r0, r1
 }
 
-// GetRewardOfShardByEpoch provides a mock function with given fields: epoch, shardID, tokenID
-func (_m *DatabaseInterface) GetRewardOfShardByEpoch(epoch uint64, shardID byte, tokenID common.Hash) (uint64, error) {
-	ret := _m.Called(epoch, shardID, tokenID)
+// GetPDEContributionStatus provides a mock function with given fields: prefix, suffix
+func (_m *DatabaseInterface) GetPDEContributionStatus(prefix []byte, suffix []byte) ([]byte, error) {
+	ret := _m.Called(prefix, suffix)
 
-	var r0 uint64
-	if rf, ok := ret.Get(0).(func(uint64, byte, common.Hash) uint64); ok {
-		r0 = rf(epoch, shardID, tokenID)
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func([]byte, []byte) []byte); ok {
+		r0 = rf(prefix, suffix)
 	} else {
-		r0 = ret.Get(0).(uint64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint64, byte, common.Hash) error); ok {
-		r1 = rf(epoch, shardID, tokenID)
+	if rf, ok := ret.Get(1).(func([]byte, []byte) error); ok {
+		r1 = rf(prefix, suffix)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1102,39 +1411,237 @@ func (_m *DatabaseInterface) GetRewardOfShardByEpoch(epoch uint64, shardID byte,
 	return r0, r1
 }
 
-// GetTransactionIndexById provides a mock function with given fields: txId
-func (_m *DatabaseInterface) GetTransactionIndexById(txId common.Hash) (common.Hash, int, error) {
-	ret := _m.Called(txId)
+// GetPDEPoolForPair provides a mock function with given fields: beaconHeight, tokenIDToBuyStr, tokenIDToSellStr
+func (_m *DatabaseInterface) GetPDEPoolForPair(beaconHeight uint64, tokenIDToBuyStr string, tokenIDToSellStr string) ([]byte, error) {
+	ret := _m.Called(beaconHeight, tokenIDToBuyStr, tokenIDToSellStr)
 
-	var r0 common.Hash
-	if rf, ok := ret.Get(0).(func(common.Hash) common.Hash); ok {
-		r0 = rf(txId)
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(uint64, string, string) []byte); ok {
+		r0 = rf(beaconHeight, tokenIDToBuyStr, tokenIDToSellStr)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(common.Hash)
+			r0 = ret.Get(0).([]byte)
 		}
 	}
 
-	var r1 int
-	if rf, ok := ret.Get(1).(func(common.Hash) int); ok {
-		r1 = rf(txId)
-	} else {
-		r1 = ret.Get(1).(int)
-	}
-
-	var r2 error
-	if rf, ok := ret.Get(2).(func(common.Hash) error); ok {
-		r2 = rf(txId)
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64, string, string) error); ok {
+		r1 = rf(beaconHeight, tokenIDToBuyStr, tokenIDToSellStr)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetTxByPublicKey provides a mock function with given fields: publicKey
-func (_m *DatabaseInterface) GetTxByPublicKey(publicKey []byte) (map[byte][]common.Hash, error) {
-	ret := _m.Called(publicKey)
+// GetPDEStatus provides a mock function with given fields: prefix, suffix
+func (_m *DatabaseInterface) GetPDEStatus(prefix []byte, suffix []byte) (byte, error) {
+	ret := _m.Called(prefix, suffix)
+
+	var r0 byte
+	if rf, ok := ret.Get(0).(func([]byte, []byte) byte); ok {
+		r0 = rf(prefix, suffix)
+	} else {
+		r0 = ret.Get(0).(byte)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte, []byte) error); ok {
+		r1 = rf(prefix, suffix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProducersBlackList provides a mock function with given fields: beaconHeight
+func (_m *DatabaseInterface) GetProducersBlackList(beaconHeight uint64) (map[string]uint8, error) {
+	ret := _m.Called(beaconHeight)
+
+	var r0 map[string]uint8
+	if rf, ok := ret.Get(0).(func(uint64) map[string]uint8); ok {
+		r0 = rf(beaconHeight)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]uint8)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(beaconHeight)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPrunedHeight provides a mock function with given fields: isBeacon, shardID
+func (_m *DatabaseInterface) GetPrunedHeight(isBeacon bool, shardID byte) (uint64, error) {
+	ret := _m.Called(isBeacon, shardID)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(bool, byte) uint64); ok {
+		r0 = rf(isBeacon, shardID)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(bool, byte) error); ok {
+		r1 = rf(isBeacon, shardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRewardOfShardByEpoch provides a mock function with given fields: epoch, shardID, tokenID
+func (_m *DatabaseInterface) GetRewardOfShardByEpoch(epoch uint64, shardID byte, tokenID common.Hash) (uint64, error) {
+	ret := _m.Called(epoch, shardID, tokenID)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(uint64, byte, common.Hash) uint64); ok {
+		r0 = rf(epoch, shardID, tokenID)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64, byte, common.Hash) error); ok {
+		r1 = rf(epoch, shardID, tokenID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSchemaVersion provides a mock function with given fields:
+func (_m *DatabaseInterface) GetSchemaVersion() (uint32, error) {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSharesOfContributorForTokenIDOnAPair provides a mock function with given fields: token1IDStr, token2IDStr, contributedTokenIDStr, contributorAddrStr
+func (_m *DatabaseInterface) GetSharesOfContributorForTokenIDOnAPair(token1IDStr string, token2IDStr string, contributedTokenIDStr string, contributorAddrStr string) (uint64, error) {
+	ret := _m.Called(token1IDStr, token2IDStr, contributedTokenIDStr, contributorAddrStr)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(string, string, string, string) uint64); ok {
+		r0 = rf(token1IDStr, token2IDStr, contributedTokenIDStr, contributorAddrStr)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, string) error); ok {
+		r1 = rf(token1IDStr, token2IDStr, contributedTokenIDStr, contributorAddrStr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSlashedEquivocation provides a mock function with given fields: chainID, committeePublicKey, height, round
+func (_m *DatabaseInterface) GetSlashedEquivocation(chainID int, committeePublicKey string, height uint64, round int) (uint64, bool, error) {
+	ret := _m.Called(chainID, committeePublicKey, height, round)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(int, string, uint64, int) uint64); ok {
+		r0 = rf(chainID, committeePublicKey, height, round)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(int, string, uint64, int) bool); ok {
+		r1 = rf(chainID, committeePublicKey, height, round)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, string, uint64, int) error); ok {
+		r2 = rf(chainID, committeePublicKey, height, round)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetTotalSharesForTokenIDOnAPair provides a mock function with given fields: token1IDStr, token2IDStr, contributedTokenIDStr
+func (_m *DatabaseInterface) GetTotalSharesForTokenIDOnAPair(token1IDStr string, token2IDStr string, contributedTokenIDStr string) (uint64, error) {
+	ret := _m.Called(token1IDStr, token2IDStr, contributedTokenIDStr)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(string, string, string) uint64); ok {
+		r0 = rf(token1IDStr, token2IDStr, contributedTokenIDStr)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(token1IDStr, token2IDStr, contributedTokenIDStr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionIndexById provides a mock function with given fields: txId
+func (_m *DatabaseInterface) GetTransactionIndexById(txId common.Hash) (common.Hash, int, error) {
+	ret := _m.Called(txId)
+
+	var r0 common.Hash
+	if rf, ok := ret.Get(0).(func(common.Hash) common.Hash); ok {
+		r0 = rf(txId)
+	} else {
+		r0 = ret.Get(0).(common.Hash)
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(common.Hash) int); ok {
+		r1 = rf(txId)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(common.Hash) error); ok {
+		r2 = rf(txId)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetTxByPublicKey provides a mock function with given fields: publicKey
+func (_m *DatabaseInterface) GetTxByPublicKey(publicKey []byte) (map[byte][]common.Hash, error) {
+	ret := _m.Called(publicKey)
 
 	var r0 map[byte][]common.Hash
 	if rf, ok := ret.Get(0).(func([]byte) map[byte][]common.Hash); ok {
@@ -1253,27 +1760,6 @@ func (_m *DatabaseInterface) HasCommitmentIndex(tokenID common.Hash, commitmentI
 	return r0, r1
 }
 
-// HasCommitteeByHeight provides a mock function with given fields: _a0
-func (_m *DatabaseInterface) HasCommitteeByHeight(_a0 uint64) (bool, error) {
-	ret := _m.Called(_a0)
-
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(uint64) bool); ok {
-		r0 = rf(_a0)
-	} else {
-		r0 = ret.Get(0).(bool)
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // HasIncomingCrossShard provides a mock function with given fields: shardID, crossShardID, crossBlkHash
 func (_m *DatabaseInterface) HasIncomingCrossShard(shardID byte, crossShardID byte, crossBlkHash common.Hash) error {
 	ret := _m.Called(shardID, crossShardID, crossBlkHash)
@@ -1330,6 +1816,27 @@ func (_m *DatabaseInterface) HasSerialNumber(tokenID common.Hash, data []byte, s
 	return r0, r1
 }
 
+// HasShardCommitteeByHeight provides a mock function with given fields: height
+func (_m *DatabaseInterface) HasShardCommitteeByHeight(height uint64) (bool, error) {
+	ret := _m.Called(height)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uint64) bool); ok {
+		r0 = rf(height)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HasValue provides a mock function with given fields: key
 func (_m *DatabaseInterface) HasValue(key []byte) (bool, error) {
 	ret := _m.Called(key)
@@ -1351,6 +1858,20 @@ func (_m *DatabaseInterface) HasValue(key []byte) (bool, error) {
 	return r0, r1
 }
 
+// ImportSnapshot provides a mock function with given fields: reader, size, schemaVersion, sections, verify
+func (_m *DatabaseInterface) ImportSnapshot(reader io.Reader, size int64, schemaVersion uint32, sections []database.SnapshotSection, verify func() error) error {
+	ret := _m.Called(reader, size, schemaVersion, sections, verify)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(io.Reader, int64, uint32, []database.SnapshotSection, func() error) error); ok {
+		r0 = rf(reader, size, schemaVersion, sections, verify)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertETHTxHashIssued provides a mock function with given fields: uniqETHTx
 func (_m *DatabaseInterface) InsertETHTxHashIssued(uniqETHTx []byte) error {
 	ret := _m.Called(uniqETHTx)
@@ -1430,6 +1951,38 @@ func (_m *DatabaseInterface) ListCommitment(tokenID common.Hash, shardID byte) (
 	return r0, r1
 }
 
+// ListCommitmentByCursor provides a mock function with given fields: tokenID, shardID, cursor, limit
+func (_m *DatabaseInterface) ListCommitmentByCursor(tokenID common.Hash, shardID byte, cursor []byte, limit int) ([]database.CommitmentItem, []byte, error) {
+	ret := _m.Called(tokenID, shardID, cursor, limit)
+
+	var r0 []database.CommitmentItem
+	if rf, ok := ret.Get(0).(func(common.Hash, byte, []byte, int) []database.CommitmentItem); ok {
+		r0 = rf(tokenID, shardID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CommitmentItem)
+		}
+	}
+
+	var r1 []byte
+	if rf, ok := ret.Get(1).(func(common.Hash, byte, []byte, int) []byte); ok {
+		r1 = rf(tokenID, shardID, cursor, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(common.Hash, byte, []byte, int) error); ok {
+		r2 = rf(tokenID, shardID, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListCommitmentIndices provides a mock function with given fields: tokenID, shardID
 func (_m *DatabaseInterface) ListCommitmentIndices(tokenID common.Hash, shardID byte) (map[uint64]string, error) {
 	ret := _m.Called(tokenID, shardID)
@@ -1453,6 +2006,61 @@ func (_m *DatabaseInterface) ListCommitmentIndices(tokenID common.Hash, shardID
 	return r0, r1
 }
 
+// ListCommitmentIndicesByCursor provides a mock function with given fields: tokenID, shardID, cursor, limit
+func (_m *DatabaseInterface) ListCommitmentIndicesByCursor(tokenID common.Hash, shardID byte, cursor []byte, limit int) ([]database.CommitmentItem, []byte, error) {
+	ret := _m.Called(tokenID, shardID, cursor, limit)
+
+	var r0 []database.CommitmentItem
+	if rf, ok := ret.Get(0).(func(common.Hash, byte, []byte, int) []database.CommitmentItem); ok {
+		r0 = rf(tokenID, shardID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CommitmentItem)
+		}
+	}
+
+	var r1 []byte
+	if rf, ok := ret.Get(1).(func(common.Hash, byte, []byte, int) []byte); ok {
+		r1 = rf(tokenID, shardID, cursor, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(common.Hash, byte, []byte, int) error); ok {
+		r2 = rf(tokenID, shardID, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListCommitmentTokenIDs provides a mock function with given fields: shardID
+func (_m *DatabaseInterface) ListCommitmentTokenIDs(shardID byte) ([]common.Hash, error) {
+	ret := _m.Called(shardID)
+
+	var r0 []common.Hash
+	if rf, ok := ret.Get(0).(func(byte) []common.Hash); ok {
+		r0 = rf(shardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(byte) error); ok {
+		r1 = rf(shardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListCommitteeReward provides a mock function with given fields:
 func (_m *DatabaseInterface) ListCommitteeReward() map[string]map[common.Hash]uint64 {
 	ret := _m.Called()
@@ -1469,6 +2077,61 @@ func (_m *DatabaseInterface) ListCommitteeReward() map[string]map[common.Hash]ui
 	return r0
 }
 
+// ListCommitteeRewardByCursor provides a mock function with given fields: cursor, limit
+func (_m *DatabaseInterface) ListCommitteeRewardByCursor(cursor []byte, limit int) ([]database.CommitteeRewardItem, []byte, error) {
+	ret := _m.Called(cursor, limit)
+
+	var r0 []database.CommitteeRewardItem
+	if rf, ok := ret.Get(0).(func([]byte, int) []database.CommitteeRewardItem); ok {
+		r0 = rf(cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CommitteeRewardItem)
+		}
+	}
+
+	var r1 []byte
+	if rf, ok := ret.Get(1).(func([]byte, int) []byte); ok {
+		r1 = rf(cursor, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func([]byte, int) error); ok {
+		r2 = rf(cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListMetadataTxIndices provides a mock function with given fields: metaType, shardID, fromHeight, fromTxIndex, toHeight, limit
+func (_m *DatabaseInterface) ListMetadataTxIndices(metaType int, shardID byte, fromHeight uint64, fromTxIndex int, toHeight uint64, limit int) ([]database.MetadataTxIndex, error) {
+	ret := _m.Called(metaType, shardID, fromHeight, fromTxIndex, toHeight, limit)
+
+	var r0 []database.MetadataTxIndex
+	if rf, ok := ret.Get(0).(func(int, byte, uint64, int, uint64, int) []database.MetadataTxIndex); ok {
+		r0 = rf(metaType, shardID, fromHeight, fromTxIndex, toHeight, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.MetadataTxIndex)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, byte, uint64, int, uint64, int) error); ok {
+		r1 = rf(metaType, shardID, fromHeight, fromTxIndex, toHeight, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListNormalToken provides a mock function with given fields:
 func (_m *DatabaseInterface) ListNormalToken() ([][]byte, error) {
 	ret := _m.Called()
@@ -1561,6 +2224,38 @@ func (_m *DatabaseInterface) ListSNDerivator(tokenID common.Hash) ([][]byte, err
 	return r0, r1
 }
 
+// ListSNDerivatorByCursor provides a mock function with given fields: tokenID, cursor, limit
+func (_m *DatabaseInterface) ListSNDerivatorByCursor(tokenID common.Hash, cursor []byte, limit int) ([][]byte, []byte, error) {
+	ret := _m.Called(tokenID, cursor, limit)
+
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func(common.Hash, []byte, int) [][]byte); ok {
+		r0 = rf(tokenID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
+	}
+
+	var r1 []byte
+	if rf, ok := ret.Get(1).(func(common.Hash, []byte, int) []byte); ok {
+		r1 = rf(tokenID, cursor, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(common.Hash, []byte, int) error); ok {
+		r2 = rf(tokenID, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListSerialNumber provides a mock function with given fields: tokenID, shardID
 func (_m *DatabaseInterface) ListSerialNumber(tokenID common.Hash, shardID byte) (map[string]uint64, error) {
 	ret := _m.Called(tokenID, shardID)
@@ -1584,6 +2279,38 @@ func (_m *DatabaseInterface) ListSerialNumber(tokenID common.Hash, shardID byte)
 	return r0, r1
 }
 
+// ListSerialNumberByCursor provides a mock function with given fields: tokenID, shardID, cursor, limit
+func (_m *DatabaseInterface) ListSerialNumberByCursor(tokenID common.Hash, shardID byte, cursor []byte, limit int) ([]database.SerialNumberItem, []byte, error) {
+	ret := _m.Called(tokenID, shardID, cursor, limit)
+
+	var r0 []database.SerialNumberItem
+	if rf, ok := ret.Get(0).(func(common.Hash, byte, []byte, int) []database.SerialNumberItem); ok {
+		r0 = rf(tokenID, shardID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.SerialNumberItem)
+		}
+	}
+
+	var r1 []byte
+	if rf, ok := ret.Get(1).(func(common.Hash, byte, []byte, int) []byte); ok {
+		r1 = rf(tokenID, shardID, cursor, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(common.Hash, byte, []byte, int) error); ok {
+		r2 = rf(tokenID, shardID, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NormalTokenIDExisted provides a mock function with given fields: tokenID
 func (_m *DatabaseInterface) NormalTokenIDExisted(tokenID common.Hash) bool {
 	ret := _m.Called(tokenID)
@@ -1700,13 +2427,13 @@ func (_m *DatabaseInterface) PutBatch(data []database.BatchData) error {
 	return r0
 }
 
-// RemoveCommitteeReward provides a mock function with given fields: committeeAddress, amount, tokenID
-func (_m *DatabaseInterface) RemoveCommitteeReward(committeeAddress []byte, amount uint64, tokenID common.Hash) error {
-	ret := _m.Called(committeeAddress, amount, tokenID)
+// RemoveCommitteeReward provides a mock function with given fields: committeeAddress, amount, tokenID, bd
+func (_m *DatabaseInterface) RemoveCommitteeReward(committeeAddress []byte, amount uint64, tokenID common.Hash, bd *[]database.BatchData) error {
+	ret := _m.Called(committeeAddress, amount, tokenID, bd)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func([]byte, uint64, common.Hash) error); ok {
-		r0 = rf(committeeAddress, amount, tokenID)
+	if rf, ok := ret.Get(0).(func([]byte, uint64, common.Hash, *[]database.BatchData) error); ok {
+		r0 = rf(committeeAddress, amount, tokenID, bd)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1812,13 +2539,13 @@ func (_m *DatabaseInterface) StoreAcceptedShardToBeacon(shardID byte, blkHeight
 	return r0
 }
 
-// StoreAutoStakingByHeight provides a mock function with given fields: _a0, _a1
-func (_m *DatabaseInterface) StoreAutoStakingByHeight(_a0 uint64, _a1 interface{}) error {
-	ret := _m.Called(_a0, _a1)
+// StoreAutoStakingByHeight provides a mock function with given fields: height, v
+func (_m *DatabaseInterface) StoreAutoStakingByHeight(height uint64, v interface{}) error {
+	ret := _m.Called(height, v)
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(uint64, interface{}) error); ok {
-		r0 = rf(_a0, _a1)
+		r0 = rf(height, v)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1826,13 +2553,13 @@ func (_m *DatabaseInterface) StoreAutoStakingByHeight(_a0 uint64, _a1 interface{
 	return r0
 }
 
-// StoreBeaconBestState provides a mock function with given fields: v
-func (_m *DatabaseInterface) StoreBeaconBestState(v interface{}) error {
-	ret := _m.Called(v)
+// StoreBeaconBestState provides a mock function with given fields: v, bd
+func (_m *DatabaseInterface) StoreBeaconBestState(v interface{}, bd *[]database.BatchData) error {
+	ret := _m.Called(v, bd)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
-		r0 = rf(v)
+	if rf, ok := ret.Get(0).(func(interface{}, *[]database.BatchData) error); ok {
+		r0 = rf(v, bd)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1840,13 +2567,13 @@ func (_m *DatabaseInterface) StoreBeaconBestState(v interface{}) error {
 	return r0
 }
 
-// StoreBeaconBlock provides a mock function with given fields: v, hash
-func (_m *DatabaseInterface) StoreBeaconBlock(v interface{}, hash common.Hash) error {
-	ret := _m.Called(v, hash)
+// StoreBeaconBlock provides a mock function with given fields: v, hash, bd
+func (_m *DatabaseInterface) StoreBeaconBlock(v interface{}, hash common.Hash, bd *[]database.BatchData) error {
+	ret := _m.Called(v, hash, bd)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(interface{}, common.Hash) error); ok {
-		r0 = rf(v, hash)
+	if rf, ok := ret.Get(0).(func(interface{}, common.Hash, *[]database.BatchData) error); ok {
+		r0 = rf(v, hash, bd)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1868,13 +2595,13 @@ func (_m *DatabaseInterface) StoreBeaconBlockIndex(hash common.Hash, idx uint64)
 	return r0
 }
 
-// StoreBeaconCommitteeByHeight provides a mock function with given fields: _a0, _a1
-func (_m *DatabaseInterface) StoreBeaconCommitteeByHeight(_a0 uint64, _a1 interface{}) error {
-	ret := _m.Called(_a0, _a1)
+// StoreBeaconCommitteeByHeight provides a mock function with given fields: height, v
+func (_m *DatabaseInterface) StoreBeaconCommitteeByHeight(height uint64, v interface{}) error {
+	ret := _m.Called(height, v)
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(uint64, interface{}) error); ok {
-		r0 = rf(_a0, _a1)
+		r0 = rf(height, v)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1882,13 +2609,13 @@ func (_m *DatabaseInterface) StoreBeaconCommitteeByHeight(_a0 uint64, _a1 interf
 	return r0
 }
 
-// StoreBurningConfirm provides a mock function with given fields: txID, height
-func (_m *DatabaseInterface) StoreBurningConfirm(txID common.Hash, height uint64) error {
-	ret := _m.Called(txID, height)
+// StoreBurningConfirm provides a mock function with given fields: txID, height, bd
+func (_m *DatabaseInterface) StoreBurningConfirm(txID common.Hash, height uint64, bd *[]database.BatchData) error {
+	ret := _m.Called(txID, height, bd)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(common.Hash, uint64) error); ok {
-		r0 = rf(txID, height)
+	if rf, ok := ret.Get(0).(func(common.Hash, uint64, *[]database.BatchData) error); ok {
+		r0 = rf(txID, height, bd)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1938,13 +2665,27 @@ func (_m *DatabaseInterface) StoreFeeEstimator(val []byte, shardID byte) error {
 	return r0
 }
 
-// StoreIncomingCrossShard provides a mock function with given fields: shardID, crossShardID, blkHeight, crossBlkHash
-func (_m *DatabaseInterface) StoreIncomingCrossShard(shardID byte, crossShardID byte, blkHeight uint64, crossBlkHash common.Hash) error {
-	ret := _m.Called(shardID, crossShardID, blkHeight, crossBlkHash)
+// StoreIncomingCrossShard provides a mock function with given fields: shardID, crossShardID, blkHeight, crossBlkHash, bd
+func (_m *DatabaseInterface) StoreIncomingCrossShard(shardID byte, crossShardID byte, blkHeight uint64, crossBlkHash common.Hash, bd *[]database.BatchData) error {
+	ret := _m.Called(shardID, crossShardID, blkHeight, crossBlkHash, bd)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(byte, byte, uint64, common.Hash) error); ok {
-		r0 = rf(shardID, crossShardID, blkHeight, crossBlkHash)
+	if rf, ok := ret.Get(0).(func(byte, byte, uint64, common.Hash, *[]database.BatchData) error); ok {
+		r0 = rf(shardID, crossShardID, blkHeight, crossBlkHash, bd)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreMetadataTxIndex provides a mock function with given fields: metaType, shardID, height, txIndex, txHash
+func (_m *DatabaseInterface) StoreMetadataTxIndex(metaType int, shardID byte, height uint64, txIndex int, txHash common.Hash) error {
+	ret := _m.Called(metaType, shardID, height, txIndex, txHash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, byte, uint64, int, common.Hash) error); ok {
+		r0 = rf(metaType, shardID, height, txIndex, txHash)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1994,6 +2735,20 @@ func (_m *DatabaseInterface) StoreOutputCoins(tokenID common.Hash, publicKey []b
 	return r0
 }
 
+// StorePendingBlockCommit provides a mock function with given fields: blockCommit
+func (_m *DatabaseInterface) StorePendingBlockCommit(blockCommit database.BlockCommit) error {
+	ret := _m.Called(blockCommit)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(database.BlockCommit) error); ok {
+		r0 = rf(blockCommit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StorePrevBestState provides a mock function with given fields: val, isBeacon, shardID
 func (_m *DatabaseInterface) StorePrevBestState(val []byte, isBeacon bool, shardID byte) error {
 	ret := _m.Called(val, isBeacon, shardID)
@@ -2050,13 +2805,41 @@ func (_m *DatabaseInterface) StorePrivacyTokenTx(tokenID common.Hash, shardID by
 	return r0
 }
 
-// StoreRewardReceiverByHeight provides a mock function with given fields: _a0, _a1
-func (_m *DatabaseInterface) StoreRewardReceiverByHeight(_a0 uint64, _a1 interface{}) error {
-	ret := _m.Called(_a0, _a1)
+// StoreProducersBlackList provides a mock function with given fields: beaconHeight, producersBlackList
+func (_m *DatabaseInterface) StoreProducersBlackList(beaconHeight uint64, producersBlackList map[string]uint8) error {
+	ret := _m.Called(beaconHeight, producersBlackList)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, map[string]uint8) error); ok {
+		r0 = rf(beaconHeight, producersBlackList)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorePrunedHeight provides a mock function with given fields: isBeacon, shardID, height
+func (_m *DatabaseInterface) StorePrunedHeight(isBeacon bool, shardID byte, height uint64) error {
+	ret := _m.Called(isBeacon, shardID, height)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(bool, byte, uint64) error); ok {
+		r0 = rf(isBeacon, shardID, height)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreRewardReceiverByHeight provides a mock function with given fields: height, v
+func (_m *DatabaseInterface) StoreRewardReceiverByHeight(height uint64, v interface{}) error {
+	ret := _m.Called(height, v)
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(uint64, interface{}) error); ok {
-		r0 = rf(_a0, _a1)
+		r0 = rf(height, v)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2092,13 +2875,13 @@ func (_m *DatabaseInterface) StoreSerialNumbers(tokenID common.Hash, serialNumbe
 	return r0
 }
 
-// StoreShardBestState provides a mock function with given fields: v, shardID
-func (_m *DatabaseInterface) StoreShardBestState(v interface{}, shardID byte) error {
-	ret := _m.Called(v, shardID)
+// StoreShardBestState provides a mock function with given fields: v, shardID, bd
+func (_m *DatabaseInterface) StoreShardBestState(v interface{}, shardID byte, bd *[]database.BatchData) error {
+	ret := _m.Called(v, shardID, bd)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(interface{}, byte) error); ok {
-		r0 = rf(v, shardID)
+	if rf, ok := ret.Get(0).(func(interface{}, byte, *[]database.BatchData) error); ok {
+		r0 = rf(v, shardID, bd)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2106,13 +2889,13 @@ func (_m *DatabaseInterface) StoreShardBestState(v interface{}, shardID byte) er
 	return r0
 }
 
-// StoreShardBlock provides a mock function with given fields: v, hash, shardID
-func (_m *DatabaseInterface) StoreShardBlock(v interface{}, hash common.Hash, shardID byte) error {
-	ret := _m.Called(v, hash, shardID)
+// StoreShardBlock provides a mock function with given fields: v, hash, shardID, bd
+func (_m *DatabaseInterface) StoreShardBlock(v interface{}, hash common.Hash, shardID byte, bd *[]database.BatchData) error {
+	ret := _m.Called(v, hash, shardID, bd)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(interface{}, common.Hash, byte) error); ok {
-		r0 = rf(v, hash, shardID)
+	if rf, ok := ret.Get(0).(func(interface{}, common.Hash, byte, *[]database.BatchData) error); ok {
+		r0 = rf(v, hash, shardID, bd)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2120,13 +2903,13 @@ func (_m *DatabaseInterface) StoreShardBlock(v interface{}, hash common.Hash, sh
 	return r0
 }
 
-// StoreShardBlockIndex provides a mock function with given fields: hash, idx, shardID
-func (_m *DatabaseInterface) StoreShardBlockIndex(hash common.Hash, idx uint64, shardID byte) error {
-	ret := _m.Called(hash, idx, shardID)
+// StoreShardBlockIndex provides a mock function with given fields: hash, idx, shardID, bd
+func (_m *DatabaseInterface) StoreShardBlockIndex(hash common.Hash, idx uint64, shardID byte, bd *[]database.BatchData) error {
+	ret := _m.Called(hash, idx, shardID, bd)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(common.Hash, uint64, byte) error); ok {
-		r0 = rf(hash, idx, shardID)
+	if rf, ok := ret.Get(0).(func(common.Hash, uint64, byte, *[]database.BatchData) error); ok {
+		r0 = rf(hash, idx, shardID, bd)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2134,13 +2917,13 @@ func (_m *DatabaseInterface) StoreShardBlockIndex(hash common.Hash, idx uint64,
 	return r0
 }
 
-// StoreShardCommitteeByHeight provides a mock function with given fields: _a0, _a1
-func (_m *DatabaseInterface) StoreShardCommitteeByHeight(_a0 uint64, _a1 interface{}) error {
-	ret := _m.Called(_a0, _a1)
+// StoreShardCommitteeByHeight provides a mock function with given fields: height, v
+func (_m *DatabaseInterface) StoreShardCommitteeByHeight(height uint64, v interface{}) error {
+	ret := _m.Called(height, v)
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(uint64, interface{}) error); ok {
-		r0 = rf(_a0, _a1)
+		r0 = rf(height, v)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2148,13 +2931,27 @@ func (_m *DatabaseInterface) StoreShardCommitteeByHeight(_a0 uint64, _a1 interfa
 	return r0
 }
 
-// StoreTransactionIndex provides a mock function with given fields: txId, blockHash, indexInBlock
-func (_m *DatabaseInterface) StoreTransactionIndex(txId common.Hash, blockHash common.Hash, indexInBlock int) error {
-	ret := _m.Called(txId, blockHash, indexInBlock)
+// StoreSlashedEquivocation provides a mock function with given fields: chainID, committeePublicKey, height, round, beaconHeight
+func (_m *DatabaseInterface) StoreSlashedEquivocation(chainID int, committeePublicKey string, height uint64, round int, beaconHeight uint64) error {
+	ret := _m.Called(chainID, committeePublicKey, height, round, beaconHeight)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(common.Hash, common.Hash, int) error); ok {
-		r0 = rf(txId, blockHash, indexInBlock)
+	if rf, ok := ret.Get(0).(func(int, string, uint64, int, uint64) error); ok {
+		r0 = rf(chainID, committeePublicKey, height, round, beaconHeight)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreTransactionIndex provides a mock function with given fields: txId, blockHash, indexInBlock, bd
+func (_m *DatabaseInterface) StoreTransactionIndex(txId common.Hash, blockHash common.Hash, indexInBlock int, bd *[]database.BatchData) error {
+	ret := _m.Called(txId, blockHash, indexInBlock, bd)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash, common.Hash, int, *[]database.BatchData) error); ok {
+		r0 = rf(txId, blockHash, indexInBlock, bd)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2176,13 +2973,55 @@ func (_m *DatabaseInterface) StoreTxByPublicKey(publicKey []byte, txID common.Ha
 	return r0
 }
 
-// TrackBridgeReqWithStatus provides a mock function with given fields: txReqID, status
-func (_m *DatabaseInterface) TrackBridgeReqWithStatus(txReqID common.Hash, status byte) error {
-	ret := _m.Called(txReqID, status)
+// TrackBridgeReqWithStatus provides a mock function with given fields: txReqID, status, bd
+func (_m *DatabaseInterface) TrackBridgeReqWithStatus(txReqID common.Hash, status byte, bd *[]database.BatchData) error {
+	ret := _m.Called(txReqID, status, bd)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(common.Hash, byte) error); ok {
-		r0 = rf(txReqID, status)
+	if rf, ok := ret.Get(0).(func(common.Hash, byte, *[]database.BatchData) error); ok {
+		r0 = rf(txReqID, status, bd)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TrackPDEContributionStatus provides a mock function with given fields: prefix, suffix, statusContent
+func (_m *DatabaseInterface) TrackPDEContributionStatus(prefix []byte, suffix []byte, statusContent []byte) error {
+	ret := _m.Called(prefix, suffix, statusContent)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte, []byte, []byte) error); ok {
+		r0 = rf(prefix, suffix, statusContent)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TrackPDEStatus provides a mock function with given fields: prefix, suffix, status
+func (_m *DatabaseInterface) TrackPDEStatus(prefix []byte, suffix []byte, status byte) error {
+	ret := _m.Called(prefix, suffix, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte, []byte, byte) error); ok {
+		r0 = rf(prefix, suffix, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateBridgeTokenInfo provides a mock function with given fields: incTokenID, externalTokenID, isCentralized, updatingAmt, updateType, bd
+func (_m *DatabaseInterface) UpdateBridgeTokenInfo(incTokenID common.Hash, externalTokenID []byte, isCentralized bool, updatingAmt uint64, updateType string, bd *[]database.BatchData) error {
+	ret := _m.Called(incTokenID, externalTokenID, isCentralized, updatingAmt, updateType, bd)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash, []byte, bool, uint64, string, *[]database.BatchData) error); ok {
+		r0 = rf(incTokenID, externalTokenID, isCentralized, updatingAmt, updateType, bd)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2190,13 +3029,27 @@ func (_m *DatabaseInterface) TrackBridgeReqWithStatus(txReqID common.Hash, statu
 	return r0
 }
 
-// UpdateBridgeTokenInfo provides a mock function with given fields: incTokenID, externalTokenID, isCentralized, updatingAmt, updateType
-func (_m *DatabaseInterface) UpdateBridgeTokenInfo(incTokenID common.Hash, externalTokenID []byte, isCentralized bool, updatingAmt uint64, updateType string) error {
-	ret := _m.Called(incTokenID, externalTokenID, isCentralized, updatingAmt, updateType)
+// UpdatePDEPoolForPair provides a mock function with given fields: beaconHeight, token1IDStr, token2IDStr, pdePoolForPairBytes
+func (_m *DatabaseInterface) UpdatePDEPoolForPair(beaconHeight uint64, token1IDStr string, token2IDStr string, pdePoolForPairBytes []byte) error {
+	ret := _m.Called(beaconHeight, token1IDStr, token2IDStr, pdePoolForPairBytes)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(common.Hash, []byte, bool, uint64, string) error); ok {
-		r0 = rf(incTokenID, externalTokenID, isCentralized, updatingAmt, updateType)
+	if rf, ok := ret.Get(0).(func(uint64, string, string, []byte) error); ok {
+		r0 = rf(beaconHeight, token1IDStr, token2IDStr, pdePoolForPairBytes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpgradeSchema provides a mock function with given fields:
+func (_m *DatabaseInterface) UpgradeSchema() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
 	} else {
 		r0 = ret.Error(0)
 	}
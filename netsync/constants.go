@@ -6,6 +6,7 @@ const (
 	workers                = 5
 	messageLiveTime        = 40 * time.Second  // in second
 	messageCleanupInterval = 300 * time.Second //in second
+	txPeerBanWindow        = 10 * time.Minute  // a peer sending TxPeerBanThreshold txs over its rate limit in this window is banned
 )
 
 // block type
@@ -10,6 +10,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/metrics"
 	"github.com/incognitochain/incognito-chain/peer"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/incognitochain/incognito-chain/wire"
@@ -33,6 +34,9 @@ type NetSync struct {
 
 	config *NetSyncConfig
 	cache  *NetSyncCache
+
+	txPeerRateLimiter *common.RateLimiter // limits the txs relayed by each peer
+	txPeerViolations  *common.RateLimiter // limits the txs a peer relays over its rate limit before it is banned
 }
 
 type NetSyncConfig struct {
@@ -57,6 +61,19 @@ type NetSyncConfig struct {
 	Consensus interface {
 		OnBFTMsg(*wire.MessageBFT)
 	}
+	// PeerBanner drops the messages of a peer which keeps sending txs over its rate limit
+	PeerBanner interface {
+		BanPeer(libp2p.ID)
+	}
+	TxPeerRate         float64 // txs per second accepted from one peer, no limit if zero
+	TxPeerBurst        uint    // txs accepted at once from one peer
+	TxPeerBanThreshold uint    // txs over the rate limit in txPeerBanWindow before a peer is banned, never banned if zero
+}
+
+// txMessage is a tx message with the peer which relayed it
+type txMessage struct {
+	remotePeer *peer.Peer
+	msg        wire.Message
 }
 
 type NetSyncCache struct {
@@ -79,6 +96,12 @@ func (netSync *NetSync) Init(cfg *NetSyncConfig) {
 		blockCache: blockCache,
 	}
 
+	// init tx rate limits of peers
+	netSync.txPeerRateLimiter = common.NewRateLimiter(cfg.TxPeerRate, cfg.TxPeerBurst)
+	if cfg.TxPeerBanThreshold > 0 {
+		netSync.txPeerViolations = common.NewRateLimiter(float64(cfg.TxPeerBanThreshold)/txPeerBanWindow.Seconds(), cfg.TxPeerBanThreshold)
+	}
+
 	// register pubsub channel
 	_, subChanTx, err := netSync.config.PubSubManager.RegisterNewSubscriber(pubsub.TransactionHashEnterNodeTopic)
 	if err != nil {
@@ -149,29 +172,13 @@ out:
 					// 	metrics.Tag:              metrics.ShardIDTag,
 					// 	metrics.TagValue:         fmt.Sprintf("shardid-%+v", netSync.config.RoleInCommittees)})
 					switch msg := msgC.(type) {
+					case *txMessage:
+						{
+							netSync.handleTxMessage(msg.remotePeer, msg.msg)
+						}
 					case *wire.MessageTx, *wire.MessageTxToken, *wire.MessageTxPrivacyToken:
 						{
-							beaconHeight := int64(-1)
-							beaconBestState, err := netSync.config.BlockChain.BestState.GetClonedBeaconBestState()
-							if err == nil {
-								beaconHeight = int64(beaconBestState.BeaconHeight)
-							} else {
-								Logger.log.Error(err)
-							}
-							switch msg := msgC.(type) {
-							case *wire.MessageTx:
-								{
-									netSync.handleMessageTx(msg, beaconHeight)
-								}
-							case *wire.MessageTxToken:
-								{
-									netSync.handleMessageTxToken(msg, beaconHeight)
-								}
-							case *wire.MessageTxPrivacyToken:
-								{
-									netSync.handleMessageTxPrivacyToken(msg, beaconHeight)
-								}
-							}
+							netSync.handleTxMessage(nil, msg.(wire.Message))
 						}
 					case *wire.MessageBFT:
 						{
@@ -237,7 +244,7 @@ func (netSync *NetSync) QueueTx(peer *peer.Peer, msg *wire.MessageTx, done chan
 		done <- struct{}{}
 		return NewNetSyncError(AlreadyShutdownError, errors.New("We're shutting down"))
 	}
-	netSync.cMessage <- msg
+	netSync.cMessage <- &txMessage{remotePeer: peer, msg: msg}
 	return nil
 }
 
@@ -247,7 +254,7 @@ func (netSync *NetSync) QueueTxToken(peer *peer.Peer, msg *wire.MessageTxToken,
 		done <- struct{}{}
 		return NewNetSyncError(AlreadyShutdownError, errors.New("we're shutting down"))
 	}
-	netSync.cMessage <- msg
+	netSync.cMessage <- &txMessage{remotePeer: peer, msg: msg}
 	return nil
 }
 
@@ -257,7 +264,7 @@ func (netSync *NetSync) QueueTxPrivacyToken(peer *peer.Peer, msg *wire.MessageTx
 		done <- struct{}{}
 		return NewNetSyncError(AlreadyShutdownError, errors.New("We're shutting down"))
 	}
-	netSync.cMessage <- msg
+	netSync.cMessage <- &txMessage{remotePeer: peer, msg: msg}
 	return nil
 }
 
@@ -300,6 +307,65 @@ func (netSync *NetSync) QueueMessage(peer *peer.Peer, msg wire.Message, done cha
 	netSync.cMessage <- msg
 }
 
+// handleTxMessage drops msg if remotePeer relays txs over its rate limit,
+// otherwise it passes msg to the handler of its type.
+// remotePeer is nil if the peer which relayed msg is unknown.
+func (netSync *NetSync) handleTxMessage(remotePeer *peer.Peer, msg wire.Message) {
+	if remotePeer != nil && !netSync.allowTxFromPeer(remotePeer.GetPeerID()) {
+		return
+	}
+	beaconHeight := int64(-1)
+	beaconBestState, err := netSync.config.BlockChain.BestState.GetClonedBeaconBestState()
+	if err == nil {
+		beaconHeight = int64(beaconBestState.BeaconHeight)
+	} else {
+		Logger.log.Error(err)
+	}
+	switch msg := msg.(type) {
+	case *wire.MessageTx:
+		{
+			netSync.handleMessageTx(msg, beaconHeight)
+		}
+	case *wire.MessageTxToken:
+		{
+			netSync.handleMessageTxToken(msg, beaconHeight)
+		}
+	case *wire.MessageTxPrivacyToken:
+		{
+			netSync.handleMessageTxPrivacyToken(msg, beaconHeight)
+		}
+	}
+}
+
+// allowTxFromPeer takes a token from the tx rate limit of peerID,
+// a peer which keeps relaying txs over its limit is reported to PeerBanner
+func (netSync *NetSync) allowTxFromPeer(peerID libp2p.ID) bool {
+	if netSync.txPeerRateLimiter.Allow(peerID.Pretty()) {
+		return true
+	}
+	Logger.log.Warnf("Peer %+v relays transactions over its rate limit", peerID.Pretty())
+	go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
+		metrics.Measurement:      metrics.TxRateLimitedByPeer,
+		metrics.MeasurementValue: float64(1),
+		metrics.Tag:              metrics.PeerIDTag,
+		metrics.TagValue:         peerID.Pretty(),
+	})
+	if netSync.txPeerViolations == nil || netSync.txPeerViolations.Allow(peerID.Pretty()) {
+		return false
+	}
+	Logger.log.Warnf("Ban peer %+v for spamming transactions", peerID.Pretty())
+	go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
+		metrics.Measurement:      metrics.PeerBannedForTxSpam,
+		metrics.MeasurementValue: float64(1),
+		metrics.Tag:              metrics.PeerIDTag,
+		metrics.TagValue:         peerID.Pretty(),
+	})
+	if netSync.config.PeerBanner != nil {
+		netSync.config.PeerBanner.BanPeer(peerID)
+	}
+	return false
+}
+
 // handleTxMsg handles transaction messages from all peers.
 func (netSync *NetSync) handleMessageTx(msg *wire.MessageTx, beaconHeight int64) {
 	Logger.log.Debug("Handling new message tx")
@@ -1001,3 +1001,46 @@ func TestHandleMessagePeerState(t *testing.T) {
 	<-time.Tick(1 * time.Second)
 	netSync.Stop()
 }
+
+type peerBanner struct {
+	banned []libp2p.ID
+}
+
+func (banner *peerBanner) BanPeer(peerID libp2p.ID) {
+	banner.banned = append(banner.banned, peerID)
+}
+
+func TestNetSyncAllowTxFromPeer(t *testing.T) {
+	banner := &peerBanner{}
+	netSync := NetSync{}
+	netSync.Init(&NetSyncConfig{
+		BlockChain:         bc,
+		PubSubManager:      pb,
+		Server:             server,
+		TxMemPool:          txPool,
+		Consensus:          consensus,
+		PeerBanner:         banner,
+		TxPeerRate:         1,
+		TxPeerBurst:        2,
+		TxPeerBanThreshold: 1,
+	})
+	peerID := libp2p.ID("peer")
+	if !netSync.allowTxFromPeer(peerID) || !netSync.allowTxFromPeer(peerID) {
+		t.Fatal("Transactions under rate limit should be allowed")
+	}
+	if netSync.allowTxFromPeer(peerID) {
+		t.Fatal("Transaction over rate limit should NOT be allowed")
+	}
+	if len(banner.banned) != 0 {
+		t.Fatal("Peer should NOT be banned")
+	}
+	if !netSync.allowTxFromPeer(libp2p.ID("other peer")) {
+		t.Fatal("Transaction of other peer should be allowed")
+	}
+	if netSync.allowTxFromPeer(peerID) {
+		t.Fatal("Transaction over rate limit should NOT be allowed")
+	}
+	if len(banner.banned) != 1 || banner.banned[0] != peerID {
+		t.Fatal("Peer should be banned")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/rpc"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/incognitochain/incognito-chain/common"
@@ -86,6 +87,7 @@ func NewConnManager(
 		registerRequests:     make(chan int, 100),
 		relayShard:           relayShard,
 		nodeMode:             nodeMode,
+		bannedPeers:          make(map[peer.ID]struct{}),
 	}
 }
 
@@ -258,6 +260,9 @@ type ConnManager struct {
 	disp      *Dispatcher
 	Requester *BlockRequester
 	Provider  *BlockProvider
+
+	bannedPeers    map[peer.ID]struct{}
+	bannedPeersMtx sync.Mutex
 }
 
 func (cm *ConnManager) PutMessage(msg *pubsub.Message) {
@@ -268,7 +273,7 @@ func (cm *ConnManager) process() {
 	for {
 		select {
 		case msg := <-cm.messages:
-			err := cm.disp.processInMessageString(string(msg.Data))
+			err := cm.disp.processInMessageString(string(msg.Data), msg.GetFrom())
 			if err != nil {
 				Logger.Warn(err)
 			}
@@ -276,6 +281,34 @@ func (cm *ConnManager) process() {
 	}
 }
 
+// BanPeer drops all messages published by pid from now on, netsync bans the peers spamming txs.
+// The highway relays the messages of every peer so it is never banned, neither is this node
+// which receives its own published messages.
+func (cm *ConnManager) BanPeer(pid peer.ID) {
+	if cm.ps == nil {
+		Logger.Warnf("Can not ban peer %v before connecting to highway", pid.Pretty())
+		return
+	}
+	if pid == cm.LocalHost.Host.ID() {
+		return
+	}
+	if addr, err := multiaddr.NewMultiaddr(cm.HighwayAddress); err == nil {
+		if hwPeerInfo, err := peer.AddrInfoFromP2pAddr(addr); err == nil && hwPeerInfo.ID == pid {
+			Logger.Warnf("Can not ban highway %v", pid.Pretty())
+			return
+		}
+	}
+	cm.bannedPeersMtx.Lock()
+	if _, ok := cm.bannedPeers[pid]; ok {
+		cm.bannedPeersMtx.Unlock()
+		return
+	}
+	cm.bannedPeers[pid] = struct{}{}
+	cm.bannedPeersMtx.Unlock()
+	Logger.Warnf("Ban peer %v", pid.Pretty())
+	cm.ps.BlacklistPeer(pid)
+}
+
 // keepHighwayConnection periodically checks liveliness of connection to highway
 // and try to connect if it's not available.
 // The method push data to the given channel to signal that the first attempt had finished.
@@ -9,6 +9,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/peer"
 	"github.com/incognitochain/incognito-chain/wire"
+	libp2p "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/pkg/errors"
 )
 
@@ -20,8 +21,9 @@ type Dispatcher struct {
 //TODO hy parse msg here
 // processInMessageString - this is sub-function of InMessageHandler
 // after receiving a good message from stream,
-// we need analyze it and process with corresponding message type
-func (d *Dispatcher) processInMessageString(msgStr string) error {
+// we need analyze it and process with corresponding message type,
+// publisher is the peer which published the message to the topic
+func (d *Dispatcher) processInMessageString(msgStr string, publisher libp2p.ID) error {
 	// NOTE: copy from peerConn.processInMessageString
 	// Parse Message header from last 24 bytes header message
 	jsonDecodeBytesRaw, err := hex.DecodeString(msgStr)
@@ -112,7 +114,7 @@ func (d *Dispatcher) processInMessageString(msgStr string) error {
 	// }
 
 	// process message for each of message type
-	errProcessMessage := d.processMessageForEachType(realType, message)
+	errProcessMessage := d.processMessageForEachType(realType, message, publisher)
 	if errProcessMessage != nil {
 		return errors.WithStack(errProcessMessage)
 	}
@@ -123,22 +125,22 @@ func (d *Dispatcher) processInMessageString(msgStr string) error {
 }
 
 // process message for each of message type
-func (d *Dispatcher) processMessageForEachType(messageType reflect.Type, message wire.Message) error {
+func (d *Dispatcher) processMessageForEachType(messageType reflect.Type, message wire.Message, publisher libp2p.ID) error {
 	// NOTE: copy from peerConn.processInMessageString
 	Logger.Debugf("Processing msgType %s", message.MessageType())
 	var peerConn *peer.PeerConn
 	switch messageType {
 	case reflect.TypeOf(&wire.MessageTx{}):
 		if d.MessageListeners.OnTx != nil {
-			d.MessageListeners.OnTx(peerConn, message.(*wire.MessageTx))
+			d.MessageListeners.OnTx(newPublisherPeerConn(publisher), message.(*wire.MessageTx))
 		}
 	case reflect.TypeOf(&wire.MessageTxToken{}):
 		if d.MessageListeners.OnTxToken != nil {
-			d.MessageListeners.OnTxToken(peerConn, message.(*wire.MessageTxToken))
+			d.MessageListeners.OnTxToken(newPublisherPeerConn(publisher), message.(*wire.MessageTxToken))
 		}
 	case reflect.TypeOf(&wire.MessageTxPrivacyToken{}):
 		if d.MessageListeners.OnTxPrivacyToken != nil {
-			d.MessageListeners.OnTxPrivacyToken(peerConn, message.(*wire.MessageTxPrivacyToken))
+			d.MessageListeners.OnTxPrivacyToken(newPublisherPeerConn(publisher), message.(*wire.MessageTxPrivacyToken))
 		}
 	case reflect.TypeOf(&wire.MessageBlockShard{}):
 		// Logger.Infof("Processing msgContent %+v", message.(*wire.MessageBlockShard).Block)
@@ -219,6 +221,17 @@ func (d *Dispatcher) processMessageForEachType(messageType reflect.Type, message
 	return nil
 }
 
+// newPublisherPeerConn returns a PeerConn which only knows the peer that published a message,
+// listeners use it to rate limit the txs of each peer
+func newPublisherPeerConn(publisher libp2p.ID) *peer.PeerConn {
+	remotePeer := &peer.Peer{}
+	remotePeer.SetPeerID(publisher)
+	peerConn := &peer.PeerConn{}
+	peerConn.SetRemotePeer(remotePeer)
+	peerConn.SetRemotePeerID(publisher)
+	return peerConn
+}
+
 type MessageListeners struct {
 	OnTx               func(p *peer.PeerConn, msg *wire.MessageTx)
 	OnTxToken          func(p *peer.PeerConn, msg *wire.MessageTxToken)
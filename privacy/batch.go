@@ -0,0 +1,73 @@
+package privacy
+
+import (
+	"errors"
+)
+
+// BatchEquation accumulates equations of the form sum(scalar_i * point_i) == identity
+// so that they are checked together with one multi-scalar multiplication.
+// Each equation is multiplied by a random weight before being added to the batch,
+// the terms with the same point (the generators shared by all proofs) are merged.
+// A point outside the prime order subgroup would be cancelled by some weights,
+// so such a batch is never valid and its equations have to be verified one by one.
+// It is not safe for concurrent access.
+type BatchEquation struct {
+	scalars []*Scalar
+	points  []*Point
+	index   map[[Ed25519KeySize]byte]int
+	count   int
+	// outsideSubgroup is true if a point of the batch is not in the prime order subgroup
+	outsideSubgroup bool
+}
+
+func NewBatchEquation() *BatchEquation {
+	return &BatchEquation{
+		scalars: []*Scalar{},
+		points:  []*Point{},
+		index:   make(map[[Ed25519KeySize]byte]int),
+	}
+}
+
+// AddEquation adds the equation sum(scalars[i] * points[i]) == identity to the batch
+func (batch *BatchEquation) AddEquation(scalars []*Scalar, points []*Point) error {
+	if len(scalars) != len(points) {
+		return errors.New("number of scalars and number of points of the equation are different")
+	}
+	weight := RandomScalar()
+	for i := 0; i < len(scalars); i++ {
+		batch.addTerm(new(Scalar).Mul(weight, scalars[i]), points[i])
+	}
+	batch.count++
+	return nil
+}
+
+func (batch *BatchEquation) addTerm(scalar *Scalar, point *Point) {
+	key := point.ToBytes()
+	if i, ok := batch.index[key]; ok {
+		batch.scalars[i].Add(batch.scalars[i], scalar)
+		return
+	}
+	if !batch.outsideSubgroup && !point.IsInPrimeOrderSubgroup() {
+		batch.outsideSubgroup = true
+	}
+	batch.index[key] = len(batch.points)
+	batch.scalars = append(batch.scalars, scalar)
+	batch.points = append(batch.points, point)
+}
+
+// Len returns the number of equations added to the batch
+func (batch *BatchEquation) Len() int {
+	return batch.count
+}
+
+// Verify returns true if all the equations of the batch hold, an empty batch is valid.
+// It returns false if a point of the batch is not in the prime order subgroup
+func (batch *BatchEquation) Verify() bool {
+	if batch.outsideSubgroup {
+		return false
+	}
+	if len(batch.points) == 0 {
+		return true
+	}
+	return new(Point).MultiScalarMult(batch.scalars, batch.points).IsIdentity()
+}
@@ -0,0 +1,63 @@
+package privacy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchEquationVerify(t *testing.T) {
+	batch := NewBatchEquation()
+	assert.True(t, batch.Verify())
+
+	for i := 0; i < 5; i++ {
+		// a * G + b * H - C == identity with C = a * G + b * H
+		a := RandomScalar()
+		b := RandomScalar()
+		C := PedCom.CommitAtIndex(a, b, PedersenValueIndex)
+		minusOne := new(Scalar).Sub(new(Scalar).FromUint64(0), new(Scalar).FromUint64(1))
+		err := batch.AddEquation([]*Scalar{a, b, minusOne}, []*Point{PedCom.G[PedersenValueIndex], PedCom.G[PedersenRandomnessIndex], C})
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 5, batch.Len())
+	// terms of the generators are merged
+	assert.Equal(t, 7, len(batch.points))
+	assert.True(t, batch.Verify())
+
+	// one wrong equation makes the batch invalid
+	err := batch.AddEquation([]*Scalar{new(Scalar).FromUint64(1)}, []*Point{RandomPoint()})
+	assert.Nil(t, err)
+	assert.False(t, batch.Verify())
+
+	err = batch.AddEquation([]*Scalar{new(Scalar).FromUint64(1)}, []*Point{})
+	assert.NotNil(t, err)
+}
+
+func TestBatchEquationVerifySmallOrderPoint(t *testing.T) {
+	// (0, -1) is the point of order 2
+	var torsionBytes [Ed25519KeySize]byte
+	torsionBytes[0] = 0xec
+	for i := 1; i < Ed25519KeySize-1; i++ {
+		torsionBytes[i] = 0xff
+	}
+	torsionBytes[Ed25519KeySize-1] = 0x7f
+	torsion, err := new(Point).FromBytes(torsionBytes)
+	assert.Nil(t, err)
+	assert.False(t, torsion.IsInPrimeOrderSubgroup())
+	assert.True(t, new(Point).Add(torsion, torsion).IsIdentity())
+
+	P := RandomPoint()
+	assert.True(t, P.IsInPrimeOrderSubgroup())
+	perturbed := new(Point).Add(P, torsion)
+	assert.False(t, perturbed.IsInPrimeOrderSubgroup())
+
+	// (P + T) - P == identity does not hold but is cancelled by every even weight
+	one := new(Scalar).FromUint64(1)
+	minusOne := new(Scalar).Sub(new(Scalar).FromUint64(0), one)
+	for i := 0; i < 20; i++ {
+		batch := NewBatchEquation()
+		err = batch.AddEquation([]*Scalar{one, minusOne}, []*Point{perturbed, P})
+		assert.Nil(t, err)
+		assert.False(t, batch.Verify())
+	}
+}
@@ -251,6 +251,13 @@ func (p *Point) Sub(pa, pb *Point) *Point {
 	return p
 }
 
+// IsInPrimeOrderSubgroup returns true if l * p is the identity where l is the order of the base point,
+// a valid point with a small order component is not in the subgroup
+func (p Point) IsInPrimeOrderSubgroup() bool {
+	curveOrder := C25519.CurveOrder()
+	return *C25519.ScalarMultKey(&p.key, &curveOrder) == C25519.Identity
+}
+
 func IsPointEqual(pa *Point, pb *Point) bool {
 	tmpa := pa.ToBytesS()
 	tmpb := pb.ToBytesS()
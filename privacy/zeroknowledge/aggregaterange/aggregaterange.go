@@ -644,4 +644,86 @@ func (proof AggregatedRangeProof) VerifyFaster() (bool, error) {
 	}
 
 	return true, nil
+}
+
+// AddToBatch adds the 2 statements checked by VerifyFaster to batch instead of checking them,
+// the proof is valid if batch.Verify() returns true
+func (proof AggregatedRangeProof) AddToBatch(batch *privacy.BatchEquation) error {
+	numValue := len(proof.cmsValue)
+	if numValue > maxOutputNumber {
+		return errors.New("Must less than maxOutputNumber")
+	}
+	numValuePad := pad(numValue)
+	aggParam := new(bulletproofParams)
+	aggParam.g = AggParam.g[0 : numValuePad*maxExp]
+	aggParam.h = AggParam.h[0 : numValuePad*maxExp]
+	aggParam.u = AggParam.u
+	csByteH := []byte{}
+	csByteG := []byte{}
+	for i := 0; i < len(aggParam.g); i++ {
+		csByteG = append(csByteG, aggParam.g[i].ToBytesS()...)
+		csByteH = append(csByteH, aggParam.h[i].ToBytesS()...)
+	}
+	aggParam.cs = append(aggParam.cs, csByteG...)
+	aggParam.cs = append(aggParam.cs, csByteH...)
+	aggParam.cs = append(aggParam.cs, aggParam.u.ToBytesS()...)
+
+	n := maxExp
+	oneNumber := new(privacy.Scalar).FromUint64(1)
+	twoNumber := new(privacy.Scalar).FromUint64(2)
+	oneVector := powerVector(oneNumber, n*numValuePad)
+	oneVectorN := powerVector(oneNumber, n)
+	twoVectorN := powerVector(twoNumber, n)
+
+	y := generateChallenge([][]byte{aggParam.cs, proof.a.ToBytesS(), proof.s.ToBytesS()})
+	z := generateChallenge([][]byte{aggParam.cs, proof.a.ToBytesS(), proof.s.ToBytesS(), y.ToBytesS()})
+	zSquare := new(privacy.Scalar).Mul(z, z)
+	x := generateChallenge([][]byte{aggParam.cs, proof.a.ToBytesS(), proof.s.ToBytesS(), proof.t1.ToBytesS(), proof.t2.ToBytesS()})
+	xSquare := new(privacy.Scalar).Mul(x, x)
+	yVector := powerVector(y, n*numValuePad)
+
+	deltaYZ := new(privacy.Scalar).Sub(z, zSquare)
+	innerProduct1, err := innerProduct(oneVector, yVector)
+	if err != nil {
+		return privacy.NewPrivacyErr(privacy.CalInnerProductErr, err)
+	}
+	deltaYZ.Mul(deltaYZ, innerProduct1)
+	innerProduct2, err := innerProduct(oneVectorN, twoVectorN)
+	if err != nil {
+		return privacy.NewPrivacyErr(privacy.CalInnerProductErr, err)
+	}
+	sum := new(privacy.Scalar).FromUint64(0)
+	zTmp := new(privacy.Scalar).Set(zSquare)
+	for j := 0; j < numValuePad; j++ {
+		zTmp.Mul(zTmp, z)
+		sum.Add(sum, zTmp)
+	}
+	sum.Mul(sum, innerProduct2)
+	deltaYZ.Sub(deltaYZ, sum)
+
+	// g^(tHat - delta(y,z)) * h^tauX * T1^-x * T2^(-x^2) * V^(-z^2) = 1, the padded values are identity
+	zero := new(privacy.Scalar).FromUint64(0)
+	scalars := []*privacy.Scalar{
+		new(privacy.Scalar).Sub(proof.tHat, deltaYZ),
+		proof.tauX,
+		new(privacy.Scalar).Sub(zero, x),
+		new(privacy.Scalar).Sub(zero, xSquare),
+	}
+	points := []*privacy.Point{
+		privacy.PedCom.G[privacy.PedersenValueIndex],
+		privacy.PedCom.G[privacy.PedersenRandomnessIndex],
+		proof.t1,
+		proof.t2,
+	}
+	expVector := vectorMulScalar(powerVector(z, numValuePad), zSquare)
+	for i := 0; i < numValue; i++ {
+		scalars = append(scalars, new(privacy.Scalar).Sub(zero, expVector[i]))
+		points = append(points, proof.cmsValue[i])
+	}
+	err = batch.AddEquation(scalars, points)
+	if err != nil {
+		return err
+	}
+
+	return proof.innerProductProof.AddToBatch(aggParam, batch)
 }
\ No newline at end of file
@@ -288,3 +288,36 @@ func BenchmarkAggregatedRangeProof_Verify16(b *testing.B)  { benchmarkAggRangePr
 func BenchmarkAggregatedRangeProof_VerifyFaster16(b *testing.B) {
 	benchmarkAggRangeProof_VerifyFaster(16, b)
 }
+
+func TestAggregatedRangeVerifyBatch(t *testing.T) {
+	batch := privacy.NewBatchEquation()
+	for i := 0; i < 5; i++ {
+		wit := new(AggregatedRangeWitness)
+		numValue := rand.Intn(maxOutputNumber) + 1
+		values := make([]uint64, numValue)
+		rands := make([]*privacy.Scalar, numValue)
+		for j := range values {
+			values[j] = uint64(rand.Uint64())
+			rands[j] = privacy.RandomScalar()
+		}
+		wit.Set(values, rands)
+
+		proof, err := wit.Prove()
+		assert.Equal(t, nil, err)
+
+		err = proof.AddToBatch(batch)
+		assert.Equal(t, nil, err)
+	}
+	assert.Equal(t, 10, batch.Len())
+	assert.Equal(t, true, batch.Verify())
+
+	// a proof for other committed values makes the batch invalid
+	wit := new(AggregatedRangeWitness)
+	wit.Set([]uint64{1, 2}, []*privacy.Scalar{privacy.RandomScalar(), privacy.RandomScalar()})
+	proof, err := wit.Prove()
+	assert.Equal(t, nil, err)
+	proof.cmsValue[0] = privacy.PedCom.CommitAtIndex(new(privacy.Scalar).FromUint64(3), privacy.RandomScalar(), privacy.PedersenValueIndex)
+	err = proof.AddToBatch(batch)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, batch.Verify())
+}
@@ -320,4 +320,62 @@ func (proof InnerProductProof) VerifyFaster(aggParam *bulletproofParams) bool {
 	}
 
 	return res
-}
\ No newline at end of file
+}
+// AddToBatch adds the equation checked by VerifyFaster to batch instead of checking it
+func (proof InnerProductProof) AddToBatch(aggParam *bulletproofParams, batch *privacy.BatchEquation) error {
+	p := new(privacy.Point)
+	p.Set(proof.p)
+	n := len(aggParam.g)
+	if len(aggParam.h) != n || len(proof.l) != len(proof.r) {
+		return errors.New("invalid length of inner product argument")
+	}
+	s := make([]*privacy.Scalar, n)
+	sInverse := make([]*privacy.Scalar, n)
+	for i := range s {
+		s[i] = new(privacy.Scalar).FromUint64(1)
+		sInverse[i] = new(privacy.Scalar).FromUint64(1)
+	}
+	logN := int(math.Log2(float64(n)))
+	if len(proof.l) != logN {
+		return errors.New("invalid length of inner product argument")
+	}
+	xSquareList := make([]*privacy.Scalar, logN)
+	xInverseSquareList := make([]*privacy.Scalar, logN)
+
+	for i := range proof.l {
+		x := generateChallenge([][]byte{aggParam.cs, p.ToBytesS(), proof.l[i].ToBytesS(), proof.r[i].ToBytesS()})
+		xInverse := new(privacy.Scalar).Invert(x)
+		xSquareList[i] = new(privacy.Scalar).Mul(x, x)
+		xInverseSquareList[i] = new(privacy.Scalar).Mul(xInverse, xInverse)
+
+		for j := 0; j < n; j++ {
+			if j&int(math.Pow(2, float64(logN-i-1))) != 0 {
+				s[j].Mul(s[j], x)
+				sInverse[j].Mul(sInverse[j], xInverse)
+			} else {
+				s[j].Mul(s[j], xInverse)
+				sInverse[j].Mul(sInverse[j], x)
+			}
+		}
+		PPrime := new(privacy.Point).AddPedersen(xSquareList[i], proof.l[i], xInverseSquareList[i], proof.r[i])
+		PPrime.Add(PPrime, p)
+		p = PPrime
+	}
+
+	// (g^s)^a (h^-s)^b u^(ab) p^-1 l^(-x^2) r^(-x^-2) = 1
+	zero := new(privacy.Scalar).FromUint64(0)
+	scalars := make([]*privacy.Scalar, 0, 2*n+2*logN+2)
+	points := make([]*privacy.Point, 0, 2*n+2*logN+2)
+	for j := 0; j < n; j++ {
+		scalars = append(scalars, new(privacy.Scalar).Mul(s[j], proof.a), new(privacy.Scalar).Mul(sInverse[j], proof.b))
+		points = append(points, aggParam.g[j], aggParam.h[j])
+	}
+	scalars = append(scalars, new(privacy.Scalar).Mul(proof.a, proof.b), new(privacy.Scalar).Sub(zero, new(privacy.Scalar).FromUint64(1)))
+	points = append(points, aggParam.u, proof.p)
+	for i := 0; i < logN; i++ {
+		scalars = append(scalars, new(privacy.Scalar).Sub(zero, xSquareList[i]), new(privacy.Scalar).Sub(zero, xInverseSquareList[i]))
+		points = append(points, proof.l[i], proof.r[i])
+	}
+
+	return batch.AddEquation(scalars, points)
+}
@@ -459,6 +459,75 @@ func (proof OneOutOfManyProof) Verify() (bool, error) {
 	return true, nil
 }
 
+// AddToBatch adds the 3 statements checked by Verify to batch instead of checking them,
+// the proof is valid if batch.Verify() returns true
+func (proof OneOutOfManyProof) AddToBatch(batch *privacy.BatchEquation) error {
+	N := len(proof.Statement.Commitments)
+	if N != privacy.CommitmentRingSize {
+		return errors.New("Invalid length of commitments list in one out of many proof")
+	}
+	n := privacy.CommitmentRingSizeExp
+
+	x := new(privacy.Scalar).FromUint64(0)
+	for j := 0; j < n; j++ {
+		x = utils.GenerateChallenge([][]byte{x.ToBytesS(), proof.cl[j].ToBytesS(), proof.ca[j].ToBytesS(), proof.cb[j].ToBytesS(), proof.cd[j].ToBytesS()})
+	}
+	zero := new(privacy.Scalar).FromUint64(0)
+	one := new(privacy.Scalar).FromUint64(1)
+	minusOne := new(privacy.Scalar).Sub(zero, one)
+	gPrivateKey := privacy.PedCom.G[privacy.PedersenPrivateKeyIndex]
+	gRandomness := privacy.PedCom.G[privacy.PedersenRandomnessIndex]
+
+	for i := 0; i < n; i++ {
+		// cl^x * ca * Com(f, za)^-1 = 1
+		err := batch.AddEquation(
+			[]*privacy.Scalar{x, one, new(privacy.Scalar).Sub(zero, proof.f[i]), new(privacy.Scalar).Sub(zero, proof.za[i])},
+			[]*privacy.Point{proof.cl[i], proof.ca[i], gPrivateKey, gRandomness})
+		if err != nil {
+			return err
+		}
+
+		// cl^(x-f) * cb * Com(0, zb)^-1 = 1
+		err = batch.AddEquation(
+			[]*privacy.Scalar{new(privacy.Scalar).Sub(x, proof.f[i]), one, new(privacy.Scalar).Sub(zero, proof.zb[i])},
+			[]*privacy.Point{proof.cl[i], proof.cb[i], gRandomness})
+		if err != nil {
+			return err
+		}
+	}
+
+	// prod(C_i^exp_i) * prod(cd_k^(-x^k)) * Com(0, zd)^-1 = 1
+	scalars := make([]*privacy.Scalar, 0, N+n+1)
+	points := make([]*privacy.Point, 0, N+n+1)
+	for i := 0; i < N; i++ {
+		iBinary := privacy.ConvertIntToBinary(i, n)
+
+		exp := new(privacy.Scalar).FromUint64(1)
+		fji := new(privacy.Scalar).FromUint64(1)
+		for j := 0; j < n; j++ {
+			if iBinary[j] == 1 {
+				fji.Set(proof.f[j])
+			} else {
+				fji.Sub(x, proof.f[j])
+			}
+
+			exp.Mul(exp, fji)
+		}
+		scalars = append(scalars, exp)
+		points = append(points, proof.Statement.Commitments[i])
+	}
+	xk := new(privacy.Scalar).Set(minusOne)
+	for k := 0; k < n; k++ {
+		scalars = append(scalars, new(privacy.Scalar).Set(xk))
+		points = append(points, proof.cd[k])
+		xk.Mul(xk, x)
+	}
+	scalars = append(scalars, new(privacy.Scalar).Sub(zero, proof.zd))
+	points = append(points, gRandomness)
+
+	return batch.AddEquation(scalars, points)
+}
+
 // Get coefficient of x^k in the polynomial p_i(x)
 func getCoefficient(iBinary []byte, k int, n int, scLs []*privacy.Scalar, l []byte) *privacy.Scalar {
 
@@ -90,3 +90,36 @@ func TestPKOneOfMany(t *testing.T) {
 	}
 }
 
+
+func TestPKOneOfManyBatch(t *testing.T) {
+	batch := privacy.NewBatchEquation()
+	proofs := make([]*OneOutOfManyProof, 5)
+	for i := 0; i < len(proofs); i++ {
+		indexIsZero := int(common.RandInt() % privacy.CommitmentRingSize)
+
+		commitments := make([]*privacy.Point, privacy.CommitmentRingSize)
+		randoms := make([]*privacy.Scalar, privacy.CommitmentRingSize)
+		for j := 0; j < privacy.CommitmentRingSize; j++ {
+			randoms[j] = privacy.RandomScalar()
+			commitments[j] = privacy.PedCom.CommitAtIndex(privacy.RandomScalar(), randoms[j], privacy.PedersenSndIndex)
+		}
+		commitments[indexIsZero] = privacy.PedCom.CommitAtIndex(new(privacy.Scalar).FromUint64(0), randoms[indexIsZero], privacy.PedersenSndIndex)
+
+		witness := new(OneOutOfManyWitness)
+		witness.Set(commitments, randoms[indexIsZero], uint64(indexIsZero))
+		proof, err := witness.Prove()
+		assert.Equal(t, nil, err)
+		proofs[i] = proof
+
+		err = proof.AddToBatch(batch)
+		assert.Equal(t, nil, err)
+	}
+	assert.Equal(t, len(proofs)*(2*privacy.CommitmentRingSizeExp+1), batch.Len())
+	assert.Equal(t, true, batch.Verify())
+
+	// a proof for other commitments makes the batch invalid
+	proofs[0].Statement.Commitments[0] = privacy.RandomPoint()
+	err := proofs[0].AddToBatch(batch)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, batch.Verify())
+}
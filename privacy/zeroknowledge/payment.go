@@ -627,7 +627,7 @@ func (proof *PaymentProof) SetBytes(proofbytes []byte) *privacy.PrivacyError {
 	return nil
 }
 
-func (proof PaymentProof) verifyNoPrivacy(pubKey privacy.PublicKey, fee uint64, db database.DatabaseInterface, shardID byte, tokenID *common.Hash) (bool, error) {
+func (proof PaymentProof) verifyNoPrivacy(pubKey privacy.PublicKey, fee uint64, db database.CoinStore, shardID byte, tokenID *common.Hash) (bool, error) {
 	var sumInputValue, sumOutputValue uint64
 	sumInputValue = 0
 	sumOutputValue = 0
@@ -716,7 +716,9 @@ func (proof PaymentProof) verifyNoPrivacy(pubKey privacy.PublicKey, fee uint64,
 	return true, nil
 }
 
-func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64, db database.DatabaseInterface, shardID byte, tokenID *common.Hash) (bool, error) {
+// verifyHasPrivacy verifies the proof with privacy,
+// if batch is not nil the one out of many proofs and the aggregated range proof are added to batch instead of being verified
+func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64, db database.CoinStore, shardID byte, tokenID *common.Hash, batch *privacy.BatchEquation) (bool, error) {
 	// verify for input coins
 	cmInputSum := make([]*privacy.Point, len(proof.oneOfManyProof))
 	for i := 0; i < len(proof.oneOfManyProof); i++ {
@@ -760,13 +762,21 @@ func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64,
 
 		proof.oneOfManyProof[i].Statement.Commitments = commitments
 
-		valid, err := proof.oneOfManyProof[i].Verify()
-		if !valid {
-			privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: One out of many failed")
-			return false, privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, err)
+		if batch != nil {
+			err := proof.oneOfManyProof[i].AddToBatch(batch)
+			if err != nil {
+				privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Cannot add one out of many proof to batch")
+				return false, privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, err)
+			}
+		} else {
+			valid, err := proof.oneOfManyProof[i].Verify()
+			if !valid {
+				privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: One out of many failed")
+				return false, privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, err)
+			}
 		}
 		// Verify for the Proof that input coins' serial number is derived from the committed derivator
-		valid, err = proof.serialNumberProof[i].Verify(nil)
+		valid, err := proof.serialNumberProof[i].Verify(nil)
 		if !valid {
 			privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Serial number privacy failed")
 			return false, privacy.NewPrivacyErr(privacy.VerifySerialNumberPrivacyProofFailedErr, err)
@@ -786,10 +796,18 @@ func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64,
 	}
 
 	// Verify the proof that output values and sum of them do not exceed v_max
-	valid, err := proof.aggregatedRangeProof.VerifyFaster()
-	if !valid {
-		privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Multi-range failed")
-		return false, privacy.NewPrivacyErr(privacy.VerifyAggregatedProofFailedErr, err)
+	if batch != nil {
+		err := proof.aggregatedRangeProof.AddToBatch(batch)
+		if err != nil {
+			privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Cannot add multi-range proof to batch")
+			return false, privacy.NewPrivacyErr(privacy.VerifyAggregatedProofFailedErr, err)
+		}
+	} else {
+		valid, err := proof.aggregatedRangeProof.VerifyFaster()
+		if !valid {
+			privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Multi-range failed")
+			return false, privacy.NewPrivacyErr(privacy.VerifyAggregatedProofFailedErr, err)
+		}
 	}
 
 	// Verify the proof that sum of all input values is equal to sum of all output values
@@ -820,12 +838,23 @@ func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64,
 	return true, nil
 }
 
-func (proof PaymentProof) Verify(hasPrivacy bool, pubKey privacy.PublicKey, fee uint64, db database.DatabaseInterface, shardID byte, tokenID *common.Hash) (bool, error) {
+func (proof PaymentProof) Verify(hasPrivacy bool, pubKey privacy.PublicKey, fee uint64, db database.CoinStore, shardID byte, tokenID *common.Hash) (bool, error) {
+	// has no privacy
+	if !hasPrivacy {
+		return proof.verifyNoPrivacy(pubKey, fee, db, shardID, tokenID)
+	}
+
+	return proof.verifyHasPrivacy(pubKey, fee, db, shardID, tokenID, nil)
+}
+
+// VerifyWithBatch verifies the proof like Verify but adds the one out of many proofs and the aggregated range proof to batch,
+// the proof is valid if it returns true and batch.Verify() returns true
+func (proof PaymentProof) VerifyWithBatch(hasPrivacy bool, pubKey privacy.PublicKey, fee uint64, db database.CoinStore, shardID byte, tokenID *common.Hash, batch *privacy.BatchEquation) (bool, error) {
 	// has no privacy
 	if !hasPrivacy {
 		return proof.verifyNoPrivacy(pubKey, fee, db, shardID, tokenID)
 	}
 
-	return proof.verifyHasPrivacy(pubKey, fee, db, shardID, tokenID)
+	return proof.verifyHasPrivacy(pubKey, fee, db, shardID, tokenID, batch)
 }
 
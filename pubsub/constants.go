@@ -10,12 +10,14 @@ const (
 	ShardRoleTopic                  = "shardroletopic"
 	BeaconRoleTopic                 = "beaconroletopic"
 	MempoolInfoTopic                = "mempoolinfotopic"
+	MempoolTxEventTopic             = "mempooltxeventtopic"
 	BeaconBeststateTopic            = "beaconbeststatetopic"
 	ShardBeststateTopic             = "shardbeststatetopic"
 	RequestShardBlockByHashTopic    = "requestshardblockbyhashtopic"
 	RequestShardBlockByHeightTopic  = "requestshardblockbyheighttopic"
 	RequestBeaconBlockByHeightTopic = "requestbeaconblockbyheighttopic"
 	RequestBeaconBlockByHashTopic   = "requestbeaconblockbyhashtopic"
+	FinalizedShardBlockTopic        = "finalizedshardblocktopic"
 	TestTopic                       = "testtopic"
 )
 
@@ -23,6 +25,7 @@ var Topics = []string{
 	NewShardblockTopic,
 	NewBeaconBlockTopic,
 	MempoolInfoTopic,
+	MempoolTxEventTopic,
 	TestTopic,
 	TransactionHashEnterNodeTopic,
 	ShardRoleTopic,
@@ -33,4 +36,5 @@ var Topics = []string{
 	RequestShardBlockByHeightTopic,
 	RequestShardBlockByHashTopic,
 	ShardBeststateTopic,
+	FinalizedShardBlockTopic,
 }
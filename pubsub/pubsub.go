@@ -18,6 +18,7 @@ type PubSubManager struct {
 	topicList      []string                         // only allow registered Topic
 	subscriberList map[string]map[uint]EventChannel // List of Subscriber
 	messageBroker  map[string][]*Message            // Message pool
+	deliveries     map[uint]chan struct{}           // closed when the last messages sent to a subscriber are delivered
 	idGenerator    uint                             // id generator for event
 	cond           *sync.Cond
 }
@@ -27,6 +28,7 @@ func NewPubSubManager() *PubSubManager {
 		topicList:      Topics,
 		subscriberList: make(map[string]map[uint]EventChannel),
 		messageBroker:  make(map[string][]*Message),
+		deliveries:     make(map[uint]chan struct{}),
 		idGenerator:    0,
 		cond:           sync.NewCond(&sync.Mutex{}),
 	}
@@ -37,15 +39,29 @@ func NewPubSubManager() *PubSubManager {
 }
 
 // Forever Loop play as an Event Channel
+// A subscriber receives the messages of its topic in the order they are published
 func (pubSubManager *PubSubManager) Start() {
 	for {
 		pubSubManager.cond.L.Lock()
 		for topic, messages := range pubSubManager.messageBroker {
-			for _, message := range messages {
-				if subMap, ok := pubSubManager.subscriberList[topic]; ok {
-					for _, event := range subMap {
-						go event.NotifyMessage(message)
-					}
+			if len(messages) == 0 {
+				continue
+			}
+			if subMap, ok := pubSubManager.subscriberList[topic]; ok {
+				for id, event := range subMap {
+					// wait for the previous messages of the subscriber without blocking the event channel
+					previous := pubSubManager.deliveries[id]
+					delivered := make(chan struct{})
+					pubSubManager.deliveries[id] = delivered
+					go func(event EventChannel, messages []*Message, previous chan struct{}, delivered chan struct{}) {
+						if previous != nil {
+							<-previous
+						}
+						for _, message := range messages {
+							event.NotifyMessage(message)
+						}
+						close(delivered)
+					}(event, messages, previous, delivered)
 				}
 			}
 			// delete message (if no thing subscribe for it then delete msg too)
@@ -90,6 +106,7 @@ func (pubSubManager *PubSubManager) Unsubscribe(topic string, subId uint) {
 	if subMap, ok := pubSubManager.subscriberList[topic]; ok {
 		if _, ok := subMap[subId]; ok {
 			delete(subMap, subId)
+			delete(pubSubManager.deliveries, subId)
 		}
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"sync"
 	"testing"
+	"time"
 )
 
 func TestNewMessage(t *testing.T) {
@@ -117,6 +118,28 @@ func TestMessageBroken(t *testing.T) {
 	pubsubManager.Unsubscribe(TestTopic, id)
 	return
 }
+func TestMessageOrder(t *testing.T) {
+	var pubsubManager = NewPubSubManager()
+	go pubsubManager.Start()
+	id, event, err := pubsubManager.RegisterNewSubscriber(TestTopic)
+	if err != nil {
+		t.Error("Error when subcription")
+	}
+	numOfMessages := 3 * ChanWorkLoad
+	for i := 0; i < numOfMessages; i++ {
+		pubsubManager.PublishMessage(NewMessage(TestTopic, i))
+		if i%7 == 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	for i := 0; i < numOfMessages; i++ {
+		msg := <-event
+		if value, ok := msg.Value.(int); !ok || value != i {
+			t.Fatalf("Expect message %+v, got %+v", i, msg.Value)
+		}
+	}
+	pubsubManager.Unsubscribe(TestTopic, id)
+}
 func TestHasTopic(t *testing.T) {
 	var pubsubManager = NewPubSubManager()
 	if !pubsubManager.HasTopic(NewBeaconBlockTopic) {
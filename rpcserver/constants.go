@@ -22,6 +22,7 @@ const (
 	getMaxShardsNumber = "getmaxshardsnumber"
 
 	getMiningInfo                 = "getmininginfo"
+	getEquivocationProofs         = "getequivocationproofs"
 	getRawMempool                 = "getrawmempool"
 	getNumberOfTxsInMempool       = "getnumberoftxsinmempool"
 	getMempoolEntry               = "getmempoolentry"
@@ -36,6 +37,12 @@ const (
 	getCrossShardPoolStateV2    = "getcrossshardpoolstatev2"
 	getShardPoolStateV2         = "getshardpoolstatev2"
 	getBeaconPoolStateV2        = "getbeaconpoolstatev2"
+	getCrossShardPoolInfo       = "getcrossshardpoolinfo"
+	getShardToBeaconPoolInfo    = "getshardtobeaconpoolinfo"
+	dropCrossShardBlock         = "dropcrossshardblock"
+	refetchCrossShardBlock      = "refetchcrossshardblock"
+	dropShardToBeaconBlock      = "dropshardtobeaconblock"
+	refetchShardToBeaconBlock   = "refetchshardtobeaconblock"
 	//getFeeEstimator             = "getfeeestimator"
 
 	getBestBlock        = "getbestblock"
@@ -51,6 +58,8 @@ const (
 	createRawTransaction                       = "createtransaction"
 	sendRawTransaction                         = "sendtransaction"
 	createAndSendTransaction                   = "createandsendtransaction"
+	replaceTransaction                         = "replacetransaction"
+	testMempoolAccept                          = "testmempoolaccept"
 	createAndSendCustomTokenTransaction        = "createandsendcustomtokentransaction"
 	sendRawCustomTokenTransaction              = "sendrawcustomtokentransaction"
 	createRawCustomTokenTransaction            = "createrawcustomtokentransaction"
@@ -86,8 +95,11 @@ const (
 	listSerialNumbers                          = "listserialnumbers"
 	listCommitments                            = "listcommitments"
 	listCommitmentIndices                      = "listcommitmentindices"
+	listTxsByMetadataType                      = "listtxsbymetadatatype"
 	createAndSendStakingTransaction            = "createandsendstakingtransaction"
 	createAndSendStopAutoStakingTransaction    = "createandsendstopautostakingtransaction"
+	createRawEquivocationProofTransaction      = "createrawequivocationprooftransaction"
+	createAndSendEquivocationProofTransaction  = "createandsendequivocationprooftransaction"
 
 	//===========For Testing and Benchmark==============
 	getAndSendTxsFromFile   = "getandsendtxsfromfile"
@@ -185,6 +197,7 @@ const (
 	subcribeNewShardBlock                       = "subcribenewshardblock"
 	subcribeNewBeaconBlock                      = "subcribenewbeaconblock"
 	subcribePendingTransaction                  = "subcribependingtransaction"
+	subcribeMempoolTxEvent                      = "subcribemempooltxevent"
 	subcribeShardCandidateByPublickey           = "subcribeshardcandidatebypublickey"
 	subcribeShardPendingValidatorByPublickey    = "subcribeshardpendingvalidatorbypublickey"
 	subcribeShardCommitteeByPublickey           = "subcribeshardcommitteebypublickey"
@@ -199,4 +212,11 @@ const (
 	subcribeBeaconBestState                     = "subcribebeaconbeststate"
 	subcribeBeaconPoolBeststate                 = "subcribebeaconpoolbeststate"
 	subcribeShardPoolBeststate                  = "subcribeshardpoolbeststate"
+	subcribeFinalizedShardBlock                 = "subcribefinalizedshardblock"
+)
+
+// number of items returned by the paginated rpc
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
 )
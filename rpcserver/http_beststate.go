@@ -45,6 +45,7 @@ func (httpServer *HttpServer) handleGetShardBestState(params interface{}, closeC
 	}
 
 	result := jsonresult.NewGetShardBestState(shardBestState)
+	result.FinalizedHeight = httpServer.blockService.GetFinalizedShardHeight(shardID)
 	Logger.log.Debugf("Get Shard BestState result: %+v", result)
 	return result, nil
 }
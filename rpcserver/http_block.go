@@ -23,9 +23,10 @@ func (httpServer *HttpServer) handleGetBestBlock(params interface{}, closeChan <
 	shardBestStates := httpServer.blockService.GetShardBestStates()
 	for shardID, best := range shardBestStates {
 		result.BestBlocks[int(shardID)] = jsonresult.GetBestBlockItem{
-			Height:   best.BestBlock.Header.Height,
-			Hash:     best.BestBlockHash.String(),
-			TotalTxs: shardBestStates[shardID].TotalTxns,
+			Height:          best.BestBlock.Header.Height,
+			Hash:            best.BestBlockHash.String(),
+			TotalTxs:        shardBestStates[shardID].TotalTxns,
+			FinalizedHeight: httpServer.blockService.GetFinalizedShardHeight(shardID),
 		}
 	}
 
@@ -161,7 +162,9 @@ func (httpServer *HttpServer) handleGetBlockChainInfo(params interface{}, closeC
 	}
 	shardsBestState := httpServer.blockService.GetShardBestStates()
 	for shardID, bestState := range shardsBestState {
-		result.BestBlocks[int(shardID)] = *(jsonresult.NewGetBestBlockItemFromShard(bestState))
+		bestBlock := jsonresult.NewGetBestBlockItemFromShard(bestState)
+		bestBlock.FinalizedHeight = httpServer.blockService.GetFinalizedShardHeight(shardID)
+		result.BestBlocks[int(shardID)] = *bestBlock
 	}
 	beaconBestState, err := httpServer.blockService.GetBeaconBestState()
 	if err != nil {
@@ -0,0 +1,92 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/bean"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+/*
+handleGetEquivocationProofs - RPC returns the proofs of the committee members which voted for two blocks of the same round,
+detected by the consensus of this node
+*/
+func (httpServer *HttpServer) handleGetEquivocationProofs(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	Logger.log.Debugf("handleGetEquivocationProofs params: %+v", params)
+	result := httpServer.config.ConsensusEngine.GetEquivocationProofs()
+	Logger.log.Debugf("handleGetEquivocationProofs result: %+v", result)
+	return result, nil
+}
+
+/*
+handleCreateRawEquivocationProofTransaction - RPC creates a tx which sends an equivocation proof to slash a committee member
+param #5: the proof, as returned by getequivocationproofs: {"ChainID": ..., "CommitteePublicKey": ..., "Votes": [...]}
+*/
+func (httpServer *HttpServer) handleCreateRawEquivocationProofTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	Logger.log.Debugf("handleCreateRawEquivocationProofTransaction params: %+v", params)
+	paramsArray := common.InterfaceSlice(params)
+	if paramsArray == nil || len(paramsArray) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 element"))
+	}
+
+	createRawTxParam, errNewParam := bean.NewCreateRawTxParam(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	data, ok := paramsArray[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Invalid Equivocation Proof %+v", paramsArray[4]))
+	}
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	proof := metadata.EquivocationProofMetadata{}
+	if err := json.Unmarshal(dataBytes, &proof); err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	equivocationProofMetadata, err := metadata.NewEquivocationProofMetadata(metadata.EquivocationProofMeta, proof.ChainID, proof.CommitteePublicKey, proof.Votes)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	txID, txBytes, txShardID, err := httpServer.txService.CreateRawTransaction(createRawTxParam, equivocationProofMetadata, *httpServer.config.Database)
+	if err.(*rpcservice.RPCError) != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.CreateTxDataError, err)
+	}
+
+	result := jsonresult.CreateTransactionResult{
+		TxID:            txID.String(),
+		Base58CheckData: base58.Base58Check{}.Encode(txBytes, common.ZeroByte),
+		ShardID:         txShardID,
+	}
+	Logger.log.Debugf("handleCreateRawEquivocationProofTransaction result: %+v", result)
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleCreateAndSendEquivocationProofTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	Logger.log.Debugf("handleCreateAndSendEquivocationProofTransaction params: %+v", params)
+	var err error
+	data, err := httpServer.handleCreateRawEquivocationProofTransaction(params, closeChan)
+	if err.(*rpcservice.RPCError) != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.CreateTxDataError, err)
+	}
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err.(*rpcservice.RPCError) != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.SendTxDataError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, tx.ShardID)
+	Logger.log.Debugf("handleCreateAndSendEquivocationProofTransaction result: %+v", result)
+	return result, nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
 	"github.com/incognitochain/incognito-chain/transaction"
@@ -99,7 +100,7 @@ func (httpServer *HttpServer) handleEstimateFee(params interface{}, closeChan <-
 			return nil, rpcservice.NewRPCError(rpcservice.RejectInvalidFeeError, err2)
 		}
 	}
-	result := jsonresult.NewEstimateFeeResult(estimateFeeCoinPerKb, estimateTxSizeInKb)
+	result := jsonresult.NewEstimateFeeResult(estimateFeeCoinPerKb, estimateTxSizeInKb, httpServer.config.TxMemPool.MinFeePerKB())
 	Logger.log.Debugf("handleEstimateFee result: %+v", result)
 	return result, nil
 }
@@ -154,15 +155,33 @@ func (httpServer *HttpServer) handleEstimateFeeWithEstimator(params interface{},
 		}
 	}
 
+	// param #4: metadata type
+	// if it is set, return fee and tx size estimated from the txs with this metadata type
+	metaType := metadata.InvalidMeta
+	hasMetaType := false
+	if len(arrayParams) >= 5 && arrayParams[4] != nil {
+		metaTypeParam, ok := arrayParams[4].(float64)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata type param is invalid"))
+		}
+		metaType = int(metaTypeParam)
+		hasMetaType = true
+	}
+
 	beaconState, err := httpServer.blockService.BlockChain.BestState.GetClonedBeaconBestState()
 	beaconHeight := beaconState.BeaconHeight
 
-	estimateFeeCoinPerKb, err := httpServer.txService.EstimateFeeWithEstimator(defaultFeeCoinPerKb, shardIDSender, numblock, tokenId, int64(beaconHeight), *httpServer.config.Database)
+	estimateFeeCoinPerKb, err := httpServer.txService.EstimateFeeWithEstimator(defaultFeeCoinPerKb, shardIDSender, numblock, tokenId, metaType, int64(beaconHeight), *httpServer.config.Database)
 	if err != nil{
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
 
-	result := jsonresult.NewEstimateFeeResult(estimateFeeCoinPerKb, 0)
+	estimateTxSizeInKb := uint64(0)
+	if hasMetaType {
+		estimateTxSizeInKb = httpServer.txService.EstimateTxSizeWithEstimator(shardIDSender, metaType)
+	}
+
+	result := jsonresult.NewEstimateFeeResult(estimateFeeCoinPerKb, estimateTxSizeInKb, httpServer.config.TxMemPool.MinFeePerKB())
 	Logger.log.Debugf("handleEstimateFeeWithEstimator result: %+v", result)
 	return result, nil
 }
@@ -2,6 +2,7 @@ package rpcserver
 
 import (
 	"errors"
+	"fmt"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
@@ -229,3 +230,120 @@ func (httpServer *HttpServer) handleGetBeaconPoolStateV2(params interface{}, clo
 	Logger.log.Debugf("handleGetBeaconPoolStateV2 result: %+v", result)
 	return result, nil
 }
+
+// getPoolBlockParams - get the shard ids and the block height of the RPCs which drop or refetch a block of a pool
+func getPoolBlockParams(params interface{}, names ...string) ([]uint64, *rpcservice.RPCError) {
+	paramsArray := common.InterfaceSlice(params)
+	if paramsArray == nil || len(paramsArray) != len(names) {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("param must be an array of %+v elements", len(names)))
+	}
+	values := make([]uint64, len(names))
+	for i, name := range names {
+		value, ok := paramsArray[i].(float64)
+		if !ok || value < 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("%+v param is invalid", name))
+		}
+		values[i] = uint64(value)
+	}
+	return values, nil
+}
+
+/*
+handleGetCrossShardPoolInfo - RPC get the blocks in cross shard pool of a shard per source shard, with the reason a pending block is waiting
+*/
+func (httpServer *HttpServer) handleGetCrossShardPoolInfo(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	Logger.log.Debugf("handleGetCrossShardPoolInfo params: %+v", params)
+	values, rpcErr := getPoolBlockParams(params, "shard id")
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	shardInfos, err := httpServer.poolStateService.GetCrossShardPoolInfo(byte(values[0]))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.NewBlockPoolInfoResult(shardInfos)
+	Logger.log.Debugf("handleGetCrossShardPoolInfo result: %+v", result)
+	return result, nil
+}
+
+/*
+handleGetShardToBeaconPoolInfo - RPC get the blocks in shard to beacon pool per shard, with the reason a pending block is waiting
+*/
+func (httpServer *HttpServer) handleGetShardToBeaconPoolInfo(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	Logger.log.Debugf("handleGetShardToBeaconPoolInfo params: %+v", params)
+	shardInfos, err := httpServer.poolStateService.GetShardToBeaconPoolInfo()
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.NewBlockPoolInfoResult(shardInfos)
+	Logger.log.Debugf("handleGetShardToBeaconPoolInfo result: %+v", result)
+	return result, nil
+}
+
+/*
+handleDropCrossShardBlock - RPC drop a block from cross shard pool, params: from shard id, to shard id, block height
+*/
+func (httpServer *HttpServer) handleDropCrossShardBlock(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	Logger.log.Debugf("handleDropCrossShardBlock params: %+v", params)
+	values, rpcErr := getPoolBlockParams(params, "from shard id", "to shard id", "block height")
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	err := httpServer.poolStateService.DropCrossShardBlock(byte(values[0]), byte(values[1]), values[2])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.DropPoolBlockError, err)
+	}
+	return true, nil
+}
+
+/*
+handleRefetchCrossShardBlock - RPC drop a block from cross shard pool if it is in pool then request it again from peers,
+params: from shard id, to shard id, block height
+*/
+func (httpServer *HttpServer) handleRefetchCrossShardBlock(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	Logger.log.Debugf("handleRefetchCrossShardBlock params: %+v", params)
+	values, rpcErr := getPoolBlockParams(params, "from shard id", "to shard id", "block height")
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	fromShard, toShard, height := byte(values[0]), byte(values[1]), values[2]
+	err := httpServer.poolStateService.DropCrossShardBlock(fromShard, toShard, height)
+	result := jsonresult.RefetchPoolBlockResult{Dropped: err == nil}
+	httpServer.config.BlockChain.Synker.RefetchBlkCrossShard(fromShard, toShard, height)
+	Logger.log.Debugf("handleRefetchCrossShardBlock result: %+v", result)
+	return result, nil
+}
+
+/*
+handleDropShardToBeaconBlock - RPC drop a block from shard to beacon pool, params: shard id, block height
+*/
+func (httpServer *HttpServer) handleDropShardToBeaconBlock(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	Logger.log.Debugf("handleDropShardToBeaconBlock params: %+v", params)
+	values, rpcErr := getPoolBlockParams(params, "shard id", "block height")
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	err := httpServer.poolStateService.DropShardToBeaconBlock(byte(values[0]), values[1])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.DropPoolBlockError, err)
+	}
+	return true, nil
+}
+
+/*
+handleRefetchShardToBeaconBlock - RPC drop a block from shard to beacon pool if it is in pool then request it again from peers,
+params: shard id, block height
+*/
+func (httpServer *HttpServer) handleRefetchShardToBeaconBlock(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	Logger.log.Debugf("handleRefetchShardToBeaconBlock params: %+v", params)
+	values, rpcErr := getPoolBlockParams(params, "shard id", "block height")
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	shardID, height := byte(values[0]), values[1]
+	err := httpServer.poolStateService.DropShardToBeaconBlock(shardID, height)
+	result := jsonresult.RefetchPoolBlockResult{Dropped: err == nil}
+	httpServer.config.BlockChain.Synker.RefetchBlkShardToBeacon(shardID, height)
+	Logger.log.Debugf("handleRefetchShardToBeaconBlock result: %+v", result)
+	return result, nil
+}
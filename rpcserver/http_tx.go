@@ -96,6 +96,58 @@ func (httpServer *HttpServer) handleCreateAndSendTx(params interface{}, closeCha
 	return result, nil
 }
 
+/*
+handleReplaceTransaction - RPC creates a transaction spending the same coins as a transaction in mempool
+with a higher fee and send it to network to replace it
+*/
+func (httpServer *HttpServer) handleReplaceTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	Logger.log.Debugf("handleReplaceTransaction params: %+v", params)
+	tx, err := httpServer.txService.BuildRawReplaceTransaction(params, *httpServer.config.Database)
+	if err != nil {
+		Logger.log.Debugf("handleReplaceTransaction result: %+v, err: %+v", nil, err)
+		return nil, err
+	}
+	txBytes, err1 := json.Marshal(tx)
+	if err1 != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.CreateTxDataError, err1)
+	}
+	txShardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
+	newParam := []interface{}{base58.Base58Check{}.Encode(txBytes, 0x00)}
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err != nil {
+		Logger.log.Debugf("handleReplaceTransaction result: %+v, err: %+v", nil, err)
+		return nil, rpcservice.NewRPCError(rpcservice.ReplaceTxError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, txShardID)
+	Logger.log.Debugf("handleReplaceTransaction result: %+v", result)
+	return result, nil
+}
+
+/*
+// handleTestMempoolAccept implements the testmempoolaccept command.
+Parameter #1—a serialized transaction or privacy token transaction
+Result—the validation stages run by mempool, the fee per KB of the transaction and the minimum fee mempool accepts,
+the transaction is neither added to mempool nor broadcast
+*/
+func (httpServer *HttpServer) handleTestMempoolAccept(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	Logger.log.Debugf("handleTestMempoolAccept params: %+v", params)
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	base58CheckData, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("base58 check data is invalid"))
+	}
+	acceptResult, err := httpServer.txService.TestMempoolAccept(base58CheckData)
+	if err != nil {
+		return nil, err
+	}
+	result := jsonresult.NewTestMempoolAcceptResult(acceptResult)
+	Logger.log.Debugf("handleTestMempoolAccept result: %+v", result)
+	return result, nil
+}
+
 func (httpServer *HttpServer) handleGetTransactionHashByReceiver(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
 	if arrayParams == nil || len(arrayParams) < 1 {
@@ -252,7 +304,8 @@ func (httpServer *HttpServer) handleCreateAndSendCustomTokenTransaction(params i
 	return tx, nil
 }
 
-// handleGetListCustomTokenHolders - return all custom token holder
+// handleGetListCustomTokenHolders - return all custom token holder, or a page of them if a cursor is given
+// params: token id, [cursor, limit]
 func (httpServer *HttpServer) handleGetListCustomTokenHolders(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
 	if arrayParams == nil || len(arrayParams) < 1 {
@@ -264,6 +317,13 @@ func (httpServer *HttpServer) handleGetListCustomTokenHolders(params interface{}
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("TokenID is invalid"))
 	}
 
+	cursor, limit, paginated, rpcErr := getPageParams(arrayParams, 1)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if paginated {
+		return httpServer.txService.GetListCustomTokenHoldersByCursor(tokenIDStr, cursor, limit)
+	}
 	return httpServer.txService.GetListCustomTokenHolders(tokenIDStr)
 }
 
@@ -571,7 +631,8 @@ func (httpServer *HttpServer) handleRandomCommitments(params interface{}, closeC
 	return result, nil
 }
 
-// handleListSerialNumbers - return list all serialnumber in shard for token ID
+// handleListSerialNumbers - return list all serialnumber in shard for token ID, or a page of them if a cursor is given
+// params: token id, shard id, [cursor, limit]
 func (httpServer *HttpServer) handleListSerialNumbers(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
 	var err error
@@ -602,6 +663,17 @@ func (httpServer *HttpServer) handleListSerialNumbers(params interface{}, closeC
 		}
 	}
 
+	cursor, limit, paginated, rpcErr := getPageParams(arrayParams, 2)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if paginated {
+		result, err := httpServer.databaseService.ListSerialNumbersByCursor(*tokenID, byte(shardID), cursor, limit)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.ListCustomTokenNotFoundError, err)
+		}
+		return result, nil
+	}
 	result, err := httpServer.databaseService.ListSerialNumbers(*tokenID, byte(shardID))
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.ListCustomTokenNotFoundError, err)
@@ -609,7 +681,8 @@ func (httpServer *HttpServer) handleListSerialNumbers(params interface{}, closeC
 	return result, nil
 }
 
-// handleListSerialNumbers - return list all serialnumber in shard for token ID
+// handleListSNDerivator - return list all serial number derivators for token ID, or a page of them if a cursor is given
+// params: token id, [cursor, limit]
 func (httpServer *HttpServer) handleListSNDerivator(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
 	var err error
@@ -633,6 +706,17 @@ func (httpServer *HttpServer) handleListSNDerivator(params interface{}, closeCha
 		}
 	}
 
+	cursor, limit, paginated, rpcErr := getPageParams(arrayParams, 1)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if paginated {
+		result, err := httpServer.databaseService.ListSNDerivatorByCursor(*tokenID, cursor, limit)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.ListCustomTokenNotFoundError, err)
+		}
+		return result, nil
+	}
 	result, err := httpServer.databaseService.ListSNDerivator(*tokenID)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.ListCustomTokenNotFoundError, err)
@@ -640,7 +724,8 @@ func (httpServer *HttpServer) handleListSNDerivator(params interface{}, closeCha
 	return result, nil
 }
 
-// handleListCommitments - return list all commitments in shard for token ID
+// handleListCommitments - return list all commitments in shard for token ID, or a page of them if a cursor is given
+// params: token id, shard id, [cursor, limit]
 func (httpServer *HttpServer) handleListCommitments(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
 	var err error
@@ -671,6 +756,17 @@ func (httpServer *HttpServer) handleListCommitments(params interface{}, closeCha
 		}
 	}
 
+	cursor, limit, paginated, rpcErr := getPageParams(arrayParams, 2)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if paginated {
+		result, err := httpServer.databaseService.ListCommitmentsByCursor(*tokenID, byte(shardID), cursor, limit)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.ListCustomTokenNotFoundError, err)
+		}
+		return result, nil
+	}
 	result, err := httpServer.databaseService.ListCommitments(*tokenID, byte(shardID))
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.ListCustomTokenNotFoundError, err)
@@ -678,7 +774,8 @@ func (httpServer *HttpServer) handleListCommitments(params interface{}, closeCha
 	return result, nil
 }
 
-// handleListCommitmentIndices - return list all commitment indices in shard for token ID
+// handleListCommitmentIndices - return list all commitment indices in shard for token ID, or a page of them if a cursor is given
+// params: token id, shard id, [cursor, limit]
 func (httpServer *HttpServer) handleListCommitmentIndices(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
 	var err error
@@ -709,6 +806,17 @@ func (httpServer *HttpServer) handleListCommitmentIndices(params interface{}, cl
 		}
 	}
 
+	cursor, limit, paginated, rpcErr := getPageParams(arrayParams, 2)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if paginated {
+		result, err := httpServer.databaseService.ListCommitmentIndicesByCursor(*tokenID, shardID, cursor, limit)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.ListCustomTokenNotFoundError, err)
+		}
+		return result, nil
+	}
 	result, err := httpServer.databaseService.ListCommitmentIndices(*tokenID, shardID)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.ListCustomTokenNotFoundError, err)
@@ -1130,3 +1238,47 @@ func (httpServer *HttpServer) handleCreateAndSendStopAutoStakingTransaction(para
 	Logger.log.Debugf("handleCreateAndSendStakingTx result: %+v", result)
 	return result, nil
 }
+
+// handleListTxsByMetadataType - list the txs with a metadata type in a shard between two block heights, page by page
+// params: metadata type, shard id, from height, to height, [limit, from tx index]
+// the next page is requested with NextHeight as from height and NextTxIndex as from tx index
+func (httpServer *HttpServer) handleListTxsByMetadataType(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	Logger.log.Debugf("handleListTxsByMetadataType params: %+v", params)
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 4 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 4 elements"))
+	}
+	metaTypeParam, ok := arrayParams[0].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata type is invalid"))
+	}
+	shardIDParam, ok := arrayParams[1].(float64)
+	if !ok || int(shardIDParam) < 0 || int(shardIDParam) >= common.MaxShardNumber {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("shard id is invalid"))
+	}
+	fromHeightParam, ok := arrayParams[2].(float64)
+	if !ok || fromHeightParam < 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("from height is invalid"))
+	}
+	toHeightParam, ok := arrayParams[3].(float64)
+	if !ok || toHeightParam < fromHeightParam {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("to height is invalid"))
+	}
+	limit := defaultPageSize
+	if len(arrayParams) > 4 {
+		limitParam, ok := arrayParams[4].(float64)
+		if !ok || limitParam <= 0 || limitParam > maxPageSize {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("limit must be between 1 and %d", maxPageSize))
+		}
+		limit = int(limitParam)
+	}
+	fromTxIndex := 0
+	if len(arrayParams) > 5 {
+		fromTxIndexParam, ok := arrayParams[5].(float64)
+		if !ok || fromTxIndexParam < 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("from tx index is invalid"))
+		}
+		fromTxIndex = int(fromTxIndexParam)
+	}
+	return httpServer.txService.ListTxsByMetadataType(int(metaTypeParam), byte(shardIDParam), uint64(fromHeightParam), fromTxIndex, uint64(toHeightParam), limit)
+}
@@ -162,3 +162,33 @@ func (httpServer *HttpServer) createAndSendTxWithMetadata(params interface{}, cl
 	newParam = append(newParam, base58CheckData)
 	return sendHandler(httpServer, newParam, closeChan)
 }
+
+// getPageParams parses the optional cursor and limit params of a list rpc, starting at arrayParams[index]
+// the rpc returns a page with the NextCursor only if the cursor param is given, otherwise it returns the whole list.
+// the cursor is the NextCursor of the previous page, empty to get the first page
+func getPageParams(arrayParams []interface{}, index int) ([]byte, int, bool, *rpcservice.RPCError) {
+	if len(arrayParams) <= index {
+		return nil, 0, false, nil
+	}
+	var cursor []byte
+	cursorStr, ok := arrayParams[index].(string)
+	if !ok {
+		return nil, 0, false, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("cursor is invalid"))
+	}
+	if len(cursorStr) > 0 {
+		var err error
+		cursor, _, err = base58.Base58Check{}.Decode(cursorStr)
+		if err != nil {
+			return nil, 0, false, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		}
+	}
+	limit := defaultPageSize
+	if len(arrayParams) > index+1 {
+		limitParam, ok := arrayParams[index+1].(float64)
+		if !ok || limitParam <= 0 || limitParam > maxPageSize {
+			return nil, 0, false, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.Errorf("limit must be between 1 and %d", maxPageSize))
+		}
+		limit = int(limitParam)
+	}
+	return cursor, limit, true, nil
+}
@@ -71,8 +71,20 @@ func (httpServer *HttpServer) handleGetRewardAmount(params interface{}, closeCha
 	return httpServer.blockService.GetRewardAmount(paymentAddress)
 }
 
-// handleListRewardAmount - Get the reward amount of all committee with all existed token
+// handleListRewardAmount - Get the reward amount of all committee with all existed token, or a page of them if a cursor is given
+// params: [cursor, limit]
 func (httpServer *HttpServer) handleListRewardAmount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	cursor, limit, paginated, rpcErr := getPageParams(common.InterfaceSlice(params), 0)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if paginated {
+		result, err := httpServer.databaseService.ListRewardAmountByCursor(cursor, limit)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+		}
+		return result, nil
+	}
 	result := httpServer.databaseService.ListRewardAmount()
 	return result, nil
 }
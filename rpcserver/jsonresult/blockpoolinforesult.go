@@ -0,0 +1,51 @@
+package jsonresult
+
+import (
+	"github.com/incognitochain/incognito-chain/mempool"
+)
+
+type BlockPoolBlockInfo struct {
+	Height     uint64 `json:"Height"`
+	Hash       string `json:"Hash"`
+	Status     string `json:"Status"`
+	Reason     string `json:"Reason,omitempty"`
+	WaitHeight uint64 `json:"WaitHeight,omitempty"`
+}
+
+type BlockPoolShardInfo struct {
+	ShardID        byte                 `json:"ShardID"`
+	LatestHeight   uint64               `json:"LatestHeight"`
+	ExpectedHeight uint64               `json:"ExpectedHeight"`
+	Blocks         []BlockPoolBlockInfo `json:"Blocks"`
+}
+
+type BlockPoolInfoResult struct {
+	Shards []BlockPoolShardInfo `json:"Shards"`
+}
+
+func NewBlockPoolInfoResult(shardInfos []mempool.BlockPoolShardInfo) *BlockPoolInfoResult {
+	result := &BlockPoolInfoResult{Shards: []BlockPoolShardInfo{}}
+	for _, shardInfo := range shardInfos {
+		shardResult := BlockPoolShardInfo{
+			ShardID:        shardInfo.ShardID,
+			LatestHeight:   shardInfo.LatestHeight,
+			ExpectedHeight: shardInfo.ExpectedHeight,
+			Blocks:         []BlockPoolBlockInfo{},
+		}
+		for _, blkInfo := range shardInfo.Blocks {
+			shardResult.Blocks = append(shardResult.Blocks, BlockPoolBlockInfo{
+				Height:     blkInfo.Height,
+				Hash:       blkInfo.Hash.String(),
+				Status:     blkInfo.Status,
+				Reason:     blkInfo.Reason,
+				WaitHeight: blkInfo.WaitHeight,
+			})
+		}
+		result.Shards = append(result.Shards, shardResult)
+	}
+	return result
+}
+
+type RefetchPoolBlockResult struct {
+	Dropped bool `json:"Dropped"`
+}
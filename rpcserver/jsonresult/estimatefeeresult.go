@@ -1,14 +1,16 @@
 package jsonresult
 
 type EstimateFeeResult struct {
-	EstimateFeeCoinPerKb uint64
-	EstimateTxSizeInKb   uint64
+	EstimateFeeCoinPerKb   uint64
+	EstimateTxSizeInKb     uint64
+	MempoolMinFeeCoinPerKb uint64
 }
 
-func NewEstimateFeeResult(estimateFeeCoinPerKb uint64, estimateTxSizeInKb uint64) *EstimateFeeResult {
+func NewEstimateFeeResult(estimateFeeCoinPerKb uint64, estimateTxSizeInKb uint64, mempoolMinFeeCoinPerKb uint64) *EstimateFeeResult {
 	result := &EstimateFeeResult{
-		EstimateFeeCoinPerKb: estimateFeeCoinPerKb,
-		EstimateTxSizeInKb:   estimateTxSizeInKb,
+		EstimateFeeCoinPerKb:   estimateFeeCoinPerKb,
+		EstimateTxSizeInKb:     estimateTxSizeInKb,
+		MempoolMinFeeCoinPerKb: mempoolMinFeeCoinPerKb,
 	}
 	return result
 }
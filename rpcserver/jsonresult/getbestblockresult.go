@@ -7,13 +7,14 @@ type GetBestBlockResult struct {
 }
 
 type GetBestBlockItem struct {
-	Height         uint64 `json:"Height"`
-	Hash           string `json:"Hash"`
-	TotalTxs       uint64 `json:"TotalTxs"`
-	BlockProducer  string `json:"BlockProducer"`
-	ValidationData string `json:"ValidationData"`
-	Epoch          uint64 `json:"Epoch"`
-	Time           int64  `json:"Time"`
+	Height          uint64 `json:"Height"`
+	Hash            string `json:"Hash"`
+	TotalTxs        uint64 `json:"TotalTxs"`
+	BlockProducer   string `json:"BlockProducer"`
+	ValidationData  string `json:"ValidationData"`
+	Epoch           uint64 `json:"Epoch"`
+	Time            int64  `json:"Time"`
+	FinalizedHeight uint64 `json:"FinalizedHeight,omitempty"` // last shard height confirmed by the beacon chain, blocks above it may still be replaced
 }
 
 func NewGetBestBlockItemFromShard(bestState *blockchain.ShardBestState) *GetBestBlockItem {
@@ -15,12 +15,15 @@ type GetMempoolInfo struct {
 	MempoolMinFee uint64             `json:"MempoolMinFee"`
 	MempoolMaxFee uint64             `json:"MempoolMaxFee"`
 	ListTxs       []GetMempoolInfoTx `json:"ListTxs"`
+	// txs of the mempool journal which were dropped on restart
+	DroppedOnRestart []GetMempoolInfoDroppedTx `json:"DroppedOnRestart,omitempty"`
 }
 
 func NewGetMempoolInfo(txMempool *mempool.TxPool) *GetMempoolInfo {
 	result := &GetMempoolInfo{
 		Size:          txMempool.Count(),
 		Bytes:         txMempool.Size(),
+		MempoolMinFee: txMempool.MinFeePerKB(),
 		MempoolMaxFee: txMempool.MaxFee(),
 	}
 	// get list data from mempool
@@ -32,6 +35,13 @@ func NewGetMempoolInfo(txMempool *mempool.TxPool) *GetMempoolInfo {
 			result.ListTxs = append(result.ListTxs, *item)
 		}
 	}
+	for _, report := range txMempool.DroppedOnRestart() {
+		result.DroppedOnRestart = append(result.DroppedOnRestart, GetMempoolInfoDroppedTx{
+			TxID:      report.TxHash.String(),
+			ErrorCode: report.ErrorCode,
+			Reason:    report.Reason,
+		})
+	}
 	// sort for time
 	if len(result.ListTxs) > 0 {
 		sort.Slice(result.ListTxs, func(i, j int) bool {
@@ -41,6 +51,12 @@ func NewGetMempoolInfo(txMempool *mempool.TxPool) *GetMempoolInfo {
 	return result
 }
 
+type GetMempoolInfoDroppedTx struct {
+	TxID      string `json:"TxID"`
+	ErrorCode int    `json:"ErrorCode"`
+	Reason    string `json:"Reason"`
+}
+
 type GetMempoolInfoTx struct {
 	TxID     string `json:"TxID"`
 	LockTime int64  `json:"LockTime"`
@@ -77,3 +93,22 @@ func NewGetPendingTxsInBlockgenResult(txs []metadata.Transaction) GetPendingTxsI
 	}
 	return GetPendingTxsInBlockgenResult{TxHashes: txHashes}
 }
+
+// ReplacedTransaction is sent to the subscribers of the mempool info when a tx in mempool is replaced
+type ReplacedTransaction struct {
+	ReplacedTxID     string `json:"ReplacedTxID"`
+	TxID             string `json:"TxID"`
+	Fee              uint64 `json:"Fee"`
+	FeeToken         uint64 `json:"FeeToken"`
+	ReplacementCount uint   `json:"ReplacementCount"`
+}
+
+func NewReplacedTransaction(replacement *mempool.TxReplacement) *ReplacedTransaction {
+	return &ReplacedTransaction{
+		ReplacedTxID:     replacement.ReplacedTxHash.String(),
+		TxID:             replacement.TxHash.String(),
+		Fee:              replacement.Fee,
+		FeeToken:         replacement.FeeToken,
+		ReplacementCount: replacement.ReplacementCount,
+	}
+}
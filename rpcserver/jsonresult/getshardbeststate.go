@@ -25,6 +25,7 @@ type GetShardBestState struct {
 	TotalTxnsExcludeSalary uint64            `json:"TotalTxnsExcludeSalary"` // for testing and benchmark
 	ActiveShards           int               `json:"ActiveShards"`
 	MetricBlockHeight      uint64            `json:"MetricBlockHeight"`
+	FinalizedHeight        uint64            `json:"FinalizedHeight"` // last shard height confirmed by the beacon chain
 }
 
 func NewGetShardBestState(data *blockchain.ShardBestState) *GetShardBestState {
@@ -0,0 +1,39 @@
+package jsonresult
+
+import (
+	"math/big"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// The results of the paginated rpc, NextCursor is passed to get the next page and is empty on the last page
+
+type ListSerialNumbersResult struct {
+	SerialNumbers map[string]uint64 `json:"SerialNumbers"`
+	NextCursor    string            `json:"NextCursor"`
+}
+
+type ListCommitmentsResult struct {
+	Commitments map[string]uint64 `json:"Commitments"`
+	NextCursor  string            `json:"NextCursor"`
+}
+
+type ListCommitmentIndicesResult struct {
+	CommitmentIndices map[uint64]string `json:"CommitmentIndices"`
+	NextCursor        string            `json:"NextCursor"`
+}
+
+type ListSNDerivatorsResult struct {
+	SNDerivators []big.Int `json:"SNDerivators"`
+	NextCursor   string    `json:"NextCursor"`
+}
+
+type ListRewardAmountResult struct {
+	RewardAmounts map[string]map[common.Hash]uint64 `json:"RewardAmounts"`
+	NextCursor    string                            `json:"NextCursor"`
+}
+
+type ListCustomTokenHoldersResult struct {
+	Holders    map[string]uint64 `json:"Holders"`
+	NextCursor string            `json:"NextCursor"`
+}
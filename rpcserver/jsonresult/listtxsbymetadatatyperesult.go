@@ -0,0 +1,18 @@
+package jsonresult
+
+type MetadataTx struct {
+	Height  uint64 `json:"Height"`
+	TxIndex int    `json:"TxIndex"`
+	TxHash  string `json:"TxHash"`
+}
+
+// ListTxsByMetadataType is a page of txs with a metadata type,
+// the next page starts at NextHeight and NextTxIndex when HasMore is true
+type ListTxsByMetadataType struct {
+	MetadataType int          `json:"MetadataType"`
+	ShardID      byte         `json:"ShardID"`
+	Txs          []MetadataTx `json:"Txs"`
+	HasMore      bool         `json:"HasMore"`
+	NextHeight   uint64       `json:"NextHeight"`
+	NextTxIndex  int          `json:"NextTxIndex"`
+}
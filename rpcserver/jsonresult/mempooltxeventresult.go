@@ -0,0 +1,40 @@
+package jsonresult
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/mempool"
+)
+
+// States of a tx streamed by subcribemempooltxevent besides the states of mempool.TxEvent
+const (
+	TxEventIncluded  = "included"
+	TxEventConfirmed = "confirmed"
+)
+
+type MempoolTxEventResult struct {
+	TxHash       string `json:"TxHash"`
+	State        string `json:"State"`
+	ErrorCode    int    `json:"ErrorCode,omitempty"`
+	ErrorMessage string `json:"ErrorMessage,omitempty"`
+	ReplacedBy   string `json:"ReplacedBy,omitempty"`
+	Reason       string `json:"Reason,omitempty"`
+	ShardID      byte   `json:"ShardID"`
+	BlockHeight  uint64 `json:"BlockHeight,omitempty"`
+	BlockHash    string `json:"BlockHash,omitempty"`
+	BeaconHeight uint64 `json:"BeaconHeight,omitempty"`
+}
+
+func NewMempoolTxEventResult(event *mempool.TxEvent) *MempoolTxEventResult {
+	result := &MempoolTxEventResult{
+		TxHash:       event.Tx.Hash().String(),
+		State:        event.State,
+		ErrorCode:    event.ErrorCode,
+		ErrorMessage: event.ErrorMessage,
+		Reason:       event.Reason,
+		ShardID:      common.GetShardIDFromLastByte(event.Tx.GetSenderAddrLastByte()),
+	}
+	if event.ReplacedBy != nil {
+		result.ReplacedBy = event.ReplacedBy.String()
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package jsonresult
+
+import (
+	"github.com/incognitochain/incognito-chain/mempool"
+)
+
+type TxValidationStageResult struct {
+	Stage        string `json:"Stage"`
+	Passed       bool   `json:"Passed"`
+	ErrorCode    int    `json:"ErrorCode,omitempty"`
+	ErrorMessage string `json:"ErrorMessage,omitempty"`
+}
+
+type TestMempoolAcceptResult struct {
+	TxID         string                    `json:"TxID"`
+	Allowed      bool                      `json:"Allowed"`
+	RejectCode   int                       `json:"RejectCode,omitempty"`
+	RejectReason string                    `json:"RejectReason,omitempty"`
+	Stages       []TxValidationStageResult `json:"Stages"`
+	FeePerKb     uint64                    `json:"FeePerKb"`
+	MinFeePerKb  uint64                    `json:"MinFeePerKb"`
+	MinFee       uint64                    `json:"MinFee"`
+}
+
+func newTxValidationError(err error) (int, string) {
+	if err == nil {
+		return 0, ""
+	}
+	if mempoolErr, ok := err.(*mempool.MempoolTxError); ok {
+		return mempoolErr.Code, mempoolErr.Error()
+	}
+	return 0, err.Error()
+}
+
+func NewTestMempoolAcceptResult(acceptResult *mempool.TxAcceptResult) *TestMempoolAcceptResult {
+	result := &TestMempoolAcceptResult{
+		TxID:        acceptResult.TxHash.String(),
+		Allowed:     acceptResult.Allowed,
+		Stages:      []TxValidationStageResult{},
+		FeePerKb:    acceptResult.FeePerKB,
+		MinFeePerKb: acceptResult.MinFeePerKB,
+		MinFee:      acceptResult.MinFee,
+	}
+	result.RejectCode, result.RejectReason = newTxValidationError(acceptResult.Err)
+	for _, stage := range acceptResult.Stages {
+		stageResult := TxValidationStageResult{Stage: stage.Stage, Passed: stage.Passed}
+		stageResult.ErrorCode, stageResult.ErrorMessage = newTxValidationError(stage.Err)
+		result.Stages = append(result.Stages, stageResult)
+	}
+	return result
+}
@@ -40,6 +40,8 @@ var HttpHandler = map[string]httpHandler{
 	getCrossShardPoolStateV2:    (*HttpServer).handleGetCrossShardPoolStateV2,
 	getShardPoolStateV2:         (*HttpServer).handleGetShardPoolStateV2,
 	getBeaconPoolStateV2:        (*HttpServer).handleGetBeaconPoolStateV2,
+	getCrossShardPoolInfo:       (*HttpServer).handleGetCrossShardPoolInfo,
+	getShardToBeaconPoolInfo:    (*HttpServer).handleGetShardToBeaconPoolInfo,
 	// ver.1
 	//getShardToBeaconPoolState: (*HttpServer).handleGetShardToBeaconPoolState,
 	//getCrossShardPoolState:    (*HttpServer).handleGetCrossShardPoolState,
@@ -59,21 +61,26 @@ var HttpHandler = map[string]httpHandler{
 	getCrossShardBlock:  (*HttpServer).handleGetCrossShardBlock,
 
 	// transaction
-	listOutputCoins:                         (*HttpServer).handleListOutputCoins,
-	createRawTransaction:                    (*HttpServer).handleCreateRawTransaction,
-	sendRawTransaction:                      (*HttpServer).handleSendRawTransaction,
-	createAndSendTransaction:                (*HttpServer).handleCreateAndSendTx,
-	getTransactionByHash:                    (*HttpServer).handleGetTransactionByHash,
-	gettransactionhashbyreceiver:            (*HttpServer).handleGetTransactionHashByReceiver,
-	gettransactionbyreceiver:                (*HttpServer).handleGetTransactionByReceiver,
-	createAndSendStakingTransaction:         (*HttpServer).handleCreateAndSendStakingTx,
-	createAndSendStopAutoStakingTransaction: (*HttpServer).handleCreateAndSendStopAutoStakingTransaction,
-	randomCommitments:                       (*HttpServer).handleRandomCommitments,
-	hasSerialNumbers:                        (*HttpServer).handleHasSerialNumbers,
-	hasSnDerivators:                         (*HttpServer).handleHasSnDerivators,
-	listSerialNumbers:                       (*HttpServer).handleListSerialNumbers,
-	listCommitments:                         (*HttpServer).handleListCommitments,
-	listCommitmentIndices:                   (*HttpServer).handleListCommitmentIndices,
+	listOutputCoins:                           (*HttpServer).handleListOutputCoins,
+	createRawTransaction:                      (*HttpServer).handleCreateRawTransaction,
+	sendRawTransaction:                        (*HttpServer).handleSendRawTransaction,
+	createAndSendTransaction:                  (*HttpServer).handleCreateAndSendTx,
+	replaceTransaction:                        (*HttpServer).handleReplaceTransaction,
+	testMempoolAccept:                         (*HttpServer).handleTestMempoolAccept,
+	getTransactionByHash:                      (*HttpServer).handleGetTransactionByHash,
+	gettransactionhashbyreceiver:              (*HttpServer).handleGetTransactionHashByReceiver,
+	gettransactionbyreceiver:                  (*HttpServer).handleGetTransactionByReceiver,
+	createAndSendStakingTransaction:           (*HttpServer).handleCreateAndSendStakingTx,
+	createAndSendStopAutoStakingTransaction:   (*HttpServer).handleCreateAndSendStopAutoStakingTransaction,
+	createRawEquivocationProofTransaction:     (*HttpServer).handleCreateRawEquivocationProofTransaction,
+	createAndSendEquivocationProofTransaction: (*HttpServer).handleCreateAndSendEquivocationProofTransaction,
+	randomCommitments:                         (*HttpServer).handleRandomCommitments,
+	hasSerialNumbers:                          (*HttpServer).handleHasSerialNumbers,
+	hasSnDerivators:                           (*HttpServer).handleHasSnDerivators,
+	listSerialNumbers:                         (*HttpServer).handleListSerialNumbers,
+	listCommitments:                           (*HttpServer).handleListCommitments,
+	listCommitmentIndices:                     (*HttpServer).handleListCommitmentIndices,
+	listTxsByMetadataType:                     (*HttpServer).handleListTxsByMetadataType,
 
 	//======Testing and Benchmark======
 	getAndSendTxsFromFile:   (*HttpServer).handleGetAndSendTxsFromFile,
@@ -158,6 +165,7 @@ var HttpHandler = map[string]httpHandler{
 	getRoleByValidatorKey:       (*HttpServer).handleGetValidatorKeyRole,
 	getIncognitoPublicKeyRole:   (*HttpServer).handleGetIncognitoPublicKeyRole,
 	getMinerRewardFromMiningKey: (*HttpServer).handleGetMinerRewardFromMiningKey,
+	getEquivocationProofs:       (*HttpServer).handleGetEquivocationProofs,
 	getProducersBlackList:       (*HttpServer).handleGetProducersBlackList,
 	getProducersBlackListDetail: (*HttpServer).handleGetProducersBlackListDetail,
 
@@ -194,6 +202,11 @@ var LimitedHttpHandler = map[string]httpHandler{
 	setTxFee:                         (*HttpServer).handleSetTxFee,
 	convertNativeTokenToPrivacyToken: (*HttpServer).handleConvertNativeTokenToPrivacyToken,
 	convertPrivacyTokenToNativeToken: (*HttpServer).handleConvertPrivacyTokenToNativeToken,
+	// block pool
+	dropCrossShardBlock:       (*HttpServer).handleDropCrossShardBlock,
+	refetchCrossShardBlock:    (*HttpServer).handleRefetchCrossShardBlock,
+	dropShardToBeaconBlock:    (*HttpServer).handleDropShardToBeaconBlock,
+	refetchShardToBeaconBlock: (*HttpServer).handleRefetchShardToBeaconBlock,
 }
 
 var WsHandler = map[string]wsHandler{
@@ -201,6 +214,7 @@ var WsHandler = map[string]wsHandler{
 	subcribeNewShardBlock:                       (*WsServer).handleSubscribeNewShardBlock,
 	subcribeNewBeaconBlock:                      (*WsServer).handleSubscribeNewBeaconBlock,
 	subcribePendingTransaction:                  (*WsServer).handleSubscribePendingTransaction,
+	subcribeMempoolTxEvent:                      (*WsServer).handleSubcribeMempoolTxEvent,
 	subcribeShardCandidateByPublickey:           (*WsServer).handleSubcribeShardCandidateByPublickey,
 	subcribeShardCommitteeByPublickey:           (*WsServer).handleSubcribeShardCommitteeByPublickey,
 	subcribeShardPendingValidatorByPublickey:    (*WsServer).handleSubcribeShardPendingValidatorByPublickey,
@@ -215,4 +229,5 @@ var WsHandler = map[string]wsHandler{
 	subcribeBeaconBestState:                     (*WsServer).handleSubscribeBeaconBestState,
 	subcribeBeaconPoolBeststate:                 (*WsServer).handleSubscribeBeaconPoolBestState,
 	subcribeShardPoolBeststate:                  (*WsServer).handleSubscribeShardPoolBeststate,
+	subcribeFinalizedShardBlock:                 (*WsServer).handleSubscribeFinalizedShardBlock,
 }
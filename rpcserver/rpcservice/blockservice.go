@@ -68,6 +68,11 @@ func (blockService BlockService) GetShardBestBlockByShardID(shardID byte) (block
 	return *shard.BestBlock, shard.BestBlockHash, err
 }
 
+// GetFinalizedShardHeight returns the last height of a shard confirmed by the beacon chain
+func (blockService BlockService) GetFinalizedShardHeight(shardID byte) uint64 {
+	return blockService.BlockChain.GetFinalizedShardHeight(shardID)
+}
+
 func (blockService BlockService) GetShardBestBlockHashes() map[int]common.Hash {
 	bestBlockHashes := make(map[int]common.Hash)
 	shards := blockService.BlockChain.BestState.GetClonedAllShardBestState()
@@ -12,6 +12,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/database"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 )
 
 type DatabaseService struct {
@@ -55,10 +56,33 @@ func (dbService DatabaseService) GetBurningConfirm(txID common.Hash) (uint64, er
 	return (*dbService.DB).GetBurningConfirm(txID)
 }
 
+// encodeCursor returns the continuation token of a page as a base58 check string, empty for the last page
+func encodeCursor(cursor []byte) string {
+	if len(cursor) == 0 {
+		return ""
+	}
+	return base58.Base58Check{}.Encode(cursor, common.ZeroByte)
+}
+
 func (dbService DatabaseService) ListSerialNumbers(tokenID common.Hash, shardID byte) (map[string]uint64, error) {
 	return (*dbService.DB).ListSerialNumber(tokenID, shardID)
 }
 
+func (dbService DatabaseService) ListSerialNumbersByCursor(tokenID common.Hash, shardID byte, cursor []byte, limit int) (*jsonresult.ListSerialNumbersResult, error) {
+	serialNumbers, nextCursor, err := (*dbService.DB).ListSerialNumberByCursor(tokenID, shardID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := &jsonresult.ListSerialNumbersResult{
+		SerialNumbers: make(map[string]uint64),
+		NextCursor:    encodeCursor(nextCursor),
+	}
+	for _, item := range serialNumbers {
+		result.SerialNumbers[base58.Base58Check{}.Encode(item.SerialNumber, common.ZeroByte)] = item.Index
+	}
+	return result, nil
+}
+
 func (dbService DatabaseService) ListSNDerivator(tokenID common.Hash) ([]big.Int, error) {
 	resultInBytes, err := (*dbService.DB).ListSNDerivator(tokenID)
 	if err != nil {
@@ -73,14 +97,59 @@ func (dbService DatabaseService) ListSNDerivator(tokenID common.Hash) ([]big.Int
 	return result, nil
 }
 
+func (dbService DatabaseService) ListSNDerivatorByCursor(tokenID common.Hash, cursor []byte, limit int) (*jsonresult.ListSNDerivatorsResult, error) {
+	snds, nextCursor, err := (*dbService.DB).ListSNDerivatorByCursor(tokenID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := &jsonresult.ListSNDerivatorsResult{
+		SNDerivators: []big.Int{},
+		NextCursor:   encodeCursor(nextCursor),
+	}
+	for _, v := range snds {
+		result.SNDerivators = append(result.SNDerivators, *(new(big.Int).SetBytes(v)))
+	}
+	return result, nil
+}
+
 func (dbService DatabaseService) ListCommitments(tokenID common.Hash, shardID byte) (map[string]uint64, error) {
 	return (*dbService.DB).ListCommitment(tokenID, shardID)
 }
 
+func (dbService DatabaseService) ListCommitmentsByCursor(tokenID common.Hash, shardID byte, cursor []byte, limit int) (*jsonresult.ListCommitmentsResult, error) {
+	commitments, nextCursor, err := (*dbService.DB).ListCommitmentByCursor(tokenID, shardID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := &jsonresult.ListCommitmentsResult{
+		Commitments: make(map[string]uint64),
+		NextCursor:  encodeCursor(nextCursor),
+	}
+	for _, item := range commitments {
+		result.Commitments[base58.Base58Check{}.Encode(item.Commitment, common.ZeroByte)] = item.Index
+	}
+	return result, nil
+}
+
 func (dbService DatabaseService) ListCommitmentIndices(tokenID common.Hash, shardID byte) (map[uint64]string, error) {
 	return (*dbService.DB).ListCommitmentIndices(tokenID, shardID)
 }
 
+func (dbService DatabaseService) ListCommitmentIndicesByCursor(tokenID common.Hash, shardID byte, cursor []byte, limit int) (*jsonresult.ListCommitmentIndicesResult, error) {
+	commitments, nextCursor, err := (*dbService.DB).ListCommitmentIndicesByCursor(tokenID, shardID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := &jsonresult.ListCommitmentIndicesResult{
+		CommitmentIndices: make(map[uint64]string),
+		NextCursor:        encodeCursor(nextCursor),
+	}
+	for _, item := range commitments {
+		result.CommitmentIndices[item.Index] = base58.Base58Check{}.Encode(item.Commitment, common.ZeroByte)
+	}
+	return result, nil
+}
+
 func (dbService DatabaseService) HasSerialNumbers(paymentAddressStr string, serialNumbersStr []interface{}, tokenID common.Hash) ([]bool, error) {
 	_, shardIDSender, err := GetKeySetFromPaymentAddressParam(paymentAddressStr)
 	if err != nil {
@@ -143,6 +212,25 @@ func (dbService DatabaseService) ListRewardAmount() map[string]map[common.Hash]u
 	return (*dbService.DB).ListCommitteeReward()
 }
 
+func (dbService DatabaseService) ListRewardAmountByCursor(cursor []byte, limit int) (*jsonresult.ListRewardAmountResult, error) {
+	rewards, nextCursor, err := (*dbService.DB).ListCommitteeRewardByCursor(cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := &jsonresult.ListRewardAmountResult{
+		RewardAmounts: make(map[string]map[common.Hash]uint64),
+		NextCursor:    encodeCursor(nextCursor),
+	}
+	for _, item := range rewards {
+		publicKey := base58.Base58Check{}.Encode(item.PublicKey, common.ZeroByte)
+		if result.RewardAmounts[publicKey] == nil {
+			result.RewardAmounts[publicKey] = make(map[common.Hash]uint64)
+		}
+		result.RewardAmounts[publicKey][item.TokenID] = item.Amount
+	}
+	return result, nil
+}
+
 func (dbService DatabaseService) GetProducersBlackList(beaconHeight uint64) (map[string]uint8, error) {
 	return (*dbService.DB).GetProducersBlackList(beaconHeight)
 }
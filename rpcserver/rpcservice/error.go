@@ -48,6 +48,9 @@ const (
 	NoSwapConfirmInst
 	GetKeySetFromPrivateKeyError
 	GetPDEStateError
+	ListTxsByMetadataTypeError
+	ReplaceTxError
+	DropPoolBlockError
 )
 
 // Standard JSON-RPC 2.0 errors.
@@ -90,6 +93,7 @@ var ErrCodeMessage = map[int]struct {
 	GetBeaconBlockByHeightError: {-2004, "Get beacon block by height error"},
 	GetBeaconBestBlockHashError: {-2004, "Get beacon best block hash error"},
 	GetBeaconBestBlockError:     {-2005, "Get beacon best block error"},
+	ListTxsByMetadataTypeError:  {-2006, "List txs by metadata type error"},
 
 	// best state -3xxx
 	GetClonedBeaconBestStateError: {-3000, "Get Cloned Beacon Best State Error"},
@@ -106,6 +110,8 @@ var ErrCodeMessage = map[int]struct {
 	// tx pool -6xxx
 	GeTxFromPoolError:   {-6000, "Get tx from mempool error"},
 	TxPoolRejectTxError: {-6001, "Can not insert tx into tx mempool"},
+	ReplaceTxError:      {-6002, "Can not replace tx in mempool"},
+	DropPoolBlockError:  {-6003, "Can not drop block from block pool"},
 
 	// decentralized bridge
 	NoSwapConfirmInst: {-7000, "No swap confirm instruction found in block"},
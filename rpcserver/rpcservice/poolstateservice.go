@@ -86,3 +86,34 @@ func (poolStateService PoolStateService) GetBeaconPoolStateV2() (*mempool.Beacon
 }
 
 
+func (poolStateService PoolStateService) GetCrossShardPoolInfo(shardID byte) ([]mempool.BlockPoolShardInfo, error) {
+	crossShardPool := mempool.GetCrossShardPool(shardID)
+	if crossShardPool == nil {
+		return nil, errors.New("Cross Shard Pool not init")
+	}
+	return crossShardPool.GetBlockPoolInfo(), nil
+}
+
+func (poolStateService PoolStateService) GetShardToBeaconPoolInfo() ([]mempool.BlockPoolShardInfo, error) {
+	shardToBeaconPool := mempool.GetShardToBeaconPool()
+	if shardToBeaconPool == nil {
+		return nil, errors.New("Shard to Beacon Pool not init")
+	}
+	return shardToBeaconPool.GetBlockPoolInfo(), nil
+}
+
+func (poolStateService PoolStateService) DropCrossShardBlock(fromShard byte, toShard byte, height uint64) error {
+	crossShardPool := mempool.GetCrossShardPool(toShard)
+	if crossShardPool == nil {
+		return errors.New("Cross Shard Pool not init")
+	}
+	return crossShardPool.RemoveBlock(fromShard, height)
+}
+
+func (poolStateService PoolStateService) DropShardToBeaconBlock(shardID byte, height uint64) error {
+	shardToBeaconPool := mempool.GetShardToBeaconPool()
+	if shardToBeaconPool == nil {
+		return errors.New("Shard to Beacon Pool not init")
+	}
+	return shardToBeaconPool.RemoveBlockByHeight(shardID, height)
+}
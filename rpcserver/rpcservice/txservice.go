@@ -204,7 +204,7 @@ func (txService TxService) EstimateFee(
 	candidateOutputCoins []*privacy.OutputCoin,
 	paymentInfos []*privacy.PaymentInfo, shardID byte,
 	numBlock uint64, hasPrivacy bool,
-	metadata metadata.Metadata,
+	meta metadata.Metadata,
 	customTokenParams *transaction.CustomTokenParamTx,
 	privacyCustomTokenParams *transaction.CustomTokenPrivacyParamTx,
 	db database.DatabaseInterface,
@@ -226,7 +226,11 @@ func (txService TxService) EstimateFee(
 		tokenId = nil
 	}
 
-	estimateFeeCoinPerKb, err := txService.EstimateFeeWithEstimator(defaultFee, shardID, numBlock, tokenId, beaconHeight, db)
+	metaType := metadata.InvalidMeta
+	if meta != nil {
+		metaType = meta.GetType()
+	}
+	estimateFeeCoinPerKb, err := txService.EstimateFeeWithEstimator(defaultFee, shardID, numBlock, tokenId, metaType, beaconHeight, db)
 	if err != nil {
 		return 0, 0, 0, err
 	}
@@ -239,7 +243,7 @@ func (txService TxService) EstimateFee(
 	if feeEstimator, ok := txService.FeeEstimator[shardID]; ok {
 		limitFee = feeEstimator.GetLimitFeeForNativeToken()
 	}
-	estimateTxSizeInKb = transaction.EstimateTxSize(transaction.NewEstimateTxSizeParam(candidateOutputCoins, paymentInfos, hasPrivacy, metadata, customTokenParams, privacyCustomTokenParams, limitFee))
+	estimateTxSizeInKb = transaction.EstimateTxSize(transaction.NewEstimateTxSizeParam(candidateOutputCoins, paymentInfos, hasPrivacy, meta, customTokenParams, privacyCustomTokenParams, limitFee))
 
 	realFee = uint64(estimateFeeCoinPerKb) * uint64(estimateTxSizeInKb)
 	return realFee, estimateFeeCoinPerKb, estimateTxSizeInKb, nil
@@ -248,7 +252,8 @@ func (txService TxService) EstimateFee(
 // EstimateFeeWithEstimator - only estimate fee by estimator and return fee per kb
 // if tokenID != nil: return fee per kb for pToken (return error if there is no exchange rate between pToken and native token)
 // if tokenID == nil: return fee per kb for native token
-func (txService TxService) EstimateFeeWithEstimator(defaultFee int64, shardID byte, numBlock uint64, tokenId *common.Hash, beaconHeight int64, db database.DatabaseInterface) (uint64, error) {
+// the estimator uses the txs with metadata type metaType (metadata.InvalidMeta for txs without metadata)
+func (txService TxService) EstimateFeeWithEstimator(defaultFee int64, shardID byte, numBlock uint64, tokenId *common.Hash, metaType int, beaconHeight int64, db database.DatabaseInterface) (uint64, error) {
 	if defaultFee == 0 {
 		return uint64(defaultFee), nil
 	}
@@ -257,7 +262,7 @@ func (txService TxService) EstimateFeeWithEstimator(defaultFee int64, shardID by
 	if defaultFee == -1 {
 		// estimate fee on the blocks before (in native token or in pToken)
 		if _, ok := txService.FeeEstimator[shardID]; ok {
-			temp, _ := txService.FeeEstimator[shardID].EstimateFee(numBlock, tokenId)
+			temp, _ := txService.FeeEstimator[shardID].EstimateFeeForMetaType(numBlock, tokenId, metaType, beaconHeight, db)
 			unitFee = uint64(temp)
 		}
 	} else {
@@ -270,6 +275,12 @@ func (txService TxService) EstimateFeeWithEstimator(defaultFee int64, shardID by
 	if feeEstimator, ok := txService.FeeEstimator[shardID]; ok {
 		limitFee = feeEstimator.GetLimitFeeForNativeToken()
 	}
+	// a full mempool only accepts txs paying more than its rolling minimum fee
+	if txService.TxMemPool != nil {
+		if mempoolMinFee := txService.TxMemPool.MinFeePerKB(); mempoolMinFee > 0 && limitFee <= mempoolMinFee {
+			limitFee = mempoolMinFee + 1
+		}
+	}
 
 	if tokenId == nil {
 		// check with limit fee
@@ -298,6 +309,14 @@ func (txService TxService) EstimateFeeWithEstimator(defaultFee int64, shardID by
 	}
 }
 
+// EstimateTxSizeWithEstimator - return the average size in kb of the txs with metadata type metaType seen by the estimator of shardID
+func (txService TxService) EstimateTxSizeWithEstimator(shardID byte, metaType int) uint64 {
+	if feeEstimator, ok := txService.FeeEstimator[shardID]; ok {
+		return feeEstimator.EstimateTxSizeForMetaType(metaType)
+	}
+	return 0
+}
+
 func (txService TxService) BuildRawTransaction(params *bean.CreateRawTxParam, meta metadata.Metadata, db database.DatabaseInterface) (*transaction.Tx, *RPCError) {
 	Logger.log.Infof("Params: \n%+v\n\n\n", params)
 
@@ -396,6 +415,36 @@ func (txService TxService) SendRawTransaction(txB58Check string) (wire.Message,
 	return txMsg, tx.Hash(), tx.PubKeyLastByteSender, nil
 }
 
+// TestMempoolAccept decodes a raw tx or a raw privacy token tx and runs the validation of mempool on it
+// without adding it to mempool or relaying it
+func (txService TxService) TestMempoolAccept(txB58Check string) (*mempool.TxAcceptResult, *RPCError) {
+	rawTxBytes, _, err := base58.Base58Check{}.Decode(txB58Check)
+	if err != nil {
+		return nil, NewRPCError(RPCInvalidParamsError, err)
+	}
+	fields := make(map[string]json.RawMessage)
+	err = json.Unmarshal(rawTxBytes, &fields)
+	if err != nil {
+		return nil, NewRPCError(RPCInvalidParamsError, err)
+	}
+	var tx metadata.Transaction
+	if _, ok := fields["TxTokenPrivacyData"]; ok {
+		tx = &transaction.TxCustomTokenPrivacy{}
+	} else {
+		tx = &transaction.Tx{}
+	}
+	err = json.Unmarshal(rawTxBytes, tx)
+	if err != nil {
+		return nil, NewRPCError(RPCInvalidParamsError, err)
+	}
+	beaconHeigh := int64(-1)
+	beaconBestState, err := txService.BlockChain.BestState.GetClonedBeaconBestState()
+	if err == nil {
+		beaconHeigh = int64(beaconBestState.BeaconHeight)
+	}
+	return txService.TxMemPool.TestMempoolAccept(tx, beaconHeigh), nil
+}
+
 func (txService TxService) BuildTokenParam(tokenParamsRaw map[string]interface{}, senderKeySet *incognitokey.KeySet, shardIDSender byte) (
 	*transaction.CustomTokenParamTx, *transaction.CustomTokenPrivacyParamTx, *RPCError) {
 
@@ -844,6 +893,25 @@ func (txService TxService) GetListCustomTokenHolders(tokenIDString string) (map[
 	return result, nil
 }
 
+func (txService TxService) GetListCustomTokenHoldersByCursor(tokenIDString string, cursor []byte, limit int) (*jsonresult.ListCustomTokenHoldersResult, *RPCError) {
+	tokenID, err := common.Hash{}.NewHashFromStr(tokenIDString)
+	if err != nil {
+		return nil, NewRPCError(RPCInvalidParamsError, errors.New("TokenID is invalid"))
+	}
+	holders, nextCursor, err := txService.BlockChain.GetListTokenHoldersByCursor(tokenID, cursor, limit)
+	if err != nil {
+		return nil, NewRPCError(UnexpectedError, err)
+	}
+	result := &jsonresult.ListCustomTokenHoldersResult{
+		Holders:    make(map[string]uint64),
+		NextCursor: encodeCursor(nextCursor),
+	}
+	for _, holder := range holders {
+		result.Holders[holder.PaymentAddress] = holder.Balance
+	}
+	return result, nil
+}
+
 func (txService TxService) GetListCustomTokenBalance(accountParam string) (jsonresult.ListCustomTokenBalance, error) {
 	result := jsonresult.ListCustomTokenBalance{ListCustomTokenBalance: []jsonresult.CustomTokenBalance{}}
 	account, err := wallet.Base58CheckDeserialize(accountParam)
@@ -1346,6 +1414,124 @@ func (txService TxService) BuildRawDefragmentAccountTransaction(params interface
 	return &tx, nil
 }
 
+// BuildRawReplaceTransaction builds a PRV transaction replacing the transaction txHash in mempool.
+// The new transaction spends the same input coins of the sender, pays the receivers and at least the minimum
+// replacement fee of the mempool, the change goes back to the sender.
+// Param #1: private key of sender
+// Param #2: hash of the transaction to be replaced
+// Param #3: list receivers
+// Param #4: fee in nano PRV, 0 to pay the minimum replacement fee
+// Param #5: hasPrivacyCoin flag: 1 or -1 (default)
+func (txService TxService) BuildRawReplaceTransaction(params interface{}, db database.DatabaseInterface) (*transaction.Tx, *RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 4 {
+		return nil, NewRPCError(RPCInvalidParamsError, errors.New("param must be an array at least 4 elements"))
+	}
+	senderKeyParam, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, NewRPCError(RPCInvalidParamsError, errors.New("sender private key is invalid"))
+	}
+	senderKeySet, shardIDSender, err := GetKeySetFromPrivateKeyParams(senderKeyParam)
+	if err != nil {
+		return nil, NewRPCError(InvalidSenderPrivateKeyError, err)
+	}
+	txHashParam, ok := arrayParams[1].(string)
+	if !ok {
+		return nil, NewRPCError(RPCInvalidParamsError, errors.New("tx hash is invalid"))
+	}
+	txHash, err := common.Hash{}.NewHashFromStr(txHashParam)
+	if err != nil {
+		return nil, NewRPCError(RPCInvalidParamsError, errors.New("tx hash is invalid"))
+	}
+	receiversParam, ok := arrayParams[2].(map[string]interface{})
+	if !ok {
+		return nil, NewRPCError(RPCInvalidParamsError, errors.New("receivers param is invalid"))
+	}
+	paymentInfos, err := NewPaymentInfosFromReceiversParam(receiversParam)
+	if err != nil {
+		return nil, NewRPCError(InvalidReceiverPaymentAddressError, err)
+	}
+	feeParam, ok := arrayParams[3].(float64)
+	if !ok || feeParam < 0 {
+		return nil, NewRPCError(RPCInvalidParamsError, errors.New("fee is invalid"))
+	}
+	hasPrivacyCoin := false
+	if len(arrayParams) > 4 {
+		hasPrivacyCoinParam, ok := arrayParams[4].(float64)
+		if !ok {
+			return nil, NewRPCError(RPCInvalidParamsError, errors.New("has privacy for tx is invalid"))
+		}
+		hasPrivacyCoin = int(hasPrivacyCoinParam) > 0
+	}
+
+	txToBeReplaced, err := txService.TxMemPool.GetTx(txHash)
+	if err != nil {
+		return nil, NewRPCError(GeTxFromPoolError, err)
+	}
+	if txToBeReplaced.GetType() != common.TxNormalType {
+		return nil, NewRPCError(ReplaceTxError, fmt.Errorf("only PRV transactions can be replaced, tx %+v has type %+v", txHash.String(), txToBeReplaced.GetType()))
+	}
+	minFee, _, err := txService.TxMemPool.MinReplacementFee(*txHash)
+	if err != nil {
+		return nil, NewRPCError(ReplaceTxError, err)
+	}
+	fee := uint64(feeParam)
+	if fee < minFee {
+		fee = minFee
+	}
+
+	// the new tx must spend the same coins to replace the tx in mempool
+	prvCoinID := &common.Hash{}
+	prvCoinID.SetBytes(common.PRVCoinID[:])
+	outCoins, err := txService.BlockChain.GetListOutputCoinsByKeyset(senderKeySet, shardIDSender, prvCoinID)
+	if err != nil {
+		return nil, NewRPCError(GetOutputCoinError, err)
+	}
+	serialNumbers := make(map[common.Hash]struct{})
+	for _, serialNumber := range txToBeReplaced.ListSerialNumbersHashH() {
+		serialNumbers[serialNumber] = struct{}{}
+	}
+	spentOutCoins := make([]*privacy.OutputCoin, 0)
+	totalInputAmount := uint64(0)
+	for _, outCoin := range outCoins {
+		if _, ok := serialNumbers[common.HashH(outCoin.CoinDetails.GetSerialNumber().ToBytesS())]; ok {
+			spentOutCoins = append(spentOutCoins, outCoin)
+			totalInputAmount += outCoin.CoinDetails.GetValue()
+		}
+	}
+	if len(spentOutCoins) == 0 || len(spentOutCoins) != len(serialNumbers) {
+		return nil, NewRPCError(ReplaceTxError, fmt.Errorf("input coins of tx %+v are not coins of the sender", txHash.String()))
+	}
+	totalAmount := fee
+	for _, paymentInfo := range paymentInfos {
+		totalAmount += paymentInfo.Amount
+	}
+	if totalAmount > totalInputAmount {
+		return nil, NewRPCError(ReplaceTxError, fmt.Errorf("input coins of tx %+v have %+v PRV, not enough to pay %+v PRV", txHash.String(), totalInputAmount, totalAmount))
+	}
+	if totalInputAmount > totalAmount {
+		paymentInfos = append(paymentInfos, &privacy.PaymentInfo{
+			PaymentAddress: senderKeySet.PaymentAddress,
+			Amount:         totalInputAmount - totalAmount,
+		})
+	}
+
+	tx := transaction.Tx{}
+	err = tx.Init(
+		transaction.NewTxPrivacyInitParams(&senderKeySet.PrivateKey,
+			paymentInfos,
+			transaction.ConvertOutputCoinToInputCoin(spentOutCoins),
+			fee,
+			hasPrivacyCoin,
+			db,
+			nil, // use for prv coin -> nil is valid
+			nil, nil))
+	if err != nil {
+		return nil, NewRPCError(CreateTxDataError, err)
+	}
+	return &tx, nil
+}
+
 //calculateOutputCoinsByMinValue
 func (txService TxService) calculateOutputCoinsByMinValue(outCoins []*privacy.OutputCoin, maxVal uint64) ([]*privacy.OutputCoin, uint64) {
 	outCoinsTmp := make([]*privacy.OutputCoin, 0)
@@ -1589,3 +1775,32 @@ func (txService TxService) GetTransactionByReceiver(keySet incognitokey.KeySet)
 	}
 	return &result, nil
 }
+
+// ListTxsByMetadataType returns a page of at most limit txs with metadata type metaType in shardID,
+// from the index fromTxIndex of the block at fromHeight up to the block at toHeight
+func (txService TxService) ListTxsByMetadataType(metaType int, shardID byte, fromHeight uint64, fromTxIndex int, toHeight uint64, limit int) (*jsonresult.ListTxsByMetadataType, *RPCError) {
+	// fetch one more tx to know if there is a next page
+	txIndices, err := txService.BlockChain.ListTxsByMetadataType(metaType, shardID, fromHeight, fromTxIndex, toHeight, limit+1)
+	if err != nil {
+		return nil, NewRPCError(ListTxsByMetadataTypeError, err)
+	}
+	result := &jsonresult.ListTxsByMetadataType{
+		MetadataType: metaType,
+		ShardID:      shardID,
+		Txs:          []jsonresult.MetadataTx{},
+	}
+	if len(txIndices) > limit {
+		result.HasMore = true
+		result.NextHeight = txIndices[limit].Height
+		result.NextTxIndex = txIndices[limit].TxIndex
+		txIndices = txIndices[:limit]
+	}
+	for _, txIndex := range txIndices {
+		result.Txs = append(result.Txs, jsonresult.MetadataTx{
+			Height:  txIndex.Height,
+			TxIndex: txIndex.TxIndex,
+			TxHash:  txIndex.TxHash.String(),
+		})
+	}
+	return result, nil
+}
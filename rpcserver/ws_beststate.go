@@ -91,3 +91,54 @@ func (wsServer *WsServer) handleSubscribeBeaconBestState(params interface{}, sub
 		}
 	}
 }
+
+// handleSubscribeFinalizedShardBlock streams the blocks of a shard once they are confirmed by the beacon chain,
+// a result with Reverted set tells that the finalized height went back because a beacon block was reverted
+func (wsServer *WsServer) handleSubscribeFinalizedShardBlock(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	Logger.log.Info("Handle Subscribe Finalized Shard Block", params, subcription)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 1 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain 1 params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	shardIDParam, ok := arrayParams[0].(float64)
+	if !ok {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Shard ID component invalid"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	shardID := byte(shardIDParam)
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.FinalizedShardBlockTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Finalized Shard Block")
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.FinalizedShardBlockTopic, subId)
+		close(cResult)
+	}()
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				finalizedShardBlock, ok := msg.Value.(*blockchain.FinalizedShardBlock)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.FinalizedShardBlock, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				if finalizedShardBlock.ShardID != shardID {
+					continue
+				}
+				cResult <- RpcSubResult{Result: *finalizedShardBlock, Error: nil}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Finalized Shard Block"}}
+				return
+			}
+		}
+	}
+}
@@ -34,12 +34,14 @@ func (wsServer *WsServer) handleSubcribeMempoolInfo(params interface{}, subcript
 		select {
 		case msg := <-subChan:
 			{
-				listTxs, ok := msg.Value.([]string)
-				if !ok {
-					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted []string, have %+v", reflect.TypeOf(msg.Value))
-					continue
+				switch value := msg.Value.(type) {
+				case []string:
+					cResult <- RpcSubResult{Result: value, Error: nil}
+				case *mempool.TxReplacement:
+					cResult <- RpcSubResult{Result: jsonresult.NewReplacedTransaction(value), Error: nil}
+				default:
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted []string or *mempool.TxReplacement, have %+v", reflect.TypeOf(msg.Value))
 				}
-				cResult <- RpcSubResult{Result: listTxs, Error: nil}
 			}
 		case <-closeChan:
 			{
@@ -1,11 +1,15 @@
 package rpcserver
 
 import (
+	"bytes"
 	"errors"
 	"reflect"
 
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/mempool"
+	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
@@ -82,3 +86,170 @@ func (wsServer *WsServer) handleSubscribePendingTransaction(params interface{},
 		}
 	}
 }
+
+// txEventFilter matches the txs of a lifecycle subscription by tx hash or by public key,
+// a public key matches the non privacy txs it signed and the txs sending output coins to it.
+// A privacy tx is signed with a random key, so it only matches the public keys of its receivers
+type txEventFilter struct {
+	txHash    *common.Hash
+	publicKey []byte
+}
+
+func (filter *txEventFilter) match(tx metadata.Transaction) bool {
+	if filter.txHash != nil {
+		return tx.Hash().IsEqual(filter.txHash)
+	}
+	if !tx.IsPrivacy() && bytes.Equal(tx.GetSigPubKey(), filter.publicKey) {
+		return true
+	}
+	receivers, _ := tx.GetReceivers()
+	tokenReceivers, _ := tx.GetTokenReceivers()
+	for _, receiver := range append(receivers, tokenReceivers...) {
+		if bytes.Equal(receiver, filter.publicKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// includedShardBlock keeps the matched txs of a shard block until the block is confirmed by beacon
+type includedShardBlock struct {
+	shardID  byte
+	height   uint64
+	txHashes []common.Hash
+}
+
+// handleSubcribeMempoolTxEvent streams the lifecycle of txs: accepted, rejected, replaced and evicted by mempool,
+// included in a shard block then confirmed by beacon.
+// Parameter #1: tx hash or base58 check encoded public key, the txs sent by a public key are only matched for non privacy txs.
+// A subscription by tx hash finishes when the tx is confirmed by beacon.
+func (wsServer *WsServer) handleSubcribeMempoolTxEvent(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	Logger.log.Info("Handle Subcribe Mempool Tx Event", params, subcription)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 1 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain 1 params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	key, ok := arrayParams[0].(string)
+	if !ok || key == "" {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Tx Hash or Public Key"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	filter := &txEventFilter{}
+	if txHash, err := (common.Hash{}).NewHashFromStr(key); err == nil && len(key) == common.HashSize*2 {
+		filter.txHash = txHash
+	} else {
+		publicKey, _, err := base58.Base58Check{}.Decode(key)
+		if err != nil || len(publicKey) == 0 {
+			err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Tx Hash or Public Key"))
+			cResult <- RpcSubResult{Error: err}
+			return
+		}
+		filter.publicKey = publicKey
+	}
+	topics := []string{pubsub.MempoolTxEventTopic, pubsub.NewShardblockTopic, pubsub.NewBeaconBlockTopic}
+	subIds := []uint{}
+	subChans := []pubsub.EventChannel{}
+	defer func() {
+		Logger.log.Info("Finish Subcribe Mempool Tx Event ", key)
+		for i, subId := range subIds {
+			wsServer.config.PubSubManager.Unsubscribe(topics[i], subId)
+		}
+		close(cResult)
+	}()
+	for _, topic := range topics {
+		subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(topic)
+		if err != nil {
+			err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+			cResult <- RpcSubResult{Error: err}
+			return
+		}
+		subIds = append(subIds, subId)
+		subChans = append(subChans, subChan)
+	}
+	included := make(map[common.Hash]*includedShardBlock)
+	// a tx already in a shard block is reported as included, and as confirmed if beacon has the block
+	if filter.txHash != nil {
+		shardID, blockHash, _, _, err := wsServer.config.BlockChain.GetTransactionByHash(*filter.txHash)
+		if err == nil {
+			shardBlock, _, err := wsServer.config.BlockChain.GetShardBlockByHash(blockHash)
+			if err == nil {
+				cResult <- RpcSubResult{Result: &jsonresult.MempoolTxEventResult{TxHash: filter.txHash.String(), State: jsonresult.TxEventIncluded, ShardID: shardID, BlockHeight: shardBlock.Header.Height, BlockHash: blockHash.String()}}
+				if wsServer.config.BlockChain.BestState.Beacon.GetBestHeightOfShard(shardID) >= shardBlock.Header.Height {
+					cResult <- RpcSubResult{Result: &jsonresult.MempoolTxEventResult{TxHash: filter.txHash.String(), State: jsonresult.TxEventConfirmed, ShardID: shardID, BlockHeight: shardBlock.Header.Height, BlockHash: blockHash.String(), BeaconHeight: wsServer.config.BlockChain.BestState.Beacon.BeaconHeight}}
+					return
+				}
+				included[blockHash] = &includedShardBlock{shardID: shardID, height: shardBlock.Header.Height, txHashes: []common.Hash{*filter.txHash}}
+			}
+		}
+	}
+	for {
+		select {
+		case msg := <-subChans[0]:
+			{
+				event, ok := msg.Value.(*mempool.TxEvent)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *mempool.TxEvent, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				if !filter.match(event.Tx) {
+					continue
+				}
+				cResult <- RpcSubResult{Result: jsonresult.NewMempoolTxEventResult(event)}
+			}
+		case msg := <-subChans[1]:
+			{
+				shardBlock, ok := msg.Value.(*blockchain.ShardBlock)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.ShardBlock, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				blockHash := *shardBlock.Hash()
+				for _, tx := range shardBlock.Body.Transactions {
+					if !filter.match(tx) {
+						continue
+					}
+					if _, ok := included[blockHash]; !ok {
+						included[blockHash] = &includedShardBlock{shardID: shardBlock.Header.ShardID, height: shardBlock.Header.Height}
+					}
+					included[blockHash].txHashes = append(included[blockHash].txHashes, *tx.Hash())
+					cResult <- RpcSubResult{Result: &jsonresult.MempoolTxEventResult{TxHash: tx.Hash().String(), State: jsonresult.TxEventIncluded, ShardID: shardBlock.Header.ShardID, BlockHeight: shardBlock.Header.Height, BlockHash: blockHash.String()}}
+				}
+			}
+		case msg := <-subChans[2]:
+			{
+				beaconBlock, ok := msg.Value.(*blockchain.BeaconBlock)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.BeaconBlock, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				for shardID, shardStates := range beaconBlock.Body.ShardState {
+					for _, shardState := range shardStates {
+						if block, ok := included[shardState.Hash]; ok {
+							for _, txHash := range block.txHashes {
+								cResult <- RpcSubResult{Result: &jsonresult.MempoolTxEventResult{TxHash: txHash.String(), State: jsonresult.TxEventConfirmed, ShardID: shardID, BlockHeight: block.height, BlockHash: shardState.Hash.String(), BeaconHeight: beaconBlock.Header.Height}}
+							}
+							delete(included, shardState.Hash)
+							if filter.txHash != nil {
+								return
+							}
+						}
+						// blocks of the shard which were not confirmed up to this height are orphans
+						for blockHash, block := range included {
+							if block.shardID == shardID && block.height <= shardState.Height {
+								delete(included, blockHash)
+							}
+						}
+					}
+				}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Mempool Tx Event " + key}}
+				return
+			}
+		}
+	}
+}
@@ -336,6 +336,8 @@ func (serverObj *Server) NewServer(listenAddrs string, db database.DatabaseInter
 		RandomClient:    randomClient,
 		ConsensusEngine: serverObj.consensusEngine,
 		Highway:         serverObj.highway,
+		PruneHeights:    cfg.Prune,
+		MetadataTxIndex: cfg.MetaTxIndex,
 	})
 	if err != nil {
 		return err
@@ -411,7 +413,12 @@ func (serverObj *Server) NewServer(listenAddrs string, db database.DatabaseInter
 		FeeEstimator:      serverObj.feeEstimator,
 		TxLifeTime:        cfg.TxPoolTTL,
 		MaxTx:             cfg.TxPoolMaxTx,
+		ReplaceFeeBump:    cfg.TxPoolReplaceFeeBump,
+		MaxReplacement:    cfg.TxPoolMaxReplacement,
+		SenderTxRate:      cfg.TxSenderRate,
+		SenderTxBurst:     cfg.TxSenderBurst,
 		DataBaseMempool:   dbmp,
+		JournalPath:       filepath.Join(cfg.DataDir, cfg.MempoolJournal),
 		IsLoadFromMempool: cfg.LoadMempool,
 		PersistMempool:    cfg.PersistMempool,
 		RelayShards:       relayShards,
@@ -441,16 +448,20 @@ func (serverObj *Server) NewServer(listenAddrs string, db database.DatabaseInter
 	// Init Net Sync manager to process messages
 	serverObj.netSync = &netsync.NetSync{}
 	serverObj.netSync.Init(&netsync.NetSyncConfig{
-		BlockChain:        serverObj.blockChain,
-		ChainParam:        chainParams,
-		TxMemPool:         serverObj.memPool,
-		Server:            serverObj,
-		Consensus:         serverObj.consensusEngine, // for onBFTMsg
-		ShardToBeaconPool: serverObj.shardToBeaconPool,
-		CrossShardPool:    serverObj.crossShardPool,
-		PubSubManager:     serverObj.pusubManager,
-		RelayShard:        relayShards,
-		RoleInCommittees:  -1,
+		BlockChain:         serverObj.blockChain,
+		ChainParam:         chainParams,
+		TxMemPool:          serverObj.memPool,
+		Server:             serverObj,
+		Consensus:          serverObj.consensusEngine, // for onBFTMsg
+		ShardToBeaconPool:  serverObj.shardToBeaconPool,
+		CrossShardPool:     serverObj.crossShardPool,
+		PubSubManager:      serverObj.pusubManager,
+		RelayShard:         relayShards,
+		RoleInCommittees:   -1,
+		PeerBanner:         serverObj.highway,
+		TxPeerRate:         cfg.TxPeerRate,
+		TxPeerBurst:        cfg.TxPeerBurst,
+		TxPeerBanThreshold: cfg.TxPeerBanThreshold,
 	})
 	// Create a connection manager.
 	var listenPeer *peer.Peer
@@ -1053,10 +1064,18 @@ func (serverObj *Server) OnGetShardToBeacon(_ *peer.PeerConn, msg *wire.MessageG
 // until the transaction has been fully processed.  Unlock the block
 // handler this does not serialize all transactions through a single thread
 // transactions don't rely on the previous one in a linear fashion like blocks.
+// getRemotePeer returns the peer which sent the messages of peerConn, nil if it is unknown
+func getRemotePeer(peerConn *peer.PeerConn) *peer.Peer {
+	if peerConn == nil {
+		return nil
+	}
+	return peerConn.GetRemotePeer()
+}
+
 func (serverObj *Server) OnTx(peer *peer.PeerConn, msg *wire.MessageTx) {
 	Logger.log.Debug("Receive a new transaction START")
 	var txProcessed chan struct{}
-	serverObj.netSync.QueueTx(nil, msg, txProcessed)
+	serverObj.netSync.QueueTx(getRemotePeer(peer), msg, txProcessed)
 	//<-txProcessed
 
 	Logger.log.Debug("Receive a new transaction END")
@@ -1065,7 +1084,7 @@ func (serverObj *Server) OnTx(peer *peer.PeerConn, msg *wire.MessageTx) {
 func (serverObj *Server) OnTxToken(peer *peer.PeerConn, msg *wire.MessageTxToken) {
 	Logger.log.Debug("Receive a new transaction(normal token) START")
 	var txProcessed chan struct{}
-	serverObj.netSync.QueueTxToken(nil, msg, txProcessed)
+	serverObj.netSync.QueueTxToken(getRemotePeer(peer), msg, txProcessed)
 	//<-txProcessed
 
 	Logger.log.Debug("Receive a new transaction(normal token) END")
@@ -1074,7 +1093,7 @@ func (serverObj *Server) OnTxToken(peer *peer.PeerConn, msg *wire.MessageTxToken
 func (serverObj *Server) OnTxPrivacyToken(peer *peer.PeerConn, msg *wire.MessageTxPrivacyToken) {
 	Logger.log.Debug("Receive a new transaction(privacy token) START")
 	var txProcessed chan struct{}
-	serverObj.netSync.QueueTxPrivacyToken(nil, msg, txProcessed)
+	serverObj.netSync.QueueTxPrivacyToken(getRemotePeer(peer), msg, txProcessed)
 	//<-txProcessed
 
 	Logger.log.Debug("Receive a new transaction(privacy token) END")
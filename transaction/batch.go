@@ -0,0 +1,146 @@
+package transaction
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+)
+
+// batchVerificationSize is the number of txs whose payment proofs are verified together in one batch
+const batchVerificationSize = 32
+
+// txProof is a payment proof with privacy of a tx and the parameters to verify it
+type txProof struct {
+	tx      *Tx
+	shardID byte
+	tokenID *common.Hash
+}
+
+// getTxProofs returns the payment proofs of tx which can be verified in a batch,
+// they are the same proofs which ValidateTransaction verifies
+func getTxProofs(tx metadata.Transaction) []txProof {
+	shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
+	prvCoinID := &common.Hash{}
+	prvCoinID.SetBytes(common.PRVCoinID[:])
+	proofs := []txProof{}
+	switch tx := tx.(type) {
+	case *Tx:
+		if tx.GetType() == common.TxRewardType || tx.GetType() == common.TxReturnStakingType {
+			return proofs
+		}
+		if tx.IsPrivacy() {
+			proofs = append(proofs, txProof{tx: tx, shardID: shardID, tokenID: prvCoinID})
+		}
+	case *TxCustomTokenPrivacy:
+		if tx.Tx.IsPrivacy() {
+			proofs = append(proofs, txProof{tx: &tx.Tx, shardID: shardID, tokenID: prvCoinID})
+		}
+		txNormal := &tx.TxPrivacyTokenData.TxNormal
+		if tx.TxPrivacyTokenData.Type != CustomTokenInit && txNormal.IsPrivacy() {
+			tokenID := tx.TxPrivacyTokenData.PropertyID
+			proofs = append(proofs, txProof{tx: txNormal, shardID: shardID, tokenID: &tokenID})
+		}
+	}
+	return proofs
+}
+
+// HasProofsToVerifyInBatch returns true if tx has payment proofs with privacy which VerifyTxProofsInBatch can verify
+func HasProofsToVerifyInBatch(tx metadata.Transaction) bool {
+	return len(getTxProofs(tx)) > 0
+}
+
+// addProofToBatch verifies the payment proof of tx with privacy,
+// its one out of many proofs and its aggregated range proof are added to batch instead of being verified
+func (tx *Tx) addProofToBatch(db database.DatabaseInterface, shardID byte, tokenID *common.Hash, batch *privacy.BatchEquation) error {
+	valid, err := tx.validateSanityDataOfProof()
+	if !valid {
+		return NewTransactionErr(TxProofVerifyFailError, err)
+	}
+	valid, err = tx.Proof.VerifyWithBatch(true, tx.SigPubKey, tx.Fee, db, shardID, tokenID, batch)
+	if !valid {
+		return NewTransactionErr(TxProofVerifyFailError, err)
+	}
+	return nil
+}
+
+// VerifyTxProofsInBatch verifies the payment proofs with privacy of txs,
+// the one out of many proofs and the aggregated range proofs of up to batchVerificationSize txs are checked together
+// with one multi-scalar multiplication and the batches are verified in parallel.
+// When a batch is invalid, the proofs of its txs are verified one by one to find the invalid txs.
+// It returns the error of each tx, nil if the proofs of the tx are valid or can not be verified in a batch.
+// The valid proofs are not verified again by ValidateTransaction.
+func VerifyTxProofsInBatch(txs []metadata.Transaction, db database.DatabaseInterface) []error {
+	errs := make([]error, len(txs))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, runtime.NumCPU())
+	for start := 0; start < len(txs); start += batchVerificationSize {
+		end := start + batchVerificationSize
+		if end > len(txs) {
+			end = len(txs)
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(start, end int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			verifyTxProofsInBatch(txs[start:end], db, errs[start:end])
+		}(start, end)
+	}
+	wg.Wait()
+	return errs
+}
+
+func verifyTxProofsInBatch(txs []metadata.Transaction, db database.DatabaseInterface, errs []error) {
+	batch := privacy.NewBatchEquation()
+	proofs := make([][]txProof, len(txs))
+	for i, tx := range txs {
+		proofs[i] = getTxProofs(tx)
+		for _, proof := range proofs[i] {
+			// the equations already added for an invalid tx only make the batch fall back to per-tx verification
+			if err := proof.tx.addProofToBatch(db, proof.shardID, proof.tokenID, batch); err != nil {
+				Logger.log.Errorf("Cannot add payment proof of tx %+v to batch: %+v", *tx.Hash(), err)
+				errs[i] = err
+				break
+			}
+		}
+	}
+	if batch.Len() == 0 {
+		return
+	}
+
+	if !batch.Verify() {
+		Logger.log.Infof("Batch verification of %+v payment proofs failed, verify them one by one", len(txs))
+		for i, tx := range txs {
+			if errs[i] != nil {
+				continue
+			}
+			for _, proof := range proofs[i] {
+				valid, err := proof.tx.Proof.Verify(true, proof.tx.SigPubKey, proof.tx.Fee, db, proof.shardID, proof.tokenID)
+				if !valid {
+					if err == nil {
+						err = errors.New("FAILED VERIFICATION PAYMENT PROOF")
+					}
+					Logger.log.Errorf("Payment proof of tx %+v is invalid: %+v", *tx.Hash(), err)
+					errs[i] = NewTransactionErr(TxProofVerifyFailError, err)
+					break
+				}
+			}
+		}
+	}
+
+	for i := range txs {
+		if errs[i] != nil {
+			continue
+		}
+		for _, proof := range proofs[i] {
+			proof.tx.isProofVerified = true
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+// newBatchTestTx mints PRV for a new sender and returns a tx with privacy spending it
+func newBatchTestTx(t *testing.T) *Tx {
+	seed := privacy.RandomScalar().ToBytesS()
+	masterKey, _ := wallet.NewMasterKey(seed)
+	childSender, _ := masterKey.NewChildKey(uint32(1))
+	childReceiver, _ := masterKey.NewChildKey(uint32(2))
+	senderKey, err := wallet.Base58CheckDeserialize(childSender.Base58CheckSerialize(wallet.PriKeyType))
+	assert.Equal(t, nil, err)
+	err = senderKey.KeySet.InitFromPrivateKey(&senderKey.KeySet.PrivateKey)
+	assert.Equal(t, nil, err)
+	receiverKey, _ := wallet.Base58CheckDeserialize(childReceiver.Base58CheckSerialize(wallet.PaymentAddressType))
+	senderPaymentAddress := senderKey.KeySet.PaymentAddress
+	shardID := common.GetShardIDFromLastByte(senderPaymentAddress.Pk[len(senderPaymentAddress.Pk)-1])
+
+	coinBaseTx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&senderPaymentAddress, 1000, &senderKey.KeySet.PrivateKey, db, nil, common.Hash{}, NormalCoinType, "PRV", 0))
+	assert.Equal(t, nil, err)
+	db.StoreCommitments(
+		common.PRVCoinID,
+		senderPaymentAddress.Pk,
+		[][]byte{coinBaseTx.(*Tx).Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().ToBytesS()},
+		shardID)
+	coinBaseOutput := ConvertOutputCoinToInputCoin(coinBaseTx.(*Tx).Proof.GetOutputCoins())
+	serialNumber := new(privacy.Point).Derive(privacy.PedCom.G[privacy.PedersenPrivateKeyIndex],
+		new(privacy.Scalar).FromBytesS(senderKey.KeySet.PrivateKey),
+		coinBaseOutput[0].CoinDetails.GetSNDerivator())
+	coinBaseOutput[0].CoinDetails.SetSerialNumber(serialNumber)
+
+	tx := &Tx{}
+	err = tx.Init(
+		NewTxPrivacyInitParams(
+			&senderKey.KeySet.PrivateKey,
+			[]*privacy.PaymentInfo{{PaymentAddress: receiverKey.KeySet.PaymentAddress, Amount: 5}},
+			coinBaseOutput, 1, true, db, nil, nil, []byte{},
+		),
+	)
+	assert.Equal(t, nil, err)
+	return tx
+}
+
+func TestVerifyTxProofsInBatch(t *testing.T) {
+	txs := []metadata.Transaction{}
+	for i := 0; i < 4; i++ {
+		txs = append(txs, newBatchTestTx(t))
+	}
+
+	errs := VerifyTxProofsInBatch(txs, db)
+	for i, tx := range txs {
+		assert.Equal(t, nil, errs[i])
+		assert.Equal(t, true, tx.(*Tx).isProofVerified)
+		shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
+		isValid, err := tx.ValidateTransaction(true, db, shardID, nil)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, isValid)
+	}
+
+	// the one out of many proof of tx 1 is modified, the batch falls back to per-tx verification
+	txs = []metadata.Transaction{newBatchTestTx(t), newBatchTestTx(t), newBatchTestTx(t)}
+	oneOfManyProof := txs[1].(*Tx).Proof.GetOneOfManyProof()[0]
+	proofBytes := oneOfManyProof.Bytes()
+	// replace za[0] which follows the 4 lists of points and the list f
+	offset := (5 * privacy.CommitmentRingSizeExp) * common.PublicKeySize
+	copy(proofBytes[offset:], privacy.RandomScalar().ToBytesS())
+	assert.Equal(t, nil, oneOfManyProof.SetBytes(proofBytes))
+	errs = VerifyTxProofsInBatch(txs, db)
+	assert.Equal(t, nil, errs[0])
+	assert.NotEqual(t, nil, errs[1])
+	assert.Equal(t, nil, errs[2])
+	assert.Equal(t, true, txs[0].(*Tx).isProofVerified)
+	assert.Equal(t, false, txs[1].(*Tx).isProofVerified)
+	assert.Equal(t, true, txs[2].(*Tx).isProofVerified)
+}
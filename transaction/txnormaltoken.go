@@ -101,7 +101,7 @@ func (customTokenTx TxNormalToken) ValidateTxWithCurrentMempool(
 }
 
 func (customTokenTx TxNormalToken) validateDoubleSpendCustomTokenWithBlockchain(
-	bcr metadata.BlockchainRetriever,
+	bcr metadata.TransactionRetriever,
 ) error {
 	listTxs, err := bcr.GetCustomTokenTxs(&customTokenTx.TxTokenData.PropertyID)
 	if err != nil {
@@ -240,7 +240,7 @@ func (customTokenTx *TxNormalToken) ValidateTransaction(hasPrivacy bool, db data
 }
 
 func (customTokenTx *TxNormalToken) GetListUTXOFromTxCustomToken(
-	bcr metadata.BlockchainRetriever,
+	bcr metadata.TransactionRetriever,
 ) bool {
 	data := make(map[common.Hash]TxNormalToken)
 	for _, vin := range customTokenTx.TxTokenData.Vins {
@@ -42,6 +42,7 @@ type Tx struct {
 	sigPrivKey       []byte       // is ALWAYS private property of struct, if privacy: 64 bytes, and otherwise, 32 bytes
 	cachedHash       *common.Hash // cached hash data of tx
 	cachedActualSize *uint64      // cached actualsize data for tx
+	isProofVerified  bool         // payment proof is verified in a batch by VerifyTxProofsInBatch
 }
 
 func (tx *Tx) UnmarshalJSON(data []byte) error {
@@ -525,16 +526,20 @@ func (tx *Tx) ValidateTransaction(hasPrivacy bool, db database.DatabaseInterface
 			}
 		}
 
-		// Verify the payment proof
-		valid, err = tx.Proof.Verify(hasPrivacy, tx.SigPubKey, tx.Fee, db, shardID, tokenID)
-		if !valid {
-			if err != nil {
-				Logger.log.Error(err)
-			}
-			Logger.log.Error("FAILED VERIFICATION PAYMENT PROOF")
-			return false, NewTransactionErr(TxProofVerifyFailError, err)
+		// Verify the payment proof, unless it has been verified in a batch
+		if hasPrivacy && tx.isProofVerified {
+			Logger.log.Debugf("PAYMENT PROOF IS VERIFIED IN BATCH")
 		} else {
-			Logger.log.Debugf("SUCCESSED VERIFICATION PAYMENT PROOF ")
+			valid, err = tx.Proof.Verify(hasPrivacy, tx.SigPubKey, tx.Fee, db, shardID, tokenID)
+			if !valid {
+				if err != nil {
+					Logger.log.Error(err)
+				}
+				Logger.log.Error("FAILED VERIFICATION PAYMENT PROOF")
+				return false, NewTransactionErr(TxProofVerifyFailError, err)
+			} else {
+				Logger.log.Debugf("SUCCESSED VERIFICATION PAYMENT PROOF ")
+			}
 		}
 	}
 	//@UNCOMMENT: metrics time